@@ -2,20 +2,33 @@ package event_sync_go
 
 import (
 	"context"
+	"slices"
 	"sync/atomic"
 
 	"github.com/Sandwichzzy/event-sync-go/event"
+	"github.com/Sandwichzzy/event-sync-go/event/contracts"
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/Sandwichzzy/event-sync-go/config"
 	"github.com/Sandwichzzy/event-sync-go/database"
+	"github.com/Sandwichzzy/event-sync-go/outbox"
+	"github.com/Sandwichzzy/event-sync-go/reconciler"
 	"github.com/Sandwichzzy/event-sync-go/synchronizer"
 	"github.com/Sandwichzzy/event-sync-go/synchronizer/node"
+	"github.com/Sandwichzzy/event-sync-go/tokens"
+	"github.com/Sandwichzzy/event-sync-go/webhooks"
 )
 
+// committedBatchBuffer 同步器与事件处理器之间最多积压的批次数
+const committedBatchBuffer = 4
+
 type EventSync struct {
 	synchronizer   *synchronizer.Synchronizer
 	eventProcessor *event.EventProcessor
+	reconciler     *reconciler.Reconciler  // 未启用 treasure-manager 处理器时为 nil
+	tokenMetadata  *tokens.MetadataFetcher // 未启用 treasure-manager 处理器时为 nil
+	outboxRelay    *outbox.Relay           // 未配置 outbox sink 时为 nil
+	webhooks       *webhooks.Dispatcher
 
 	shutdown context.CancelCauseFunc
 	stopped  atomic.Bool
@@ -33,7 +46,10 @@ func NewEventSync(ctx context.Context, cfg *config.Config, shutdown context.Canc
 		return nil, err
 	}
 
-	syncer, err := synchronizer.NewSynchronizer(cfg, db, ethClient, shutdown)
+	// 同步器每提交一个批次就通知事件处理器；通道写满时同步器等待事件处理器追上
+	committedBatches := make(chan synchronizer.CommittedBatch, committedBatchBuffer)
+
+	syncer, err := synchronizer.NewSynchronizer(cfg, db, ethClient, committedBatches, shutdown)
 	if err != nil {
 		log.Error("new synchronizer fail", "err", err)
 		return nil, err
@@ -43,17 +59,60 @@ func NewEventSync(ctx context.Context, cfg *config.Config, shutdown context.Canc
 		LoopInterval:    cfg.Chain.LoopInterval,
 		EventStartBlock: cfg.Chain.StartingHeight,
 		EventBlockStep:  cfg.Chain.BlockStep,
+		AbiDir:          cfg.AbiDir,
+		Processors:      cfg.Processors,
+		Contracts:       cfg.Contracts,
 	}
 
-	eventProcessor, err := event.NewEventProcessor(db, eventConfig, shutdown)
+	eventProcessor, err := event.NewEventProcessor(db, eventConfig, committedBatches, shutdown)
 	if err != nil {
 		log.Error("new event processor fail", "err", err)
 		return nil, err
 	}
 
+	// 余额对账和代币元数据只针对 TreasureManager 合约，未启用该处理器时不启动
+	var (
+		balanceReconciler *reconciler.Reconciler
+		tokenMetadata     *tokens.MetadataFetcher
+	)
+	if slices.Contains(cfg.Processors, contracts.TreasureManagerProcessorName) {
+		// 处理器创建时已经校验只绑定了一个合约
+		treasureManager := cfg.ContractAddresses(contracts.TreasureManagerProcessorName)[0]
+		balanceReconciler, err = reconciler.NewReconciler(ctx, cfg, db, treasureManager, shutdown)
+		if err != nil {
+			log.Error("new reconciler fail", "err", err)
+			return nil, err
+		}
+
+		tokenMetadata, err = tokens.NewMetadataFetcher(ctx, cfg, db, treasureManager, shutdown)
+		if err != nil {
+			log.Error("new token metadata fetcher fail", "err", err)
+			return nil, err
+		}
+	}
+
+	var outboxRelay *outbox.Relay
+	if len(cfg.OutboxSinks) > 0 {
+		outboxRelay, err = outbox.NewRelay(cfg, db, shutdown)
+		if err != nil {
+			log.Error("new outbox relay fail", "err", err)
+			return nil, err
+		}
+	}
+
+	webhookDispatcher, err := webhooks.NewDispatcher(cfg, db, shutdown)
+	if err != nil {
+		log.Error("new webhook dispatcher fail", "err", err)
+		return nil, err
+	}
+
 	out := &EventSync{
 		synchronizer:   syncer,
 		eventProcessor: eventProcessor,
+		reconciler:     balanceReconciler,
+		tokenMetadata:  tokenMetadata,
+		outboxRelay:    outboxRelay,
+		webhooks:       webhookDispatcher,
 		shutdown:       shutdown,
 	}
 	return out, nil
@@ -68,6 +127,28 @@ func (es *EventSync) Start(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	if es.reconciler != nil {
+		err = es.reconciler.Start()
+		if err != nil {
+			return err
+		}
+	}
+	if es.tokenMetadata != nil {
+		err = es.tokenMetadata.Start()
+		if err != nil {
+			return err
+		}
+	}
+	if es.outboxRelay != nil {
+		err = es.outboxRelay.Start()
+		if err != nil {
+			return err
+		}
+	}
+	err = es.webhooks.Start()
+	if err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -80,6 +161,28 @@ func (es *EventSync) Stop(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	if es.reconciler != nil {
+		err = es.reconciler.Close()
+		if err != nil {
+			return err
+		}
+	}
+	if es.tokenMetadata != nil {
+		err = es.tokenMetadata.Close()
+		if err != nil {
+			return err
+		}
+	}
+	if es.outboxRelay != nil {
+		err = es.outboxRelay.Close()
+		if err != nil {
+			return err
+		}
+	}
+	err = es.webhooks.Close()
+	if err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -0,0 +1,335 @@
+// Package apikeys HTTP API 的访问密钥和限流：携带密钥的请求按密钥的令牌桶和每日配额限流，
+// 未携带密钥的请求按客户端地址使用更严格的令牌桶
+package apikeys
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/google/uuid"
+
+	"github.com/Sandwichzzy/event-sync-go/common/tasks"
+	"github.com/Sandwichzzy/event-sync-go/config"
+	"github.com/Sandwichzzy/event-sync-go/database/event"
+)
+
+const (
+	// keyPrefix 所有密钥的固定前缀，便于在日志和配置中识别
+	keyPrefix = "esk_"
+	// displayPrefixLength 保存并在 apikey list 中显示的密钥前缀长度
+	displayPrefixLength = len(keyPrefix) + 8
+	// keyCacheTTL 密钥查询结果的缓存时间，吊销和修改限额最迟在这段时间后生效
+	keyCacheTTL = 30 * time.Second
+	// maxMissingKeys 最多缓存的不存在的密钥数，超过后不再缓存，查询仍然受客户端地址的令牌桶限制
+	maxMissingKeys = 10000
+	// lookupRate / lookupBurst 匿名访问关闭时，未缓存或无效的密钥按客户端地址使用的令牌桶
+	lookupRate  = 1
+	lookupBurst = 10
+	// usageFlushInterval 把实例内累加的每日请求数写入数据库的间隔
+	usageFlushInterval = time.Second
+
+	// ScopeWebhooks 管理 webhook 订阅（/api/v1/webhooks）
+	ScopeWebhooks = "webhooks"
+)
+
+// Scopes 所有可以授予密钥的 scope
+var Scopes = []string{ScopeWebhooks}
+
+// NewKey 生成一个随机密钥，返回明文、用于显示的前缀和保存到数据库的哈希
+func NewKey() (key string, prefix string, hash string, err error) {
+	secret := make([]byte, 24)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", "", err
+	}
+	key = keyPrefix + hex.EncodeToString(secret)
+	return key, key[:displayPrefixLength], HashKey(key), nil
+}
+
+// HashKey 密钥明文的 SHA-256（小写 hex），数据库中只保存这个值
+func HashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// Decision 一次请求的检查结果，Status 为 0 时放行，否则为 401 或 429
+type Decision struct {
+	Status     int
+	Message    string
+	RetryAfter time.Duration // 429 时客户端应等待的时间
+	Key        *event.ApiKey // 放行的请求携带的密钥，匿名请求为 nil
+}
+
+// Guard 检查请求携带的密钥并限流。令牌桶在每个 API 实例内独立计数；每日请求数先在实例内累加，
+// 每 usageFlushInterval 把增量写入数据库并读回所有实例的合计。配额按本地已知的合计判断，
+// 多个实例同时接近配额时最多超出各实例在一个写入间隔内放行的请求数
+type Guard struct {
+	keys    event.ApiKeysDB
+	cfg     config.RateLimitConfig
+	limiter *Limiter
+	now     func() time.Time
+
+	mu      sync.Mutex
+	cached  map[string]cachedKey       // 以密钥哈希为键，只保存数据库中存在的密钥，数量受密钥总数限制
+	missing map[string]time.Time       // 数据库中不存在的密钥哈希和查询时间，最多 maxMissingKeys 个
+	usage   map[usageKey]*usageCounter // 本实例的每日请求计数，写入数据库后删除过去日期的计数
+	sweptAt time.Time
+
+	resourceCtx    context.Context
+	resourceCancel context.CancelFunc
+	tasks          tasks.Group
+}
+
+type cachedKey struct {
+	key      *event.ApiKey
+	loadedAt time.Time
+}
+
+// usageKey 每日请求数按密钥和 UTC 自然日（YYYY-MM-DD）计数
+type usageKey struct {
+	guid uuid.UUID
+	day  string
+}
+
+// usageCounter 本实例对一个密钥一天的请求计数：total 为最近一次写入数据库后读回的所有实例的合计，pending 为尚未写入的本地增量
+type usageCounter struct {
+	day     time.Time
+	total   int64
+	pending int64
+}
+
+func NewGuard(keys event.ApiKeysDB, cfg config.RateLimitConfig) *Guard {
+	resCtx, resCancel := context.WithCancel(context.Background())
+	return &Guard{
+		keys:           keys,
+		cfg:            cfg,
+		limiter:        NewLimiter(),
+		now:            time.Now,
+		cached:         make(map[string]cachedKey),
+		missing:        make(map[string]time.Time),
+		usage:          make(map[usageKey]*usageCounter),
+		resourceCtx:    resCtx,
+		resourceCancel: resCancel,
+		tasks: tasks.Group{HandleCrit: func(err error) {
+			log.Error("critical error in api key guard", "err", err)
+		}},
+	}
+}
+
+// Start 启动定期写入每日请求数的任务
+func (g *Guard) Start() {
+	g.tasks.Go(func() error {
+		ticker := time.NewTicker(usageFlushInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-g.resourceCtx.Done():
+				return nil
+			case <-ticker.C:
+				g.flushUsage()
+			}
+		}
+	})
+}
+
+// Close 停止定期写入，并把剩余的请求数写入数据库
+func (g *Guard) Close() error {
+	g.resourceCancel()
+	err := g.tasks.Wait()
+	g.flushUsage()
+	return err
+}
+
+// Check 检查一次请求，apiKey 为空时按 client（客户端地址）使用匿名限额。
+// 没有缓存的密钥需要查询数据库，与无效的密钥一样先按客户端地址限流，避免用随机密钥绕过匿名限额并放大数据库查询。
+// 查询密钥失败时返回错误
+func (g *Guard) Check(apiKey string, client string) (Decision, error) {
+	now := g.now()
+	if apiKey == "" {
+		if g.cfg.AnonymousRate <= 0 {
+			return Decision{Status: http.StatusUnauthorized, Message: "api key required"}, nil
+		}
+		if ok, wait := g.limiter.Allow("client:"+client, g.cfg.AnonymousRate, g.cfg.AnonymousBurst, now); !ok {
+			return Decision{Status: http.StatusTooManyRequests, Message: "rate limit exceeded, use an api key for a higher limit", RetryAfter: wait}, nil
+		}
+		return Decision{}, nil
+	}
+
+	hash := HashKey(apiKey)
+	key, cached := g.cachedLookup(hash, now)
+	if !cached || key == nil || key.Revoked() {
+		rate, burst := g.cfg.AnonymousRate, g.cfg.AnonymousBurst
+		if rate <= 0 {
+			rate, burst = lookupRate, lookupBurst
+		}
+		if ok, wait := g.limiter.Allow("client:"+client, rate, burst, now); !ok {
+			return Decision{Status: http.StatusTooManyRequests, Message: "rate limit exceeded", RetryAfter: wait}, nil
+		}
+	}
+	if !cached {
+		var err error
+		if key, err = g.load(hash, now); err != nil {
+			return Decision{}, err
+		}
+	}
+	if key == nil || key.Revoked() {
+		return Decision{Status: http.StatusUnauthorized, Message: "invalid api key"}, nil
+	}
+
+	rate, burst, quota := g.cfg.KeyRate, g.cfg.KeyBurst, g.cfg.KeyDailyQuota
+	if key.RateLimit > 0 {
+		rate = key.RateLimit
+	}
+	if key.Burst > 0 {
+		burst = key.Burst
+	}
+	if key.DailyQuota > 0 {
+		quota = key.DailyQuota
+	}
+	if ok, wait := g.limiter.Allow("key:"+key.GUID.String(), rate, burst, now); !ok {
+		return Decision{Status: http.StatusTooManyRequests, Message: "rate limit exceeded", RetryAfter: wait}, nil
+	}
+	if quota <= 0 {
+		return Decision{Key: key}, nil
+	}
+	if !g.countUsage(key.GUID, quota, now) {
+		midnight := now.UTC().Truncate(24 * time.Hour).Add(24 * time.Hour)
+		return Decision{Status: http.StatusTooManyRequests, Message: "daily quota exceeded", RetryAfter: midnight.Sub(now)}, nil
+	}
+	return Decision{Key: key}, nil
+}
+
+// countUsage 在本地计数中为密钥当天的请求数加一，已知的合计达到 quota 时不计数并返回 false
+func (g *Guard) countUsage(guid uuid.UUID, quota int64, now time.Time) bool {
+	day := now.UTC().Truncate(24 * time.Hour)
+	k := usageKey{guid: guid, day: day.Format(time.DateOnly)}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	counter, ok := g.usage[k]
+	if !ok {
+		counter = &usageCounter{day: day}
+		g.usage[k] = counter
+	}
+	if counter.total+counter.pending >= quota {
+		return false
+	}
+	counter.pending++
+	return true
+}
+
+// flushUsage 把本地累加的请求数写入数据库并读回合计，写入失败的增量保留到下一次；已经写入的过去日期的计数被删除
+func (g *Guard) flushUsage() {
+	g.mu.Lock()
+	deltas := make(map[usageKey]int64)
+	for k, counter := range g.usage {
+		if counter.pending > 0 {
+			deltas[k] = counter.pending
+		}
+	}
+	g.mu.Unlock()
+
+	for k, delta := range deltas {
+		g.mu.Lock()
+		day := g.usage[k].day
+		g.mu.Unlock()
+		total, err := g.keys.AddApiKeyUsage(k.guid, day, delta)
+		if err != nil {
+			log.Warn("failed to count api key usage", "key", k.guid, "err", err)
+			continue
+		}
+		g.mu.Lock()
+		counter := g.usage[k]
+		counter.pending -= delta
+		counter.total = total
+		g.mu.Unlock()
+	}
+
+	today := g.now().UTC().Format(time.DateOnly)
+	g.mu.Lock()
+	for k, counter := range g.usage {
+		if k.day != today && counter.pending == 0 {
+			delete(g.usage, k)
+		}
+	}
+	g.mu.Unlock()
+}
+
+// cachedLookup 从缓存中查找密钥，cached 为 false 时需要查询数据库；key 为 nil 且 cached 为 true 表示密钥不存在
+func (g *Guard) cachedLookup(hash string, now time.Time) (key *event.ApiKey, cached bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if now.Sub(g.sweptAt) >= sweepInterval {
+		g.sweptAt = now
+		for h, entry := range g.cached {
+			if now.Sub(entry.loadedAt) >= keyCacheTTL {
+				delete(g.cached, h)
+			}
+		}
+		for h, loadedAt := range g.missing {
+			if now.Sub(loadedAt) >= keyCacheTTL {
+				delete(g.missing, h)
+			}
+		}
+	}
+	if entry, ok := g.cached[hash]; ok && now.Sub(entry.loadedAt) < keyCacheTTL {
+		return entry.key, true
+	}
+	if loadedAt, ok := g.missing[hash]; ok && now.Sub(loadedAt) < keyCacheTTL {
+		return nil, true
+	}
+	return nil, false
+}
+
+// load 按哈希查询密钥并缓存 keyCacheTTL，不存在的密钥在未达到 maxMissingKeys 时同样缓存
+func (g *Guard) load(hash string, now time.Time) (*event.ApiKey, error) {
+	key, err := g.keys.ApiKeyByHash(hash)
+	if err != nil {
+		return nil, err
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if key != nil {
+		g.cached[hash] = cachedKey{key: key, loadedAt: now}
+	} else if _, ok := g.missing[hash]; ok || len(g.missing) < maxMissingKeys {
+		g.missing[hash] = now
+	}
+	return key, nil
+}
+
+// NewApiKey 生成密钥并保存，返回保存的记录和只显示一次的明文。scopes 必须是 Scopes 中的值
+func NewApiKey(keys event.ApiKeysDB, name string, rateLimit float64, burst int, dailyQuota int64, scopes []string) (*event.ApiKey, string, error) {
+	for _, scope := range scopes {
+		if !slices.Contains(Scopes, scope) {
+			return nil, "", fmt.Errorf("unknown scope %q, expected one of %v", scope, Scopes)
+		}
+	}
+	if scopes == nil {
+		scopes = []string{}
+	}
+	key, prefix, hash, err := NewKey()
+	if err != nil {
+		return nil, "", err
+	}
+	record := &event.ApiKey{
+		GUID:       uuid.New(),
+		Name:       name,
+		KeyPrefix:  prefix,
+		KeyHash:    hash,
+		RateLimit:  rateLimit,
+		Burst:      burst,
+		DailyQuota: dailyQuota,
+		Scopes:     scopes,
+		CreatedAt:  time.Now(),
+	}
+	if err := keys.StoreApiKey(record); err != nil {
+		return nil, "", err
+	}
+	return record, key, nil
+}
@@ -0,0 +1,140 @@
+package apikeys
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Sandwichzzy/event-sync-go/config"
+	"github.com/Sandwichzzy/event-sync-go/database/event"
+)
+
+type fakeKeys struct {
+	event.ApiKeysDB
+	keys    map[string]*event.ApiKey
+	lookups int
+	usage   map[uuid.UUID]int64
+}
+
+func (f *fakeKeys) ApiKeyByHash(hash string) (*event.ApiKey, error) {
+	f.lookups++
+	return f.keys[hash], nil
+}
+
+func (f *fakeKeys) AddApiKeyUsage(guid uuid.UUID, day time.Time, requests int64) (int64, error) {
+	f.usage[guid] += requests
+	return f.usage[guid], nil
+}
+
+func TestLimiter(t *testing.T) {
+	now := time.Unix(1704067200, 0)
+	limiter := NewLimiter()
+	for i := 0; i < 2; i++ {
+		ok, _ := limiter.Allow("a", 1, 2, now)
+		require.True(t, ok)
+	}
+	ok, wait := limiter.Allow("a", 1, 2, now)
+	require.False(t, ok)
+	require.Equal(t, time.Second, wait)
+	// 其它 id 的桶互不影响
+	ok, _ = limiter.Allow("b", 1, 2, now)
+	require.True(t, ok)
+
+	ok, _ = limiter.Allow("a", 1, 2, now.Add(time.Second))
+	require.True(t, ok)
+	// 补满的桶会被清理
+	limiter.Allow("c", 1, 2, now.Add(time.Hour))
+	require.Len(t, limiter.buckets, 1)
+}
+
+func TestGuard(t *testing.T) {
+	key, prefix, hash, err := NewKey()
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(key, prefix))
+	require.Equal(t, hash, HashKey(key))
+
+	limited := &event.ApiKey{GUID: uuid.New(), KeyHash: hash, Burst: 100, DailyQuota: 3}
+	revokedAt := time.Unix(1704067200, 0)
+	revoked := &event.ApiKey{GUID: uuid.New(), KeyHash: HashKey("esk_revoked"), RevokedAt: &revokedAt}
+	keys := &fakeKeys{
+		keys:  map[string]*event.ApiKey{hash: limited, revoked.KeyHash: revoked},
+		usage: make(map[uuid.UUID]int64),
+	}
+	now := time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC)
+	guard := NewGuard(keys, config.RateLimitConfig{KeyRate: 10, KeyBurst: 20, KeyDailyQuota: 1000, AnonymousRate: 1, AnonymousBurst: 1})
+	guard.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		decision, err := guard.Check(key, "10.0.0.1")
+		require.NoError(t, err)
+		require.Zero(t, decision.Status)
+		require.Equal(t, limited.GUID, decision.Key.GUID)
+	}
+	// 每日配额按 UTC 自然日计算，超过后等待到第二天
+	decision, err := guard.Check(key, "10.0.0.1")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusTooManyRequests, decision.Status)
+	require.Equal(t, time.Hour, decision.RetryAfter)
+	// 密钥查询结果被缓存
+	require.Equal(t, 1, keys.lookups)
+
+	// 请求数在实例内累加，定期写入数据库；读回的合计包含其它实例的请求
+	require.Empty(t, keys.usage)
+	guard.flushUsage()
+	require.Equal(t, int64(3), keys.usage[limited.GUID])
+	limited.DailyQuota = 5
+	keys.usage[limited.GUID] += 2
+	decision, err = guard.Check(key, "10.0.0.1")
+	require.NoError(t, err)
+	require.Zero(t, decision.Status)
+	guard.flushUsage()
+	require.Equal(t, int64(6), keys.usage[limited.GUID])
+	decision, err = guard.Check(key, "10.0.0.1")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusTooManyRequests, decision.Status)
+
+	// 无效的密钥按客户端地址的匿名令牌桶计数，超过后不再查询数据库
+	for _, invalid := range []string{"esk_revoked", "esk_unknown"} {
+		decision, err = guard.Check(invalid, "10.0.0.4")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusUnauthorized, decision.Status)
+		now = now.Add(time.Second)
+	}
+	lookups := keys.lookups
+	decision, err = guard.Check("esk_random", "10.0.0.4")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusUnauthorized, decision.Status)
+	decision, err = guard.Check("esk_random2", "10.0.0.4")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusTooManyRequests, decision.Status)
+	require.Equal(t, lookups+1, keys.lookups)
+
+	// 不存在的密钥最多缓存 maxMissingKeys 个
+	for i := 0; i < maxMissingKeys+10; i++ {
+		_, err := guard.load(HashKey(fmt.Sprintf("esk_missing_%d", i)), now)
+		require.NoError(t, err)
+	}
+	require.Len(t, guard.missing, maxMissingKeys)
+
+	decision, err = guard.Check("", "10.0.0.1")
+	require.NoError(t, err)
+	require.Zero(t, decision.Status)
+	require.Nil(t, decision.Key)
+	decision, err = guard.Check("", "10.0.0.1")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusTooManyRequests, decision.Status)
+	require.Equal(t, time.Second, decision.RetryAfter)
+	decision, err = guard.Check("", "10.0.0.2")
+	require.NoError(t, err)
+	require.Zero(t, decision.Status)
+
+	guard.cfg.AnonymousRate = 0
+	decision, err = guard.Check("", "10.0.0.3")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusUnauthorized, decision.Status)
+}
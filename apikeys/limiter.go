@@ -0,0 +1,77 @@
+package apikeys
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// sweepInterval 清理已经补满的令牌桶和过期的密钥缓存的间隔
+const sweepInterval = time.Minute
+
+// Limiter 按 id 维护的令牌桶，桶的速率和容量在每次调用时传入，密钥的限额修改后立即生效
+type Limiter struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	sweptAt time.Time
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	rate    float64
+	burst   float64
+}
+
+// fill 按经过的时间补充令牌，最多补到容量
+func (b *bucket) fill(now time.Time) {
+	if elapsed := now.Sub(b.updated).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(b.burst, b.tokens+elapsed*b.rate)
+		b.updated = now
+	}
+}
+
+func NewLimiter() *Limiter {
+	return &Limiter{buckets: make(map[string]*bucket)}
+}
+
+// Allow 从 id 的令牌桶取一个令牌，桶每秒补充 rate 个令牌、最多 burst 个，新建的桶是满的。
+// 没有令牌时返回 false 和补充一个令牌需要等待的时间。rate <= 0 时不限流
+func (l *Limiter) Allow(id string, rate float64, burst int, now time.Time) (bool, time.Duration) {
+	if rate <= 0 {
+		return true, 0
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sweep(now)
+
+	b, ok := l.buckets[id]
+	if !ok {
+		b = &bucket{tokens: float64(burst), updated: now}
+		l.buckets[id] = b
+	}
+	b.rate, b.burst = rate, float64(burst)
+	b.fill(now)
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	return false, time.Duration((1 - b.tokens) / rate * float64(time.Second))
+}
+
+// sweep 删除已经补满的桶，这些桶与新建的桶没有区别，避免客户端地址很多时内存持续增长
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.sweptAt) < sweepInterval {
+		return
+	}
+	l.sweptAt = now
+	for id, b := range l.buckets {
+		b.fill(now)
+		if b.tokens >= b.burst {
+			delete(l.buckets, id)
+		}
+	}
+}
@@ -2,19 +2,35 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/Sandwichzzy/event-sync-go/services/api"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/google/uuid"
 
 	"github.com/urfave/cli/v2"
 
 	event_sync "github.com/Sandwichzzy/event-sync-go"
+	"github.com/Sandwichzzy/event-sync-go/apikeys"
 	"github.com/Sandwichzzy/event-sync-go/common/cliapp"
 	"github.com/Sandwichzzy/event-sync-go/common/opio"
 	"github.com/Sandwichzzy/event-sync-go/config"
 	"github.com/Sandwichzzy/event-sync-go/database"
+	"github.com/Sandwichzzy/event-sync-go/event"
+	"github.com/Sandwichzzy/event-sync-go/event/contracts"
+	"github.com/Sandwichzzy/event-sync-go/export"
 	flags2 "github.com/Sandwichzzy/event-sync-go/flags"
+	"github.com/Sandwichzzy/event-sync-go/migrations"
 	"github.com/Sandwichzzy/event-sync-go/services/grpc"
+	"github.com/Sandwichzzy/event-sync-go/synchronizer/node"
+	"github.com/Sandwichzzy/event-sync-go/verifier"
 )
 
 func runIndexer(ctx *cli.Context, shutdown context.CancelCauseFunc) (cliapp.Lifecycle, error) {
@@ -24,6 +40,12 @@ func runIndexer(ctx *cli.Context, shutdown context.CancelCauseFunc) (cliapp.Life
 		log.Error("failed to load config", "err", err)
 		return nil, err
 	}
+	if cfg.AutoMigrate {
+		if err := withDB(ctx, migrateUp); err != nil {
+			log.Error("failed to run migrations", "err", err)
+			return nil, err
+		}
+	}
 	return event_sync.NewEventSync(ctx.Context, &cfg, shutdown)
 }
 
@@ -53,8 +75,34 @@ func runApi(ctx *cli.Context, _ context.CancelCauseFunc) (cliapp.Lifecycle, erro
 	return api.NewApi(ctx.Context, &cfg)
 }
 
-func runMigrations(ctx *cli.Context) error {
-	log.Info("Running migrations...")
+// loadMigrations 未指定 --migrations-dir 时使用编译进二进制的迁移文件
+func loadMigrations(cfg *config.Config) ([]database.Migration, error) {
+	if cfg.Migrations == "" {
+		return database.LoadMigrations(migrations.FS)
+	}
+	return database.LoadMigrations(os.DirFS(cfg.Migrations))
+}
+
+// migrateUp 执行所有未执行的迁移，然后进行 worker 表 schema v2 的数据迁移
+func migrateUp(db *database.DB, cfg *config.Config) error {
+	ms, err := loadMigrations(cfg)
+	if err != nil {
+		return err
+	}
+	count, err := db.MigrateUp(ms)
+	if err != nil {
+		return err
+	}
+	log.Info("migrations up to date", "applied", count)
+	// worker 表 schema v2 的数据迁移：从 contract_events 重建 v1 遗留记录
+	treasureManagers := cfg.ContractAddresses(contracts.TreasureManagerProcessorName)
+	if len(treasureManagers) == 0 {
+		return nil
+	}
+	return contracts.MigrateWorkerRowsV2(db, treasureManagers[0])
+}
+
+func withDB(ctx *cli.Context, fn func(db *database.DB, cfg *config.Config) error) error {
 	cfg, err := config.LoadConfig(ctx)
 	if err != nil {
 		log.Error("failed to load config", "err", err)
@@ -72,7 +120,342 @@ func runMigrations(ctx *cli.Context) error {
 			return
 		}
 	}(db)
-	return db.ExecuteSQLMigration(cfg.Migrations)
+	return fn(db, &cfg)
+}
+
+func runMigrations(ctx *cli.Context) error {
+	log.Info("Running migrations...")
+	return withDB(ctx, migrateUp)
+}
+
+func runMigrationsDown(ctx *cli.Context) error {
+	return withDB(ctx, func(db *database.DB, cfg *config.Config) error {
+		ms, err := loadMigrations(cfg)
+		if err != nil {
+			return err
+		}
+		reverted, err := db.MigrateDown(ms, ctx.Int(stepsFlag.Name))
+		if err != nil {
+			return err
+		}
+		log.Info("reverted migrations", "count", len(reverted))
+		return nil
+	})
+}
+
+func runMigrationsRedo(ctx *cli.Context) error {
+	return withDB(ctx, func(db *database.DB, cfg *config.Config) error {
+		ms, err := loadMigrations(cfg)
+		if err != nil {
+			return err
+		}
+		// 只重新执行回滚的版本，不执行其他未执行的迁移
+		reverted, err := db.MigrateDown(ms, 1)
+		if err != nil {
+			return err
+		}
+		for _, m := range reverted {
+			if err := db.ApplyMigration(m); err != nil {
+				return err
+			}
+			log.Info("reapplied migration", "version", m.Version, "name", m.Name)
+		}
+		return nil
+	})
+}
+
+func runMigrationsStatus(ctx *cli.Context) error {
+	return withDB(ctx, func(db *database.DB, cfg *config.Config) error {
+		ms, err := loadMigrations(cfg)
+		if err != nil {
+			return err
+		}
+		statuses, err := db.MigrationStatus(ms)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+		drift, missing := false, false
+		for _, status := range statuses {
+			state, appliedAt := "pending", "-"
+			if status.Applied {
+				state, appliedAt = "applied", status.AppliedAt.Format(time.RFC3339)
+			}
+			if status.Drift {
+				state, drift = "drift", true
+			}
+			if status.Missing {
+				state, missing = "missing file", true
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", status.Version, status.Name, state, appliedAt)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+		if drift {
+			return fmt.Errorf("checksum drift detected in applied migrations")
+		}
+		if missing {
+			return fmt.Errorf("applied migrations without migration files")
+		}
+		return nil
+	})
+}
+
+var (
+	stepsFlag = &cli.IntFlag{
+		Name:  "steps",
+		Value: 1,
+		Usage: "number of migrations to revert",
+	}
+	reindexFromFlag = &cli.Uint64Flag{
+		Name:     "from",
+		Usage:    "first block of the range to reindex",
+		Required: true,
+	}
+	reindexToFlag = &cli.Uint64Flag{
+		Name:  "to",
+		Usage: "last block of the range to reindex, defaults to the latest processed event block",
+	}
+	reindexProcessorFlag = &cli.StringSliceFlag{
+		Name:  "processor",
+		Usage: "contract processors to reindex, defaults to the enabled processors",
+	}
+	reindexChunkSizeFlag = &cli.Uint64Flag{
+		Name:  "chunk-size",
+		Value: 1000,
+		Usage: "number of blocks reindexed in one transaction",
+	}
+	verifyRpcSamplesFlag = &cli.IntFlag{
+		Name:  "rpc-samples",
+		Usage: "number of random block headers compared against the chain rpc, 0 disables the rpc check",
+	}
+	verifySampleLimitFlag = &cli.IntFlag{
+		Name:  "sample-limit",
+		Value: 20,
+		Usage: "maximum number of problem samples listed per check",
+	}
+	exportEntityFlag = &cli.StringFlag{
+		Name:     "entity",
+		Usage:    "entity to export: deposits, withdrawals, grants or manager-updates",
+		Required: true,
+	}
+	exportFromFlag = &cli.StringFlag{
+		Name:  "from",
+		Usage: "first event time to export (inclusive), unix seconds, RFC 3339 or YYYY-MM-DD (UTC)",
+	}
+	exportToFlag = &cli.StringFlag{
+		Name:  "to",
+		Usage: "last event time to export (inclusive), unix seconds, RFC 3339 or YYYY-MM-DD (UTC, the whole day)",
+	}
+	exportFormatFlag = &cli.StringFlag{
+		Name:  "format",
+		Value: export.FormatCSV,
+		Usage: "output format: csv or ndjson",
+	}
+	exportTokenFlag = &cli.StringFlag{
+		Name:  "token",
+		Usage: "only export events of this token address",
+	}
+	exportOutputFlag = &cli.StringFlag{
+		Name:  "output",
+		Usage: "file to write the export to, defaults to stdout",
+	}
+	apiKeyNameFlag = &cli.StringFlag{
+		Name:     "name",
+		Usage:    "name of the api key owner",
+		Required: true,
+	}
+	apiKeyRateLimitFlag = &cli.Float64Flag{
+		Name:  "rate-limit",
+		Usage: "requests per second allowed for the key, 0 uses the api service default",
+	}
+	apiKeyBurstFlag = &cli.IntFlag{
+		Name:  "burst",
+		Usage: "token bucket size of the key, 0 uses the api service default",
+	}
+	apiKeyDailyQuotaFlag = &cli.Int64Flag{
+		Name:  "daily-quota",
+		Usage: "requests allowed per UTC day for the key, 0 uses the api service default",
+	}
+	apiKeyScopeFlag = &cli.StringSliceFlag{
+		Name:  "scope",
+		Usage: "restricted api the key may access, can be repeated: webhooks",
+	}
+	apiKeyIdFlag = &cli.StringFlag{
+		Name:     "id",
+		Usage:    "guid of the api key",
+		Required: true,
+	}
+)
+
+// runApiKeyCreate 创建 API key，明文只在这里输出一次，数据库中只保存哈希
+func runApiKeyCreate(ctx *cli.Context) error {
+	return withDB(ctx, func(db *database.DB, cfg *config.Config) error {
+		key, secret, err := apikeys.NewApiKey(db.ApiKeys, ctx.String(apiKeyNameFlag.Name),
+			ctx.Float64(apiKeyRateLimitFlag.Name), ctx.Int(apiKeyBurstFlag.Name), ctx.Int64(apiKeyDailyQuotaFlag.Name),
+			ctx.StringSlice(apiKeyScopeFlag.Name))
+		if err != nil {
+			log.Error("failed to create api key", "err", err)
+			return err
+		}
+		fmt.Printf("guid: %s\nkey:  %s\n", key.GUID, secret)
+		log.Info("api key created, store the key now, it cannot be shown again", "guid", key.GUID, "name", key.Name)
+		return nil
+	})
+}
+
+// runApiKeyRevoke 吊销 API key，API 服务最多 30 秒后拒绝该密钥
+func runApiKeyRevoke(ctx *cli.Context) error {
+	guid, err := uuid.Parse(ctx.String(apiKeyIdFlag.Name))
+	if err != nil {
+		return fmt.Errorf("invalid api key id %q: %w", ctx.String(apiKeyIdFlag.Name), err)
+	}
+	return withDB(ctx, func(db *database.DB, cfg *config.Config) error {
+		revoked, err := db.ApiKeys.RevokeApiKey(guid)
+		if err != nil {
+			return err
+		}
+		if !revoked {
+			return fmt.Errorf("api key %s not found or already revoked", guid)
+		}
+		log.Info("api key revoked", "guid", guid)
+		return nil
+	})
+}
+
+// runApiKeyList 列出所有 API key 的限额和当天（UTC）的请求数，限额为 0 表示使用 API 服务的默认值
+func runApiKeyList(ctx *cli.Context) error {
+	return withDB(ctx, func(db *database.DB, cfg *config.Config) error {
+		keys, err := db.ApiKeys.ApiKeys()
+		if err != nil {
+			return err
+		}
+		usage, err := db.ApiKeys.ApiKeyUsage(time.Now())
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "GUID\tNAME\tPREFIX\tRATE LIMIT\tBURST\tDAILY QUOTA\tSCOPES\tTODAY\tCREATED AT\tSTATUS")
+		for _, key := range keys {
+			status := "active"
+			if key.Revoked() {
+				status = "revoked " + key.RevokedAt.Format(time.RFC3339)
+			}
+			scopes := "-"
+			if len(key.Scopes) > 0 {
+				scopes = strings.Join(key.Scopes, ",")
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%g\t%d\t%d\t%s\t%d\t%s\t%s\n", key.GUID, key.Name, key.KeyPrefix,
+				key.RateLimit, key.Burst, key.DailyQuota, scopes, usage[key.GUID], key.CreatedAt.Format(time.RFC3339), status)
+		}
+		return w.Flush()
+	})
+}
+
+// runExport 把一个实体在时间范围内的记录按 (block_number, log_index) 升序流式导出为 CSV 或 NDJSON
+func runExport(ctx *cli.Context) error {
+	opts := export.Options{Entity: ctx.String(exportEntityFlag.Name), Format: ctx.String(exportFormatFlag.Name)}
+	if _, err := export.Columns(opts.Entity); err != nil {
+		return err
+	}
+	if err := export.ValidateFormat(opts.Format); err != nil {
+		return err
+	}
+	var err error
+	if ctx.IsSet(exportFromFlag.Name) {
+		if opts.Filter.FromTimestamp, err = export.ParseTime(ctx.String(exportFromFlag.Name), false); err != nil {
+			return err
+		}
+	}
+	if ctx.IsSet(exportToFlag.Name) {
+		if opts.Filter.ToTimestamp, err = export.ParseTime(ctx.String(exportToFlag.Name), true); err != nil {
+			return err
+		}
+		if opts.Filter.ToTimestamp < opts.Filter.FromTimestamp {
+			return fmt.Errorf("--to is before --from")
+		}
+	}
+	if ctx.IsSet(exportTokenFlag.Name) {
+		if !common.IsHexAddress(ctx.String(exportTokenFlag.Name)) {
+			return fmt.Errorf("invalid token address %q", ctx.String(exportTokenFlag.Name))
+		}
+		token := common.HexToAddress(ctx.String(exportTokenFlag.Name))
+		opts.Filter.TokenAddress = &token
+	}
+
+	return withDB(ctx, func(db *database.DB, cfg *config.Config) error {
+		out := os.Stdout
+		if ctx.IsSet(exportOutputFlag.Name) {
+			file, err := os.Create(ctx.String(exportOutputFlag.Name))
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			out = file
+		}
+		count, err := export.Export(ctx.Context, db.EventExport, db.Tokens, out, opts)
+		if err != nil {
+			log.Error("export failed", "entity", opts.Entity, "rows", count, "err", err)
+			return err
+		}
+		log.Info("export finished", "entity", opts.Entity, "format", opts.Format, "rows", count)
+		return nil
+	})
+}
+
+// runReindex 不访问 RPC，从 contract_events 重建区间内的处理器数据，用于修复解码问题后重新生成 worker 表
+func runReindex(ctx *cli.Context) error {
+	return withDB(ctx, func(db *database.DB, cfg *config.Config) error {
+		eventConfig := &event.EventProcessorConfig{
+			EventBlockStep: ctx.Uint64(reindexChunkSizeFlag.Name),
+			AbiDir:         cfg.AbiDir,
+			Processors:     cfg.Processors,
+			Contracts:      cfg.Contracts,
+		}
+		if ctx.IsSet(reindexProcessorFlag.Name) {
+			eventConfig.Processors = ctx.StringSlice(reindexProcessorFlag.Name)
+		}
+		var toHeight *big.Int
+		if ctx.IsSet(reindexToFlag.Name) {
+			toHeight = new(big.Int).SetUint64(ctx.Uint64(reindexToFlag.Name))
+		}
+		fromHeight := new(big.Int).SetUint64(ctx.Uint64(reindexFromFlag.Name))
+		return event.Reindex(ctx.Context, db, eventConfig, fromHeight, toHeight)
+	})
+}
+
+// runVerify 检查索引数据的完整性，把 JSON 报告输出到标准输出，发现问题时以非零状态退出
+func runVerify(ctx *cli.Context) error {
+	return withDB(ctx, func(db *database.DB, cfg *config.Config) error {
+		var client node.EthClient
+		if samples := ctx.Int(verifyRpcSamplesFlag.Name); samples > 0 {
+			ethClient, err := node.DialEthClient(ctx.Context, cfg.Chain.ChainRpcUrl)
+			if err != nil {
+				log.Error("failed to dial eth client", "err", err)
+				return err
+			}
+			defer ethClient.Close()
+			client = ethClient
+		}
+
+		report := verifier.Verify(db, client, verifier.Config{
+			SampleLimit:     ctx.Int(verifySampleLimitFlag.Name),
+			RpcSamples:      ctx.Int(verifyRpcSamplesFlag.Name),
+			EventStartBlock: cfg.Chain.StartingHeight,
+		})
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return err
+		}
+		if !report.OK {
+			log.Error("index verification failed", "checks", report.Failed())
+			return fmt.Errorf("index verification failed: %v", report.Failed())
+		}
+		return nil
+	})
 }
 
 func NewCli() *cli.App {
@@ -105,6 +488,74 @@ func NewCli() *cli.App {
 				Flags:       flags,
 				Description: "Runs the database migrations",
 				Action:      runMigrations,
+				Subcommands: []*cli.Command{
+					{
+						Name:   "up",
+						Flags:  flags,
+						Usage:  "Applies all pending migrations",
+						Action: runMigrations,
+					},
+					{
+						Name:   "down",
+						Flags:  append(append([]cli.Flag{}, flags...), stepsFlag),
+						Usage:  "Reverts the most recently applied migrations",
+						Action: runMigrationsDown,
+					},
+					{
+						Name:   "redo",
+						Flags:  flags,
+						Usage:  "Reverts and re-applies the latest applied migration only",
+						Action: runMigrationsRedo,
+					},
+					{
+						Name:   "status",
+						Flags:  flags,
+						Usage:  "Prints the status of every migration",
+						Action: runMigrationsStatus,
+					},
+				},
+			},
+			{
+				Name:        "reindex",
+				Flags:       append(append([]cli.Flag{}, flags...), reindexFromFlag, reindexToFlag, reindexProcessorFlag, reindexChunkSizeFlag),
+				Description: "Rebuilds the processor tables of a block range from contract_events without RPC calls",
+				Action:      runReindex,
+			},
+			{
+				Name:        "verify",
+				Flags:       append(append([]cli.Flag{}, flags...), verifyRpcSamplesFlag, verifySampleLimitFlag),
+				Description: "Verifies the integrity of the indexed data and prints a JSON report",
+				Action:      runVerify,
+			},
+			{
+				Name:        "export",
+				Flags:       append(append([]cli.Flag{}, flags...), exportEntityFlag, exportFromFlag, exportToFlag, exportFormatFlag, exportTokenFlag, exportOutputFlag),
+				Description: "Streams the records of an entity in a time range to CSV or NDJSON",
+				Action:      runExport,
+			},
+			{
+				Name:        "apikey",
+				Description: "Manages the api keys of the http api",
+				Subcommands: []*cli.Command{
+					{
+						Name:   "create",
+						Flags:  append(append([]cli.Flag{}, flags...), apiKeyNameFlag, apiKeyRateLimitFlag, apiKeyBurstFlag, apiKeyDailyQuotaFlag, apiKeyScopeFlag),
+						Usage:  "Creates an api key and prints it once",
+						Action: runApiKeyCreate,
+					},
+					{
+						Name:   "revoke",
+						Flags:  append(append([]cli.Flag{}, flags...), apiKeyIdFlag),
+						Usage:  "Revokes an api key",
+						Action: runApiKeyRevoke,
+					},
+					{
+						Name:   "list",
+						Flags:  flags,
+						Usage:  "Lists the api keys with their limits and today's usage",
+						Action: runApiKeyList,
+					},
+				},
 			},
 			{
 				Name:        "version",
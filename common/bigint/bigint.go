@@ -1,6 +1,9 @@
 package bigint
 
-import "math/big"
+import (
+	"math/big"
+	"strings"
+)
 
 var (
 	Zero = big.NewInt(0)
@@ -46,3 +49,29 @@ func StringToBigInt(value string) *big.Int {
 	}
 	return intValue
 }
+
+// FormatUnits 把最小单位的整数金额按 decimals 转成十进制字符串（不丢精度，去掉小数末尾的0）
+// 例如 FormatUnits(1500000000000000000, 18) == "1.5"
+func FormatUnits(amount *big.Int, decimals uint8) string {
+	if amount == nil {
+		return ""
+	}
+	if decimals == 0 {
+		return amount.String()
+	}
+
+	abs := new(big.Int).Abs(amount)
+	base := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	integer, fraction := new(big.Int).QuoRem(abs, base, new(big.Int))
+
+	result := integer.String()
+	if fraction.Sign() != 0 {
+		fractionStr := fraction.String()
+		fractionStr = strings.Repeat("0", int(decimals)-len(fractionStr)) + fractionStr
+		result += "." + strings.TrimRight(fractionStr, "0")
+	}
+	if amount.Sign() < 0 {
+		result = "-" + result
+	}
+	return result
+}
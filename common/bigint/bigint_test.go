@@ -27,3 +27,16 @@ func TestClamp(t *testing.T) {
 	require.False(t, end == result)
 	require.Equal(t, uint64(5), result.Uint64())
 }
+
+func TestFormatUnits(t *testing.T) {
+	require.Equal(t, "", FormatUnits(nil, 18))
+	require.Equal(t, "0", FormatUnits(big.NewInt(0), 18))
+	require.Equal(t, "123", FormatUnits(big.NewInt(123), 0))
+	require.Equal(t, "1.5", FormatUnits(big.NewInt(1_500_000), 6))
+	require.Equal(t, "0.000001", FormatUnits(big.NewInt(1), 6))
+	require.Equal(t, "-2.01", FormatUnits(big.NewInt(-2_010_000), 6))
+
+	// 超过 uint64 范围的金额不能丢精度
+	amount, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	require.Equal(t, "123456789012.34567890123456789", FormatUnits(amount, 18))
+}
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -11,20 +13,31 @@ import (
 )
 
 const (
-	defaultConfirmations = 64
-	defaultLoopInterval  = 5000
-	TreasureManagerAddr  = "0x388fF618Ca5c1b8F28D4E845B431Ca3D4200140e"
+	defaultConfirmations     = 64
+	defaultLoopInterval      = 5000
+	defaultReconcileInterval = time.Minute
 )
 
 type Config struct {
-	Migrations     string
-	Chain          ChainConfig
-	MasterDB       DBConfig
-	SlaveDB        DBConfig
-	SlaveDbEnable  bool
-	ApiCacheEnable bool
-	HTTPServer     ServerConfig
-	GrpcServer     ServerConfig
+	Migrations                 string
+	AutoMigrate                bool
+	Chain                      ChainConfig
+	MasterDB                   DBConfig
+	SlaveDB                    DBConfig
+	SlaveDbEnable              bool
+	ApiCacheEnable             bool
+	ApiCache                   ApiCacheConfig
+	ApiRateLimit               RateLimitConfig
+	HTTPServer                 ServerConfig
+	GrpcServer                 ServerConfig
+	Grpc                       GrpcConfig
+	ReconcileInterval          time.Duration
+	AbiDir                     string
+	Processors                 []string
+	Contracts                  []ContractBinding
+	OutboxSinks                []string
+	WebhookMaxAttempts         int
+	WebhookAllowPrivateTargets bool
 }
 
 type ChainConfig struct {
@@ -37,6 +50,13 @@ type ChainConfig struct {
 	LoopInterval   time.Duration
 }
 
+// ContractBinding 把一个合约地址绑定到一个合约处理器，AbiFile 为空时处理器使用自己的 ABI（或 ABI 目录）
+type ContractBinding struct {
+	Processor string
+	Address   common.Address
+	AbiFile   string
+}
+
 type DBConfig struct {
 	Host     string
 	Port     int
@@ -50,6 +70,41 @@ type ServerConfig struct {
 	Port int
 }
 
+// ApiCacheConfig API 响应缓存配置，RedisURL 为空时使用进程内 LRU
+type ApiCacheConfig struct {
+	RedisURL     string
+	Size         int           // 进程内 LRU 最多缓存的响应数
+	TTL          time.Duration // 随索引高度变化的响应的过期时间
+	FinalizedTTL time.Duration // 区块或时间范围在索引高度以内的响应的过期时间
+}
+
+// RateLimitConfig HTTP API 的限流配置，速率为每秒请求数
+type RateLimitConfig struct {
+	KeyRate        float64 // API key 未单独配置时的默认速率，0 表示不限
+	KeyBurst       int     // API key 未单独配置时的默认令牌桶容量
+	KeyDailyQuota  int64   // API key 未单独配置时的默认每日（UTC）请求数，0 表示不限
+	AnonymousRate  float64 // 未携带 API key 时每个客户端地址的速率，0 表示必须携带 API key
+	AnonymousBurst int     // 未携带 API key 时每个客户端地址的令牌桶容量
+}
+
+// GrpcConfig grpc 服务的认证、超时和 TLS 配置
+type GrpcConfig struct {
+	ApiKeys        []string      // 为空时不认证
+	RequestTimeout time.Duration // unary 请求的默认 deadline
+	TLS            TLSConfig
+}
+
+// TLSConfig CertFile 和 KeyFile 都设置时启用 TLS，再设置 ClientCAFile 时校验客户端证书（mTLS）
+type TLSConfig struct {
+	CertFile     string
+	KeyFile      string
+	ClientCAFile string
+}
+
+func (c TLSConfig) Enabled() bool {
+	return c.CertFile != "" && c.KeyFile != ""
+}
+
 func LoadConfig(cliCtx *cli.Context) (Config, error) {
 	var cfg Config
 	cfg = NewConfig(cliCtx)
@@ -60,26 +115,73 @@ func LoadConfig(cliCtx *cli.Context) (Config, error) {
 	if cfg.Chain.LoopInterval == 0 {
 		cfg.Chain.LoopInterval = defaultLoopInterval
 	}
+	if cfg.ReconcileInterval == 0 {
+		cfg.ReconcileInterval = defaultReconcileInterval
+	}
+	contracts, err := ParseContractBindings(cliCtx.StringSlice(flags.ContractsFlag.Name))
+	if err != nil {
+		return cfg, err
+	}
+	cfg.Contracts = contracts
+	cfg.Chain.Contracts = cfg.ContractAddresses("")
 	log.Info("loaded chain config", "config", cfg.Chain)
 	return cfg, nil
 }
 
-func LoadContracts() []common.Address {
-	var Contracts []common.Address
-	Contracts = append(Contracts, common.HexToAddress(TreasureManagerAddr))
-	return Contracts
+// ParseContractBindings 解析 <processor>=<address>[@<abi file>] 格式的合约绑定，至少需要一个绑定
+func ParseContractBindings(values []string) ([]ContractBinding, error) {
+	bindings := make([]ContractBinding, 0, len(values))
+	for _, value := range values {
+		processor, target, ok := strings.Cut(strings.TrimSpace(value), "=")
+		if !ok || processor == "" {
+			return nil, fmt.Errorf("invalid contract %q, expected <processor>=<address>[@<abi file>]", value)
+		}
+		address, abiFile, _ := strings.Cut(target, "@")
+		if !common.IsHexAddress(address) {
+			return nil, fmt.Errorf("invalid contract %q: invalid address %q", value, address)
+		}
+		bindings = append(bindings, ContractBinding{Processor: processor, Address: common.HexToAddress(address), AbiFile: abiFile})
+	}
+	if len(bindings) == 0 {
+		return nil, fmt.Errorf("no contracts configured")
+	}
+	return bindings, nil
+}
+
+// ContractBindings 绑定到 processor 的合约
+func (c *Config) ContractBindings(processor string) []ContractBinding {
+	var bindings []ContractBinding
+	for _, binding := range c.Contracts {
+		if binding.Processor == processor {
+			bindings = append(bindings, binding)
+		}
+	}
+	return bindings
+}
+
+// ContractAddresses 绑定到 processor 的合约地址（去重），processor 为空时返回所有绑定的地址，即同步器拉取日志的合约
+func (c *Config) ContractAddresses(processor string) []common.Address {
+	seen := make(map[common.Address]bool, len(c.Contracts))
+	var addresses []common.Address
+	for _, binding := range c.Contracts {
+		if (processor == "" || binding.Processor == processor) && !seen[binding.Address] {
+			seen[binding.Address] = true
+			addresses = append(addresses, binding.Address)
+		}
+	}
+	return addresses
 }
 
 func NewConfig(cliCtx *cli.Context) Config {
 	return Config{
-		Migrations: cliCtx.String(flags.MigrationsFlag.Name),
+		Migrations:  cliCtx.String(flags.MigrationsFlag.Name),
+		AutoMigrate: cliCtx.Bool(flags.AutoMigrateFlag.Name),
 		Chain: ChainConfig{
 			ChainId:        cliCtx.Uint(flags.ChainIdFlag.Name),
 			ChainRpcUrl:    cliCtx.String(flags.ChainRpcFlag.Name),
 			StartingHeight: cliCtx.Uint64(flags.StartingHeightFlag.Name),
 			Confirmations:  cliCtx.Uint64(flags.ConfirmationsFlag.Name),
 			BlockStep:      cliCtx.Uint64(flags.BlocksStepFlag.Name),
-			Contracts:      LoadContracts(),
 			LoopInterval:   cliCtx.Duration(flags.LoopIntervalFlag.Name),
 		},
 		MasterDB: DBConfig{
@@ -96,7 +198,21 @@ func NewConfig(cliCtx *cli.Context) Config {
 			User:     cliCtx.String(flags.SlaveDbUserFlag.Name),
 			Password: cliCtx.String(flags.SlaveDbPasswordFlag.Name),
 		},
-		SlaveDbEnable: cliCtx.Bool(flags.SlaveDbEnableFlag.Name),
+		SlaveDbEnable:  cliCtx.Bool(flags.SlaveDbEnableFlag.Name),
+		ApiCacheEnable: cliCtx.Bool(flags.ApiCacheEnableFlag.Name),
+		ApiCache: ApiCacheConfig{
+			RedisURL:     cliCtx.String(flags.ApiCacheRedisFlag.Name),
+			Size:         cliCtx.Int(flags.ApiCacheSizeFlag.Name),
+			TTL:          cliCtx.Duration(flags.ApiCacheTTLFlag.Name),
+			FinalizedTTL: cliCtx.Duration(flags.ApiCacheFinalizedTTLFlag.Name),
+		},
+		ApiRateLimit: RateLimitConfig{
+			KeyRate:        cliCtx.Float64(flags.ApiKeyRateLimitFlag.Name),
+			KeyBurst:       cliCtx.Int(flags.ApiKeyBurstFlag.Name),
+			KeyDailyQuota:  cliCtx.Int64(flags.ApiKeyDailyQuotaFlag.Name),
+			AnonymousRate:  cliCtx.Float64(flags.ApiAnonymousRateLimitFlag.Name),
+			AnonymousBurst: cliCtx.Int(flags.ApiAnonymousBurstFlag.Name),
+		},
 		HTTPServer: ServerConfig{
 			Host: cliCtx.String(flags.HttpHostFlag.Name),
 			Port: cliCtx.Int(flags.HttpPortFlag.Name),
@@ -105,5 +221,20 @@ func NewConfig(cliCtx *cli.Context) Config {
 			Host: cliCtx.String(flags.GrpcHostFlag.Name),
 			Port: cliCtx.Int(flags.GrpcPortFlag.Name),
 		},
+		Grpc: GrpcConfig{
+			ApiKeys:        cliCtx.StringSlice(flags.GrpcApiKeysFlag.Name),
+			RequestTimeout: cliCtx.Duration(flags.GrpcRequestTimeoutFlag.Name),
+			TLS: TLSConfig{
+				CertFile:     cliCtx.String(flags.GrpcTlsCertFlag.Name),
+				KeyFile:      cliCtx.String(flags.GrpcTlsKeyFlag.Name),
+				ClientCAFile: cliCtx.String(flags.GrpcTlsClientCAFlag.Name),
+			},
+		},
+		ReconcileInterval:          cliCtx.Duration(flags.ReconcileIntervalFlag.Name),
+		AbiDir:                     cliCtx.String(flags.AbiDirFlag.Name),
+		OutboxSinks:                cliCtx.StringSlice(flags.OutboxSinksFlag.Name),
+		WebhookMaxAttempts:         cliCtx.Int(flags.WebhookMaxAttemptsFlag.Name),
+		WebhookAllowPrivateTargets: cliCtx.Bool(flags.WebhookAllowPrivateTargetsFlag.Name),
+		Processors:                 cliCtx.StringSlice(flags.ProcessorsFlag.Name),
 	}
 }
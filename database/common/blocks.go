@@ -28,6 +28,7 @@ func (BlockHeader) TableName() string {
 type BlocksView interface {
 	BlockHeader(hash common.Hash) (*BlockHeader, error)
 	BlockHeaderByNumber(*big.Int) (*BlockHeader, error)
+	BlockHeadersInRange(fromHeight *big.Int, toHeight *big.Int) ([]BlockHeader, error)
 	BlockHeaderWithFilter(BlockHeader) (*BlockHeader, error)
 	BlockHeaderWithScope(func(db *gorm.DB) *gorm.DB) (*BlockHeader, error)
 	LatestBlockHeader() (*BlockHeader, error)
@@ -46,6 +47,19 @@ func (b blocksDB) BlockHeaderByNumber(number *big.Int) (*BlockHeader, error) {
 	return b.BlockHeaderWithFilter(BlockHeader{Number: number})
 }
 
+// BlockHeadersInRange 一次查询返回 [fromHeight, toHeight] 内的区块头，按高度升序
+func (b blocksDB) BlockHeadersInRange(fromHeight *big.Int, toHeight *big.Int) ([]BlockHeader, error) {
+	var headers []BlockHeader
+	result := b.gorm.Table("block_headers").
+		Where("number >= ? AND number <= ?", fromHeight, toHeight).
+		Order("number ASC").
+		Find(&headers)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return headers, nil
+}
+
 func (b blocksDB) BlockHeader(hash common.Hash) (*BlockHeader, error) {
 	return b.BlockHeaderWithFilter(BlockHeader{Hash: hash})
 }
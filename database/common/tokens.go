@@ -0,0 +1,163 @@
+package common
+
+import (
+	"math/big"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+type Token struct {
+	Address   common.Address `gorm:"primaryKey;serializer:bytes" json:"address"`
+	Name      string         `json:"name"`
+	Symbol    string         `json:"symbol"`
+	Decimals  uint8          `json:"decimals"`
+	IsNative  bool           `json:"is_native"`
+	Timestamp uint64         `json:"timestamp"`
+}
+
+func (Token) TableName() string {
+	return "tokens"
+}
+
+// TokenMetadataFailure 读取元数据失败的代币地址，NextAttemptAt（unix 秒）之前不再重试
+type TokenMetadataFailure struct {
+	Address       common.Address `gorm:"primaryKey;serializer:bytes"`
+	Attempts      int
+	LastError     string
+	FailedAt      uint64
+	NextAttemptAt uint64
+}
+
+func (TokenMetadataFailure) TableName() string {
+	return "token_metadata_failures"
+}
+
+type TokensView interface {
+	TokenByAddress(common.Address) (*Token, error)
+	TokensByAddresses([]common.Address) (map[common.Address]Token, error)
+	// UnknownTokenAddresses 按地址顺序返回 block_number >= fromBlock 的业务事件中出现过、
+	// 既没有元数据也没有失败记录的代币地址，fromBlock 为 nil 时扫描全部事件
+	UnknownTokenAddresses(fromBlock *big.Int, limit int) ([]common.Address, error)
+	// DueTokenMetadataFailures 按 next_attempt_at 升序返回已经到了重试时间的失败记录
+	DueTokenMetadataFailures(now uint64, limit int) ([]TokenMetadataFailure, error)
+}
+
+type TokensDB interface {
+	TokensView
+	// StoreTokens 写入元数据并删除这些地址的失败记录
+	StoreTokens([]Token) error
+	// StoreTokenMetadataFailures 写入或覆盖失败记录
+	StoreTokenMetadataFailures([]TokenMetadataFailure) error
+}
+
+type tokensDB struct {
+	gorm *gorm.DB
+}
+
+func NewTokensDB(db *gorm.DB) TokensDB {
+	return &tokensDB{gorm: db}
+}
+
+func (db tokensDB) TokenByAddress(address common.Address) (*Token, error) {
+	var token Token
+	result := db.gorm.Where(&Token{Address: address}).Take(&token)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return &token, nil
+}
+
+// TokensByAddresses 批量查询代币元数据，尚未缓存的地址不会出现在返回的 map 中
+func (db tokensDB) TokensByAddresses(addresses []common.Address) (map[common.Address]Token, error) {
+	tokens := make(map[common.Address]Token, len(addresses))
+	if len(addresses) == 0 {
+		return tokens, nil
+	}
+
+	// address 以小写 hex 存储（serializer:bytes），这里按同样的编码查询
+	hexAddresses := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		hexAddresses = append(hexAddresses, hexutil.Encode(address[:]))
+	}
+
+	var rows []Token
+	result := db.gorm.Where("address IN ?", hexAddresses).Find(&rows)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	for _, token := range rows {
+		tokens[token.Address] = token
+	}
+	return tokens, nil
+}
+
+func (db tokensDB) UnknownTokenAddresses(fromBlock *big.Int, limit int) ([]common.Address, error) {
+	// 区块条件放在每个子查询内，增量扫描时只读取新写入的事件
+	blockCondition, args := "TRUE", []interface{}{}
+	if fromBlock != nil {
+		blockCondition = "block_number >= ?"
+		args = []interface{}{fromBlock, fromBlock, fromBlock}
+	}
+	var hexAddresses []string
+	result := db.gorm.Raw(`
+		SELECT token_address FROM (
+			SELECT token_address FROM deposit_tokens WHERE `+blockCondition+`
+			UNION SELECT token_address FROM withdraw_tokens WHERE `+blockCondition+`
+			UNION SELECT token_address FROM grant_reward_tokens WHERE `+blockCondition+`
+		) AS seen
+		WHERE NOT EXISTS (SELECT 1 FROM tokens WHERE tokens.address = seen.token_address)
+		  AND NOT EXISTS (SELECT 1 FROM token_metadata_failures f WHERE f.address = seen.token_address)
+		ORDER BY token_address
+		LIMIT ?`, append(args, limit)...).Scan(&hexAddresses)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	addresses := make([]common.Address, 0, len(hexAddresses))
+	for _, hexAddress := range hexAddresses {
+		addresses = append(addresses, common.HexToAddress(hexAddress))
+	}
+	return addresses, nil
+}
+
+func (db tokensDB) DueTokenMetadataFailures(now uint64, limit int) ([]TokenMetadataFailure, error) {
+	var failures []TokenMetadataFailure
+	result := db.gorm.Where("next_attempt_at <= ?", now).
+		Order("next_attempt_at ASC").
+		Limit(limit).
+		Find(&failures)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return failures, nil
+}
+
+func (db tokensDB) StoreTokens(tokens []Token) error {
+	if len(tokens) == 0 {
+		return nil
+	}
+	result := db.gorm.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(&tokens, len(tokens))
+	if result.Error != nil {
+		return result.Error
+	}
+	hexAddresses := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		hexAddresses = append(hexAddresses, hexutil.Encode(token.Address[:]))
+	}
+	return db.gorm.Where("address IN ?", hexAddresses).Delete(&TokenMetadataFailure{}).Error
+}
+
+func (db tokensDB) StoreTokenMetadataFailures(failures []TokenMetadataFailure) error {
+	if len(failures) == 0 {
+		return nil
+	}
+	result := db.gorm.Clauses(clause.OnConflict{UpdateAll: true}).CreateInBatches(&failures, len(failures))
+	return result.Error
+}
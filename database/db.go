@@ -3,10 +3,7 @@ package database
 import (
 	"context"
 	"fmt"
-	"os"
-	"path/filepath"
 
-	"github.com/cockroachdb/errors"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 
@@ -27,6 +24,17 @@ type DB struct {
 	WithdrawTokens        worker.WithdrawTokensDB
 	GrantRewardTokens     worker.GrantRewardTokensDB
 	WithdrawManagerUpdate worker.WithdrawManagerUpdateDB
+	TreasuryBalances      worker.TreasuryBalancesDB
+	ReconcileMismatches   worker.ReconcileMismatchesDB
+	RewardLedger          worker.RewardLedgerDB
+	TokenStats            worker.TokenStatsDB
+	AddressActivity       worker.AddressActivityDB
+	EventExport           worker.EventExportDB
+	Tokens                common.TokensDB
+	DecodedEvents         event.DecodedEventsDB
+	Outbox                event.OutboxDB
+	Webhooks              event.WebhooksDB
+	ApiKeys               event.ApiKeysDB
 }
 
 func NewDB(ctx context.Context, dbConfig config.DBConfig) (*DB, error) {
@@ -69,6 +77,17 @@ func NewDB(ctx context.Context, dbConfig config.DBConfig) (*DB, error) {
 		WithdrawTokens:        worker.NewWithdrawTokensDB(gorm),
 		GrantRewardTokens:     worker.NewGrantRewardTokensDB(gorm),
 		WithdrawManagerUpdate: worker.NewWithdrawManagerUpdateDB(gorm),
+		TreasuryBalances:      worker.NewTreasuryBalancesDB(gorm),
+		ReconcileMismatches:   worker.NewReconcileMismatchesDB(gorm),
+		RewardLedger:          worker.NewRewardLedgerDB(gorm),
+		TokenStats:            worker.NewTokenStatsDB(gorm),
+		AddressActivity:       worker.NewAddressActivityDB(gorm),
+		EventExport:           worker.NewEventExportDB(gorm),
+		Tokens:                common.NewTokensDB(gorm),
+		DecodedEvents:         event.NewDecodedEventsDB(gorm),
+		Outbox:                event.NewOutboxDB(gorm),
+		Webhooks:              event.NewWebhooksDB(gorm),
+		ApiKeys:               event.NewApiKeysDB(gorm),
 	}
 
 	return db, nil
@@ -85,6 +104,17 @@ func (db *DB) Transaction(fn func(db *DB) error) error {
 			WithdrawTokens:        worker.NewWithdrawTokensDB(tx),
 			GrantRewardTokens:     worker.NewGrantRewardTokensDB(tx),
 			WithdrawManagerUpdate: worker.NewWithdrawManagerUpdateDB(tx),
+			TreasuryBalances:      worker.NewTreasuryBalancesDB(tx),
+			ReconcileMismatches:   worker.NewReconcileMismatchesDB(tx),
+			RewardLedger:          worker.NewRewardLedgerDB(tx),
+			TokenStats:            worker.NewTokenStatsDB(tx),
+			AddressActivity:       worker.NewAddressActivityDB(tx),
+			EventExport:           worker.NewEventExportDB(tx),
+			Tokens:                common.NewTokensDB(tx),
+			DecodedEvents:         event.NewDecodedEventsDB(tx),
+			Outbox:                event.NewOutboxDB(tx),
+			Webhooks:              event.NewWebhooksDB(tx),
+			ApiKeys:               event.NewApiKeysDB(tx),
 		}
 		return fn(txDB)
 	})
@@ -97,24 +127,3 @@ func (db *DB) Close() error {
 	}
 	return sql.Close()
 }
-
-func (db *DB) ExecuteSQLMigration(migrationsFolder string) error {
-	err := filepath.Walk(migrationsFolder, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return errors.Wrap(err, fmt.Sprintf("Failed to process migration file: %s", path))
-		}
-		if info.IsDir() {
-			return nil
-		}
-		fileContent, readErr := os.ReadFile(path)
-		if readErr != nil {
-			return errors.Wrap(readErr, fmt.Sprintf("Error reading SQL file: %s", path))
-		}
-		execErr := db.gorm.Exec(string(fileContent)).Error
-		if execErr != nil {
-			return errors.Wrap(execErr, fmt.Sprintf("Error executing SQL script: %s", path))
-		}
-		return nil
-	})
-	return err
-}
@@ -0,0 +1,126 @@
+// Package dbtest 需要数据库的测试使用的辅助函数。
+// 测试连接 EVENT_SYNC_MASTER_DB_* 指定的已执行迁移的数据库（建议使用专用的测试库），未设置时跳过；
+// 所有数据在事务内写入，结束时回滚。
+package dbtest
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+
+	"github.com/Sandwichzzy/event-sync-go/config"
+	"github.com/Sandwichzzy/event-sync-go/database"
+	common2 "github.com/Sandwichzzy/event-sync-go/database/common"
+	"github.com/Sandwichzzy/event-sync-go/database/utils"
+	"github.com/Sandwichzzy/event-sync-go/database/worker"
+)
+
+var errRollback = errors.New("rollback test data")
+
+// Open 连接测试数据库，EVENT_SYNC_MASTER_DB_HOST 未设置时跳过测试
+func Open(tb testing.TB) *database.DB {
+	tb.Helper()
+	host := os.Getenv("EVENT_SYNC_MASTER_DB_HOST")
+	if host == "" {
+		tb.Skip("EVENT_SYNC_MASTER_DB_HOST not set")
+	}
+	port, _ := strconv.Atoi(os.Getenv("EVENT_SYNC_MASTER_DB_PORT"))
+	db, err := database.NewDB(context.Background(), config.DBConfig{
+		Host:     host,
+		Port:     port,
+		Name:     os.Getenv("EVENT_SYNC_MASTER_DB_NAME"),
+		User:     os.Getenv("EVENT_SYNC_MASTER_DB_USER"),
+		Password: os.Getenv("EVENT_SYNC_MASTER_DB_PASSWORD"),
+	})
+	if err != nil {
+		tb.Fatal(err)
+	}
+	tb.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+// InTx 在事务内执行 fn，结束时回滚
+func InTx(tb testing.TB, db *database.DB, fn func(tx *database.DB)) {
+	tb.Helper()
+	err := db.Transaction(func(tx *database.DB) error {
+		fn(tx)
+		return errRollback
+	})
+	if err != nil && !errors.Is(err, errRollback) {
+		tb.Fatal(err)
+	}
+}
+
+// NextBlockHeaders 生成高度为当前最新区块头加 offsets 的区块头（不写入），每个区块的 parent_hash 为前一个生成的区块
+// （第一个为当前最新区块头）的 hash。offsets 不连续时得到缺失的区块，表为空时从高度 0 开始
+func NextBlockHeaders(tb testing.TB, tx *database.DB, offsets ...uint64) []common2.BlockHeader {
+	tb.Helper()
+	latest, err := tx.Blocks.LatestBlockHeader()
+	if err != nil {
+		tb.Fatal(err)
+	}
+	base, parentHash := uint64(0), crypto.Keccak256Hash([]byte("dbtest"))
+	if latest != nil {
+		base, parentHash = latest.Number.Uint64(), latest.Hash
+	}
+
+	headers := make([]common2.BlockHeader, 0, len(offsets))
+	for _, offset := range offsets {
+		number := new(big.Int).SetUint64(base + offset)
+		header := &types.Header{Number: number, ParentHash: parentHash, Time: 1_700_000_000 + number.Uint64(), Difficulty: big.NewInt(0)}
+		headers = append(headers, common2.BlockHeader{
+			Hash:       header.Hash(),
+			ParentHash: header.ParentHash,
+			Number:     number,
+			Timestamp:  header.Time,
+			RLPHeader:  (*utils.RLPHeader)(header),
+		})
+		parentHash = header.Hash()
+	}
+	return headers
+}
+
+// IntegrityIssues SeedIntegrityIssues 写入的问题数据。库中已有的数据可能也有问题，断言时应与写入前的结果比较
+type IntegrityIssues struct {
+	Gap          *big.Int  // 缺失的区块高度
+	LinkBreak    *big.Int  // parent_hash 与上一高度区块 hash 不一致的区块高度
+	OrphanWorker uuid.UUID // 在 contract_events 中找不到源事件的 deposit_tokens 记录
+}
+
+// SeedIntegrityIssues 在最新区块之后写入一个缺失的区块、一个 parent_hash 不一致的区块头和一条孤立的 worker 记录
+func SeedIntegrityIssues(tb testing.TB, tx *database.DB) IntegrityIssues {
+	tb.Helper()
+	headers := NextBlockHeaders(tb, tx, 1, 2, 4)
+	headers[1].ParentHash = crypto.Keccak256Hash([]byte("dbtest mismatch"))
+	if err := tx.Blocks.StoreBlockHeaders(headers); err != nil {
+		tb.Fatal(err)
+	}
+
+	orphan := worker.DepositTokens{
+		GUID:            uuid.New(),
+		BlockNumber:     headers[0].Number,
+		BlockHash:       headers[0].Hash,
+		TransactionHash: crypto.Keccak256Hash([]byte("dbtest orphan")),
+		LogIndex:        0,
+		TokenAddress:    common.HexToAddress("0x00000000000000000000000000000000000000e1"),
+		Sender:          common.HexToAddress("0x00000000000000000000000000000000000000e2"),
+		Amount:          big.NewInt(1),
+		Timestamp:       headers[0].Timestamp,
+	}
+	if err := tx.DepositTokens.StoreDepositTokens([]worker.DepositTokens{orphan}); err != nil {
+		tb.Fatal(err)
+	}
+	return IntegrityIssues{
+		Gap:          new(big.Int).Add(headers[1].Number, big.NewInt(1)),
+		LinkBreak:    headers[1].Number,
+		OrphanWorker: orphan.GUID,
+	}
+}
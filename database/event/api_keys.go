@@ -0,0 +1,130 @@
+package event
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ApiKey 一个 HTTP API 访问密钥，只保存明文的 SHA-256。RateLimit / Burst / DailyQuota 为 0 时使用 API 服务配置的默认值，
+// Scopes 为密钥可以访问的受限接口
+type ApiKey struct {
+	GUID       uuid.UUID  `gorm:"primaryKey" json:"guid"`
+	Name       string     `json:"name"`
+	KeyPrefix  string     `json:"key_prefix"`
+	KeyHash    string     `json:"-"`
+	RateLimit  float64    `json:"rate_limit"`
+	Burst      int        `json:"burst"`
+	DailyQuota int64      `json:"daily_quota"`
+	Scopes     []string   `gorm:"serializer:json" json:"scopes"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+func (ApiKey) TableName() string {
+	return "api_keys"
+}
+
+// Revoked 密钥是否已经吊销
+func (k *ApiKey) Revoked() bool {
+	return k.RevokedAt != nil
+}
+
+// HasScope 密钥是否可以访问 scope 对应的受限接口
+func (k *ApiKey) HasScope(scope string) bool {
+	return slices.Contains(k.Scopes, scope)
+}
+
+type ApiKeysView interface {
+	// ApiKeys 按创建时间返回所有密钥，包括已吊销的密钥
+	ApiKeys() ([]ApiKey, error)
+	// ApiKeyByHash 按明文的 SHA-256 查询密钥，不存在时返回 nil
+	ApiKeyByHash(keyHash string) (*ApiKey, error)
+	// ApiKeyUsage 返回每个密钥在 day（UTC 自然日）的请求数，没有请求的密钥不在结果中
+	ApiKeyUsage(day time.Time) (map[uuid.UUID]int64, error)
+}
+
+type ApiKeysDB interface {
+	ApiKeysView
+	StoreApiKey(*ApiKey) error
+	RevokeApiKey(uuid.UUID) (bool, error)
+	// AddApiKeyUsage 把密钥在 day 的请求数加上 requests 并返回相加后的请求数
+	AddApiKeyUsage(guid uuid.UUID, day time.Time, requests int64) (int64, error)
+}
+
+type apiKeysDB struct {
+	gorm *gorm.DB
+}
+
+func NewApiKeysDB(db *gorm.DB) ApiKeysDB {
+	return &apiKeysDB{gorm: db}
+}
+
+func (db *apiKeysDB) ApiKeys() ([]ApiKey, error) {
+	var keys []ApiKey
+	result := db.gorm.Order("created_at ASC").Find(&keys)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return keys, nil
+}
+
+func (db *apiKeysDB) ApiKeyByHash(keyHash string) (*ApiKey, error) {
+	var key ApiKey
+	result := db.gorm.Where("key_hash = ?", keyHash).Take(&key)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return &key, nil
+}
+
+func (db *apiKeysDB) ApiKeyUsage(day time.Time) (map[uuid.UUID]int64, error) {
+	var rows []struct {
+		ApiKeyGUID uuid.UUID
+		Requests   int64
+	}
+	result := db.gorm.Table("api_key_usage").Select("api_key_guid, requests").
+		Where("day = ?", day.UTC().Format(time.DateOnly)).
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	usage := make(map[uuid.UUID]int64, len(rows))
+	for _, row := range rows {
+		usage[row.ApiKeyGUID] = row.Requests
+	}
+	return usage, nil
+}
+
+func (db *apiKeysDB) StoreApiKey(key *ApiKey) error {
+	result := db.gorm.Create(key)
+	return result.Error
+}
+
+// RevokeApiKey 吊销密钥，密钥不存在或已经吊销时返回 false
+func (db *apiKeysDB) RevokeApiKey(guid uuid.UUID) (bool, error) {
+	result := db.gorm.Model(&ApiKey{}).
+		Where("guid = ? AND revoked_at IS NULL", guid).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (db *apiKeysDB) AddApiKeyUsage(guid uuid.UUID, day time.Time, requests int64) (int64, error) {
+	var total int64
+	result := db.gorm.Raw(`INSERT INTO api_key_usage (api_key_guid, day, requests) VALUES (?, ?, ?)
+ON CONFLICT (api_key_guid, day) DO UPDATE SET requests = api_key_usage.requests + EXCLUDED.requests
+RETURNING requests`, guid, day.UTC().Format(time.DateOnly), requests).Scan(&total)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return total, nil
+}
@@ -6,9 +6,12 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+
+	"github.com/Sandwichzzy/event-sync-go/database/utils"
 )
 
 type ContractEvent struct {
@@ -20,6 +23,9 @@ type ContractEvent struct {
 	EventSignature  common.Hash `gorm:"serializer:bytes"`
 	Timestamp       uint64
 	RLPLog          *types.Log `gorm:"serializer:rlp;column:rlp_bytes"`
+
+	// BlockNumber 不是 contract_events 的列，按区块范围查询时从 block_headers 关联得到（只读）
+	BlockNumber *big.Int `gorm:"->;serializer:u256;column:block_number"`
 }
 
 func (ContractEvent) TableName() string {
@@ -30,6 +36,10 @@ func (c *ContractEvent) AfterFind(tx *gorm.DB) error {
 	c.RLPLog.BlockHash = c.BlockHash
 	c.RLPLog.TxHash = c.TransactionHash
 	c.RLPLog.Index = uint(c.LogIndex)
+	// RLP 编码只包含 address/topics/data，区块号需要从关联查询中补齐
+	if c.BlockNumber != nil {
+		c.RLPLog.BlockNumber = c.BlockNumber.Uint64()
+	}
 	return nil
 }
 
@@ -55,6 +65,19 @@ type ContractEventsView interface {
 	ContractEventWithFilter(ContractEvent) (*ContractEvent, error)
 	ContractEventsWithFilter(ContractEvent, *big.Int, *big.Int) ([]ContractEvent, error)
 	LatestContractEventWithFilter(ContractEvent) (*ContractEvent, error)
+	QueryContractEvents(filter ContractEventQuery, page utils.Page) ([]ContractEvent, utils.PageInfo, error)
+	ContractEventWithBlockNumber(uuid.UUID) (*ContractEvent, error)
+}
+
+// ContractEventQuery 原始合约事件列表的查询条件，零值字段不过滤，区块和时间范围均包含边界
+type ContractEventQuery struct {
+	ContractAddress *common.Address
+	EventSignature  *common.Hash
+	TransactionHash *common.Hash
+	FromBlock       *big.Int
+	ToBlock         *big.Int
+	FromTimestamp   uint64
+	ToTimestamp     uint64
 }
 
 type ContractEventDB interface {
@@ -100,7 +123,7 @@ func (db *contractEventDB) ContractEventsWithFilter(filter ContractEvent, fromHe
 	query := db.gorm.Table("contract_events").Where(&filter)
 	query = query.Joins("INNER JOIN block_headers ON contract_events.block_hash = block_headers.hash")
 	query = query.Where("block_headers.number >= ? AND block_headers.number <= ?", fromHeight, toHeight)
-	query = query.Order("block_headers.number ASC").Select("contract_events.*")
+	query = query.Order("block_headers.number ASC").Select("contract_events.*, block_headers.number AS block_number")
 	var events []ContractEvent
 	result := query.Find(&events)
 	if result.Error != nil {
@@ -124,6 +147,65 @@ func (db *contractEventDB) LatestContractEventWithFilter(filter ContractEvent) (
 	return &l1ContractEvent, nil
 }
 
+// contractEventPageColumns 原始合约事件的分页排序列，区块号从 block_headers 关联得到
+var contractEventPageColumns = utils.PageColumns{
+	BlockNumber: "block_headers.number",
+	LogIndex:    "contract_events.log_index",
+	Select:      "contract_events.*, block_headers.number AS block_number",
+}
+
+// QueryContractEvents 按过滤条件分页查询原始合约事件，按 (区块号, log_index) 排序
+func (db *contractEventDB) QueryContractEvents(filter ContractEventQuery, page utils.Page) ([]ContractEvent, utils.PageInfo, error) {
+	query := func() *gorm.DB {
+		q := db.withBlockNumber()
+		if filter.ContractAddress != nil {
+			q = q.Where("contract_events.contract_address = ?", hexutil.Encode(filter.ContractAddress[:]))
+		}
+		if filter.EventSignature != nil {
+			q = q.Where("contract_events.event_signature = ?", hexutil.Encode(filter.EventSignature[:]))
+		}
+		if filter.TransactionHash != nil {
+			q = q.Where("contract_events.transaction_hash = ?", hexutil.Encode(filter.TransactionHash[:]))
+		}
+		if filter.FromBlock != nil {
+			q = q.Where("block_headers.number >= ?", filter.FromBlock)
+		}
+		if filter.ToBlock != nil {
+			q = q.Where("block_headers.number <= ?", filter.ToBlock)
+		}
+		if filter.FromTimestamp > 0 {
+			q = q.Where("contract_events.timestamp >= ?", filter.FromTimestamp)
+		}
+		if filter.ToTimestamp > 0 {
+			q = q.Where("contract_events.timestamp <= ?", filter.ToTimestamp)
+		}
+		return q
+	}
+	return utils.QueryPage(query, contractEventPageColumns, page, func(e ContractEvent) (*big.Int, uint64) {
+		return e.BlockNumber, e.LogIndex
+	})
+}
+
+// ContractEventWithBlockNumber 按 GUID 查询一条原始合约事件并补齐区块号，不存在时返回 nil
+func (db *contractEventDB) ContractEventWithBlockNumber(guid uuid.UUID) (*ContractEvent, error) {
+	var events []ContractEvent
+	result := db.withBlockNumber().
+		Where("contract_events.guid = ?", guid.String()).
+		Select(contractEventPageColumns.Select).
+		Limit(1).
+		Find(&events)
+	if result.Error != nil {
+		return nil, result.Error
+	} else if len(events) == 0 {
+		return nil, nil
+	}
+	return &events[0], nil
+}
+
+func (db *contractEventDB) withBlockNumber() *gorm.DB {
+	return db.gorm.Model(&ContractEvent{}).Joins("INNER JOIN block_headers ON contract_events.block_hash = block_headers.hash")
+}
+
 func NewContractEventsDB(db *gorm.DB) ContractEventDB {
 	return &contractEventDB{gorm: db}
 }
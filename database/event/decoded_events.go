@@ -0,0 +1,111 @@
+package event
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type DecodedEvent struct {
+	GUID              uuid.UUID       `gorm:"primaryKey" json:"guid"`
+	ContractEventGUID uuid.UUID       `json:"contract_event_guid"`
+	ContractAddress   common.Address  `gorm:"serializer:bytes" json:"contract_address"`
+	ContractName      string          `json:"contract_name"`
+	EventName         string          `json:"event_name"`
+	EventSignature    common.Hash     `gorm:"serializer:bytes" json:"event_signature"`
+	BlockNumber       *big.Int        `gorm:"serializer:u256" json:"block_number"`
+	BlockHash         common.Hash     `gorm:"serializer:bytes" json:"block_hash"`
+	TransactionHash   common.Hash     `gorm:"serializer:bytes" json:"transaction_hash"`
+	LogIndex          uint64          `json:"log_index"`
+	Args              json.RawMessage `gorm:"serializer:json" json:"args"`
+	ArgTypes          json.RawMessage `gorm:"serializer:json" json:"arg_types"`
+	Timestamp         uint64          `json:"timestamp"`
+}
+
+func (DecodedEvent) TableName() string {
+	return "decoded_events"
+}
+
+type DecodedEventsView interface {
+	QueryDecodedEventsList(contractAddress *common.Address, eventName string, page int, pageSize int) ([]DecodedEvent, uint64)
+}
+
+type DecodedEventsDB interface {
+	DecodedEventsView
+	StoreDecodedEvents([]DecodedEvent) error
+	// DeleteDecodedEventsInRange 删除区间内 contracts 的解码结果，contracts 为空时删除所有合约的解码结果
+	DeleteDecodedEventsInRange(contracts []common.Address, fromHeight *big.Int, toHeight *big.Int) error
+}
+
+type decodedEventsDB struct {
+	gorm *gorm.DB
+}
+
+func NewDecodedEventsDB(db *gorm.DB) DecodedEventsDB {
+	return &decodedEventsDB{gorm: db}
+}
+
+func (db *decodedEventsDB) QueryDecodedEventsList(contractAddress *common.Address, eventName string, page int, pageSize int) ([]DecodedEvent, uint64) {
+	var (
+		decodedEvents []DecodedEvent
+		total         int64
+	)
+
+	query := db.gorm.Model(&DecodedEvent{})
+	if contractAddress != nil {
+		query = query.Where(&DecodedEvent{ContractAddress: *contractAddress})
+	}
+	if eventName != "" {
+		query = query.Where("event_name = ?", eventName)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		fmt.Printf("count decoded_events error: %v\n", err)
+		return nil, 0
+	}
+
+	offset := (page - 1) * pageSize
+	result := query.
+		Order("block_number desc, log_index desc").
+		Limit(pageSize).
+		Offset(offset).
+		Find(&decodedEvents)
+
+	if result.Error != nil {
+		fmt.Printf("query decoded_events error: %v\n", result.Error)
+		return nil, 0
+	}
+
+	return decodedEvents, uint64(total)
+}
+
+// StoreDecodedEvents 写入解码结果，同一条 contract_event 已经解码过时跳过
+func (db *decodedEventsDB) StoreDecodedEvents(decodedEvents []DecodedEvent) error {
+	if len(decodedEvents) == 0 {
+		return nil
+	}
+	result := db.gorm.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "contract_event_guid"}},
+		DoNothing: true,
+	}).CreateInBatches(&decodedEvents, len(decodedEvents))
+	return result.Error
+}
+
+func (db *decodedEventsDB) DeleteDecodedEventsInRange(contracts []common.Address, fromHeight *big.Int, toHeight *big.Int) error {
+	query := db.gorm.Where("block_number >= ? AND block_number <= ?", fromHeight, toHeight)
+	if len(contracts) > 0 {
+		hexAddresses := make([]string, 0, len(contracts))
+		for _, contract := range contracts {
+			hexAddresses = append(hexAddresses, hexutil.Encode(contract[:]))
+		}
+		query = query.Where("contract_address IN ?", hexAddresses)
+	}
+	result := query.Delete(&DecodedEvent{})
+	return result.Error
+}
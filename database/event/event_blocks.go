@@ -33,6 +33,8 @@ type BlocksView interface {
 type EventBlocksDB interface {
 	BlocksView
 	StoreEventBlocks([]EventBlocks) error
+	StoreEventBlocksInRange(fromHeight *big.Int, toHeight *big.Int) error
+	DeleteEventBlocksInRange(fromHeight *big.Int, toHeight *big.Int) error
 }
 
 type evnetBlocksDB struct {
@@ -57,6 +59,20 @@ func (e evnetBlocksDB) StoreEventBlocks(eventBlocks []EventBlocks) error {
 	return result.Error
 }
 
+// StoreEventBlocksInRange 用一条 INSERT ... SELECT 把 block_headers 中 [fromHeight, toHeight] 的区块写入 event_blocks，已存在的跳过
+func (e evnetBlocksDB) StoreEventBlocksInRange(fromHeight *big.Int, toHeight *big.Int) error {
+	result := e.gorm.Exec(`INSERT INTO event_blocks (guid, hash, parent_hash, number, timestamp)
+		SELECT gen_random_uuid()::text, hash, parent_hash, number, timestamp FROM block_headers
+		WHERE number >= ? AND number <= ?
+		ON CONFLICT DO NOTHING`, fromHeight, toHeight)
+	return result.Error
+}
+
+func (e evnetBlocksDB) DeleteEventBlocksInRange(fromHeight *big.Int, toHeight *big.Int) error {
+	result := e.gorm.Where("number >= ? AND number <= ?", fromHeight, toHeight).Delete(&EventBlocks{})
+	return result.Error
+}
+
 func NewEventBlocksDB(db *gorm.DB) EventBlocksDB {
 	return &evnetBlocksDB{gorm: db}
 }
@@ -0,0 +1,199 @@
+package event
+
+import (
+	"encoding/json"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	OutboxActionAdded   = "added"
+	OutboxActionRemoved = "removed"
+)
+
+// outboxLockKey 写入 outbox 前持有到事务结束的 advisory lock。BIGSERIAL 的 ID 在插入时分配、在提交时才可见，
+// 并发的写入事务可能让较大的 ID 先于较小的 ID 可见，按 id > 游标 读取的 relay、webhook 和订阅流会跳过较小的 ID。
+// 所有写入者串行化后，一个事务分配 ID 时之前分配的 ID 都已经提交。
+// contract_events 的删除触发器 outbox_contract_event_removed（migrations/0015）以十进制字面量 8031453476610930546 使用同一个键，修改时需同步
+const outboxLockKey = 0x6f7574626f787772 // "outboxwr"
+
+// OutboxMessage 事务性发件箱中的一条消息，ID 为投递顺序和 sink 的偏移量
+type OutboxMessage struct {
+	ID              uint64          `gorm:"primaryKey;autoIncrement" json:"id"`
+	ContractAddress common.Address  `gorm:"serializer:bytes" json:"contract_address"`
+	EventType       string          `json:"event_type"`
+	Action          string          `json:"action"`
+	BlockNumber     *big.Int        `gorm:"serializer:u256" json:"block_number"`
+	BlockHash       common.Hash     `gorm:"serializer:bytes" json:"block_hash"`
+	TransactionHash common.Hash     `gorm:"serializer:bytes" json:"transaction_hash"`
+	LogIndex        uint64          `json:"log_index"`
+	Payload         json.RawMessage `gorm:"serializer:json" json:"payload"`
+	Timestamp       uint64          `json:"timestamp"`
+}
+
+func (OutboxMessage) TableName() string {
+	return "outbox"
+}
+
+// OutboxOffset 一个 sink 在一个合约上已经投递成功的最大消息 ID
+type OutboxOffset struct {
+	Sink            string         `gorm:"primaryKey"`
+	ContractAddress common.Address `gorm:"primaryKey;serializer:bytes"`
+	LastID          uint64
+	UpdatedAt       time.Time
+}
+
+func (OutboxOffset) TableName() string {
+	return "outbox_offsets"
+}
+
+type OutboxView interface {
+	OutboxContracts() ([]common.Address, error)
+	OutboxMessagesAfter(contractAddress common.Address, afterID uint64, limit int) ([]OutboxMessage, error)
+	OutboxOffset(sink string, contractAddress common.Address) (uint64, error)
+	LatestOutboxID() (uint64, error)
+	OutboxMessagesAfterID(afterID uint64, limit int) ([]OutboxMessage, error)
+	OutboxIDBeforePosition(blockNumber *big.Int, logIndex uint64) (uint64, error)
+}
+
+type OutboxDB interface {
+	OutboxView
+	StoreOutboxMessages([]OutboxMessage) error
+	StoreRemovedOutboxMessages(contractAddress common.Address, fromHeight *big.Int, toHeight *big.Int) error
+	UpdateOutboxOffset(sink string, contractAddress common.Address, lastID uint64) error
+}
+
+type outboxDB struct {
+	gorm *gorm.DB
+}
+
+func NewOutboxDB(db *gorm.DB) OutboxDB {
+	return &outboxDB{gorm: db}
+}
+
+func (db *outboxDB) OutboxContracts() ([]common.Address, error) {
+	var messages []OutboxMessage
+	result := db.gorm.Distinct("contract_address").Find(&messages)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	contracts := make([]common.Address, 0, len(messages))
+	for _, message := range messages {
+		contracts = append(contracts, message.ContractAddress)
+	}
+	return contracts, nil
+}
+
+// OutboxMessagesAfter 按 ID 升序返回合约 ID 大于 afterID 的最多 limit 条消息
+func (db *outboxDB) OutboxMessagesAfter(contractAddress common.Address, afterID uint64, limit int) ([]OutboxMessage, error) {
+	var messages []OutboxMessage
+	result := db.gorm.Where(&OutboxMessage{ContractAddress: contractAddress}).
+		Where("id > ?", afterID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&messages)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return messages, nil
+}
+
+// LatestOutboxID 返回最大的消息 ID，outbox 为空时为 0
+func (db *outboxDB) LatestOutboxID() (uint64, error) {
+	var latest uint64
+	result := db.gorm.Model(&OutboxMessage{}).Select("COALESCE(MAX(id), 0)").Scan(&latest)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return latest, nil
+}
+
+// OutboxMessagesAfterID 按 ID 升序返回所有合约 ID 大于 afterID 的最多 limit 条消息
+func (db *outboxDB) OutboxMessagesAfterID(afterID uint64, limit int) ([]OutboxMessage, error) {
+	var messages []OutboxMessage
+	result := db.gorm.Where("id > ?", afterID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&messages)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return messages, nil
+}
+
+// OutboxIDBeforePosition 把 (blockNumber, logIndex) 游标转换为消息 ID：
+// 返回位于游标之后的第一条 added 消息的 ID 减一，游标之后没有事件时返回最大消息 ID
+func (db *outboxDB) OutboxIDBeforePosition(blockNumber *big.Int, logIndex uint64) (uint64, error) {
+	var afterID uint64
+	result := db.gorm.Raw(`SELECT COALESCE(
+			(SELECT MIN(id) - 1 FROM outbox WHERE action = ? AND (block_number > ? OR (block_number = ? AND log_index > ?))),
+			(SELECT MAX(id) FROM outbox),
+			0)`,
+		OutboxActionAdded, blockNumber, blockNumber, logIndex).Scan(&afterID)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return afterID, nil
+}
+
+// OutboxOffset 返回 sink 在合约上的投递偏移量，没有记录时为 0
+func (db *outboxDB) OutboxOffset(sink string, contractAddress common.Address) (uint64, error) {
+	var offsets []OutboxOffset
+	result := db.gorm.Where(&OutboxOffset{Sink: sink, ContractAddress: contractAddress}).Limit(1).Find(&offsets)
+	if result.Error != nil {
+		return 0, result.Error
+	} else if len(offsets) == 0 {
+		return 0, nil
+	}
+	return offsets[0].LastID, nil
+}
+
+// lockOutbox 获取 outboxLockKey，写入 outbox 的方法都必须在事务内调用
+func (db *outboxDB) lockOutbox() error {
+	return db.gorm.Exec("SELECT pg_advisory_xact_lock(?)", outboxLockKey).Error
+}
+
+// StoreOutboxMessages 写入消息，必须在事务内调用，见 outboxLockKey
+func (db *outboxDB) StoreOutboxMessages(messages []OutboxMessage) error {
+	if len(messages) == 0 {
+		return nil
+	}
+	if err := db.lockOutbox(); err != nil {
+		return err
+	}
+	result := db.gorm.Omit("id").CreateInBatches(&messages, len(messages))
+	return result.Error
+}
+
+// StoreRemovedOutboxMessages 为合约在 [fromHeight, toHeight] 内最新一条仍为 added 的事件补发 removed 消息，
+// 在删除并重建区间数据前调用，必须在事务内调用，见 outboxLockKey
+func (db *outboxDB) StoreRemovedOutboxMessages(contractAddress common.Address, fromHeight *big.Int, toHeight *big.Int) error {
+	if err := db.lockOutbox(); err != nil {
+		return err
+	}
+	result := db.gorm.Exec(`INSERT INTO outbox (contract_address, event_type, action, block_number, block_hash, transaction_hash, log_index, payload, timestamp)
+		SELECT contract_address, event_type, ?, block_number, block_hash, transaction_hash, log_index, payload, timestamp
+		FROM (
+			SELECT DISTINCT ON (block_hash, log_index) * FROM outbox
+			WHERE contract_address = ? AND block_number >= ? AND block_number <= ?
+			ORDER BY block_hash, log_index, id DESC
+		) latest
+		WHERE action = ?
+		ORDER BY block_number, log_index`,
+		OutboxActionRemoved, hexutil.Encode(contractAddress[:]), fromHeight, toHeight, OutboxActionAdded)
+	return result.Error
+}
+
+func (db *outboxDB) UpdateOutboxOffset(sink string, contractAddress common.Address, lastID uint64) error {
+	offset := OutboxOffset{Sink: sink, ContractAddress: contractAddress, LastID: lastID, UpdatedAt: time.Now()}
+	result := db.gorm.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "sink"}, {Name: "contract_address"}},
+		DoUpdates: clause.AssignmentColumns([]string{"last_id", "updated_at"}),
+	}).Create(&offset)
+	return result.Error
+}
@@ -0,0 +1,18 @@
+package event
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/Sandwichzzy/event-sync-go/migrations"
+)
+
+// TestOutboxLockKeyInTrigger 删除触发器必须使用与 Go 写入者相同的 advisory lock
+func TestOutboxLockKeyInTrigger(t *testing.T) {
+	sql, err := migrations.FS.ReadFile("0015_lock_outbox_trigger.up.sql")
+	require.NoError(t, err)
+	require.True(t, strings.Contains(string(sql), "pg_advisory_xact_lock("+strconv.FormatUint(outboxLockKey, 10)+")"))
+}
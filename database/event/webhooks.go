@@ -0,0 +1,246 @@
+package event
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliveryDelivered = "delivered"
+	WebhookDeliveryDead      = "dead"
+)
+
+// WebhookSubscription 一个 HTTP 回调订阅，EventTypes 为空时接收所有事件类型，
+// TokenAddress / Address 为 nil 时不按代币 / 地址过滤。OwnerGUID 为创建订阅的 API key
+type WebhookSubscription struct {
+	GUID         uuid.UUID       `gorm:"primaryKey" json:"guid"`
+	OwnerGUID    uuid.UUID       `json:"owner_guid"`
+	URL          string          `json:"url"`
+	Secret       string          `json:"-"`
+	EventTypes   []string        `gorm:"serializer:json" json:"event_types"`
+	TokenAddress *common.Address `gorm:"serializer:bytes" json:"token_address"`
+	Address      *common.Address `gorm:"serializer:bytes" json:"address"`
+	Active       bool            `json:"active"`
+	CreatedAt    time.Time       `json:"created_at"`
+	UpdatedAt    time.Time       `json:"updated_at"`
+}
+
+func (WebhookSubscription) TableName() string {
+	return "webhook_subscriptions"
+}
+
+// WebhookDelivery 一条 outbox 消息对一个订阅的投递，Payload 为实际发送的请求体，
+// 待投递记录在 NextAttemptAt 之后才会被投递
+type WebhookDelivery struct {
+	GUID             uuid.UUID       `gorm:"primaryKey" json:"guid"`
+	SubscriptionGUID uuid.UUID       `json:"subscription_guid"`
+	OutboxID         uint64          `json:"outbox_id"`
+	EventType        string          `json:"event_type"`
+	Action           string          `json:"action"`
+	Payload          json.RawMessage `gorm:"serializer:json" json:"payload"`
+	Status           string          `json:"status"`
+	Attempts         int             `json:"attempts"`
+	LastError        string          `json:"last_error"`
+	NextAttemptAt    time.Time       `gorm:"default:now()" json:"next_attempt_at"`
+	CreatedAt        time.Time       `json:"created_at"`
+	UpdatedAt        time.Time       `json:"updated_at"`
+}
+
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+type WebhooksView interface {
+	WebhookSubscriptions(activeOnly bool) ([]WebhookSubscription, error)
+	WebhookSubscriptionsByOwner(owner uuid.UUID) ([]WebhookSubscription, error)
+	WebhookSubscription(uuid.UUID) (*WebhookSubscription, error)
+	WebhookDelivery(uuid.UUID) (*WebhookDelivery, error)
+	QueryWebhookDeliveries(subscriptionGUID uuid.UUID, status string, page int, pageSize int) ([]WebhookDelivery, uint64)
+	PendingWebhookDeliveries(now time.Time, limit int) ([]WebhookDelivery, error)
+}
+
+type WebhooksDB interface {
+	WebhooksView
+	StoreWebhookSubscription(*WebhookSubscription) error
+	SetWebhookSubscriptionActive(guid uuid.UUID, active bool) (bool, error)
+	DeleteWebhookSubscription(uuid.UUID) (bool, error)
+	StoreWebhookDeliveries([]WebhookDelivery) error
+	UpdateWebhookDelivery(guid uuid.UUID, status string, attempts int, lastError string, nextAttemptAt time.Time) error
+	RedeliverWebhookDelivery(uuid.UUID) (bool, error)
+}
+
+type webhooksDB struct {
+	gorm *gorm.DB
+}
+
+func NewWebhooksDB(db *gorm.DB) WebhooksDB {
+	return &webhooksDB{gorm: db}
+}
+
+func (db *webhooksDB) WebhookSubscriptions(activeOnly bool) ([]WebhookSubscription, error) {
+	var subscriptions []WebhookSubscription
+	query := db.gorm.Model(&WebhookSubscription{})
+	if activeOnly {
+		query = query.Where("active = ?", true)
+	}
+	result := query.Order("created_at ASC").Find(&subscriptions)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return subscriptions, nil
+}
+
+// WebhookSubscriptionsByOwner 按创建时间返回 API key 创建的所有订阅
+func (db *webhooksDB) WebhookSubscriptionsByOwner(owner uuid.UUID) ([]WebhookSubscription, error) {
+	var subscriptions []WebhookSubscription
+	result := db.gorm.Where("owner_guid = ?", owner).Order("created_at ASC").Find(&subscriptions)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return subscriptions, nil
+}
+
+func (db *webhooksDB) WebhookSubscription(guid uuid.UUID) (*WebhookSubscription, error) {
+	var subscription WebhookSubscription
+	result := db.gorm.Where(&WebhookSubscription{GUID: guid}).Take(&subscription)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return &subscription, nil
+}
+
+func (db *webhooksDB) WebhookDelivery(guid uuid.UUID) (*WebhookDelivery, error) {
+	var delivery WebhookDelivery
+	result := db.gorm.Where(&WebhookDelivery{GUID: guid}).Take(&delivery)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return &delivery, nil
+}
+
+// QueryWebhookDeliveries 按 outbox_id 倒序分页查询订阅的投递记录，status 为空时不过滤
+func (db *webhooksDB) QueryWebhookDeliveries(subscriptionGUID uuid.UUID, status string, page int, pageSize int) ([]WebhookDelivery, uint64) {
+	var (
+		deliveries []WebhookDelivery
+		total      int64
+	)
+
+	query := db.gorm.Model(&WebhookDelivery{}).Where("subscription_guid = ?", subscriptionGUID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		fmt.Printf("count webhook_deliveries error: %v\n", err)
+		return nil, 0
+	}
+
+	offset := (page - 1) * pageSize
+	result := query.
+		Order("outbox_id desc").
+		Limit(pageSize).
+		Offset(offset).
+		Find(&deliveries)
+
+	if result.Error != nil {
+		fmt.Printf("query webhook_deliveries error: %v\n", result.Error)
+		return nil, 0
+	}
+
+	return deliveries, uint64(total)
+}
+
+// PendingWebhookDeliveries 按 outbox_id 升序返回启用中的订阅最多 limit 条在 now 之前到期的待投递记录，
+// 停用的订阅的投递保持待投递。同一订阅中有更早的记录还在退避时，之后的记录不返回
+func (db *webhooksDB) PendingWebhookDeliveries(now time.Time, limit int) ([]WebhookDelivery, error) {
+	var deliveries []WebhookDelivery
+	result := db.gorm.Where("status = ? AND next_attempt_at <= ?", WebhookDeliveryPending, now).
+		Where("subscription_guid IN (SELECT guid FROM webhook_subscriptions WHERE active)").
+		Where(`NOT EXISTS (SELECT 1 FROM webhook_deliveries earlier
+			WHERE earlier.subscription_guid = webhook_deliveries.subscription_guid AND earlier.status = ?
+			AND earlier.outbox_id < webhook_deliveries.outbox_id AND earlier.next_attempt_at > ?)`, WebhookDeliveryPending, now).
+		Order("outbox_id ASC").
+		Limit(limit).
+		Find(&deliveries)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return deliveries, nil
+}
+
+func (db *webhooksDB) StoreWebhookSubscription(subscription *WebhookSubscription) error {
+	result := db.gorm.Create(subscription)
+	return result.Error
+}
+
+// SetWebhookSubscriptionActive 启用或停用订阅，订阅不存在时返回 false
+func (db *webhooksDB) SetWebhookSubscriptionActive(guid uuid.UUID, active bool) (bool, error) {
+	result := db.gorm.Model(&WebhookSubscription{}).Where("guid = ?", guid).Updates(map[string]interface{}{
+		"active":     active,
+		"updated_at": time.Now(),
+	})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// DeleteWebhookSubscription 删除订阅及其投递记录，订阅不存在时返回 false
+func (db *webhooksDB) DeleteWebhookSubscription(guid uuid.UUID) (bool, error) {
+	result := db.gorm.Where(&WebhookSubscription{GUID: guid}).Delete(&WebhookSubscription{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// StoreWebhookDeliveries 写入投递记录，同一订阅同一条 outbox 消息只写入一次
+func (db *webhooksDB) StoreWebhookDeliveries(deliveries []WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	result := db.gorm.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(&deliveries, len(deliveries))
+	return result.Error
+}
+
+// UpdateWebhookDelivery 记录一次投递的结果，nextAttemptAt 只对 pending 状态有意义
+func (db *webhooksDB) UpdateWebhookDelivery(guid uuid.UUID, status string, attempts int, lastError string, nextAttemptAt time.Time) error {
+	result := db.gorm.Model(&WebhookDelivery{}).Where("guid = ?", guid).Updates(map[string]interface{}{
+		"status":          status,
+		"attempts":        attempts,
+		"last_error":      lastError,
+		"next_attempt_at": nextAttemptAt,
+		"updated_at":      time.Now(),
+	})
+	return result.Error
+}
+
+// RedeliverWebhookDelivery 把死信投递重置为待投递，投递不存在或不是死信时返回 false
+func (db *webhooksDB) RedeliverWebhookDelivery(guid uuid.UUID) (bool, error) {
+	result := db.gorm.Model(&WebhookDelivery{}).
+		Where("guid = ? AND status = ?", guid, WebhookDeliveryDead).
+		Updates(map[string]interface{}{
+			"status":          WebhookDeliveryPending,
+			"attempts":        0,
+			"next_attempt_at": time.Now(),
+			"updated_at":      time.Now(),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
@@ -0,0 +1,38 @@
+package database
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/log"
+	"gorm.io/gorm"
+)
+
+// eventProcessLockKey 处理事件（index）和重建区间（reindex）共用的 advisory lock，保证两者不会同时写入 worker 表和 event_blocks
+const eventProcessLockKey = 0x6576656e74696478 // "eventidx"
+
+// TryLockEventProcessing 尝试获取 eventProcessLockKey 并持有到事务结束，必须在事务内调用。
+// 重建正在进行时返回 false，调用方应放弃本批次稍后重试
+func (db *DB) TryLockEventProcessing() (bool, error) {
+	var locked bool
+	if err := db.gorm.Raw("SELECT pg_try_advisory_xact_lock(?)", eventProcessLockKey).Scan(&locked).Error; err != nil {
+		return false, err
+	}
+	return locked, nil
+}
+
+// WithEventProcessingLock 在一个固定的连接上获取会话级的 eventProcessLockKey 后执行 fn，fn 返回后释放。
+// 正在处理的 index 批次提交后才能拿到锁，持有期间 index 跳过新的批次
+func (db *DB) WithEventProcessingLock(ctx context.Context, fn func() error) error {
+	return db.gorm.WithContext(ctx).Connection(func(conn *gorm.DB) error {
+		if err := conn.Exec("SELECT pg_advisory_lock(?)", eventProcessLockKey).Error; err != nil {
+			return err
+		}
+		defer func() {
+			// ctx 取消后也要释放，否则锁会随连接留在连接池里
+			if err := conn.WithContext(context.Background()).Exec("SELECT pg_advisory_unlock(?)", eventProcessLockKey).Error; err != nil {
+				log.Error("release event processing lock fail", "err", err)
+			}
+		}()
+		return fn()
+	})
+}
@@ -0,0 +1,31 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/Sandwichzzy/event-sync-go/database"
+	"github.com/Sandwichzzy/event-sync-go/database/dbtest"
+)
+
+// TestEventProcessingLock 需要数据库：reindex 持有锁时 index 的事务拿不到锁，释放后可以拿到
+func TestEventProcessingLock(t *testing.T) {
+	db := dbtest.Open(t)
+	tryLock := func() bool {
+		var locked bool
+		require.NoError(t, db.Transaction(func(tx *database.DB) error {
+			var err error
+			locked, err = tx.TryLockEventProcessing()
+			return err
+		}))
+		return locked
+	}
+
+	require.NoError(t, db.WithEventProcessingLock(context.Background(), func() error {
+		require.False(t, tryLock())
+		return nil
+	}))
+	require.True(t, tryLock())
+}
@@ -0,0 +1,128 @@
+package database
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	common2 "github.com/Sandwichzzy/event-sync-go/database/common"
+)
+
+// BlockGap 表中缺失的连续区块高度 [From, To]
+type BlockGap struct {
+	From *big.Int `gorm:"serializer:u256" json:"from"`
+	To   *big.Int `gorm:"serializer:u256" json:"to"`
+}
+
+// HeaderLinkBreak 区块的 parent_hash 与上一高度区块的 hash 不一致
+type HeaderLinkBreak struct {
+	Number       *big.Int    `gorm:"serializer:u256" json:"number"`
+	ParentHash   common.Hash `gorm:"serializer:bytes" json:"parent_hash"`
+	PreviousHash common.Hash `gorm:"serializer:bytes" json:"previous_hash"`
+}
+
+// OrphanRow 引用的区块头或源事件不存在的记录
+type OrphanRow struct {
+	Table     string      `json:"table"`
+	GUID      string      `json:"guid"`
+	BlockHash common.Hash `gorm:"serializer:bytes" json:"block_hash"`
+	LogIndex  uint64      `json:"log_index"`
+}
+
+// BlockHeaderGaps 返回 block_headers 在最小和最大高度之间缺失的区间，samples 最多 limit 条
+func (db *DB) BlockHeaderGaps(limit int) ([]BlockGap, int64, error) {
+	return db.blockGaps("block_headers", limit)
+}
+
+// EventBlockGaps 返回 event_blocks 在最小和最大高度之间缺失的区间，samples 最多 limit 条
+func (db *DB) EventBlockGaps(limit int) ([]BlockGap, int64, error) {
+	return db.blockGaps("event_blocks", limit)
+}
+
+func (db *DB) blockGaps(table string, limit int) ([]BlockGap, int64, error) {
+	gaps := `SELECT number + 1 AS "from", next_number - 1 AS "to" FROM (
+		SELECT number, LEAD(number) OVER (ORDER BY number) AS next_number FROM ` + table + `
+	) numbers WHERE next_number > number + 1`
+	return samplesWithCount[BlockGap](db, gaps, `ORDER BY "from"`, limit)
+}
+
+// UnprocessedBlockHeaders 返回 (startBlock, event_blocks 最新高度] 内有区块头但没有 event_blocks 记录的连续区间，samples 最多 limit 条。
+// 事件处理从 startBlock 的下一个区块开始，EventBlockGaps 只能发现 event_blocks 最小和最大高度之间的缺失
+func (db *DB) UnprocessedBlockHeaders(startBlock *big.Int, limit int) ([]BlockGap, int64, error) {
+	missing := `SELECT MIN(number) AS "from", MAX(number) AS "to" FROM (
+		SELECT b.number, b.number - ROW_NUMBER() OVER (ORDER BY b.number) AS run
+		FROM block_headers b
+		WHERE b.number > ? AND b.number <= (SELECT MAX(number) FROM event_blocks)
+		  AND NOT EXISTS (SELECT 1 FROM event_blocks e WHERE e.number = b.number)
+	) numbers GROUP BY run`
+	return samplesWithCount[BlockGap](db, missing, `ORDER BY "from"`, limit, startBlock)
+}
+
+// BrokenHeaderLinks 返回 parent_hash 与上一高度区块 hash 不一致的区块头
+func (db *DB) BrokenHeaderLinks(limit int) ([]HeaderLinkBreak, int64, error) {
+	breaks := `SELECT b.number, b.parent_hash, p.hash AS previous_hash
+		FROM block_headers b JOIN block_headers p ON p.number = b.number - 1
+		WHERE b.parent_hash <> p.hash`
+	return samplesWithCount[HeaderLinkBreak](db, breaks, "ORDER BY number", limit)
+}
+
+// EventBlocksWithoutHeader 返回 hash 在 block_headers 中不存在的 event_blocks 记录（进度与区块头不一致）
+func (db *DB) EventBlocksWithoutHeader(limit int) ([]OrphanRow, int64, error) {
+	orphans := `SELECT 'event_blocks' AS "table", e.guid, e.hash AS block_hash, 0 AS log_index
+		FROM event_blocks e WHERE NOT EXISTS (SELECT 1 FROM block_headers b WHERE b.hash = e.hash)`
+	return samplesWithCount[OrphanRow](db, orphans, "ORDER BY block_hash", limit)
+}
+
+// OrphanContractEvents 返回 block_hash 在 block_headers 中不存在的 contract_events 记录
+func (db *DB) OrphanContractEvents(limit int) ([]OrphanRow, int64, error) {
+	orphans := `SELECT 'contract_events' AS "table", c.guid, c.block_hash, c.log_index
+		FROM contract_events c WHERE NOT EXISTS (SELECT 1 FROM block_headers b WHERE b.hash = c.block_hash)`
+	return samplesWithCount[OrphanRow](db, orphans, "ORDER BY block_hash, log_index", limit)
+}
+
+// OrphanWorkerRows 返回在 contract_events 中找不到 (block_hash, log_index) 源事件的 worker 记录。
+// schema v1 遗留记录没有事件位置，由 CountLegacyWorkerRows 单独统计。
+func (db *DB) OrphanWorkerRows(limit int) ([]OrphanRow, int64, error) {
+	var (
+		samples []OrphanRow
+		total   int64
+	)
+	for _, table := range workerTables {
+		orphans := `SELECT '` + table + `' AS "table", w.guid, w.block_hash, w.log_index
+			FROM ` + table + ` w WHERE w.block_hash IS NOT NULL AND NOT EXISTS (
+				SELECT 1 FROM contract_events c WHERE c.block_hash = w.block_hash AND c.log_index = w.log_index)`
+		rows, count, err := samplesWithCount[OrphanRow](db, orphans, "ORDER BY block_hash, log_index", limit-len(samples))
+		if err != nil {
+			return nil, 0, err
+		}
+		samples = append(samples, rows...)
+		total += count
+	}
+	return samples, total, nil
+}
+
+// RandomBlockHeaders 随机返回 limit 个区块头，用于与链上数据抽样比对
+func (db *DB) RandomBlockHeaders(limit int) ([]common2.BlockHeader, error) {
+	var headers []common2.BlockHeader
+	result := db.gorm.Order("RANDOM()").Limit(limit).Find(&headers)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return headers, nil
+}
+
+// samplesWithCount 统计 query 的总行数，并按 order 取前 limit 行作为样本，args 为 query 的参数
+func samplesWithCount[T any](db *DB, query string, order string, limit int, args ...interface{}) ([]T, int64, error) {
+	var total int64
+	if err := db.gorm.Raw("SELECT COUNT(*) FROM ("+query+") issues", args...).Scan(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	samples := make([]T, 0)
+	if total == 0 || limit <= 0 {
+		return samples, total, nil
+	}
+	if err := db.gorm.Raw("SELECT * FROM ("+query+") issues "+order+" LIMIT ?", append(args, limit)...).Scan(&samples).Error; err != nil {
+		return nil, 0, err
+	}
+	return samples, total, nil
+}
@@ -0,0 +1,78 @@
+package database_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/Sandwichzzy/event-sync-go/database"
+	"github.com/Sandwichzzy/event-sync-go/database/dbtest"
+)
+
+// TestIntegrityChecks 需要数据库：EVENT_SYNC_MASTER_DB_HOST=... go test -run IntegrityChecks ./database/
+func TestIntegrityChecks(t *testing.T) {
+	db := dbtest.Open(t)
+	dbtest.InTx(t, db, func(tx *database.DB) {
+		_, gaps, err := tx.BlockHeaderGaps(0)
+		require.NoError(t, err)
+		_, breaks, err := tx.BrokenHeaderLinks(0)
+		require.NoError(t, err)
+		_, orphans, err := tx.OrphanWorkerRows(0)
+		require.NoError(t, err)
+
+		issues := dbtest.SeedIntegrityIssues(t, tx)
+
+		// 写入的问题都在最新区块之后，样本取全部问题后按位置查找
+		gapSamples, count, err := tx.BlockHeaderGaps(int(gaps) + 1)
+		require.NoError(t, err)
+		require.Equal(t, gaps+1, count)
+		last := gapSamples[len(gapSamples)-1]
+		require.Equal(t, issues.Gap.String(), last.From.String())
+		require.Equal(t, issues.Gap.String(), last.To.String())
+
+		breakSamples, count, err := tx.BrokenHeaderLinks(int(breaks) + 1)
+		require.NoError(t, err)
+		require.Equal(t, breaks+1, count)
+		require.Equal(t, issues.LinkBreak.String(), breakSamples[len(breakSamples)-1].Number.String())
+
+		orphanSamples, count, err := tx.OrphanWorkerRows(int(orphans) + 1)
+		require.NoError(t, err)
+		require.Equal(t, orphans+1, count)
+		found := false
+		for _, row := range orphanSamples {
+			found = found || (row.Table == "deposit_tokens" && row.GUID == issues.OrphanWorker.String())
+		}
+		require.True(t, found, "orphan worker row not reported")
+
+		// limit 只限制样本数，不影响总数
+		samples, count, err := tx.BlockHeaderGaps(0)
+		require.NoError(t, err)
+		require.Empty(t, samples)
+		require.Equal(t, gaps+1, count)
+	})
+}
+
+// TestUnprocessedBlockHeaders 需要数据库：有区块头但没有 event_blocks 记录的区块按连续区间报告
+func TestUnprocessedBlockHeaders(t *testing.T) {
+	db := dbtest.Open(t)
+	dbtest.InTx(t, db, func(tx *database.DB) {
+		headers := dbtest.NextBlockHeaders(t, tx, 1, 2, 3)
+		require.NoError(t, tx.Blocks.StoreBlockHeaders(headers))
+		require.NoError(t, tx.EventBlocks.StoreEventBlocksInRange(headers[0].Number, headers[0].Number))
+		require.NoError(t, tx.EventBlocks.StoreEventBlocksInRange(headers[2].Number, headers[2].Number))
+
+		// 写入的区块头都在已有区块头之后，起始区块取 headers[0] 的上一个区块，只检查写入的区块
+		startBlock := new(big.Int).Sub(headers[0].Number, big.NewInt(1))
+		samples, count, err := tx.UnprocessedBlockHeaders(startBlock, 10)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), count)
+		require.Equal(t, headers[1].Number.String(), samples[0].From.String())
+		require.Equal(t, headers[1].Number.String(), samples[0].To.String())
+
+		// 起始区块之前的区块不检查
+		_, count, err = tx.UnprocessedBlockHeaders(headers[1].Number, 10)
+		require.NoError(t, err)
+		require.Equal(t, int64(0), count)
+	})
+}
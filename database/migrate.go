@@ -0,0 +1,282 @@
+package database
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"gorm.io/gorm"
+)
+
+// migrationLockKey 执行迁移时持有的 advisory lock，避免多个进程（如 index --auto-migrate 与 migrate）同时迁移
+const migrationLockKey = 0x6576656e7473796e // "eventsyn"
+
+const createSchemaMigrationsSQL = `
+CREATE TABLE IF NOT EXISTS schema_migrations (
+    version     BIGINT PRIMARY KEY,
+    name        VARCHAR NOT NULL,
+    checksum    VARCHAR NOT NULL,
+    applied_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+)`
+
+// SchemaMigration schema_migrations 表中的一条已执行记录
+type SchemaMigration struct {
+	Version   int64
+	Name      string
+	Checksum  string
+	AppliedAt time.Time
+}
+
+func (SchemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// Migration 一个版本的迁移，Checksum 为 up 文件内容的 sha256
+type Migration struct {
+	Version  int64
+	Name     string
+	Up       string
+	Down     string
+	Checksum string
+}
+
+// MigrationStatus 迁移文件与数据库记录对比后的状态
+type MigrationStatus struct {
+	Migration
+	Applied   bool
+	AppliedAt time.Time
+	Drift     bool // 已执行的文件内容发生了变化
+	Missing   bool // 数据库中有执行记录但迁移文件已经不存在，Migration 只有记录中的版本、名称和 checksum
+}
+
+// LoadMigrations 从 fsys 读取 <版本号>_<名称>.up.sql / .down.sql，按版本号排序。
+// 每个版本必须有 up 文件，down 文件可选（缺少时该版本不能回滚）。
+func LoadMigrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		fileName := entry.Name()
+		if entry.IsDir() || path.Ext(fileName) != ".sql" {
+			continue
+		}
+
+		var direction string
+		switch {
+		case strings.HasSuffix(fileName, ".up.sql"):
+			direction = "up"
+		case strings.HasSuffix(fileName, ".down.sql"):
+			direction = "down"
+		default:
+			return nil, fmt.Errorf("migration file %s must end with .up.sql or .down.sql", fileName)
+		}
+
+		base := strings.TrimSuffix(fileName, "."+direction+".sql")
+		versionStr, name, ok := strings.Cut(base, "_")
+		if !ok {
+			return nil, fmt.Errorf("migration file %s must be named <version>_<name>.%s.sql", fileName, direction)
+		}
+		version, err := strconv.ParseInt(versionStr, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version in migration file %s: %w", fileName, err)
+		}
+
+		content, err := fs.ReadFile(fsys, fileName)
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: name}
+			byVersion[version] = m
+		} else if m.Name != name {
+			return nil, fmt.Errorf("migration version %d has different names: %s and %s", version, m.Name, name)
+		}
+		if direction == "up" {
+			m.Up = string(content)
+			sum := sha256.Sum256(content)
+			m.Checksum = hex.EncodeToString(sum[:])
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration version %d (%s) has no up file", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// MigrationStatus 对比迁移文件和 schema_migrations 中的记录，按版本号排序，包括文件已经不存在的已执行版本
+func (db *DB) MigrationStatus(migrations []Migration) ([]MigrationStatus, error) {
+	applied, err := db.appliedMigrations()
+	if err != nil {
+		return nil, err
+	}
+	return migrationStatuses(migrations, applied), nil
+}
+
+func migrationStatuses(migrations []Migration, applied map[int64]SchemaMigration) []MigrationStatus {
+	statuses := make([]MigrationStatus, 0, len(migrations))
+	known := make(map[int64]bool, len(migrations))
+	for _, m := range migrations {
+		known[m.Version] = true
+		status := MigrationStatus{Migration: m}
+		if record, ok := applied[m.Version]; ok {
+			status.Applied = true
+			status.AppliedAt = record.AppliedAt
+			status.Drift = record.Checksum != m.Checksum
+		}
+		statuses = append(statuses, status)
+	}
+	for version, record := range applied {
+		if known[version] {
+			continue
+		}
+		statuses = append(statuses, MigrationStatus{
+			Migration: Migration{Version: record.Version, Name: record.Name, Checksum: record.Checksum},
+			Applied:   true,
+			AppliedAt: record.AppliedAt,
+			Missing:   true,
+		})
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+	return statuses
+}
+
+// MigrateUp 按版本顺序执行所有未执行的迁移，每个版本一个事务。
+// 已执行版本的文件内容与记录的 checksum 不一致时拒绝执行。返回本次执行的版本数。
+func (db *DB) MigrateUp(migrations []Migration) (int, error) {
+	statuses, err := db.MigrationStatus(migrations)
+	if err != nil {
+		return 0, err
+	}
+	if err := checkDrift(statuses); err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, status := range statuses {
+		if status.Applied {
+			continue
+		}
+		m := status.Migration
+		if err := db.ApplyMigration(m); err != nil {
+			return count, err
+		}
+		log.Info("applied migration", "version", m.Version, "name", m.Name)
+		count++
+	}
+	return count, nil
+}
+
+// ApplyMigration 在一个事务内执行迁移 m 并记录版本，m 已经被其他进程执行时不做修改
+func (db *DB) ApplyMigration(m Migration) error {
+	err := db.gorm.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", migrationLockKey).Error; err != nil {
+			return err
+		}
+		// 拿到锁之后再确认一次，可能已经被其他进程执行
+		var exists int64
+		if err := tx.Model(&SchemaMigration{}).Where("version = ?", m.Version).Count(&exists).Error; err != nil {
+			return err
+		} else if exists > 0 {
+			return nil
+		}
+		if err := tx.Exec(m.Up).Error; err != nil {
+			return err
+		}
+		return tx.Create(&SchemaMigration{Version: m.Version, Name: m.Name, Checksum: m.Checksum, AppliedAt: time.Now()}).Error
+	})
+	if err != nil {
+		return fmt.Errorf("apply migration %d_%s: %w", m.Version, m.Name, err)
+	}
+	return nil
+}
+
+// MigrateDown 按版本倒序回滚最近执行的 steps 个迁移。返回本次回滚的迁移（按回滚顺序）。
+func (db *DB) MigrateDown(migrations []Migration, steps int) ([]Migration, error) {
+	statuses, err := db.MigrationStatus(migrations)
+	if err != nil {
+		return nil, err
+	}
+
+	// 数据库中有记录但文件已经不存在的版本无法回滚
+	for _, status := range statuses {
+		if status.Missing {
+			return nil, fmt.Errorf("applied migration %d_%s has no migration file", status.Version, status.Name)
+		}
+	}
+
+	var reverted []Migration
+	for i := len(statuses) - 1; i >= 0 && len(reverted) < steps; i-- {
+		status := statuses[i]
+		if !status.Applied {
+			continue
+		}
+		m := status.Migration
+		if m.Down == "" {
+			return reverted, fmt.Errorf("migration %d_%s has no down file", m.Version, m.Name)
+		}
+		err := db.gorm.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", migrationLockKey).Error; err != nil {
+				return err
+			}
+			if err := tx.Exec(m.Down).Error; err != nil {
+				return err
+			}
+			return tx.Where("version = ?", m.Version).Delete(&SchemaMigration{}).Error
+		})
+		if err != nil {
+			return reverted, fmt.Errorf("revert migration %d_%s: %w", m.Version, m.Name, err)
+		}
+		log.Info("reverted migration", "version", m.Version, "name", m.Name)
+		reverted = append(reverted, m)
+	}
+	return reverted, nil
+}
+
+func (db *DB) appliedMigrations() (map[int64]SchemaMigration, error) {
+	if err := db.gorm.Exec(createSchemaMigrationsSQL).Error; err != nil {
+		return nil, err
+	}
+	var records []SchemaMigration
+	if err := db.gorm.Order("version asc").Find(&records).Error; err != nil {
+		return nil, err
+	}
+	applied := make(map[int64]SchemaMigration, len(records))
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+	return applied, nil
+}
+
+func checkDrift(statuses []MigrationStatus) error {
+	var drifted []string
+	for _, status := range statuses {
+		if status.Drift {
+			drifted = append(drifted, fmt.Sprintf("%d_%s", status.Version, status.Name))
+		}
+	}
+	if len(drifted) > 0 {
+		return errors.New("checksum drift detected in applied migrations: " + strings.Join(drifted, ", "))
+	}
+	return nil
+}
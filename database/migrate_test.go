@@ -0,0 +1,75 @@
+package database
+
+import (
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/Sandwichzzy/event-sync-go/migrations"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	fsys := fstest.MapFS{
+		"0002_second.up.sql":  {Data: []byte("CREATE TABLE b ()")},
+		"0001_first.up.sql":   {Data: []byte("CREATE TABLE a ()")},
+		"0001_first.down.sql": {Data: []byte("DROP TABLE a")},
+		"README.md":           {Data: []byte("ignored")},
+	}
+	ms, err := LoadMigrations(fsys)
+	require.NoError(t, err)
+	require.Len(t, ms, 2)
+	require.Equal(t, int64(1), ms[0].Version)
+	require.Equal(t, "first", ms[0].Name)
+	require.Equal(t, "DROP TABLE a", ms[0].Down)
+	require.Equal(t, int64(2), ms[1].Version)
+	require.Empty(t, ms[1].Down)
+	require.Len(t, ms[1].Checksum, 64)
+
+	// 缺少 up 文件
+	_, err = LoadMigrations(fstest.MapFS{"0001_first.down.sql": {Data: []byte("DROP TABLE a")}})
+	require.Error(t, err)
+
+	// 文件名不合法
+	_, err = LoadMigrations(fstest.MapFS{"first.sql": {Data: []byte("")}})
+	require.Error(t, err)
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	ms, err := LoadMigrations(migrations.FS)
+	require.NoError(t, err)
+	require.NotEmpty(t, ms)
+	for i, m := range ms {
+		require.Equal(t, int64(i+1), m.Version)
+		require.NotEmpty(t, m.Down, "migration %d_%s has no down file", m.Version, m.Name)
+	}
+}
+
+func TestMigrationStatuses(t *testing.T) {
+	ms := []Migration{
+		{Version: 1, Name: "first", Checksum: "a"},
+		{Version: 3, Name: "third", Checksum: "c"},
+	}
+	appliedAt := time.Unix(1704067200, 0)
+	applied := map[int64]SchemaMigration{
+		1: {Version: 1, Name: "first", Checksum: "changed", AppliedAt: appliedAt},
+		2: {Version: 2, Name: "second", Checksum: "b", AppliedAt: appliedAt},
+	}
+	statuses := migrationStatuses(ms, applied)
+	require.Len(t, statuses, 3)
+
+	require.True(t, statuses[0].Applied)
+	require.True(t, statuses[0].Drift)
+	require.False(t, statuses[0].Missing)
+
+	// 已执行但文件已经删除的版本按版本号排在中间
+	require.Equal(t, int64(2), statuses[1].Version)
+	require.Equal(t, "second", statuses[1].Name)
+	require.True(t, statuses[1].Applied)
+	require.True(t, statuses[1].Missing)
+	require.Equal(t, appliedAt, statuses[1].AppliedAt)
+
+	require.False(t, statuses[2].Applied)
+	require.False(t, statuses[2].Missing)
+}
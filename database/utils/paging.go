@@ -0,0 +1,231 @@
+package utils
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// CountMode 分页查询返回总数的方式
+type CountMode int
+
+const (
+	// CountNone 不统计总数
+	CountNone CountMode = iota
+	// CountExact COUNT(*) 精确统计，表很大时较慢
+	CountExact
+	// CountApproximate 使用查询计划的估算行数，不扫描数据
+	CountApproximate
+)
+
+func (m CountMode) String() string {
+	switch m {
+	case CountExact:
+		return "exact"
+	case CountApproximate:
+		return "approximate"
+	default:
+		return "none"
+	}
+}
+
+// ParseCountMode 解析 none / exact / approximate，空字符串返回 fallback
+func ParseCountMode(s string, fallback CountMode) (CountMode, error) {
+	switch strings.ToLower(s) {
+	case "":
+		return fallback, nil
+	case "none":
+		return CountNone, nil
+	case "exact":
+		return CountExact, nil
+	case "approximate":
+		return CountApproximate, nil
+	}
+	return CountNone, fmt.Errorf("invalid count %q, expected none, exact or approximate", s)
+}
+
+// Cursor 按 (block_number, log_index) 排序的分页游标。
+// Before 为 false 时表示位置之后（按 Ascending 的排序方向）的记录，为 true 时表示位置之前的记录，
+// 两种情况下返回的记录都按 Ascending 排序。
+type Cursor struct {
+	BlockNumber *big.Int
+	LogIndex    uint64
+	Ascending   bool
+	Before      bool
+}
+
+// Encode 把游标编码为不透明的字符串
+func (c Cursor) Encode() string {
+	order, direction := "d", "n"
+	if c.Ascending {
+		order = "a"
+	}
+	if c.Before {
+		direction = "p"
+	}
+	raw := fmt.Sprintf("%s:%d:%s:%s", c.BlockNumber, c.LogIndex, order, direction)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ErrInvalidCursor 游标不是 Cursor.Encode 的输出
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// ParseCursor 解析 Cursor.Encode 编码的游标
+func ParseCursor(s string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	parts := strings.Split(string(raw), ":")
+	if len(parts) != 4 {
+		return nil, ErrInvalidCursor
+	}
+	blockNumber, ok := new(big.Int).SetString(parts[0], 10)
+	if !ok || blockNumber.Sign() < 0 {
+		return nil, ErrInvalidCursor
+	}
+	logIndex, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if (parts[2] != "a" && parts[2] != "d") || (parts[3] != "n" && parts[3] != "p") {
+		return nil, ErrInvalidCursor
+	}
+	return &Cursor{BlockNumber: blockNumber, LogIndex: logIndex, Ascending: parts[2] == "a", Before: parts[3] == "p"}, nil
+}
+
+// Page 分页参数。Cursor 不为空时按游标（keyset）分页，忽略 Page 和 Ascending；否则按页码（offset）分页
+type Page struct {
+	Page      int
+	PageSize  int
+	Ascending bool
+	Cursor    *Cursor
+	Count     CountMode
+}
+
+// PageInfo 分页结果。Count 为 CountNone 时 Total 为 0；Next / Prev 为空表示该方向没有更多记录
+type PageInfo struct {
+	Total uint64
+	Count CountMode
+	Next  *Cursor
+	Prev  *Cursor
+}
+
+// PageColumns 分页查询使用的排序列和查询列，Select 为空时查询全部列
+type PageColumns struct {
+	BlockNumber string
+	LogIndex    string
+	Select      string
+}
+
+// QueryPage 按 (block_number, log_index) 分页查询。query 每次调用返回一个新的带过滤条件的查询，
+// position 返回一条记录的排序位置，用于生成 next / prev 游标
+func QueryPage[T any](query func() *gorm.DB, columns PageColumns, page Page, position func(T) (*big.Int, uint64)) ([]T, PageInfo, error) {
+	info := PageInfo{Count: page.Count}
+	switch page.Count {
+	case CountExact:
+		var total int64
+		if err := query().Count(&total).Error; err != nil {
+			return nil, info, err
+		}
+		info.Total = uint64(total)
+	case CountApproximate:
+		total, err := estimateRows(query(), columns.Select)
+		if err != nil {
+			return nil, info, err
+		}
+		info.Total = total
+	}
+
+	ascending, before := page.Ascending, false
+	if page.Cursor != nil {
+		ascending, before = page.Cursor.Ascending, page.Cursor.Before
+	}
+	// 向前翻页时反向查询再把结果倒过来
+	scanAscending := ascending != before
+	direction, comparison := "DESC", "<"
+	if scanAscending {
+		direction, comparison = "ASC", ">"
+	}
+
+	q := query()
+	if columns.Select != "" {
+		q = q.Select(columns.Select)
+	}
+	if page.Cursor != nil {
+		q = q.Where(fmt.Sprintf("(%s, %s) %s (?, ?)", columns.BlockNumber, columns.LogIndex, comparison), page.Cursor.BlockNumber, page.Cursor.LogIndex)
+	} else if page.Page > 1 {
+		q = q.Offset((page.Page - 1) * page.PageSize)
+	}
+	var rows []T
+	result := q.
+		Order(columns.BlockNumber + " " + direction).
+		Order(columns.LogIndex + " " + direction).
+		Limit(page.PageSize + 1).
+		Find(&rows)
+	if result.Error != nil {
+		return nil, info, result.Error
+	}
+
+	hasMore := len(rows) > page.PageSize
+	if hasMore {
+		rows = rows[:page.PageSize]
+	}
+	if before {
+		for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+			rows[i], rows[j] = rows[j], rows[i]
+		}
+	}
+	if len(rows) == 0 {
+		return rows, info, nil
+	}
+
+	cursorAt := func(row T, before bool) *Cursor {
+		blockNumber, logIndex := position(row)
+		return &Cursor{BlockNumber: blockNumber, LogIndex: logIndex, Ascending: ascending, Before: before}
+	}
+	// 往后翻页：有多余的一行说明还有下一页；带游标或页码大于 1 时说明之前还有记录。往前翻页反之
+	if (!before && hasMore) || before {
+		info.Next = cursorAt(rows[len(rows)-1], false)
+	}
+	if (before && hasMore) || (!before && (page.Cursor != nil || page.Page > 1)) {
+		info.Prev = cursorAt(rows[0], true)
+	}
+	return rows, info, nil
+}
+
+// estimateRows 读取查询计划估算的行数（EXPLAIN 的 Plan Rows），不执行查询
+func estimateRows(query *gorm.DB, selectExpr string) (uint64, error) {
+	if selectExpr != "" {
+		query = query.Select(selectExpr)
+	}
+	var rows []map[string]interface{}
+	stmt := query.Session(&gorm.Session{DryRun: true}).Find(&rows).Statement
+
+	ctx := stmt.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	var plan string
+	if err := stmt.ConnPool.QueryRowContext(ctx, "EXPLAIN (FORMAT JSON) "+stmt.SQL.String(), stmt.Vars...).Scan(&plan); err != nil {
+		return 0, err
+	}
+	var explain []struct {
+		Plan struct {
+			PlanRows float64 `json:"Plan Rows"`
+		} `json:"Plan"`
+	}
+	if err := json.Unmarshal([]byte(plan), &explain); err != nil {
+		return 0, err
+	} else if len(explain) == 0 {
+		return 0, errors.New("empty query plan")
+	}
+	return uint64(explain[0].Plan.PlanRows), nil
+}
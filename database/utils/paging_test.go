@@ -0,0 +1,34 @@
+package utils
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	cursor := Cursor{BlockNumber: big.NewInt(1140200), LogIndex: 7, Ascending: true, Before: true}
+	parsed, err := ParseCursor(cursor.Encode())
+	require.NoError(t, err)
+	require.Equal(t, cursor, *parsed)
+
+	for _, invalid := range []string{"", "not-base64!", "MTox", Cursor{BlockNumber: big.NewInt(1)}.Encode() + "x"} {
+		_, err := ParseCursor(invalid)
+		require.ErrorIs(t, err, ErrInvalidCursor, invalid)
+	}
+}
+
+func TestParseCountMode(t *testing.T) {
+	mode, err := ParseCountMode("", CountExact)
+	require.NoError(t, err)
+	require.Equal(t, CountExact, mode)
+
+	mode, err = ParseCountMode("Approximate", CountExact)
+	require.NoError(t, err)
+	require.Equal(t, CountApproximate, mode)
+	require.Equal(t, "approximate", mode.String())
+
+	_, err = ParseCountMode("maybe", CountNone)
+	require.Error(t, err)
+}
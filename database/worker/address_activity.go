@@ -0,0 +1,130 @@
+package worker
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/Sandwichzzy/event-sync-go/database/utils"
+)
+
+const (
+	ActivityDeposit       = "deposit"
+	ActivityWithdraw      = "withdraw"
+	ActivityGrant         = "grant"
+	ActivityManagerUpdate = "manager_update"
+)
+
+// AddressActivity 地址时间线中的一条记录，来自充值、提现、奖励发放或提现管理员变更。
+// 提现管理员变更没有代币和金额，TokenAddress 和 Amount 为空；只有提现记录有 Receiver
+type AddressActivity struct {
+	EventType       string
+	GUID            uuid.UUID
+	BlockNumber     *big.Int    `gorm:"serializer:u256"`
+	BlockHash       common.Hash `gorm:"serializer:bytes"`
+	TransactionHash common.Hash `gorm:"serializer:bytes"`
+	LogIndex        uint64
+	TokenAddress    *common.Address `gorm:"serializer:bytes"`
+	Sender          common.Address  `gorm:"serializer:bytes"` // sender / granter / withdraw_manager
+	Receiver        *common.Address `gorm:"serializer:bytes"`
+	Amount          *big.Int        `gorm:"serializer:u256"`
+	Timestamp       uint64
+}
+
+// position 记录在链上的排序位置，用于分页游标
+func (e AddressActivity) position() (*big.Int, uint64) {
+	return e.BlockNumber, e.LogIndex
+}
+
+// AddressTokenSummary 地址在一个代币上的充值、提现（作为发起方或接收方）和奖励发放（事件中的 granter 为该地址）的笔数与合计金额
+type AddressTokenSummary struct {
+	TokenAddress  common.Address `gorm:"serializer:bytes"`
+	DepositCount  uint64
+	Deposited     *big.Int `gorm:"serializer:u256"`
+	WithdrawCount uint64
+	Withdrawn     *big.Int `gorm:"serializer:u256"`
+	GranterCount  uint64
+	GranterAmount *big.Int `gorm:"serializer:u256"`
+}
+
+type AddressActivityView interface {
+	// QueryAddressActivity 按 (block_number, log_index) 分页查询涉及 address 的充值（sender）、提现（sender / receiver）、
+	// 奖励发放（granter）和提现管理员变更（withdraw_manager），filter 中的代币、交易、区块和时间条件作用于合并后的时间线
+	QueryAddressActivity(address common.Address, filter EventFilter, page utils.Page) ([]AddressActivity, utils.PageInfo, error)
+	// QueryAddressTokenSummaries 按代币汇总地址的充值、提现和作为 granter 的奖励发放，按代币地址排序
+	QueryAddressTokenSummaries(address common.Address) ([]AddressTokenSummary, error)
+}
+
+type AddressActivityDB interface {
+	AddressActivityView
+}
+
+type addressActivityDB struct {
+	gorm *gorm.DB
+}
+
+func NewAddressActivityDB(db *gorm.DB) AddressActivityDB {
+	return &addressActivityDB{gorm: db}
+}
+
+// addressActivityColumns 合并后的时间线中可以过滤的列，参与方由 address 决定
+var addressActivityColumns = eventColumns{token: "token_address"}
+
+const addressActivitySQL = `
+	SELECT 'deposit' AS event_type, guid, block_number, block_hash, transaction_hash, log_index,
+	       token_address, sender, NULL AS receiver, amount, timestamp
+	FROM deposit_tokens WHERE sender = @address
+	UNION ALL
+	SELECT 'withdraw', guid, block_number, block_hash, transaction_hash, log_index,
+	       token_address, sender, receiver, amount, timestamp
+	FROM withdraw_tokens WHERE sender = @address OR receiver = @address
+	UNION ALL
+	SELECT 'grant', guid, block_number, block_hash, transaction_hash, log_index,
+	       token_address, granter, NULL, amount, timestamp
+	FROM grant_reward_tokens WHERE granter = @address
+	UNION ALL
+	SELECT 'manager_update', guid, block_number, block_hash, transaction_hash, log_index,
+	       NULL, withdraw_manager, NULL, NULL, timestamp
+	FROM withdraw_manager_update WHERE withdraw_manager = @address`
+
+func (db *addressActivityDB) QueryAddressActivity(address common.Address, filter EventFilter, page utils.Page) ([]AddressActivity, utils.PageInfo, error) {
+	if filter.Sender != nil || filter.Receiver != nil || filter.Address != nil {
+		return nil, utils.PageInfo{}, fmt.Errorf("sender, receiver and address %w on address activity", ErrUnsupportedFilter)
+	}
+	args := map[string]interface{}{"address": hexutil.Encode(address[:])}
+	query := func() *gorm.DB {
+		q, _ := filter.apply(db.gorm.Table("(?) AS activity", db.gorm.Raw(addressActivitySQL, args)), addressActivityColumns)
+		return q
+	}
+	return utils.QueryPage(query, eventPageColumns, page, AddressActivity.position)
+}
+
+func (db *addressActivityDB) QueryAddressTokenSummaries(address common.Address) ([]AddressTokenSummary, error) {
+	var summaries []AddressTokenSummary
+	result := db.gorm.Raw(`
+		SELECT token_address,
+		       SUM(deposit_count) AS deposit_count, SUM(deposited) AS deposited,
+		       SUM(withdraw_count) AS withdraw_count, SUM(withdrawn) AS withdrawn,
+		       SUM(granter_count) AS granter_count, SUM(granter_amount) AS granter_amount
+		FROM (
+			SELECT token_address, COUNT(*) AS deposit_count, SUM(amount) AS deposited,
+			       0 AS withdraw_count, 0 AS withdrawn, 0 AS granter_count, 0 AS granter_amount
+			FROM deposit_tokens WHERE sender = @address GROUP BY token_address
+			UNION ALL
+			SELECT token_address, 0, 0, COUNT(*), SUM(amount), 0, 0
+			FROM withdraw_tokens WHERE sender = @address OR receiver = @address GROUP BY token_address
+			UNION ALL
+			SELECT token_address, 0, 0, 0, 0, COUNT(*), SUM(amount)
+			FROM grant_reward_tokens WHERE granter = @address GROUP BY token_address
+		) AS s
+		GROUP BY token_address
+		ORDER BY token_address`, map[string]interface{}{"address": hexutil.Encode(address[:])}).Scan(&summaries)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return summaries, nil
+}
@@ -8,15 +8,25 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/google/uuid"
+
+	"github.com/Sandwichzzy/event-sync-go/database/utils"
 )
 
 type DepositTokens struct {
-	GUID         uuid.UUID      `gorm:"primaryKey" json:"guid"`
-	BlockNumber  *big.Int       `gorm:"serializer:u256" json:"block_number"`
-	TokenAddress common.Address `json:"token_address" gorm:"serializer:bytes"`
-	Sender       common.Address `json:"sender" gorm:"serializer:bytes"`
-	Amount       *big.Int       `gorm:"serializer:u256"`
-	Timestamp    uint64
+	GUID            uuid.UUID      `gorm:"primaryKey" json:"guid"`
+	BlockNumber     *big.Int       `gorm:"serializer:u256" json:"block_number"`
+	BlockHash       common.Hash    `gorm:"serializer:bytes" json:"block_hash"`
+	TransactionHash common.Hash    `gorm:"serializer:bytes" json:"transaction_hash"`
+	LogIndex        uint64         `json:"log_index"`
+	TokenAddress    common.Address `json:"token_address" gorm:"serializer:bytes"`
+	Sender          common.Address `json:"sender" gorm:"serializer:bytes"`
+	Amount          *big.Int       `gorm:"serializer:u256"`
+	Timestamp       uint64
+}
+
+// position 记录在链上的排序位置，用于分页游标
+func (e DepositTokens) position() (*big.Int, uint64) {
+	return e.BlockNumber, e.LogIndex
 }
 
 func (DepositTokens) TableName() string {
@@ -24,6 +34,8 @@ func (DepositTokens) TableName() string {
 }
 
 type DepositTokensView interface {
+	QueryDepositTokens(filter EventFilter, page utils.Page) ([]DepositTokens, utils.PageInfo, error)
+	DepositTokensByGUID(guid string) (*DepositTokens, error)
 	QueryDepositTokensList(page int, pageSize int) ([]DepositTokens, uint64)
 	QueryDepositTokensById(string) (*DepositTokens, error)
 }
@@ -59,6 +71,8 @@ func (db depositTokensDB) QueryDepositTokensList(page int, pageSize int) ([]Depo
 
 	offset := (page - 1) * pageSize
 	result := db.gorm.
+		Order("block_number DESC").
+		Order("log_index DESC").
 		Limit(pageSize).
 		Offset(offset).
 		Find(&depositTokens)
@@ -72,10 +86,23 @@ func (db depositTokensDB) QueryDepositTokensList(page int, pageSize int) ([]Depo
 }
 
 func (db depositTokensDB) StoreDepositTokens(depositTokensList []DepositTokens) error {
-	result := db.gorm.CreateInBatches(&depositTokensList, len(depositTokensList))
+	if len(depositTokensList) == 0 {
+		return nil
+	}
+	result := db.gorm.Clauses(eventPositionUpsert).CreateInBatches(&depositTokensList, len(depositTokensList))
 	return result.Error
 }
 
 func NewDepositTokensDB(db *gorm.DB) DepositTokensDB {
 	return &depositTokensDB{gorm: db}
 }
+
+// QueryDepositTokens 按过滤条件分页查询充值记录，返回当前页和分页信息（总数、前后页游标）
+func (db depositTokensDB) QueryDepositTokens(filter EventFilter, page utils.Page) ([]DepositTokens, utils.PageInfo, error) {
+	return queryEvents(db.gorm, DepositTokens{}, depositTokensColumns, filter, page, DepositTokens.position)
+}
+
+// DepositTokensByGUID 按 GUID 查询一条充值记录，不存在时返回 nil
+func (db depositTokensDB) DepositTokensByGUID(guid string) (*DepositTokens, error) {
+	return queryEventByGUID[DepositTokens](db.gorm, guid)
+}
@@ -0,0 +1,71 @@
+package worker
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type EventExportView interface {
+	// StreamDepositTokens 按 (block_number, log_index) 升序逐行读取满足过滤条件的充值记录，fn 返回错误时停止读取并返回该错误，
+	// ctx 结束时取消查询
+	StreamDepositTokens(ctx context.Context, filter EventFilter, fn func(DepositTokens) error) error
+	// StreamWithdrawTokens 同 StreamDepositTokens，读取提现记录
+	StreamWithdrawTokens(ctx context.Context, filter EventFilter, fn func(WithdrawTokens) error) error
+	// StreamGrantRewardTokens 同 StreamDepositTokens，读取奖励发放记录
+	StreamGrantRewardTokens(ctx context.Context, filter EventFilter, fn func(GrantRewardTokens) error) error
+	// StreamWithdrawManagerUpdates 同 StreamDepositTokens，读取提现管理员变更记录
+	StreamWithdrawManagerUpdates(ctx context.Context, filter EventFilter, fn func(WithdrawManagerUpdate) error) error
+}
+
+type EventExportDB interface {
+	EventExportView
+}
+
+type eventExportDB struct {
+	gorm *gorm.DB
+}
+
+func NewEventExportDB(db *gorm.DB) EventExportDB {
+	return &eventExportDB{gorm: db}
+}
+
+func (db *eventExportDB) StreamDepositTokens(ctx context.Context, filter EventFilter, fn func(DepositTokens) error) error {
+	return streamEvents(db.gorm.WithContext(ctx), DepositTokens{}, depositTokensColumns, filter, fn)
+}
+
+func (db *eventExportDB) StreamWithdrawTokens(ctx context.Context, filter EventFilter, fn func(WithdrawTokens) error) error {
+	return streamEvents(db.gorm.WithContext(ctx), WithdrawTokens{}, withdrawTokensColumns, filter, fn)
+}
+
+func (db *eventExportDB) StreamGrantRewardTokens(ctx context.Context, filter EventFilter, fn func(GrantRewardTokens) error) error {
+	return streamEvents(db.gorm.WithContext(ctx), GrantRewardTokens{}, grantRewardTokensColumns, filter, fn)
+}
+
+func (db *eventExportDB) StreamWithdrawManagerUpdates(ctx context.Context, filter EventFilter, fn func(WithdrawManagerUpdate) error) error {
+	return streamEvents(db.gorm.WithContext(ctx), WithdrawManagerUpdate{}, withdrawManagerUpdateColumns, filter, fn)
+}
+
+// streamEvents 用游标逐行读取一张 worker 表，不会把结果集全部加载到内存
+func streamEvents[T any](db *gorm.DB, model T, columns eventColumns, filter EventFilter, fn func(T) error) error {
+	query, err := filter.apply(db.Model(&model), columns)
+	if err != nil {
+		return err
+	}
+	rows, err := query.Order("block_number ASC").Order("log_index ASC").Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row T
+		if err := query.ScanRows(rows, &row); err != nil {
+			return err
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
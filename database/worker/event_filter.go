@@ -0,0 +1,116 @@
+package worker
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"gorm.io/gorm"
+
+	"github.com/Sandwichzzy/event-sync-go/database/utils"
+)
+
+// ErrUnsupportedFilter 查询的表没有过滤条件对应的列
+var ErrUnsupportedFilter = errors.New("filter is not supported")
+
+// EventFilter worker 事件列表的查询条件，零值字段不过滤。
+// Sender 匹配事件的发起方（充值 / 提现的 sender、奖励发放的 granter、提现管理员变更的 withdraw_manager），
+// Address 匹配事件的任一参与方。
+type EventFilter struct {
+	TokenAddress    *common.Address
+	Sender          *common.Address
+	Receiver        *common.Address
+	Address         *common.Address
+	TransactionHash *common.Hash
+	FromBlock       *big.Int // 包含
+	ToBlock         *big.Int // 包含
+	FromTimestamp   uint64   // 包含
+	ToTimestamp     uint64   // 包含
+}
+
+// eventColumns 一张 worker 表中与 EventFilter 对应的列，为空表示该表没有这一列
+type eventColumns struct {
+	token    string
+	sender   string
+	receiver string
+}
+
+var (
+	depositTokensColumns         = eventColumns{token: "token_address", sender: "sender"}
+	withdrawTokensColumns        = eventColumns{token: "token_address", sender: "sender", receiver: "receiver"}
+	grantRewardTokensColumns     = eventColumns{token: "token_address", sender: "granter"}
+	withdrawManagerUpdateColumns = eventColumns{sender: "withdraw_manager"}
+)
+
+// apply 把过滤条件加到查询上，表中没有对应列的过滤条件返回错误
+func (f EventFilter) apply(query *gorm.DB, columns eventColumns) (*gorm.DB, error) {
+	if f.TokenAddress != nil {
+		if columns.token == "" {
+			return nil, fmt.Errorf("token_address %w", ErrUnsupportedFilter)
+		}
+		query = query.Where(columns.token+" = ?", hexutil.Encode(f.TokenAddress[:]))
+	}
+	if f.Sender != nil {
+		query = query.Where(columns.sender+" = ?", hexutil.Encode(f.Sender[:]))
+	}
+	if f.Receiver != nil {
+		if columns.receiver == "" {
+			return nil, fmt.Errorf("receiver %w", ErrUnsupportedFilter)
+		}
+		query = query.Where(columns.receiver+" = ?", hexutil.Encode(f.Receiver[:]))
+	}
+	if f.Address != nil {
+		address := hexutil.Encode(f.Address[:])
+		if columns.receiver != "" {
+			query = query.Where(columns.sender+" = ? OR "+columns.receiver+" = ?", address, address)
+		} else {
+			query = query.Where(columns.sender+" = ?", address)
+		}
+	}
+	if f.TransactionHash != nil {
+		query = query.Where("transaction_hash = ?", hexutil.Encode(f.TransactionHash[:]))
+	}
+	if f.FromBlock != nil {
+		query = query.Where("block_number >= ?", f.FromBlock)
+	}
+	if f.ToBlock != nil {
+		query = query.Where("block_number <= ?", f.ToBlock)
+	}
+	if f.FromTimestamp > 0 {
+		query = query.Where("timestamp >= ?", f.FromTimestamp)
+	}
+	if f.ToTimestamp > 0 {
+		query = query.Where("timestamp <= ?", f.ToTimestamp)
+	}
+	return query, nil
+}
+
+// eventPageColumns worker 表的分页排序列
+var eventPageColumns = utils.PageColumns{BlockNumber: "block_number", LogIndex: "log_index"}
+
+// queryEvents 按过滤条件分页查询一张 worker 表，按 (block_number, log_index) 排序
+func queryEvents[T any](db *gorm.DB, model T, columns eventColumns, filter EventFilter, page utils.Page, position func(T) (*big.Int, uint64)) ([]T, utils.PageInfo, error) {
+	// 先校验一次过滤条件，之后每次构建查询都不会再出错
+	if _, err := filter.apply(db, columns); err != nil {
+		return nil, utils.PageInfo{}, err
+	}
+	query := func() *gorm.DB {
+		q, _ := filter.apply(db.Model(&model), columns)
+		return q
+	}
+	return utils.QueryPage(query, eventPageColumns, page, position)
+}
+
+// queryEventByGUID 按 GUID 查询一张 worker 表中的一条记录，不存在时返回 nil
+func queryEventByGUID[T any](db *gorm.DB, guid string) (*T, error) {
+	var rows []T
+	result := db.Where("guid = ?", guid).Limit(1).Find(&rows)
+	if result.Error != nil {
+		return nil, result.Error
+	} else if len(rows) == 0 {
+		return nil, nil
+	}
+	return &rows[0], nil
+}
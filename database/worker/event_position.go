@@ -0,0 +1,27 @@
+package worker
+
+import (
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+	"gorm.io/gorm/clause"
+)
+
+// eventGUIDNamespace worker 表确定性 GUID 的命名空间
+var eventGUIDNamespace = uuid.MustParse("5c1c52c8-5a0b-4a43-9d54-0e5b1f0f6a7e")
+
+// EventGUID 根据事件在链上的位置（区块哈希 + 日志索引）生成确定性的 GUID，
+// 同一条日志无论处理多少次都得到相同的 GUID
+func EventGUID(blockHash common.Hash, logIndex uint64) uuid.UUID {
+	var name [common.HashLength + 8]byte
+	copy(name[:], blockHash[:])
+	binary.BigEndian.PutUint64(name[common.HashLength:], logIndex)
+	return uuid.NewSHA1(eventGUIDNamespace, name[:])
+}
+
+// eventPositionUpsert worker 表按 (block_hash, log_index) 做 upsert，重复处理同一区间不会产生重复记录
+var eventPositionUpsert = clause.OnConflict{
+	Columns:   []clause.Column{{Name: "block_hash"}, {Name: "log_index"}},
+	UpdateAll: true,
+}
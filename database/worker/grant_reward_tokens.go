@@ -8,15 +8,25 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/Sandwichzzy/event-sync-go/database/utils"
 )
 
 type GrantRewardTokens struct {
-	GUID         uuid.UUID      `gorm:"primaryKey" json:"guid"`
-	BlockNumber  *big.Int       `gorm:"serializer:u256" json:"block_number"`
-	TokenAddress common.Address `gorm:"serializer:bytes" json:"token_address"`
-	Granter      common.Address `gorm:"serializer:bytes" json:"granter"`
-	Amount       *big.Int       `gorm:"serializer:u256" json:"amount"`
-	Timestamp    uint64         `json:"timestamp"`
+	GUID            uuid.UUID      `gorm:"primaryKey" json:"guid"`
+	BlockNumber     *big.Int       `gorm:"serializer:u256" json:"block_number"`
+	BlockHash       common.Hash    `gorm:"serializer:bytes" json:"block_hash"`
+	TransactionHash common.Hash    `gorm:"serializer:bytes" json:"transaction_hash"`
+	LogIndex        uint64         `json:"log_index"`
+	TokenAddress    common.Address `gorm:"serializer:bytes" json:"token_address"`
+	Granter         common.Address `gorm:"serializer:bytes" json:"granter"`
+	Amount          *big.Int       `gorm:"serializer:u256" json:"amount"`
+	Timestamp       uint64         `json:"timestamp"`
+}
+
+// position 记录在链上的排序位置，用于分页游标
+func (e GrantRewardTokens) position() (*big.Int, uint64) {
+	return e.BlockNumber, e.LogIndex
 }
 
 func (GrantRewardTokens) TableName() string {
@@ -24,6 +34,8 @@ func (GrantRewardTokens) TableName() string {
 }
 
 type GrantRewardTokensView interface {
+	QueryGrantRewardTokens(filter EventFilter, page utils.Page) ([]GrantRewardTokens, utils.PageInfo, error)
+	GrantRewardTokensByGUID(guid string) (*GrantRewardTokens, error)
 	QueryGrantRewardTokensList(page int, pageSize int, order string) ([]GrantRewardTokens, uint64)
 }
 
@@ -77,6 +89,16 @@ func (db *grantRewardTokensDB) StoreGrantRewardTokens(grantRewardTokensList []Gr
 	if len(grantRewardTokensList) == 0 {
 		return nil
 	}
-	result := db.gorm.CreateInBatches(&grantRewardTokensList, len(grantRewardTokensList))
+	result := db.gorm.Clauses(eventPositionUpsert).CreateInBatches(&grantRewardTokensList, len(grantRewardTokensList))
 	return result.Error
 }
+
+// QueryGrantRewardTokens 按过滤条件分页查询奖励发放记录，返回当前页和分页信息（总数、前后页游标）
+func (db *grantRewardTokensDB) QueryGrantRewardTokens(filter EventFilter, page utils.Page) ([]GrantRewardTokens, utils.PageInfo, error) {
+	return queryEvents(db.gorm, GrantRewardTokens{}, grantRewardTokensColumns, filter, page, GrantRewardTokens.position)
+}
+
+// GrantRewardTokensByGUID 按 GUID 查询一条奖励发放记录，不存在时返回 nil
+func (db *grantRewardTokensDB) GrantRewardTokensByGUID(guid string) (*GrantRewardTokens, error) {
+	return queryEventByGUID[GrantRewardTokens](db.gorm, guid)
+}
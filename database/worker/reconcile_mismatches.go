@@ -0,0 +1,92 @@
+package worker
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// ReconcileKindTreasuryBalance 金库代币余额对账
+	ReconcileKindTreasuryBalance = "treasury_balance"
+	// ReconcileKindRewardClaimable 用户可领取奖励对账
+	ReconcileKindRewardClaimable = "reward_claimable"
+)
+
+type ReconcileMismatch struct {
+	GUID         uuid.UUID      `gorm:"primaryKey" json:"guid"`
+	Kind         string         `json:"kind"`
+	TokenAddress common.Address `gorm:"serializer:bytes" json:"token_address"`
+	Account      string         `json:"account"`
+	BlockNumber  *big.Int       `gorm:"serializer:u256" json:"block_number"`
+	BlockHash    common.Hash    `gorm:"serializer:bytes" json:"block_hash"`
+	Projected    *big.Int       `gorm:"serializer:u256" json:"projected"`
+	OnChain      *big.Int       `gorm:"serializer:u256" json:"on_chain"`
+	Timestamp    uint64         `json:"timestamp"`
+}
+
+func (ReconcileMismatch) TableName() string {
+	return "reconcile_mismatches"
+}
+
+type ReconcileMismatchesView interface {
+	QueryReconcileMismatchesList(kind string, page int, pageSize int) ([]ReconcileMismatch, uint64)
+}
+
+type ReconcileMismatchesDB interface {
+	ReconcileMismatchesView
+	StoreReconcileMismatches([]ReconcileMismatch) error
+}
+
+type reconcileMismatchesDB struct {
+	gorm *gorm.DB
+}
+
+func NewReconcileMismatchesDB(db *gorm.DB) ReconcileMismatchesDB {
+	return &reconcileMismatchesDB{gorm: db}
+}
+
+func (db *reconcileMismatchesDB) QueryReconcileMismatchesList(kind string, page int, pageSize int) ([]ReconcileMismatch, uint64) {
+	var (
+		mismatches []ReconcileMismatch
+		total      int64
+	)
+
+	query := db.gorm.Model(&ReconcileMismatch{})
+	if kind != "" {
+		query = query.Where("kind = ?", kind)
+	}
+
+	// 统计总数
+	if err := query.Count(&total).Error; err != nil {
+		fmt.Printf("count reconcile_mismatches error: %v\n", err)
+		return nil, 0
+	}
+
+	// 分页查询
+	offset := (page - 1) * pageSize
+	result := query.
+		Order("block_number desc").
+		Limit(pageSize).
+		Offset(offset).
+		Find(&mismatches)
+
+	if result.Error != nil {
+		fmt.Printf("query reconcile_mismatches error: %v\n", result.Error)
+		return nil, 0
+	}
+
+	return mismatches, uint64(total)
+}
+
+func (db *reconcileMismatchesDB) StoreReconcileMismatches(mismatches []ReconcileMismatch) error {
+	if len(mismatches) == 0 {
+		return nil
+	}
+	result := db.gorm.CreateInBatches(&mismatches, len(mismatches))
+	return result.Error
+}
@@ -0,0 +1,250 @@
+package worker
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	RewardEntryGrant = "grant"
+	RewardEntryClaim = "claim"
+)
+
+type RewardLedgerEntry struct {
+	GUID         uuid.UUID      `gorm:"primaryKey" json:"guid"`
+	UserAddress  common.Address `gorm:"serializer:bytes" json:"user_address"`
+	TokenAddress common.Address `gorm:"serializer:bytes" json:"token_address"`
+	EntryType    string         `json:"entry_type"`
+	Amount       *big.Int       `gorm:"serializer:u256" json:"amount"`
+	BlockNumber  *big.Int       `gorm:"serializer:u256" json:"block_number"`
+	BlockHash    common.Hash    `gorm:"serializer:bytes" json:"block_hash"`
+	Timestamp    uint64         `json:"timestamp"`
+}
+
+func (RewardLedgerEntry) TableName() string {
+	return "reward_ledger_entries"
+}
+
+type RewardBalance struct {
+	UserAddress           common.Address `gorm:"primaryKey;serializer:bytes" json:"user_address"`
+	TokenAddress          common.Address `gorm:"primaryKey;serializer:bytes" json:"token_address"`
+	Granted               *big.Int       `gorm:"serializer:u256" json:"granted"`
+	Claimed               *big.Int       `gorm:"serializer:u256" json:"claimed"`
+	Claimable             *big.Int       `gorm:"serializer:u256" json:"claimable"`
+	BlockNumber           *big.Int       `gorm:"serializer:u256" json:"block_number"`
+	ReconciledBlockNumber *big.Int       `gorm:"serializer:u256" json:"reconciled_block_number"`
+	Timestamp             uint64         `json:"timestamp"`
+}
+
+func (RewardBalance) TableName() string {
+	return "reward_balances"
+}
+
+type RewardLedgerView interface {
+	QueryRewardBalances(userAddress common.Address) ([]RewardBalance, error)
+	QueryRewardLedgerEntries(userAddress common.Address, page int, pageSize int) ([]RewardLedgerEntry, uint64)
+	RewardBalancesToReconcile(blockNumber *big.Int, limit int) ([]RewardBalance, error)
+	RewardGrantTotalsInRange(fromHeight *big.Int, toHeight *big.Int) ([]RewardBalance, error)
+}
+
+type RewardLedgerDB interface {
+	RewardLedgerView
+	StoreRewardLedgerEntries([]RewardLedgerEntry) error
+	StoreRewardGrants([]RewardBalance) error
+	ApplyRewardClaim(balance RewardBalance, amount *big.Int, blockNumber *big.Int) (bool, error)
+	MarkRewardBalanceReconciled(balance RewardBalance, blockNumber *big.Int) error
+	DeleteRewardGrantEntriesInRange(fromHeight *big.Int, toHeight *big.Int) error
+	DeleteRewardClaimEntriesFrom(userAddress common.Address, tokenAddress common.Address, fromHeight *big.Int) error
+	RecomputeRewardBalance(userAddress common.Address, tokenAddress common.Address) error
+}
+
+type rewardLedgerDB struct {
+	gorm *gorm.DB
+}
+
+func NewRewardLedgerDB(db *gorm.DB) RewardLedgerDB {
+	return &rewardLedgerDB{gorm: db}
+}
+
+func (db *rewardLedgerDB) QueryRewardBalances(userAddress common.Address) ([]RewardBalance, error) {
+	var balances []RewardBalance
+	result := db.gorm.Where(&RewardBalance{UserAddress: userAddress}).Order("token_address asc").Find(&balances)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return balances, nil
+}
+
+func (db *rewardLedgerDB) QueryRewardLedgerEntries(userAddress common.Address, page int, pageSize int) ([]RewardLedgerEntry, uint64) {
+	var (
+		entries []RewardLedgerEntry
+		total   int64
+	)
+
+	query := db.gorm.Model(&RewardLedgerEntry{}).Where(&RewardLedgerEntry{UserAddress: userAddress})
+
+	// 统计总数
+	if err := query.Count(&total).Error; err != nil {
+		fmt.Printf("count reward_ledger_entries error: %v\n", err)
+		return nil, 0
+	}
+
+	// 分页查询
+	offset := (page - 1) * pageSize
+	result := query.
+		Order("block_number desc").
+		Limit(pageSize).
+		Offset(offset).
+		Find(&entries)
+
+	if result.Error != nil {
+		fmt.Printf("query reward_ledger_entries error: %v\n", result.Error)
+		return nil, 0
+	}
+
+	return entries, uint64(total)
+}
+
+// RewardBalancesToReconcile 返回最近对账高度低于 blockNumber、且最后一次发放不晚于 blockNumber 的奖励汇总记录
+func (db *rewardLedgerDB) RewardBalancesToReconcile(blockNumber *big.Int, limit int) ([]RewardBalance, error) {
+	var balances []RewardBalance
+	result := db.gorm.Where("reconciled_block_number < ? AND block_number <= ?", blockNumber, blockNumber).
+		Order("reconciled_block_number asc").
+		Limit(limit).
+		Find(&balances)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return balances, nil
+}
+
+// RewardGrantTotalsInRange 按 (user_address, token_address) 汇总 [fromHeight, toHeight] 内的发放流水，金额在 Granted 中
+func (db *rewardLedgerDB) RewardGrantTotalsInRange(fromHeight *big.Int, toHeight *big.Int) ([]RewardBalance, error) {
+	var balances []RewardBalance
+	result := db.gorm.Model(&RewardLedgerEntry{}).
+		Select("user_address, token_address, SUM(amount) AS granted, MAX(block_number) AS block_number").
+		Where("entry_type = ? AND block_number >= ? AND block_number <= ?", RewardEntryGrant, fromHeight, toHeight).
+		Group("user_address, token_address").
+		Scan(&balances)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return balances, nil
+}
+
+func (db *rewardLedgerDB) StoreRewardLedgerEntries(entries []RewardLedgerEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	result := db.gorm.CreateInBatches(&entries, len(entries))
+	return result.Error
+}
+
+// StoreRewardGrants 把本批次的发放金额累加到 granted 和 claimable 上，
+// 同一 (user_address, token_address) 在一次调用中只能出现一次。
+func (db *rewardLedgerDB) StoreRewardGrants(balances []RewardBalance) error {
+	if len(balances) == 0 {
+		return nil
+	}
+	result := db.gorm.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_address"}, {Name: "token_address"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"granted":      gorm.Expr("reward_balances.granted + excluded.granted"),
+			"claimable":    gorm.Expr("reward_balances.claimable + excluded.claimable"),
+			"block_number": gorm.Expr("excluded.block_number"),
+			"timestamp":    gorm.Expr("excluded.timestamp"),
+		}),
+	}).CreateInBatches(&balances, len(balances))
+	return result.Error
+}
+
+// ApplyRewardClaim 记一笔领取：claimed 增加、claimable 减少，并更新对账高度。
+// 如果在此期间又有更高区块的发放写入（block_number > blockNumber），不做修改并返回 false。
+func (db *rewardLedgerDB) ApplyRewardClaim(balance RewardBalance, amount *big.Int, blockNumber *big.Int) (bool, error) {
+	result := db.gorm.Model(&RewardBalance{}).
+		Where(&RewardBalance{UserAddress: balance.UserAddress, TokenAddress: balance.TokenAddress}).
+		Where("block_number <= ?", blockNumber).
+		Updates(map[string]interface{}{
+			"claimed":                 gorm.Expr("claimed + ?", amount),
+			"claimable":               gorm.Expr("claimable - ?", amount),
+			"reconciled_block_number": blockNumber,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (db *rewardLedgerDB) MarkRewardBalanceReconciled(balance RewardBalance, blockNumber *big.Int) error {
+	result := db.gorm.Model(&RewardBalance{}).
+		Where(&RewardBalance{UserAddress: balance.UserAddress, TokenAddress: balance.TokenAddress}).
+		Update("reconciled_block_number", blockNumber)
+	return result.Error
+}
+
+func (db *rewardLedgerDB) DeleteRewardGrantEntriesInRange(fromHeight *big.Int, toHeight *big.Int) error {
+	result := db.gorm.Where("entry_type = ? AND block_number >= ? AND block_number <= ?", RewardEntryGrant, fromHeight, toHeight).
+		Delete(&RewardLedgerEntry{})
+	return result.Error
+}
+
+// DeleteRewardClaimEntriesFrom 删除 (userAddress, tokenAddress) 在 fromHeight 及之后由对账推导出的领取流水。
+// 这些领取是按当时本地的 claimable 推导的，重建 fromHeight 之后的发放流水后需要撤销，由对账任务在最新高度重新推导。
+func (db *rewardLedgerDB) DeleteRewardClaimEntriesFrom(userAddress common.Address, tokenAddress common.Address, fromHeight *big.Int) error {
+	result := db.gorm.Where("user_address = ? AND token_address = ? AND entry_type = ? AND block_number >= ?",
+		hexutil.Encode(userAddress[:]), hexutil.Encode(tokenAddress[:]), RewardEntryClaim, fromHeight).
+		Delete(&RewardLedgerEntry{})
+	return result.Error
+}
+
+// RecomputeRewardBalance 按流水重新计算 (userAddress, tokenAddress) 的奖励汇总：
+// granted / claimed 为发放和领取流水之和，claimable = granted - claimed（不低于 0），
+// block_number 为最后一次发放的区块，reconciled_block_number 为最后一次领取的区块，
+// 使撤销过领取的记录重新进入对账。没有任何流水时删除汇总记录。
+func (db *rewardLedgerDB) RecomputeRewardBalance(userAddress common.Address, tokenAddress common.Address) error {
+	args := map[string]interface{}{
+		"user":  hexutil.Encode(userAddress[:]),
+		"token": hexutil.Encode(tokenAddress[:]),
+		"grant": RewardEntryGrant,
+		"claim": RewardEntryClaim,
+	}
+	err := db.gorm.Exec(`
+		INSERT INTO reward_balances (user_address, token_address, granted, claimed, claimable, block_number, reconciled_block_number, timestamp)
+		SELECT user_address, token_address, granted, claimed, GREATEST(granted - claimed, 0), block_number, reconciled_block_number, timestamp
+		FROM (
+			SELECT user_address, token_address,
+			       COALESCE(SUM(amount) FILTER (WHERE entry_type = @grant), 0) AS granted,
+			       COALESCE(SUM(amount) FILTER (WHERE entry_type = @claim), 0) AS claimed,
+			       COALESCE(MAX(block_number) FILTER (WHERE entry_type = @grant), 0) AS block_number,
+			       COALESCE(MAX(block_number) FILTER (WHERE entry_type = @claim), 0) AS reconciled_block_number,
+			       COALESCE(MAX(timestamp) FILTER (WHERE entry_type = @grant), MAX(timestamp)) AS timestamp
+			FROM reward_ledger_entries
+			WHERE user_address = @user AND token_address = @token
+			GROUP BY user_address, token_address
+		) AS e
+		ON CONFLICT (user_address, token_address) DO UPDATE SET
+			granted = EXCLUDED.granted,
+			claimed = EXCLUDED.claimed,
+			claimable = EXCLUDED.claimable,
+			block_number = EXCLUDED.block_number,
+			reconciled_block_number = EXCLUDED.reconciled_block_number,
+			timestamp = EXCLUDED.timestamp`, args).Error
+	if err != nil {
+		return fmt.Errorf("recompute reward balance: %w", err)
+	}
+	err = db.gorm.Exec(`
+		DELETE FROM reward_balances b
+		WHERE b.user_address = @user AND b.token_address = @token
+		  AND NOT EXISTS (SELECT 1 FROM reward_ledger_entries e WHERE e.user_address = b.user_address AND e.token_address = b.token_address)`, args).Error
+	if err != nil {
+		return fmt.Errorf("delete empty reward balance: %w", err)
+	}
+	return nil
+}
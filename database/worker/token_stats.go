@@ -0,0 +1,183 @@
+package worker
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"gorm.io/gorm"
+)
+
+const (
+	StatsBucketHour = "hour"
+	StatsBucketDay  = "day"
+)
+
+// StatsBucketSeconds 每种桶的长度（秒），桶按 UTC 对齐
+var StatsBucketSeconds = map[string]uint64{
+	StatsBucketHour: 3600,
+	StatsBucketDay:  86400,
+}
+
+// TokenStats 一个代币在一个时间桶内的充值、提现和奖励发放汇总。
+// 只在写入或重建（reindex）worker 记录时重新计算，链重组需要对受影响的区间执行 reindex
+type TokenStats struct {
+	BucketSize       string         `gorm:"primaryKey"`
+	BucketStart      uint64         `gorm:"primaryKey"`
+	TokenAddress     common.Address `gorm:"primaryKey;serializer:bytes"`
+	DepositCount     uint64
+	DepositVolume    *big.Int `gorm:"serializer:u256"`
+	UniqueDepositors uint64
+	WithdrawCount    uint64
+	WithdrawVolume   *big.Int `gorm:"serializer:u256"`
+	GrantCount       uint64
+	GrantVolume      *big.Int `gorm:"serializer:u256"`
+}
+
+func (TokenStats) TableName() string {
+	return "token_stats"
+}
+
+// GranterStats 一个发放者在一个时间桶内某个代币的奖励发放汇总，查询多个桶时为区间内的合计
+type GranterStats struct {
+	BucketSize   string         `gorm:"primaryKey"`
+	BucketStart  uint64         `gorm:"primaryKey"`
+	TokenAddress common.Address `gorm:"primaryKey;serializer:bytes"`
+	Granter      common.Address `gorm:"primaryKey;serializer:bytes"`
+	GrantCount   uint64
+	GrantVolume  *big.Int `gorm:"serializer:u256"`
+}
+
+func (GranterStats) TableName() string {
+	return "granter_stats"
+}
+
+type TokenStatsView interface {
+	// QueryTokenStats 按桶起始时间升序返回 [fromTimestamp, toTimestamp) 内的汇总，token 为空时返回所有代币
+	QueryTokenStats(bucketSize string, fromTimestamp uint64, toTimestamp uint64, token *common.Address) ([]TokenStats, error)
+	// QueryTopGranters 返回 [fromTimestamp, toTimestamp) 内每个代币发放金额最多的 limit 个发放者（按金额降序），BucketStart 为 0
+	QueryTopGranters(bucketSize string, fromTimestamp uint64, toTimestamp uint64, token *common.Address, limit int) ([]GranterStats, error)
+}
+
+type TokenStatsDB interface {
+	TokenStatsView
+	// RefreshTokenStats 从 worker 表重新计算包含 [fromTimestamp, toTimestamp] 的所有小时桶和天桶
+	RefreshTokenStats(fromTimestamp uint64, toTimestamp uint64) error
+	// EventTimestampRange 返回 [fromHeight, toHeight] 内充值、提现、奖励发放记录的最早和最晚时间，没有记录时 ok 为 false
+	EventTimestampRange(fromHeight *big.Int, toHeight *big.Int) (from uint64, to uint64, ok bool, err error)
+}
+
+type tokenStatsDB struct {
+	gorm *gorm.DB
+}
+
+func NewTokenStatsDB(db *gorm.DB) TokenStatsDB {
+	return &tokenStatsDB{gorm: db}
+}
+
+func (db *tokenStatsDB) QueryTokenStats(bucketSize string, fromTimestamp uint64, toTimestamp uint64, token *common.Address) ([]TokenStats, error) {
+	query := db.gorm.Where("bucket_size = ? AND bucket_start >= ? AND bucket_start < ?", bucketSize, fromTimestamp, toTimestamp)
+	if token != nil {
+		query = query.Where("token_address = ?", hexutil.Encode(token[:]))
+	}
+	var stats []TokenStats
+	result := query.Order("bucket_start ASC").Order("token_address ASC").Find(&stats)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return stats, nil
+}
+
+func (db *tokenStatsDB) QueryTopGranters(bucketSize string, fromTimestamp uint64, toTimestamp uint64, token *common.Address, limit int) ([]GranterStats, error) {
+	tokenFilter, args := "", []interface{}{bucketSize, fromTimestamp, toTimestamp}
+	if token != nil {
+		tokenFilter = "AND token_address = ?"
+		args = append(args, hexutil.Encode(token[:]))
+	}
+	args = append(args, limit)
+
+	var stats []GranterStats
+	result := db.gorm.Raw(`
+		SELECT token_address, granter, grant_count, grant_volume FROM (
+			SELECT token_address, granter, SUM(grant_count) AS grant_count, SUM(grant_volume) AS grant_volume,
+			       ROW_NUMBER() OVER (PARTITION BY token_address ORDER BY SUM(grant_volume) DESC, granter) AS granter_rank
+			FROM granter_stats
+			WHERE bucket_size = ? AND bucket_start >= ? AND bucket_start < ? `+tokenFilter+`
+			GROUP BY token_address, granter
+		) AS ranked
+		WHERE granter_rank <= ?
+		ORDER BY token_address, granter_rank`, args...).Scan(&stats)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return stats, nil
+}
+
+func (db *tokenStatsDB) RefreshTokenStats(fromTimestamp uint64, toTimestamp uint64) error {
+	for _, bucketSize := range []string{StatsBucketHour, StatsBucketDay} {
+		seconds := StatsBucketSeconds[bucketSize]
+		start := fromTimestamp - fromTimestamp%seconds
+		end := toTimestamp - toTimestamp%seconds + seconds
+
+		if err := db.gorm.Exec("DELETE FROM token_stats WHERE bucket_size = ? AND bucket_start >= ? AND bucket_start < ?", bucketSize, start, end).Error; err != nil {
+			return fmt.Errorf("delete token stats: %w", err)
+		}
+		if err := db.gorm.Exec("DELETE FROM granter_stats WHERE bucket_size = ? AND bucket_start >= ? AND bucket_start < ?", bucketSize, start, end).Error; err != nil {
+			return fmt.Errorf("delete granter stats: %w", err)
+		}
+
+		err := db.gorm.Exec(`
+			INSERT INTO token_stats (bucket_size, bucket_start, token_address, deposit_count, deposit_volume, unique_depositors, withdraw_count, withdraw_volume, grant_count, grant_volume)
+			SELECT @bucket_size, bucket_start, token_address,
+			       SUM(deposit_count), SUM(deposit_volume), SUM(unique_depositors),
+			       SUM(withdraw_count), SUM(withdraw_volume), SUM(grant_count), SUM(grant_volume)
+			FROM (
+				SELECT timestamp - timestamp % @seconds AS bucket_start, token_address,
+				       COUNT(*) AS deposit_count, COALESCE(SUM(amount), 0) AS deposit_volume, COUNT(DISTINCT sender) AS unique_depositors,
+				       0 AS withdraw_count, 0 AS withdraw_volume, 0 AS grant_count, 0 AS grant_volume
+				FROM deposit_tokens WHERE timestamp >= @start AND timestamp < @end GROUP BY 1, 2
+				UNION ALL
+				SELECT timestamp - timestamp % @seconds, token_address, 0, 0, 0, COUNT(*), COALESCE(SUM(amount), 0), 0, 0
+				FROM withdraw_tokens WHERE timestamp >= @start AND timestamp < @end GROUP BY 1, 2
+				UNION ALL
+				SELECT timestamp - timestamp % @seconds, token_address, 0, 0, 0, 0, 0, COUNT(*), COALESCE(SUM(amount), 0)
+				FROM grant_reward_tokens WHERE timestamp >= @start AND timestamp < @end GROUP BY 1, 2
+			) AS s
+			GROUP BY bucket_start, token_address`,
+			map[string]interface{}{"bucket_size": bucketSize, "seconds": seconds, "start": start, "end": end}).Error
+		if err != nil {
+			return fmt.Errorf("refresh token stats: %w", err)
+		}
+
+		err = db.gorm.Exec(`
+			INSERT INTO granter_stats (bucket_size, bucket_start, token_address, granter, grant_count, grant_volume)
+			SELECT @bucket_size, timestamp - timestamp % @seconds, token_address, granter, COUNT(*), COALESCE(SUM(amount), 0)
+			FROM grant_reward_tokens WHERE timestamp >= @start AND timestamp < @end
+			GROUP BY 2, 3, 4`,
+			map[string]interface{}{"bucket_size": bucketSize, "seconds": seconds, "start": start, "end": end}).Error
+		if err != nil {
+			return fmt.Errorf("refresh granter stats: %w", err)
+		}
+	}
+	return nil
+}
+
+func (db *tokenStatsDB) EventTimestampRange(fromHeight *big.Int, toHeight *big.Int) (uint64, uint64, bool, error) {
+	var timestampRange struct {
+		From *uint64
+		To   *uint64
+	}
+	result := db.gorm.Raw(`
+		SELECT MIN(timestamp) AS "from", MAX(timestamp) AS "to" FROM (
+			SELECT timestamp FROM deposit_tokens WHERE block_number >= @from AND block_number <= @to
+			UNION ALL SELECT timestamp FROM withdraw_tokens WHERE block_number >= @from AND block_number <= @to
+			UNION ALL SELECT timestamp FROM grant_reward_tokens WHERE block_number >= @from AND block_number <= @to
+		) AS events`, map[string]interface{}{"from": fromHeight, "to": toHeight}).Scan(&timestampRange)
+	if result.Error != nil {
+		return 0, 0, false, result.Error
+	} else if timestampRange.From == nil || timestampRange.To == nil {
+		return 0, 0, false, nil
+	}
+	return *timestampRange.From, *timestampRange.To, true, nil
+}
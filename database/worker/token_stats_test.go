@@ -0,0 +1,112 @@
+package worker_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Sandwichzzy/event-sync-go/database"
+	"github.com/Sandwichzzy/event-sync-go/database/dbtest"
+	"github.com/Sandwichzzy/event-sync-go/database/worker"
+)
+
+// TestRefreshTokenStats 需要数据库：EVENT_SYNC_MASTER_DB_HOST=... go test -run RefreshTokenStats ./database/worker/
+func TestRefreshTokenStats(t *testing.T) {
+	db := dbtest.Open(t)
+	dbtest.InTx(t, db, func(tx *database.DB) {
+		headers := dbtest.NextBlockHeaders(t, tx, 1, 2)
+		require.NoError(t, tx.Blocks.StoreBlockHeaders(headers))
+		from, to := headers[0].Timestamp, headers[1].Timestamp
+
+		// 每次运行使用新的代币地址，只断言该代币的汇总，不受库中已有数据影响
+		token := common.BytesToAddress(crypto.Keccak256([]byte(uuid.New().String()))[:20])
+		granter := common.HexToAddress("0x00000000000000000000000000000000000000f1")
+		txHash := func() common.Hash { return crypto.Keccak256Hash([]byte(uuid.New().String())) }
+
+		deposits := []worker.DepositTokens{
+			{GUID: uuid.New(), BlockNumber: headers[0].Number, BlockHash: headers[0].Hash, TransactionHash: txHash(), TokenAddress: token,
+				Sender: common.HexToAddress("0x00000000000000000000000000000000000000d1"), Amount: big.NewInt(10), Timestamp: from},
+			{GUID: uuid.New(), BlockNumber: headers[0].Number, BlockHash: headers[0].Hash, TransactionHash: txHash(), LogIndex: 1, TokenAddress: token,
+				Sender: common.HexToAddress("0x00000000000000000000000000000000000000d2"), Amount: big.NewInt(20), Timestamp: from},
+		}
+		withdraw := worker.WithdrawTokens{GUID: uuid.New(), BlockNumber: headers[1].Number, BlockHash: headers[1].Hash, TransactionHash: txHash(), TokenAddress: token,
+			Sender: granter, Receiver: common.HexToAddress("0x00000000000000000000000000000000000000d3"), Amount: big.NewInt(5), Timestamp: to}
+		grant := worker.GrantRewardTokens{GUID: uuid.New(), BlockNumber: headers[1].Number, BlockHash: headers[1].Hash, TransactionHash: txHash(), LogIndex: 1,
+			TokenAddress: token, Granter: granter, Amount: big.NewInt(7), Timestamp: to}
+		require.NoError(t, tx.DepositTokens.StoreDepositTokens(deposits))
+		require.NoError(t, tx.WithdrawTokens.StoreWithdrawTokens([]worker.WithdrawTokens{withdraw}))
+		require.NoError(t, tx.GrantRewardTokens.StoreGrantRewardTokens([]worker.GrantRewardTokens{grant}))
+
+		// 对同一区间重复计算不会累加
+		for i := 0; i < 2; i++ {
+			require.NoError(t, tx.TokenStats.RefreshTokenStats(from, to))
+			total := sumTokenStats(t, tx, token, from, to)
+			require.Equal(t, uint64(2), total.DepositCount)
+			require.Equal(t, "30", total.DepositVolume.String())
+			require.Equal(t, uint64(2), total.UniqueDepositors)
+			require.Equal(t, uint64(1), total.WithdrawCount)
+			require.Equal(t, "5", total.WithdrawVolume.String())
+			require.Equal(t, uint64(1), total.GrantCount)
+			require.Equal(t, "7", total.GrantVolume.String())
+		}
+		top, err := tx.TokenStats.QueryTopGranters(worker.StatsBucketDay, bucketStart(from, worker.StatsBucketDay), bucketEnd(to, worker.StatsBucketDay), &token, 10)
+		require.NoError(t, err)
+		require.Len(t, top, 1)
+		require.Equal(t, granter, top[0].Granter)
+
+		// 与 reindex 相同：删除区间内的 worker 记录后重新计算，被删除的记录从统计中移除
+		require.NoError(t, tx.DeleteWorkerRowsInRange(headers[1].Number, headers[1].Number))
+		require.NoError(t, tx.TokenStats.RefreshTokenStats(from, to))
+		total := sumTokenStats(t, tx, token, from, to)
+		require.Equal(t, uint64(2), total.DepositCount)
+		require.Zero(t, total.WithdrawCount)
+		require.Zero(t, total.GrantCount)
+		top, err = tx.TokenStats.QueryTopGranters(worker.StatsBucketDay, bucketStart(from, worker.StatsBucketDay), bucketEnd(to, worker.StatsBucketDay), &token, 10)
+		require.NoError(t, err)
+		require.Empty(t, top)
+
+		// 重新写入后再次计算恢复
+		require.NoError(t, tx.WithdrawTokens.StoreWithdrawTokens([]worker.WithdrawTokens{withdraw}))
+		require.NoError(t, tx.TokenStats.RefreshTokenStats(from, to))
+		total = sumTokenStats(t, tx, token, from, to)
+		require.Equal(t, uint64(1), total.WithdrawCount)
+		require.Zero(t, total.GrantCount)
+	})
+}
+
+func bucketStart(timestamp uint64, bucketSize string) uint64 {
+	return timestamp - timestamp%worker.StatsBucketSeconds[bucketSize]
+}
+
+func bucketEnd(timestamp uint64, bucketSize string) uint64 {
+	return bucketStart(timestamp, bucketSize) + worker.StatsBucketSeconds[bucketSize]
+}
+
+// sumTokenStats 合计代币在 [from, to] 涉及的所有小时桶，并检查与天桶的合计一致
+func sumTokenStats(t *testing.T, tx *database.DB, token common.Address, from, to uint64) worker.TokenStats {
+	t.Helper()
+	var totals []worker.TokenStats
+	for _, bucketSize := range []string{worker.StatsBucketHour, worker.StatsBucketDay} {
+		stats, err := tx.TokenStats.QueryTokenStats(bucketSize, bucketStart(from, bucketSize), bucketEnd(to, bucketSize), &token)
+		require.NoError(t, err)
+		total := worker.TokenStats{DepositVolume: new(big.Int), WithdrawVolume: new(big.Int), GrantVolume: new(big.Int)}
+		for _, s := range stats {
+			total.DepositCount += s.DepositCount
+			total.DepositVolume.Add(total.DepositVolume, s.DepositVolume)
+			total.UniqueDepositors += s.UniqueDepositors
+			total.WithdrawCount += s.WithdrawCount
+			total.WithdrawVolume.Add(total.WithdrawVolume, s.WithdrawVolume)
+			total.GrantCount += s.GrantCount
+			total.GrantVolume.Add(total.GrantVolume, s.GrantVolume)
+		}
+		totals = append(totals, total)
+	}
+	require.Equal(t, totals[0].DepositCount, totals[1].DepositCount)
+	require.Equal(t, totals[0].WithdrawCount, totals[1].WithdrawCount)
+	require.Equal(t, totals[0].GrantCount, totals[1].GrantCount)
+	return totals[1]
+}
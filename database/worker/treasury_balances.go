@@ -0,0 +1,162 @@
+package worker
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type TreasuryBalance struct {
+	GUID         uuid.UUID      `gorm:"primaryKey" json:"guid"`
+	TokenAddress common.Address `gorm:"serializer:bytes" json:"token_address"`
+	BlockNumber  *big.Int       `gorm:"serializer:u256" json:"block_number"`
+	BlockHash    common.Hash    `gorm:"serializer:bytes" json:"block_hash"`
+	Balance      *big.Int       `gorm:"serializer:u256" json:"balance"`
+	Reconciled   bool           `json:"reconciled"`
+	Timestamp    uint64         `json:"timestamp"`
+}
+
+func (TreasuryBalance) TableName() string {
+	return "treasury_balances"
+}
+
+type TreasuryBalancesView interface {
+	TreasuryBalanceAt(tokenAddress common.Address, blockNumber *big.Int) (*TreasuryBalance, error)
+	TreasuryBalanceTokensInRange(fromHeight *big.Int, toHeight *big.Int) ([]common.Address, error)
+	LatestUnreconciledTreasuryBalances() ([]TreasuryBalance, error)
+	QueryTreasuryBalancesList(page int, pageSize int, order string) ([]TreasuryBalance, uint64)
+}
+
+type TreasuryBalancesDB interface {
+	TreasuryBalancesView
+	StoreTreasuryBalances([]TreasuryBalance) error
+	MarkTreasuryBalancesReconciled(tokenAddress common.Address, blockNumber *big.Int) error
+	DeleteTreasuryBalancesInRange(fromHeight *big.Int, toHeight *big.Int) error
+	ShiftTreasuryBalances(tokenAddress common.Address, afterBlockNumber *big.Int, delta *big.Int) error
+}
+
+type treasuryBalancesDB struct {
+	gorm *gorm.DB
+}
+
+func NewTreasuryBalancesDB(db *gorm.DB) TreasuryBalancesDB {
+	return &treasuryBalancesDB{gorm: db}
+}
+
+// TreasuryBalanceAt 返回代币在 blockNumber 及之前最新的余额快照，没有记录时返回 nil
+func (db *treasuryBalancesDB) TreasuryBalanceAt(tokenAddress common.Address, blockNumber *big.Int) (*TreasuryBalance, error) {
+	var balance TreasuryBalance
+	result := db.gorm.Where(&TreasuryBalance{TokenAddress: tokenAddress}).
+		Where("block_number <= ?", blockNumber).
+		Order("block_number DESC").
+		Take(&balance)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return &balance, nil
+}
+
+// TreasuryBalanceTokensInRange 返回在 [fromHeight, toHeight] 内有余额快照的代币
+func (db *treasuryBalancesDB) TreasuryBalanceTokensInRange(fromHeight *big.Int, toHeight *big.Int) ([]common.Address, error) {
+	var balances []TreasuryBalance
+	result := db.gorm.Distinct("token_address").
+		Where("block_number >= ? AND block_number <= ?", fromHeight, toHeight).
+		Find(&balances)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	tokens := make([]common.Address, 0, len(balances))
+	for _, balance := range balances {
+		tokens = append(tokens, balance.TokenAddress)
+	}
+	return tokens, nil
+}
+
+// LatestUnreconciledTreasuryBalances 每个代币取最新一条尚未对账的快照
+func (db *treasuryBalancesDB) LatestUnreconciledTreasuryBalances() ([]TreasuryBalance, error) {
+	var balances []TreasuryBalance
+	result := db.gorm.Raw(`SELECT DISTINCT ON (token_address) * FROM treasury_balances
+		WHERE reconciled = FALSE ORDER BY token_address, block_number DESC`).Scan(&balances)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return balances, nil
+}
+
+func (db *treasuryBalancesDB) QueryTreasuryBalancesList(page int, pageSize int, order string) ([]TreasuryBalance, uint64) {
+	var (
+		balances []TreasuryBalance
+		total    int64
+	)
+
+	if order == "" {
+		order = "block_number desc"
+	}
+
+	// 统计总数
+	if err := db.gorm.Model(&TreasuryBalance{}).Count(&total).Error; err != nil {
+		fmt.Printf("count treasury_balances error: %v\n", err)
+		return nil, 0
+	}
+
+	// 分页查询
+	offset := (page - 1) * pageSize
+	result := db.gorm.
+		Order(order).
+		Limit(pageSize).
+		Offset(offset).
+		Find(&balances)
+
+	if result.Error != nil {
+		fmt.Printf("query treasury_balances error: %v\n", result.Error)
+		return nil, 0
+	}
+
+	return balances, uint64(total)
+}
+
+func (db *treasuryBalancesDB) StoreTreasuryBalances(balances []TreasuryBalance) error {
+	if len(balances) == 0 {
+		return nil
+	}
+	result := db.gorm.CreateInBatches(&balances, len(balances))
+	return result.Error
+}
+
+// MarkTreasuryBalancesReconciled 将代币在 blockNumber 及之前的快照全部标记为已对账
+func (db *treasuryBalancesDB) MarkTreasuryBalancesReconciled(tokenAddress common.Address, blockNumber *big.Int) error {
+	result := db.gorm.Model(&TreasuryBalance{}).
+		Where(&TreasuryBalance{TokenAddress: tokenAddress}).
+		Where("block_number <= ?", blockNumber).
+		Update("reconciled", true)
+	return result.Error
+}
+
+func (db *treasuryBalancesDB) DeleteTreasuryBalancesInRange(fromHeight *big.Int, toHeight *big.Int) error {
+	result := db.gorm.Where("block_number >= ? AND block_number <= ?", fromHeight, toHeight).Delete(&TreasuryBalance{})
+	return result.Error
+}
+
+// ShiftTreasuryBalances 代币在 afterBlockNumber 之后的快照余额全部加上 delta（可以为负），
+// 并重新标记为未对账。用于重建区间后修正区间之后的累计余额。
+func (db *treasuryBalancesDB) ShiftTreasuryBalances(tokenAddress common.Address, afterBlockNumber *big.Int, delta *big.Int) error {
+	if delta.Sign() == 0 {
+		return nil
+	}
+	result := db.gorm.Model(&TreasuryBalance{}).
+		Where(&TreasuryBalance{TokenAddress: tokenAddress}).
+		Where("block_number > ?", afterBlockNumber).
+		Updates(map[string]interface{}{
+			"balance":    gorm.Expr("balance + ?", delta.String()),
+			"reconciled": false,
+		})
+	return result.Error
+}
@@ -8,20 +8,32 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/Sandwichzzy/event-sync-go/database/utils"
 )
 
 type WithdrawManagerUpdate struct {
 	GUID            uuid.UUID      `gorm:"primaryKey" json:"guid"`
 	BlockNumber     *big.Int       `gorm:"serializer:u256" json:"block_number"`
+	BlockHash       common.Hash    `gorm:"serializer:bytes" json:"block_hash"`
+	TransactionHash common.Hash    `gorm:"serializer:bytes" json:"transaction_hash"`
+	LogIndex        uint64         `json:"log_index"`
 	WithdrawManager common.Address `gorm:"serializer:bytes" json:"withdraw_manager"`
 	Timestamp       uint64         `json:"timestamp"`
 }
 
+// position 记录在链上的排序位置，用于分页游标
+func (e WithdrawManagerUpdate) position() (*big.Int, uint64) {
+	return e.BlockNumber, e.LogIndex
+}
+
 func (WithdrawManagerUpdate) TableName() string {
 	return "withdraw_manager_update"
 }
 
 type WithdrawManagerUpdateView interface {
+	QueryWithdrawManagerUpdates(filter EventFilter, page utils.Page) ([]WithdrawManagerUpdate, utils.PageInfo, error)
+	WithdrawManagerUpdateByGUID(guid string) (*WithdrawManagerUpdate, error)
 	QueryWithdrawManagerUpdateList(page int, pageSize int, order string) ([]WithdrawManagerUpdate, uint64)
 }
 
@@ -75,6 +87,16 @@ func (db *withdrawManagerUpdateDB) StoreWithdrawManagerUpdates(updateList []With
 	if len(updateList) == 0 {
 		return nil
 	}
-	result := db.gorm.CreateInBatches(&updateList, len(updateList))
+	result := db.gorm.Clauses(eventPositionUpsert).CreateInBatches(&updateList, len(updateList))
 	return result.Error
 }
+
+// QueryWithdrawManagerUpdates 按过滤条件分页查询提现管理员变更记录，返回当前页和分页信息（总数、前后页游标）
+func (db *withdrawManagerUpdateDB) QueryWithdrawManagerUpdates(filter EventFilter, page utils.Page) ([]WithdrawManagerUpdate, utils.PageInfo, error) {
+	return queryEvents(db.gorm, WithdrawManagerUpdate{}, withdrawManagerUpdateColumns, filter, page, WithdrawManagerUpdate.position)
+}
+
+// WithdrawManagerUpdateByGUID 按 GUID 查询一条提现管理员变更记录，不存在时返回 nil
+func (db *withdrawManagerUpdateDB) WithdrawManagerUpdateByGUID(guid string) (*WithdrawManagerUpdate, error) {
+	return queryEventByGUID[WithdrawManagerUpdate](db.gorm, guid)
+}
@@ -8,16 +8,26 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/Sandwichzzy/event-sync-go/database/utils"
 )
 
 type WithdrawTokens struct {
-	GUID         uuid.UUID      `gorm:"primaryKey" json:"guid"`
-	BlockNumber  *big.Int       `gorm:"serializer:u256" json:"block_number"`
-	TokenAddress common.Address `gorm:"serializer:bytes" json:"token_address"`
-	Sender       common.Address `gorm:"serializer:bytes" json:"sender"`
-	Receiver     common.Address `gorm:"serializer:bytes" json:"receiver"`
-	Amount       *big.Int       `gorm:"serializer:u256" json:"amount"`
-	Timestamp    uint64         `json:"timestamp"`
+	GUID            uuid.UUID      `gorm:"primaryKey" json:"guid"`
+	BlockNumber     *big.Int       `gorm:"serializer:u256" json:"block_number"`
+	BlockHash       common.Hash    `gorm:"serializer:bytes" json:"block_hash"`
+	TransactionHash common.Hash    `gorm:"serializer:bytes" json:"transaction_hash"`
+	LogIndex        uint64         `json:"log_index"`
+	TokenAddress    common.Address `gorm:"serializer:bytes" json:"token_address"`
+	Sender          common.Address `gorm:"serializer:bytes" json:"sender"`
+	Receiver        common.Address `gorm:"serializer:bytes" json:"receiver"`
+	Amount          *big.Int       `gorm:"serializer:u256" json:"amount"`
+	Timestamp       uint64         `json:"timestamp"`
+}
+
+// position 记录在链上的排序位置，用于分页游标
+func (e WithdrawTokens) position() (*big.Int, uint64) {
+	return e.BlockNumber, e.LogIndex
 }
 
 func (WithdrawTokens) TableName() string {
@@ -25,6 +35,8 @@ func (WithdrawTokens) TableName() string {
 }
 
 type WithdrawTokensView interface {
+	QueryWithdrawTokens(filter EventFilter, page utils.Page) ([]WithdrawTokens, utils.PageInfo, error)
+	WithdrawTokensByGUID(guid string) (*WithdrawTokens, error)
 	QueryWithdrawTokensList(page int, pageSize int, order string) ([]WithdrawTokens, uint64)
 }
 
@@ -77,6 +89,16 @@ func (db *withdrawTokensDB) StoreWithdrawTokens(withdrawTokensList []WithdrawTok
 	if len(withdrawTokensList) == 0 {
 		return nil
 	}
-	result := db.gorm.CreateInBatches(&withdrawTokensList, len(withdrawTokensList))
+	result := db.gorm.Clauses(eventPositionUpsert).CreateInBatches(&withdrawTokensList, len(withdrawTokensList))
 	return result.Error
 }
+
+// QueryWithdrawTokens 按过滤条件分页查询提现记录，返回当前页和分页信息（总数、前后页游标）
+func (db *withdrawTokensDB) QueryWithdrawTokens(filter EventFilter, page utils.Page) ([]WithdrawTokens, utils.PageInfo, error) {
+	return queryEvents(db.gorm, WithdrawTokens{}, withdrawTokensColumns, filter, page, WithdrawTokens.position)
+}
+
+// WithdrawTokensByGUID 按 GUID 查询一条提现记录，不存在时返回 nil
+func (db *withdrawTokensDB) WithdrawTokensByGUID(guid string) (*WithdrawTokens, error) {
+	return queryEventByGUID[WithdrawTokens](db.gorm, guid)
+}
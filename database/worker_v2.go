@@ -0,0 +1,42 @@
+package database
+
+import "math/big"
+
+// workerTables 按 (block_hash, log_index) 定位的 worker 表
+var workerTables = []string{"deposit_tokens", "withdraw_tokens", "grant_reward_tokens", "withdraw_manager_update"}
+
+// CountLegacyWorkerRows 统计 schema v1 遗留的、没有事件位置（block_hash 为 NULL）的 worker 记录数
+func (db *DB) CountLegacyWorkerRows() (int64, error) {
+	var total int64
+	for _, table := range workerTables {
+		var count int64
+		if err := db.gorm.Table(table).Where("block_hash IS NULL").Count(&count).Error; err != nil {
+			return 0, err
+		}
+		total += count
+	}
+	return total, nil
+}
+
+// DeleteLegacyWorkerRows 删除 schema v1 遗留的 worker 记录，返回删除的行数
+func (db *DB) DeleteLegacyWorkerRows() (int64, error) {
+	var total int64
+	for _, table := range workerTables {
+		result := db.gorm.Exec("DELETE FROM " + table + " WHERE block_hash IS NULL")
+		if result.Error != nil {
+			return 0, result.Error
+		}
+		total += result.RowsAffected
+	}
+	return total, nil
+}
+
+// DeleteWorkerRowsInRange 删除 [fromHeight, toHeight] 内的 worker 记录，重建区间前调用
+func (db *DB) DeleteWorkerRowsInRange(fromHeight *big.Int, toHeight *big.Int) error {
+	for _, table := range workerTables {
+		if err := db.gorm.Exec("DELETE FROM "+table+" WHERE block_number >= ? AND block_number <= ?", fromHeight, toHeight).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
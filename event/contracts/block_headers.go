@@ -0,0 +1,47 @@
+package contracts
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/Sandwichzzy/event-sync-go/database"
+	common2 "github.com/Sandwichzzy/event-sync-go/database/common"
+)
+
+// blockHeaderLookup 在同一个事务内按区块号查询区块头并缓存。
+// 第一次查询时用一条查询取出整个批次 [fromHeight, toHeight] 的区块头，区间外的区块逐个查询。
+type blockHeaderLookup struct {
+	tx         *database.DB
+	fromHeight *big.Int
+	toHeight   *big.Int
+	loaded     bool
+	headers    map[uint64]*common2.BlockHeader
+}
+
+func newBlockHeaderLookup(tx *database.DB, fromHeight *big.Int, toHeight *big.Int) *blockHeaderLookup {
+	return &blockHeaderLookup{tx: tx, fromHeight: fromHeight, toHeight: toHeight, headers: make(map[uint64]*common2.BlockHeader)}
+}
+
+func (l *blockHeaderLookup) header(number *big.Int) (*common2.BlockHeader, error) {
+	if !l.loaded {
+		headers, err := l.tx.Blocks.BlockHeadersInRange(l.fromHeight, l.toHeight)
+		if err != nil {
+			return nil, err
+		}
+		for i := range headers {
+			l.headers[headers[i].Number.Uint64()] = &headers[i]
+		}
+		l.loaded = true
+	}
+	if header, ok := l.headers[number.Uint64()]; ok {
+		return header, nil
+	}
+	header, err := l.tx.Blocks.BlockHeaderByNumber(number)
+	if err != nil {
+		return nil, err
+	} else if header == nil {
+		return nil, fmt.Errorf("block header %s not found", number)
+	}
+	l.headers[number.Uint64()] = header
+	return header, nil
+}
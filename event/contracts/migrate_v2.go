@@ -0,0 +1,64 @@
+package contracts
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/Sandwichzzy/event-sync-go/common/bigint"
+	"github.com/Sandwichzzy/event-sync-go/database"
+)
+
+// migrateV2BlockStep 迁移时每次从 contract_events 解析的区块数
+const migrateV2BlockStep = 10_000
+
+// MigrateWorkerRowsV2 把 schema v1 的 worker 记录迁移到 v2。
+// v1 记录没有保存日志位置（区块号也因为 RLP 日志不含区块号而为 0），无法逐条关联回原始日志，
+// 因此在一个事务内从 contract_events 重新解析已处理区间（不超过 event_blocks 最新高度）内的
+// TreasureManager 事件，按 (block_hash, log_index) upsert 为 v2 记录，再删除 v1 记录。
+// 没有 v1 记录时直接返回，可以重复执行。
+func MigrateWorkerRowsV2(db *database.DB, treasureManager common.Address) error {
+	legacyRows, err := db.CountLegacyWorkerRows()
+	if err != nil {
+		return err
+	} else if legacyRows == 0 {
+		return nil
+	}
+
+	latestEventBlock, err := db.EventBlocks.LatestEventBlockHeader()
+	if err != nil {
+		return err
+	}
+
+	tm, err := NewTreasureManager(treasureManager)
+	if err != nil {
+		return err
+	}
+
+	log.Info("migrating worker rows to schema v2", "legacyRows", legacyRows)
+	return db.Transaction(func(tx *database.DB) error {
+		var migrated int
+		if latestEventBlock != nil {
+			for from := big.NewInt(0); from.Cmp(latestEventBlock.Number) <= 0; {
+				to := bigint.Clamp(from, latestEventBlock.Number, migrateV2BlockStep)
+				depositTokens, grantsRewardTokens, withdrawManagerUpdates, withdrawTokens, err := tm.ProcessTreasureManagerEvents(tx, from, to)
+				if err != nil {
+					return err
+				}
+				if err := storeWorkerRows(tx, depositTokens, grantsRewardTokens, withdrawManagerUpdates, withdrawTokens); err != nil {
+					return err
+				}
+				migrated += len(depositTokens) + len(withdrawTokens) + len(grantsRewardTokens) + len(withdrawManagerUpdates)
+				from = new(big.Int).Add(to, bigint.One)
+			}
+		}
+
+		deleted, err := tx.DeleteLegacyWorkerRows()
+		if err != nil {
+			return err
+		}
+		log.Info("migrated worker rows to schema v2", "rebuiltRows", migrated, "deletedLegacyRows", deleted)
+		return nil
+	})
+}
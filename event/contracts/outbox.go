@@ -0,0 +1,103 @@
+package contracts
+
+import (
+	"encoding/json"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/Sandwichzzy/event-sync-go/database"
+	"github.com/Sandwichzzy/event-sync-go/database/event"
+	"github.com/Sandwichzzy/event-sync-go/database/worker"
+)
+
+// outboxEvent 一条待写入 outbox 的 TreasureManager 事件，payload 中的金额为十进制字符串
+type outboxEvent struct {
+	eventType       string
+	blockNumber     *big.Int
+	blockHash       common.Hash
+	transactionHash common.Hash
+	logIndex        uint64
+	timestamp       uint64
+	payload         map[string]interface{}
+}
+
+// buildOutboxMessages 把本批次解析出的 TreasureManager 事件按 (block_number, log_index) 排序后转换为 added 消息
+func buildOutboxMessages(contractAddress common.Address, depositTokens []worker.DepositTokens, grantsRewardTokens []worker.GrantRewardTokens, withdrawManagerUpdates []worker.WithdrawManagerUpdate, withdrawTokens []worker.WithdrawTokens) ([]event.OutboxMessage, error) {
+	events := make([]outboxEvent, 0, len(depositTokens)+len(grantsRewardTokens)+len(withdrawManagerUpdates)+len(withdrawTokens))
+	for _, dt := range depositTokens {
+		events = append(events, outboxEvent{"DepositToken", dt.BlockNumber, dt.BlockHash, dt.TransactionHash, dt.LogIndex, dt.Timestamp, map[string]interface{}{
+			"token_address": dt.TokenAddress,
+			"sender":        dt.Sender,
+			"amount":        amountString(dt.Amount),
+		}})
+	}
+	for _, wt := range withdrawTokens {
+		events = append(events, outboxEvent{"WithdrawToken", wt.BlockNumber, wt.BlockHash, wt.TransactionHash, wt.LogIndex, wt.Timestamp, map[string]interface{}{
+			"token_address": wt.TokenAddress,
+			"sender":        wt.Sender,
+			"receiver":      wt.Receiver,
+			"amount":        amountString(wt.Amount),
+		}})
+	}
+	for _, gt := range grantsRewardTokens {
+		events = append(events, outboxEvent{"GrantRewardTokenAmount", gt.BlockNumber, gt.BlockHash, gt.TransactionHash, gt.LogIndex, gt.Timestamp, map[string]interface{}{
+			"token_address": gt.TokenAddress,
+			"granter":       gt.Granter,
+			"amount":        amountString(gt.Amount),
+		}})
+	}
+	for _, wmu := range withdrawManagerUpdates {
+		events = append(events, outboxEvent{"WithdrawManagerUpdate", wmu.BlockNumber, wmu.BlockHash, wmu.TransactionHash, wmu.LogIndex, wmu.Timestamp, map[string]interface{}{
+			"withdraw_manager": wmu.WithdrawManager,
+		}})
+	}
+	sort.Slice(events, func(i, j int) bool {
+		if c := events[i].blockNumber.Cmp(events[j].blockNumber); c != 0 {
+			return c < 0
+		}
+		return events[i].logIndex < events[j].logIndex
+	})
+
+	messages := make([]event.OutboxMessage, 0, len(events))
+	for _, e := range events {
+		payload, err := json.Marshal(e.payload)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, event.OutboxMessage{
+			ContractAddress: contractAddress,
+			EventType:       e.eventType,
+			Action:          event.OutboxActionAdded,
+			BlockNumber:     e.blockNumber,
+			BlockHash:       e.blockHash,
+			TransactionHash: e.transactionHash,
+			LogIndex:        e.logIndex,
+			Payload:         payload,
+			Timestamp:       e.timestamp,
+		})
+	}
+	return messages, nil
+}
+
+// storeOutboxMessages 在事务 tx 内为本批次的 TreasureManager 事件写入 outbox 消息
+func storeOutboxMessages(tx *database.DB, contractAddress common.Address, depositTokens []worker.DepositTokens, grantsRewardTokens []worker.GrantRewardTokens, withdrawManagerUpdates []worker.WithdrawManagerUpdate, withdrawTokens []worker.WithdrawTokens) error {
+	messages, err := buildOutboxMessages(contractAddress, depositTokens, grantsRewardTokens, withdrawManagerUpdates, withdrawTokens)
+	if err != nil {
+		return err
+	}
+	if err := tx.Outbox.StoreOutboxMessages(messages); err != nil {
+		log.Error("store outbox messages fail", "err", err)
+		return err
+	}
+	return nil
+}
+
+func amountString(amount *big.Int) string {
+	if amount == nil {
+		return "0"
+	}
+	return amount.String()
+}
@@ -0,0 +1,79 @@
+package contracts
+
+import (
+	"bytes"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+
+	"github.com/Sandwichzzy/event-sync-go/database/worker"
+)
+
+type rewardKey struct {
+	user  common.Address
+	token common.Address
+}
+
+// buildRewardLedger 把 GrantRewardTokenAmount 事件转换为奖励流水（grant），并按 (用户, 代币) 汇总本批次发放金额。
+// 事件中的 granter 即获得奖励、之后可以领取的用户地址。
+func buildRewardLedger(headers *blockHeaderLookup, grantRewardTokens []worker.GrantRewardTokens) ([]worker.RewardLedgerEntry, []worker.RewardBalance, error) {
+	if len(grantRewardTokens) == 0 {
+		return nil, nil, nil
+	}
+
+	entries := make([]worker.RewardLedgerEntry, 0, len(grantRewardTokens))
+	grants := make(map[rewardKey]*worker.RewardBalance)
+	for _, grant := range grantRewardTokens {
+		if grant.Amount == nil {
+			continue
+		}
+		header, err := headers.header(grant.BlockNumber)
+		if err != nil {
+			return nil, nil, err
+		}
+		entries = append(entries, worker.RewardLedgerEntry{
+			GUID:         uuid.New(),
+			UserAddress:  grant.Granter,
+			TokenAddress: grant.TokenAddress,
+			EntryType:    worker.RewardEntryGrant,
+			Amount:       grant.Amount,
+			BlockNumber:  header.Number,
+			BlockHash:    header.Hash,
+			Timestamp:    header.Timestamp,
+		})
+
+		key := rewardKey{user: grant.Granter, token: grant.TokenAddress}
+		balance, ok := grants[key]
+		if !ok {
+			balance = &worker.RewardBalance{
+				UserAddress:           grant.Granter,
+				TokenAddress:          grant.TokenAddress,
+				Granted:               new(big.Int),
+				Claimed:               new(big.Int),
+				Claimable:             new(big.Int),
+				ReconciledBlockNumber: new(big.Int),
+			}
+			grants[key] = balance
+		}
+		balance.Granted.Add(balance.Granted, grant.Amount)
+		balance.Claimable.Add(balance.Claimable, grant.Amount)
+		if balance.BlockNumber == nil || header.Number.Cmp(balance.BlockNumber) > 0 {
+			balance.BlockNumber = header.Number
+			balance.Timestamp = header.Timestamp
+		}
+	}
+
+	balances := make([]worker.RewardBalance, 0, len(grants))
+	for _, balance := range grants {
+		balances = append(balances, *balance)
+	}
+	sort.Slice(balances, func(i, j int) bool {
+		if c := bytes.Compare(balances[i].UserAddress[:], balances[j].UserAddress[:]); c != 0 {
+			return c < 0
+		}
+		return bytes.Compare(balances[i].TokenAddress[:], balances[j].TokenAddress[:]) < 0
+	})
+	return entries, balances, nil
+}
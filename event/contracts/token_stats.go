@@ -0,0 +1,58 @@
+package contracts
+
+import (
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/Sandwichzzy/event-sync-go/database"
+	"github.com/Sandwichzzy/event-sync-go/database/worker"
+)
+
+// timestampRange 一批事件覆盖的时间范围，用于确定需要重新计算的统计桶
+type timestampRange struct {
+	from, to uint64
+	ok       bool
+}
+
+func (r *timestampRange) add(timestamp uint64) {
+	if !r.ok || timestamp < r.from {
+		r.from = timestamp
+	}
+	if !r.ok || timestamp > r.to {
+		r.to = timestamp
+	}
+	r.ok = true
+}
+
+func (r *timestampRange) merge(other timestampRange) {
+	if other.ok {
+		r.add(other.from)
+		r.add(other.to)
+	}
+}
+
+// eventTimestamps 返回充值、提现和奖励发放记录的时间范围
+func eventTimestamps(depositTokens []worker.DepositTokens, grantsRewardTokens []worker.GrantRewardTokens, withdrawTokens []worker.WithdrawTokens) timestampRange {
+	var r timestampRange
+	for _, dt := range depositTokens {
+		r.add(dt.Timestamp)
+	}
+	for _, gr := range grantsRewardTokens {
+		r.add(gr.Timestamp)
+	}
+	for _, wt := range withdrawTokens {
+		r.add(wt.Timestamp)
+	}
+	return r
+}
+
+// refreshTokenStats 在事务 tx 内重新计算时间范围涉及的小时 / 天统计桶
+func refreshTokenStats(tx *database.DB, r timestampRange) error {
+	if !r.ok {
+		return nil
+	}
+	if err := tx.TokenStats.RefreshTokenStats(r.from, r.to); err != nil {
+		log.Error("refresh token stats fail", "from", r.from, "to", r.to, "err", err)
+		return err
+	}
+	return nil
+}
@@ -3,28 +3,28 @@ package contracts
 import (
 	"context"
 	"math/big"
-	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
-	"github.com/google/uuid"
 
 	"github.com/Sandwichzzy/event-sync-go/bindings"
-	"github.com/Sandwichzzy/event-sync-go/config"
 	"github.com/Sandwichzzy/event-sync-go/database"
 	"github.com/Sandwichzzy/event-sync-go/database/event"
 	"github.com/Sandwichzzy/event-sync-go/database/worker"
 )
 
+// TreasureManagerProcessorName 在配置中启用 TreasureManager 处理器使用的名称
+const TreasureManagerProcessorName = "treasure-manager"
+
 type TreasureManager struct {
-	db        *database.DB
+	Address   common.Address // 配置中绑定到 treasure-manager 的合约地址
 	TmAbi     *abi.ABI
 	TmFilter  *bindings.TreasureManagerFilterer
 	TmContext context.Context
 }
 
-func NewTreasureManager(db *database.DB) (*TreasureManager, error) {
+func NewTreasureManager(address common.Address) (*TreasureManager, error) {
 	treasureManagerAbi, err := bindings.TreasureManagerMetaData.GetAbi()
 	if err != nil {
 		log.Error("binding treasure manager data abi fail", "err", err)
@@ -38,21 +38,29 @@ func NewTreasureManager(db *database.DB) (*TreasureManager, error) {
 	}
 
 	return &TreasureManager{
-		db:        db,
+		Address:   address,
 		TmAbi:     treasureManagerAbi,
 		TmFilter:  treasureManagerFilter,
 		TmContext: context.Background(),
 	}, nil
 }
 
-func (tm *TreasureManager) ProcessTreasureManagerEvents(fromHeight *big.Int, toHeight *big.Int) ([]worker.DepositTokens, []worker.GrantRewardTokens, []worker.WithdrawManagerUpdate, []worker.WithdrawTokens, error) {
-	contractEventFilter := event.ContractEvent{ContractAddress: common.HexToAddress(config.TreasureManagerAddr)}
+func (tm *TreasureManager) Name() string {
+	return TreasureManagerProcessorName
+}
+
+func (tm *TreasureManager) Contracts() []common.Address {
+	return []common.Address{tm.Address}
+}
+
+func (tm *TreasureManager) ProcessTreasureManagerEvents(db *database.DB, fromHeight *big.Int, toHeight *big.Int) ([]worker.DepositTokens, []worker.GrantRewardTokens, []worker.WithdrawManagerUpdate, []worker.WithdrawTokens, error) {
+	contractEventFilter := event.ContractEvent{ContractAddress: tm.Address}
 	log.Info("query contracts filter",
-		"TreasureManagerAddr", config.TreasureManagerAddr,
+		"TreasureManagerAddr", tm.Address,
 		"fromHeight", fromHeight,
 		"toHeight", toHeight,
 	)
-	contractEventList, err := tm.db.ContractEvent.ContractEventsWithFilter(contractEventFilter, fromHeight, toHeight)
+	contractEventList, err := db.ContractEvent.ContractEventsWithFilter(contractEventFilter, fromHeight, toHeight)
 	if err != nil {
 		log.Error("filter contract event by address and start/end block fail", "err", err)
 		return nil, nil, nil, nil, err
@@ -80,12 +88,15 @@ func (tm *TreasureManager) ProcessTreasureManagerEvents(fromHeight *big.Int, toH
 				"Sender", depositTokenEvent.Sender)
 
 			tempDepositToken := worker.DepositTokens{
-				GUID:         uuid.New(),
-				BlockNumber:  big.NewInt(int64(eventItem.RLPLog.BlockNumber)),
-				TokenAddress: depositTokenEvent.TokenAddress,
-				Sender:       depositTokenEvent.Sender,
-				Amount:       depositTokenEvent.Amount,
-				Timestamp:    uint64(time.Now().Unix()),
+				GUID:            worker.EventGUID(eventItem.BlockHash, eventItem.LogIndex),
+				BlockNumber:     eventItem.BlockNumber,
+				BlockHash:       eventItem.BlockHash,
+				TransactionHash: eventItem.TransactionHash,
+				LogIndex:        eventItem.LogIndex,
+				TokenAddress:    depositTokenEvent.TokenAddress,
+				Sender:          depositTokenEvent.Sender,
+				Amount:          depositTokenEvent.Amount,
+				Timestamp:       eventItem.Timestamp,
 			}
 
 			depositTokens = append(depositTokens, tempDepositToken)
@@ -102,15 +113,19 @@ func (tm *TreasureManager) ProcessTreasureManagerEvents(fromHeight *big.Int, toH
 				"TokenAddress", withdrawTokenEvent.TokenAddress,
 				"Amount", withdrawTokenEvent.Amount,
 				"Sender", withdrawTokenEvent.Sender,
+				"WithdrawAddress", withdrawTokenEvent.WithdrawAddress,
 			)
 			tempWithdrawToken := worker.WithdrawTokens{
-				GUID:         uuid.New(),
-				BlockNumber:  big.NewInt(int64(eventItem.RLPLog.BlockNumber)),
-				TokenAddress: withdrawTokenEvent.TokenAddress,
-				Sender:       withdrawTokenEvent.Sender,
-				Receiver:     common.Address{},
-				Amount:       withdrawTokenEvent.Amount,
-				Timestamp:    uint64(time.Now().Unix()),
+				GUID:            worker.EventGUID(eventItem.BlockHash, eventItem.LogIndex),
+				BlockNumber:     eventItem.BlockNumber,
+				BlockHash:       eventItem.BlockHash,
+				TransactionHash: eventItem.TransactionHash,
+				LogIndex:        eventItem.LogIndex,
+				TokenAddress:    withdrawTokenEvent.TokenAddress,
+				Sender:          withdrawTokenEvent.Sender,
+				Receiver:        withdrawTokenEvent.WithdrawAddress,
+				Amount:          withdrawTokenEvent.Amount,
+				Timestamp:       eventItem.Timestamp,
 			}
 			withdrawTokens = append(withdrawTokens, tempWithdrawToken)
 		}
@@ -129,12 +144,15 @@ func (tm *TreasureManager) ProcessTreasureManagerEvents(fromHeight *big.Int, toH
 			)
 
 			tempgrantsRewardToken := worker.GrantRewardTokens{
-				GUID:         uuid.New(),
-				BlockNumber:  big.NewInt(int64(eventItem.RLPLog.BlockNumber)),
-				TokenAddress: grantRewardEvent.TokenAddress,
-				Granter:      grantRewardEvent.Granter,
-				Amount:       grantRewardEvent.Amount,
-				Timestamp:    uint64(time.Now().Unix()),
+				GUID:            worker.EventGUID(eventItem.BlockHash, eventItem.LogIndex),
+				BlockNumber:     eventItem.BlockNumber,
+				BlockHash:       eventItem.BlockHash,
+				TransactionHash: eventItem.TransactionHash,
+				LogIndex:        eventItem.LogIndex,
+				TokenAddress:    grantRewardEvent.TokenAddress,
+				Granter:         grantRewardEvent.Granter,
+				Amount:          grantRewardEvent.Amount,
+				Timestamp:       eventItem.Timestamp,
 			}
 			grantsRewardTokens = append(grantsRewardTokens, tempgrantsRewardToken)
 		}
@@ -152,10 +170,13 @@ func (tm *TreasureManager) ProcessTreasureManagerEvents(fromHeight *big.Int, toH
 			)
 
 			tempWithdrawManagerUpdate := worker.WithdrawManagerUpdate{
-				GUID:            uuid.New(),
-				BlockNumber:     big.NewInt(int64(eventItem.RLPLog.BlockNumber)),
+				GUID:            worker.EventGUID(eventItem.BlockHash, eventItem.LogIndex),
+				BlockNumber:     eventItem.BlockNumber,
+				BlockHash:       eventItem.BlockHash,
+				TransactionHash: eventItem.TransactionHash,
+				LogIndex:        eventItem.LogIndex,
 				WithdrawManager: withdrawManagerEvent.WithdrawManager,
-				Timestamp:       uint64(time.Now().Unix()),
+				Timestamp:       eventItem.Timestamp,
 			}
 			withdrawManagerUpdates = append(withdrawManagerUpdates, tempWithdrawManagerUpdate)
 		}
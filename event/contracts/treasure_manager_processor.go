@@ -0,0 +1,102 @@
+package contracts
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/Sandwichzzy/event-sync-go/database"
+	"github.com/Sandwichzzy/event-sync-go/database/worker"
+)
+
+// ProcessEvents 解析 [fromHeight, toHeight] 内的 TreasureManager 事件，在事务 tx 内写入 worker 表和 outbox，
+// 并更新由这些事件投影出来的按小时 / 天统计、金库余额和用户奖励账本
+func (tm *TreasureManager) ProcessEvents(tx *database.DB, fromHeight *big.Int, toHeight *big.Int) error {
+	depositTokens, grantsRewardTokens, withdrawManagerUpdates, withdrawTokens, err := tm.ProcessTreasureManagerEvents(tx, fromHeight, toHeight)
+	if err != nil {
+		log.Error("parse treasure manager contracts events fail", "err", err)
+		return err
+	}
+	return tm.storeEvents(tx, fromHeight, toHeight, depositTokens, grantsRewardTokens, withdrawManagerUpdates, withdrawTokens)
+}
+
+// storeEvents 在事务 tx 内写入 [fromHeight, toHeight] 内解析出的事件及其投影
+func (tm *TreasureManager) storeEvents(tx *database.DB, fromHeight *big.Int, toHeight *big.Int, depositTokens []worker.DepositTokens, grantsRewardTokens []worker.GrantRewardTokens, withdrawManagerUpdates []worker.WithdrawManagerUpdate, withdrawTokens []worker.WithdrawTokens) error {
+	if err := storeWorkerRows(tx, depositTokens, grantsRewardTokens, withdrawManagerUpdates, withdrawTokens); err != nil {
+		return err
+	}
+	if err := storeOutboxMessages(tx, tm.Address, depositTokens, grantsRewardTokens, withdrawManagerUpdates, withdrawTokens); err != nil {
+		return err
+	}
+	if err := refreshTokenStats(tx, eventTimestamps(depositTokens, grantsRewardTokens, withdrawTokens)); err != nil {
+		return err
+	}
+
+	headers := newBlockHeaderLookup(tx, fromHeight, toHeight)
+	treasuryBalances, err := projectTreasuryBalances(tx, headers, fromHeight, depositTokens, withdrawTokens)
+	if err != nil {
+		log.Error("project treasury balances fail", "err", err)
+		return err
+	}
+	if len(treasuryBalances) > 0 {
+		err := tx.TreasuryBalances.StoreTreasuryBalances(treasuryBalances)
+		if err != nil {
+			log.Error("store treasury balances fail", "err", err)
+			return err
+		}
+	}
+
+	rewardEntries, rewardGrants, err := buildRewardLedger(headers, grantsRewardTokens)
+	if err != nil {
+		log.Error("build reward ledger fail", "err", err)
+		return err
+	}
+	if len(rewardEntries) > 0 {
+		if err := tx.RewardLedger.StoreRewardLedgerEntries(rewardEntries); err != nil {
+			log.Error("store reward ledger entries fail", "err", err)
+			return err
+		}
+		if err := tx.RewardLedger.StoreRewardGrants(rewardGrants); err != nil {
+			log.Error("store reward grants fail", "err", err)
+			return err
+		}
+	}
+	return nil
+}
+
+// storeWorkerRows 在事务 tx 内写入解析出的 TreasureManager 事件
+func storeWorkerRows(tx *database.DB, depositTokens []worker.DepositTokens, grantsRewardTokens []worker.GrantRewardTokens, withdrawManagerUpdates []worker.WithdrawManagerUpdate, withdrawTokens []worker.WithdrawTokens) error {
+	if len(depositTokens) > 0 {
+		err := tx.DepositTokens.StoreDepositTokens(depositTokens)
+		if err != nil {
+			log.Error("store deposit tokens fail", "err", err)
+			return err
+		}
+	}
+
+	if len(withdrawTokens) > 0 {
+		err := tx.WithdrawTokens.StoreWithdrawTokens(withdrawTokens)
+		if err != nil {
+			log.Error("store withdraw tokens fail", "err", err)
+			return err
+		}
+	}
+
+	if len(grantsRewardTokens) > 0 {
+		err := tx.GrantRewardTokens.StoreGrantRewardTokens(grantsRewardTokens)
+		if err != nil {
+			log.Error("store grants reward tokens fail", "err", err)
+			return err
+		}
+	}
+
+	if len(withdrawManagerUpdates) > 0 {
+		err := tx.WithdrawManagerUpdate.StoreWithdrawManagerUpdates(withdrawManagerUpdates)
+		if err != nil {
+			log.Error("store withdraw manager update fail", "err", err)
+			return err
+		}
+	}
+
+	return nil
+}
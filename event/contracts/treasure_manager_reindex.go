@@ -0,0 +1,155 @@
+package contracts
+
+import (
+	"bytes"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/Sandwichzzy/event-sync-go/database"
+)
+
+// ReindexEvents 从 contract_events 重建 [fromHeight, toHeight] 内的 TreasureManager 数据：
+// 为区间内已经发出的 outbox 消息补发 removed，删除区间内的 worker 记录、余额快照和奖励发放流水后重新解析写入并重新计算涉及的统计桶，
+// 再把重建前后区间末尾余额的差值补到区间之后的余额快照上；
+// 区间内有发放的用户撤销 fromHeight 及之后推导出的领取流水，并按流水重新计算奖励汇总，由对账任务重新推导领取。
+func (tm *TreasureManager) ReindexEvents(tx *database.DB, fromHeight *big.Int, toHeight *big.Int) error {
+	depositTokens, grantsRewardTokens, withdrawManagerUpdates, withdrawTokens, err := tm.ProcessTreasureManagerEvents(tx, fromHeight, toHeight)
+	if err != nil {
+		log.Error("parse treasure manager contracts events fail", "err", err)
+		return err
+	}
+
+	// 1. 记录重建前区间末尾的余额、区间内的发放总额和记录的时间范围
+	tokens, err := tx.TreasuryBalances.TreasuryBalanceTokensInRange(fromHeight, toHeight)
+	if err != nil {
+		return err
+	}
+	for _, dt := range depositTokens {
+		tokens = append(tokens, dt.TokenAddress)
+	}
+	for _, wt := range withdrawTokens {
+		tokens = append(tokens, wt.TokenAddress)
+	}
+	tokens = uniqueAddresses(tokens)
+
+	oldBalances := make(map[common.Address]*big.Int, len(tokens))
+	for _, token := range tokens {
+		balance, err := treasuryBalanceAt(tx, token, toHeight)
+		if err != nil {
+			return err
+		}
+		oldBalances[token] = balance
+	}
+	oldGrants, err := tx.RewardLedger.RewardGrantTotalsInRange(fromHeight, toHeight)
+	if err != nil {
+		return err
+	}
+	var statsRange timestampRange
+	statsRange.from, statsRange.to, statsRange.ok, err = tx.TokenStats.EventTimestampRange(fromHeight, toHeight)
+	if err != nil {
+		return err
+	}
+
+	// 2. 删除区间内由 TreasureManager 事件产生的数据，并为已经发出的 outbox 消息补发 removed
+	if err := tx.Outbox.StoreRemovedOutboxMessages(tm.Address, fromHeight, toHeight); err != nil {
+		return err
+	}
+	if err := tx.DeleteWorkerRowsInRange(fromHeight, toHeight); err != nil {
+		return err
+	}
+	if err := tx.TreasuryBalances.DeleteTreasuryBalancesInRange(fromHeight, toHeight); err != nil {
+		return err
+	}
+	if err := tx.RewardLedger.DeleteRewardGrantEntriesInRange(fromHeight, toHeight); err != nil {
+		return err
+	}
+
+	// 3. 重新写入
+	if err := storeWorkerRows(tx, depositTokens, grantsRewardTokens, withdrawManagerUpdates, withdrawTokens); err != nil {
+		return err
+	}
+	if err := storeOutboxMessages(tx, tm.Address, depositTokens, grantsRewardTokens, withdrawManagerUpdates, withdrawTokens); err != nil {
+		return err
+	}
+	// 统计桶按重建前后记录的时间范围重新计算，被删除的记录从统计中移除
+	statsRange.merge(eventTimestamps(depositTokens, grantsRewardTokens, withdrawTokens))
+	if err := refreshTokenStats(tx, statsRange); err != nil {
+		return err
+	}
+	headers := newBlockHeaderLookup(tx, fromHeight, toHeight)
+	treasuryBalances, err := projectTreasuryBalances(tx, headers, fromHeight, depositTokens, withdrawTokens)
+	if err != nil {
+		log.Error("project treasury balances fail", "err", err)
+		return err
+	}
+	if err := tx.TreasuryBalances.StoreTreasuryBalances(treasuryBalances); err != nil {
+		log.Error("store treasury balances fail", "err", err)
+		return err
+	}
+	rewardEntries, rewardGrants, err := buildRewardLedger(headers, grantsRewardTokens)
+	if err != nil {
+		log.Error("build reward ledger fail", "err", err)
+		return err
+	}
+	if err := tx.RewardLedger.StoreRewardLedgerEntries(rewardEntries); err != nil {
+		log.Error("store reward ledger entries fail", "err", err)
+		return err
+	}
+
+	// 4. 修正区间之后的余额快照
+	for _, token := range tokens {
+		newBalance, err := treasuryBalanceAt(tx, token, toHeight)
+		if err != nil {
+			return err
+		}
+		delta := new(big.Int).Sub(newBalance, oldBalances[token])
+		if err := tx.TreasuryBalances.ShiftTreasuryBalances(token, toHeight, delta); err != nil {
+			return err
+		}
+	}
+
+	// 5. 修正奖励汇总：区间内重建前后有发放的 (用户, 代币) 撤销 fromHeight 之后推导出的领取，再按流水重新计算
+	keys := make(map[rewardKey]struct{}, len(rewardGrants)+len(oldGrants))
+	for _, grant := range rewardGrants {
+		keys[rewardKey{user: grant.UserAddress, token: grant.TokenAddress}] = struct{}{}
+	}
+	for _, old := range oldGrants {
+		keys[rewardKey{user: old.UserAddress, token: old.TokenAddress}] = struct{}{}
+	}
+	for key := range keys {
+		if err := tx.RewardLedger.DeleteRewardClaimEntriesFrom(key.user, key.token, fromHeight); err != nil {
+			log.Error("delete reward claim entries fail", "user", key.user, "token", key.token, "err", err)
+			return err
+		}
+		if err := tx.RewardLedger.RecomputeRewardBalance(key.user, key.token); err != nil {
+			log.Error("recompute reward balance fail", "user", key.user, "token", key.token, "err", err)
+			return err
+		}
+	}
+	return nil
+}
+
+// treasuryBalanceAt 返回代币在 blockNumber 的余额，没有快照时为 0
+func treasuryBalanceAt(tx *database.DB, token common.Address, blockNumber *big.Int) (*big.Int, error) {
+	balance, err := tx.TreasuryBalances.TreasuryBalanceAt(token, blockNumber)
+	if err != nil {
+		return nil, err
+	} else if balance == nil || balance.Balance == nil {
+		return new(big.Int), nil
+	}
+	return balance.Balance, nil
+}
+
+func uniqueAddresses(addresses []common.Address) []common.Address {
+	sort.Slice(addresses, func(i, j int) bool { return bytes.Compare(addresses[i][:], addresses[j][:]) < 0 })
+	unique := addresses[:0]
+	for _, address := range addresses {
+		if len(unique) == 0 || address != unique[len(unique)-1] {
+			unique = append(unique, address)
+		}
+	}
+	return unique
+}
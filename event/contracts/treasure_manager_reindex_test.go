@@ -0,0 +1,187 @@
+package contracts
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Sandwichzzy/event-sync-go/database"
+	common2 "github.com/Sandwichzzy/event-sync-go/database/common"
+	"github.com/Sandwichzzy/event-sync-go/database/dbtest"
+	"github.com/Sandwichzzy/event-sync-go/database/event"
+	"github.com/Sandwichzzy/event-sync-go/database/worker"
+)
+
+// 发放后对账推导出领取，再按更小的发放金额重建：推导出的领取被撤销，汇总按流水重新计算
+func TestReindexEventsRevertsRewardClaims(t *testing.T) {
+	db := dbtest.Open(t)
+	dbtest.InTx(t, db, func(tx *database.DB) {
+		headers := dbtest.NextBlockHeaders(t, tx, 1, 2)
+		require.NoError(t, tx.Blocks.StoreBlockHeaders(headers))
+
+		tm, err := NewTreasureManager(randomAddress())
+		require.NoError(t, err)
+		token, user := randomAddress(), randomAddress()
+		storeTreasureManagerLog(t, tx, tm, headers[0], 0, "GrantRewardTokenAmount", []common.Hash{common.BytesToHash(token[:])}, user, big.NewInt(40))
+
+		// 解码错误时写入的发放金额为 100
+		from, to := headers[0].Number, headers[0].Number
+		depositTokens, grantsRewardTokens, withdrawManagerUpdates, withdrawTokens, err := tm.ProcessTreasureManagerEvents(tx, from, to)
+		require.NoError(t, err)
+		require.Len(t, grantsRewardTokens, 1)
+		grantsRewardTokens[0].Amount = big.NewInt(100)
+		require.NoError(t, tm.storeEvents(tx, from, to, depositTokens, grantsRewardTokens, withdrawManagerUpdates, withdrawTokens))
+
+		// 对账时链上可领取 30，推导出领取 70
+		balance := rewardBalance(t, tx, user, token)
+		claimed := big.NewInt(70)
+		applied, err := tx.RewardLedger.ApplyRewardClaim(*balance, claimed, headers[1].Number)
+		require.NoError(t, err)
+		require.True(t, applied)
+		require.NoError(t, tx.RewardLedger.StoreRewardLedgerEntries([]worker.RewardLedgerEntry{{
+			GUID:         uuid.New(),
+			UserAddress:  user,
+			TokenAddress: token,
+			EntryType:    worker.RewardEntryClaim,
+			Amount:       claimed,
+			BlockNumber:  headers[1].Number,
+			BlockHash:    headers[1].Hash,
+			Timestamp:    headers[1].Timestamp,
+		}}))
+
+		require.NoError(t, tm.ReindexEvents(tx, from, to))
+
+		balance = rewardBalance(t, tx, user, token)
+		require.Equal(t, "40", balance.Granted.String())
+		require.Equal(t, "0", balance.Claimed.String())
+		require.Equal(t, "40", balance.Claimable.String())
+		require.Equal(t, headers[0].Number.String(), balance.BlockNumber.String())
+		require.Equal(t, "0", balance.ReconciledBlockNumber.String())
+		require.Equal(t, headers[0].Timestamp, balance.Timestamp)
+
+		entries, total := tx.RewardLedger.QueryRewardLedgerEntries(user, 1, 10)
+		require.Equal(t, uint64(1), total)
+		require.Equal(t, worker.RewardEntryGrant, entries[0].EntryType)
+		require.Equal(t, "40", entries[0].Amount.String())
+	})
+}
+
+// 重建改变了充值金额、发放金额和事件时间：区间之后的余额快照平移差值，奖励汇总按新发放金额计算，
+// 统计桶按重建前后的时间范围重新计算
+func TestReindexEventsCorrectsAmountsAndTimestamps(t *testing.T) {
+	db := dbtest.Open(t)
+	dbtest.InTx(t, db, func(tx *database.DB) {
+		headers := dbtest.NextBlockHeaders(t, tx, 1, 2)
+		require.NoError(t, tx.Blocks.StoreBlockHeaders(headers))
+
+		tm, err := NewTreasureManager(randomAddress())
+		require.NoError(t, err)
+		token, user, sender := randomAddress(), randomAddress(), randomAddress()
+		tokenTopic, senderTopic := common.BytesToHash(token[:]), common.BytesToHash(sender[:])
+		storeTreasureManagerLog(t, tx, tm, headers[0], 0, "DepositToken", []common.Hash{tokenTopic, senderTopic}, big.NewInt(100))
+		storeTreasureManagerLog(t, tx, tm, headers[0], 1, "GrantRewardTokenAmount", []common.Hash{tokenTopic}, user, big.NewInt(40))
+		storeTreasureManagerLog(t, tx, tm, headers[1], 0, "DepositToken", []common.Hash{tokenTopic, senderTopic}, big.NewInt(5))
+
+		// 解码错误时 headers[0] 的充值金额为 70、发放金额为 100，时间晚了 3 天
+		const shift = 3 * 86400
+		from, to := headers[0].Number, headers[1].Number
+		depositTokens, grantsRewardTokens, withdrawManagerUpdates, withdrawTokens, err := tm.ProcessTreasureManagerEvents(tx, from, to)
+		require.NoError(t, err)
+		require.Len(t, depositTokens, 2)
+		require.Len(t, grantsRewardTokens, 1)
+		require.Equal(t, headers[0].Number.String(), depositTokens[0].BlockNumber.String())
+		depositTokens[0].Amount = big.NewInt(70)
+		depositTokens[0].Timestamp += shift
+		grantsRewardTokens[0].Amount = big.NewInt(100)
+		grantsRewardTokens[0].Timestamp += shift
+		require.NoError(t, tm.storeEvents(tx, from, to, depositTokens, grantsRewardTokens, withdrawManagerUpdates, withdrawTokens))
+
+		stats := sumDayStats(t, tx, token)
+		require.Equal(t, "75", stats.DepositVolume.String())
+		require.Equal(t, "100", stats.GrantVolume.String())
+
+		require.NoError(t, tm.ReindexEvents(tx, headers[0].Number, headers[0].Number))
+
+		balance, err := treasuryBalanceAt(tx, token, headers[0].Number)
+		require.NoError(t, err)
+		require.Equal(t, "100", balance.String())
+		balance, err = treasuryBalanceAt(tx, token, headers[1].Number)
+		require.NoError(t, err)
+		require.Equal(t, "105", balance.String())
+
+		reward := rewardBalance(t, tx, user, token)
+		require.Equal(t, "40", reward.Granted.String())
+		require.Equal(t, "40", reward.Claimable.String())
+
+		stats = sumDayStats(t, tx, token)
+		require.Equal(t, uint64(2), stats.DepositCount)
+		require.Equal(t, "105", stats.DepositVolume.String())
+		require.Equal(t, uint64(1), stats.GrantCount)
+		require.Equal(t, "40", stats.GrantVolume.String())
+		buckets, err := tx.TokenStats.QueryTokenStats(worker.StatsBucketDay, 0, math.MaxInt64, &token)
+		require.NoError(t, err)
+		for _, bucket := range buckets {
+			require.Contains(t, []uint64{dayStart(headers[0].Timestamp), dayStart(headers[1].Timestamp)}, bucket.BucketStart)
+		}
+	})
+}
+
+func randomAddress() common.Address {
+	return common.BytesToAddress(crypto.Keccak256([]byte(uuid.New().String())))
+}
+
+// storeTreasureManagerLog 把 TreasureManager 事件 name 编码成日志写入 contract_events，indexed 为除事件签名之外的 topics
+func storeTreasureManagerLog(t *testing.T, tx *database.DB, tm *TreasureManager, header common2.BlockHeader, index uint, name string, indexed []common.Hash, args ...interface{}) {
+	t.Helper()
+	abiEvent := tm.TmAbi.Events[name]
+	data, err := abiEvent.Inputs.NonIndexed().Pack(args...)
+	require.NoError(t, err)
+	log := &types.Log{
+		Address:     tm.Address,
+		Topics:      append([]common.Hash{abiEvent.ID}, indexed...),
+		Data:        data,
+		BlockNumber: header.Number.Uint64(),
+		BlockHash:   header.Hash,
+		TxHash:      crypto.Keccak256Hash([]byte(uuid.New().String())),
+		Index:       index,
+	}
+	require.NoError(t, tx.ContractEvent.StoreContractEvents([]event.ContractEvent{event.ContractEventFromLog(log, header.Timestamp)}))
+}
+
+func rewardBalance(t *testing.T, tx *database.DB, user common.Address, token common.Address) *worker.RewardBalance {
+	t.Helper()
+	balances, err := tx.RewardLedger.QueryRewardBalances(user)
+	require.NoError(t, err)
+	for i := range balances {
+		if balances[i].TokenAddress == token {
+			return &balances[i]
+		}
+	}
+	t.Fatalf("no reward balance for %s %s", user, token)
+	return nil
+}
+
+func dayStart(timestamp uint64) uint64 {
+	return timestamp - timestamp%worker.StatsBucketSeconds[worker.StatsBucketDay]
+}
+
+// sumDayStats 汇总 token 所有天桶的统计
+func sumDayStats(t *testing.T, tx *database.DB, token common.Address) worker.TokenStats {
+	t.Helper()
+	buckets, err := tx.TokenStats.QueryTokenStats(worker.StatsBucketDay, 0, math.MaxInt64, &token)
+	require.NoError(t, err)
+	total := worker.TokenStats{DepositVolume: new(big.Int), GrantVolume: new(big.Int)}
+	for _, bucket := range buckets {
+		total.DepositCount += bucket.DepositCount
+		total.DepositVolume.Add(total.DepositVolume, bucket.DepositVolume)
+		total.GrantCount += bucket.GrantCount
+		total.GrantVolume.Add(total.GrantVolume, bucket.GrantVolume)
+	}
+	return total
+}
@@ -0,0 +1,88 @@
+package contracts
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+
+	"github.com/Sandwichzzy/event-sync-go/database"
+	"github.com/Sandwichzzy/event-sync-go/database/worker"
+)
+
+// projectTreasuryBalances 在每个代币 fromHeight 之前最新余额快照的基础上，按区块累加本批次充值(+)与提现(-)的金额，
+// 为每个有余额变化的区块生成一条快照。必须在写入 worker 表的同一个事务内调用。
+func projectTreasuryBalances(tx *database.DB, headers *blockHeaderLookup, fromHeight *big.Int, depositTokens []worker.DepositTokens, withdrawTokens []worker.WithdrawTokens) ([]worker.TreasuryBalance, error) {
+	deltas := make(map[common.Address]map[uint64]*big.Int)
+	addDelta := func(token common.Address, blockNumber *big.Int, amount *big.Int) {
+		if amount == nil {
+			return
+		}
+		blocks, ok := deltas[token]
+		if !ok {
+			blocks = make(map[uint64]*big.Int)
+			deltas[token] = blocks
+		}
+		number := blockNumber.Uint64()
+		if _, ok := blocks[number]; !ok {
+			blocks[number] = new(big.Int)
+		}
+		blocks[number].Add(blocks[number], amount)
+	}
+	for _, dt := range depositTokens {
+		addDelta(dt.TokenAddress, dt.BlockNumber, dt.Amount)
+	}
+	for _, wt := range withdrawTokens {
+		if wt.Amount != nil {
+			addDelta(wt.TokenAddress, wt.BlockNumber, new(big.Int).Neg(wt.Amount))
+		}
+	}
+	if len(deltas) == 0 {
+		return nil, nil
+	}
+
+	tokens := make([]common.Address, 0, len(deltas))
+	for token := range deltas {
+		tokens = append(tokens, token)
+	}
+	sort.Slice(tokens, func(i, j int) bool { return bytes.Compare(tokens[i][:], tokens[j][:]) < 0 })
+
+	baseHeight := new(big.Int).Sub(fromHeight, big.NewInt(1))
+	var balances []worker.TreasuryBalance
+	for _, token := range tokens {
+		latest, err := tx.TreasuryBalances.TreasuryBalanceAt(token, baseHeight)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query latest treasury balance of %s: %w", token, err)
+		}
+		balance := new(big.Int)
+		if latest != nil {
+			balance.Set(latest.Balance)
+		}
+
+		numbers := make([]uint64, 0, len(deltas[token]))
+		for number := range deltas[token] {
+			numbers = append(numbers, number)
+		}
+		sort.Slice(numbers, func(i, j int) bool { return numbers[i] < numbers[j] })
+
+		for _, number := range numbers {
+			header, err := headers.header(new(big.Int).SetUint64(number))
+			if err != nil {
+				return nil, err
+			}
+			balance.Add(balance, deltas[token][number])
+			balances = append(balances, worker.TreasuryBalance{
+				GUID:         uuid.New(),
+				TokenAddress: token,
+				BlockNumber:  header.Number,
+				BlockHash:    header.Hash,
+				Balance:      new(big.Int).Set(balance),
+				Timestamp:    header.Timestamp,
+			})
+		}
+	}
+	return balances, nil
+}
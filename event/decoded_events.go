@@ -0,0 +1,139 @@
+package event
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/google/uuid"
+
+	"github.com/Sandwichzzy/event-sync-go/config"
+	"github.com/Sandwichzzy/event-sync-go/database"
+	"github.com/Sandwichzzy/event-sync-go/database/event"
+	"github.com/Sandwichzzy/event-sync-go/event/decoder"
+)
+
+// DecodedEventsProcessorName 在配置中启用通用事件解码处理器使用的名称
+const DecodedEventsProcessorName = "decoded-events"
+
+// decodedEventsProcessor 用 ABI 通用解码合约事件，写入 decoded_events。
+// 没有绑定合约时用 ABI 目录解码所有同步的合约；绑定了合约时只解码这些合约，
+// 绑定中指定了 ABI 文件的合约只用该 ABI 解码，其余合约使用 ABI 目录
+type decodedEventsProcessor struct {
+	decoder   *decoder.Decoder
+	contracts []common.Address
+	decoders  map[common.Address]*decoder.Decoder
+}
+
+func newDecodedEventsProcessor(abiDir string, bindings []config.ContractBinding) (*decodedEventsProcessor, error) {
+	eventDecoder, err := decoder.LoadDir(abiDir)
+	if err != nil {
+		log.Error("load abi dir fail", "dir", abiDir, "err", err)
+		return nil, err
+	}
+	p := &decodedEventsProcessor{decoder: eventDecoder, decoders: make(map[common.Address]*decoder.Decoder, len(bindings))}
+	for _, binding := range bindings {
+		if _, ok := p.decoders[binding.Address]; ok {
+			return nil, fmt.Errorf("contract %s is bound to %s more than once", binding.Address, DecodedEventsProcessorName)
+		}
+		p.contracts = append(p.contracts, binding.Address)
+		if binding.AbiFile == "" {
+			p.decoders[binding.Address] = eventDecoder
+			continue
+		}
+		content, err := os.ReadFile(binding.AbiFile)
+		if err != nil {
+			return nil, fmt.Errorf("read abi of contract %s: %w", binding.Address, err)
+		}
+		contractDecoder := decoder.New()
+		name := strings.TrimSuffix(filepath.Base(binding.AbiFile), filepath.Ext(binding.AbiFile))
+		if err := contractDecoder.AddABI(name, content); err != nil {
+			return nil, fmt.Errorf("load abi of contract %s: %w", binding.Address, err)
+		}
+		p.decoders[binding.Address] = contractDecoder
+	}
+	return p, nil
+}
+
+func (p *decodedEventsProcessor) Name() string {
+	return DecodedEventsProcessorName
+}
+
+func (p *decodedEventsProcessor) Contracts() []common.Address {
+	return p.contracts
+}
+
+// decoderFor 返回合约使用的解码器，没有绑定合约时所有合约都使用 ABI 目录
+func (p *decodedEventsProcessor) decoderFor(contract common.Address) *decoder.Decoder {
+	if len(p.contracts) == 0 {
+		return p.decoder
+	}
+	return p.decoders[contract]
+}
+
+// contractEvents 读取 [fromHeight, toHeight] 内需要解码的合约事件
+func (p *decodedEventsProcessor) contractEvents(tx *database.DB, fromHeight *big.Int, toHeight *big.Int) ([]event.ContractEvent, error) {
+	if len(p.contracts) == 0 {
+		if p.decoder.Empty() {
+			return nil, nil
+		}
+		return tx.ContractEvent.ContractEventsWithFilter(event.ContractEvent{}, fromHeight, toHeight)
+	}
+	var contractEvents []event.ContractEvent
+	for _, contract := range p.contracts {
+		events, err := tx.ContractEvent.ContractEventsWithFilter(event.ContractEvent{ContractAddress: contract}, fromHeight, toHeight)
+		if err != nil {
+			return nil, err
+		}
+		contractEvents = append(contractEvents, events...)
+	}
+	return contractEvents, nil
+}
+
+// ProcessEvents 解码 [fromHeight, toHeight] 内的所有合约事件并写入 decoded_events。
+// 没有匹配 ABI 的事件直接跳过；单条事件解码失败只记录日志，不影响其他处理器。
+func (p *decodedEventsProcessor) ProcessEvents(tx *database.DB, fromHeight *big.Int, toHeight *big.Int) error {
+	contractEvents, err := p.contractEvents(tx, fromHeight, toHeight)
+	if err != nil {
+		return err
+	}
+
+	decodedEvents := make([]event.DecodedEvent, 0, len(contractEvents))
+	for _, contractEvent := range contractEvents {
+		decoded, err := p.decoderFor(contractEvent.ContractAddress).Decode(contractEvent.RLPLog)
+		if err != nil {
+			log.Warn("decode contract event fail", "guid", contractEvent.GUID, "tx", contractEvent.TransactionHash, "err", err)
+			continue
+		} else if decoded == nil {
+			continue
+		}
+		decodedEvents = append(decodedEvents, event.DecodedEvent{
+			GUID:              uuid.New(),
+			ContractEventGUID: contractEvent.GUID,
+			ContractAddress:   contractEvent.ContractAddress,
+			ContractName:      decoded.ContractName,
+			EventName:         decoded.EventName,
+			EventSignature:    contractEvent.EventSignature,
+			BlockNumber:       contractEvent.BlockNumber,
+			BlockHash:         contractEvent.BlockHash,
+			TransactionHash:   contractEvent.TransactionHash,
+			LogIndex:          contractEvent.LogIndex,
+			Args:              decoded.Args,
+			ArgTypes:          decoded.ArgTypes,
+			Timestamp:         contractEvent.Timestamp,
+		})
+	}
+	return tx.DecodedEvents.StoreDecodedEvents(decodedEvents)
+}
+
+// ReindexEvents 删除 [fromHeight, toHeight] 内绑定合约（没有绑定时为所有合约）的 decoded_events 后按当前 ABI 重新解码
+func (p *decodedEventsProcessor) ReindexEvents(tx *database.DB, fromHeight *big.Int, toHeight *big.Int) error {
+	if err := tx.DecodedEvents.DeleteDecodedEventsInRange(p.contracts, fromHeight, toHeight); err != nil {
+		return err
+	}
+	return p.ProcessEvents(tx, fromHeight, toHeight)
+}
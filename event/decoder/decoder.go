@@ -0,0 +1,253 @@
+package decoder
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// candidate 一个事件签名（topic0）对应的某个 ABI 中的事件定义
+type candidate struct {
+	contractName string
+	event        abi.Event
+}
+
+// Decoder 根据加载的 ABI 把任意合约日志解码为事件名和参数，不需要为合约生成绑定代码。
+// 不同 ABI 中可能存在签名相同的事件（如 OwnershipTransferred、ERC20/ERC721 的 Transfer），
+// 解码时选择 indexed 参数个数与日志 topics 数量一致的第一个定义。
+type Decoder struct {
+	events map[common.Hash][]candidate
+}
+
+// Decoded 解码结果
+// Args 为参数名到值的 JSON 对象：整数为 JSON 数字（不丢精度），address/bytes/hash 为小写 hex 字符串，
+// 数组为 JSON 数组，tuple 为 JSON 对象；ArgTypes 为参数名到 Solidity 类型的 JSON 对象
+type Decoded struct {
+	ContractName string
+	EventName    string
+	Args         json.RawMessage
+	ArgTypes     json.RawMessage
+}
+
+func New() *Decoder {
+	return &Decoder{events: make(map[common.Hash][]candidate)}
+}
+
+// LoadDir 加载目录（含子目录）下所有 .json 文件中的 ABI，合约名取文件名（不含扩展名）
+// 目录不存在时不报错，返回空的 Decoder
+func LoadDir(dir string) (*Decoder, error) {
+	d := New()
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		log.Warn("abi dir not found, generic event decoding disabled", "dir", dir)
+		return d, nil
+	}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || filepath.Ext(path) != ".json" {
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("read abi file %s: %w", path, err)
+		}
+		name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		if err := d.AddABI(name, content); err != nil {
+			return fmt.Errorf("load abi file %s: %w", path, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	log.Info("loaded abi files for generic event decoding", "dir", dir, "events", len(d.events))
+	return d, nil
+}
+
+// AddABI 加载一份 ABI，content 可以是 ABI 数组，也可以是带 "abi" 字段的编译产物（forge/hardhat artifact）
+func (d *Decoder) AddABI(contractName string, content []byte) error {
+	var artifact struct {
+		ABI json.RawMessage `json:"abi"`
+	}
+	trimmed := strings.TrimSpace(string(content))
+	if strings.HasPrefix(trimmed, "{") {
+		if err := json.Unmarshal(content, &artifact); err != nil {
+			return err
+		}
+		if len(artifact.ABI) == 0 {
+			return fmt.Errorf("no abi field in artifact")
+		}
+		content = artifact.ABI
+	}
+
+	parsed, err := abi.JSON(strings.NewReader(string(content)))
+	if err != nil {
+		return err
+	}
+	for _, event := range parsed.Events {
+		if event.Anonymous {
+			continue
+		}
+		d.events[event.ID] = append(d.events[event.ID], candidate{contractName: contractName, event: event})
+	}
+	return nil
+}
+
+// Empty 没有加载任何事件定义
+func (d *Decoder) Empty() bool {
+	return len(d.events) == 0
+}
+
+// Decode 解码一条日志，没有匹配的事件定义时返回 nil, nil
+func (d *Decoder) Decode(l *types.Log) (*Decoded, error) {
+	if len(l.Topics) == 0 {
+		return nil, nil
+	}
+	for _, c := range d.events[l.Topics[0]] {
+		var indexed abi.Arguments
+		for _, input := range c.event.Inputs {
+			if input.Indexed {
+				indexed = append(indexed, input)
+			}
+		}
+		if len(indexed) != len(l.Topics)-1 {
+			continue
+		}
+		return decodeEvent(c, l)
+	}
+	return nil, nil
+}
+
+// decodeEvent 按参数位置解码：UnpackIntoMap / ParseTopicsIntoMap 以参数名为键，重名的参数会互相覆盖
+func decodeEvent(c candidate, l *types.Log) (*Decoded, error) {
+	nonIndexed, err := c.event.Inputs.NonIndexed().Unpack(l.Data)
+	if err != nil {
+		return nil, fmt.Errorf("unpack %s data: %w", c.event.Name, err)
+	}
+
+	args := make(map[string]interface{}, len(c.event.Inputs))
+	argTypes := make(map[string]string, len(c.event.Inputs))
+	var topicIndex, dataIndex int
+	for i, input := range c.event.Inputs {
+		var value interface{}
+		if input.Indexed {
+			value, err = parseTopic(input, l.Topics[1+topicIndex])
+			if err != nil {
+				return nil, fmt.Errorf("parse %s topic %d: %w", c.event.Name, topicIndex, err)
+			}
+			topicIndex++
+		} else {
+			value = nonIndexed[dataIndex]
+			dataIndex++
+		}
+
+		// abi 解析时把未命名参数命名为 argN，可能与已命名的参数重名
+		name := input.Name
+		if name == "" {
+			name = fmt.Sprintf("arg%d", i)
+		}
+		for _, ok := args[name]; ok; _, ok = args[name] {
+			name = fmt.Sprintf("%s_%d", name, i)
+		}
+		args[name] = jsonValue(value)
+		argTypes[name] = input.Type.String()
+	}
+
+	argsJSON, err := json.Marshal(args)
+	if err != nil {
+		return nil, err
+	}
+	argTypesJSON, err := json.Marshal(argTypes)
+	if err != nil {
+		return nil, err
+	}
+	return &Decoded{
+		ContractName: c.contractName,
+		EventName:    c.event.Name,
+		Args:         argsJSON,
+		ArgTypes:     argTypesJSON,
+	}, nil
+}
+
+// parseTopic 解码一个 indexed 参数，动态类型（string、bytes、数组）只能得到 topic 中的哈希
+func parseTopic(input abi.Argument, topic common.Hash) (interface{}, error) {
+	values := make(map[string]interface{}, 1)
+	input.Name = "value"
+	if err := abi.ParseTopicsIntoMap(values, abi.Arguments{input}, []common.Hash{topic}); err != nil {
+		return nil, err
+	}
+	return values[input.Name], nil
+}
+
+// jsonValue 把 abi 解码出的 Go 值转换为适合写入 JSONB 的值
+func jsonValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case nil:
+		return nil
+	case *big.Int:
+		return json.Number(value.String())
+	case common.Address:
+		return hexutil.Encode(value[:])
+	case common.Hash:
+		return value.Hex()
+	case []byte:
+		return hexutil.Encode(value)
+	case string, bool:
+		return value
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return json.Number(fmt.Sprintf("%d", rv.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return json.Number(fmt.Sprintf("%d", rv.Uint()))
+	case reflect.Array:
+		// bytesN 解码为 [N]byte
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, rv.Len())
+			reflect.Copy(reflect.ValueOf(b), rv)
+			return hexutil.Encode(b)
+		}
+		return jsonList(rv)
+	case reflect.Slice:
+		return jsonList(rv)
+	case reflect.Struct:
+		// tuple 解码为匿名结构体，字段名为 abi 组件名的驼峰形式，json tag 为原始组件名
+		fields := make(map[string]interface{}, rv.NumField())
+		for i := 0; i < rv.NumField(); i++ {
+			field := rv.Type().Field(i)
+			name := field.Tag.Get("json")
+			if name == "" {
+				name = field.Name
+			}
+			fields[name] = jsonValue(rv.Field(i).Interface())
+		}
+		return fields
+	case reflect.Pointer:
+		if rv.IsNil() {
+			return nil
+		}
+		return jsonValue(rv.Elem().Interface())
+	}
+	return v
+}
+
+func jsonList(rv reflect.Value) []interface{} {
+	list := make([]interface{}, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		list[i] = jsonValue(rv.Index(i).Interface())
+	}
+	return list
+}
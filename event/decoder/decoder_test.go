@@ -0,0 +1,83 @@
+package decoder
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Sandwichzzy/event-sync-go/bindings"
+)
+
+func TestDecodeTreasureManagerEvent(t *testing.T) {
+	d, err := LoadDir("../../abis")
+	require.NoError(t, err)
+	require.False(t, d.Empty())
+
+	tmAbi, err := bindings.TreasureManagerMetaData.GetAbi()
+	require.NoError(t, err)
+
+	token := common.HexToAddress("0x00000000000000000000000000000000000000AA")
+	sender := common.HexToAddress("0x00000000000000000000000000000000000000BB")
+	receiver := common.HexToAddress("0x00000000000000000000000000000000000000CC")
+	amount, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+
+	withdrawEvent := tmAbi.Events["WithdrawToken"]
+	data, err := withdrawEvent.Inputs.NonIndexed().Pack(sender, receiver, amount)
+	require.NoError(t, err)
+
+	decoded, err := d.Decode(&types.Log{
+		Topics: []common.Hash{withdrawEvent.ID, common.BytesToHash(token.Bytes())},
+		Data:   data,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, decoded)
+	require.Equal(t, "TreasureManager", decoded.ContractName)
+	require.Equal(t, "WithdrawToken", decoded.EventName)
+	require.JSONEq(t, `{
+		"tokenAddress": "0x00000000000000000000000000000000000000aa",
+		"sender": "0x00000000000000000000000000000000000000bb",
+		"withdrawAddress": "0x00000000000000000000000000000000000000cc",
+		"amount": 123456789012345678901234567890
+	}`, string(decoded.Args))
+
+	var argTypes map[string]string
+	require.NoError(t, json.Unmarshal(decoded.ArgTypes, &argTypes))
+	require.Equal(t, "uint256", argTypes["amount"])
+
+	// 未知事件签名不解码
+	decoded, err = d.Decode(&types.Log{Topics: []common.Hash{common.HexToHash("0x01")}})
+	require.NoError(t, err)
+	require.Nil(t, decoded)
+}
+
+func TestDecodeUnnamedInputs(t *testing.T) {
+	d := New()
+	// 未命名参数被命名为 arg1，与第一个参数重名
+	require.NoError(t, d.AddABI("Unnamed", []byte(`[{"type":"event","name":"Moved","anonymous":false,"inputs":[
+		{"name":"arg1","type":"address","indexed":true},
+		{"name":"","type":"uint256","indexed":false},
+		{"name":"","type":"uint256","indexed":false}
+	]}]`)))
+
+	event := d.events[crypto.Keccak256Hash([]byte("Moved(address,uint256,uint256)"))][0].event
+	data, err := event.Inputs.NonIndexed().Pack(big.NewInt(1), big.NewInt(2))
+	require.NoError(t, err)
+
+	decoded, err := d.Decode(&types.Log{
+		Topics: []common.Hash{event.ID, common.BytesToHash(common.HexToAddress("0xAA").Bytes())},
+		Data:   data,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, decoded)
+	require.JSONEq(t, `{
+		"arg1": "0x00000000000000000000000000000000000000aa",
+		"arg1_1": 1,
+		"arg2": 2
+	}`, string(decoded.Args))
+	require.JSONEq(t, `{"arg1": "address", "arg1_1": "uint256", "arg2": "uint256"}`, string(decoded.ArgTypes))
+}
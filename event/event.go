@@ -2,26 +2,32 @@ package event
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
 	"time"
 
 	"github.com/ethereum/go-ethereum/log"
-	"github.com/google/uuid"
 	"gorm.io/gorm"
 
 	"github.com/Sandwichzzy/event-sync-go/common/bigint"
 	"github.com/Sandwichzzy/event-sync-go/common/tasks"
+	"github.com/Sandwichzzy/event-sync-go/config"
 	"github.com/Sandwichzzy/event-sync-go/database"
 	"github.com/Sandwichzzy/event-sync-go/database/common"
-	"github.com/Sandwichzzy/event-sync-go/database/event"
-	"github.com/Sandwichzzy/event-sync-go/event/contracts"
+	"github.com/Sandwichzzy/event-sync-go/synchronizer"
 )
 
+// errReindexRunning reindex 正在进行，本批次回滚后稍后重试
+var errReindexRunning = errors.New("reindex is running")
+
 type EventProcessorConfig struct {
 	LoopInterval    time.Duration
-	EventStartBlock uint64 // 事件起始区块
-	EventBlockStep  uint64 // 每次处理的区块步长
+	EventStartBlock uint64                   // 事件起始区块
+	EventBlockStep  uint64                   // 每次处理的区块步长
+	AbiDir          string                   // 通用事件解码的 ABI 目录
+	Processors      []string                 // 启用的合约处理器名称，见 RegisteredProcessors
+	Contracts       []config.ContractBinding // 合约地址与处理器的绑定
 }
 
 type EventProcessor struct {
@@ -31,14 +37,18 @@ type EventProcessor struct {
 	resourceCancel    context.CancelFunc
 	tasks             tasks.Group
 	LatestBlockHeader *common.BlockHeader
-	TreasureManager   *contracts.TreasureManager
+	processors        []*processorRunner
+	batches           <-chan synchronizer.CommittedBatch
 }
 
-func NewEventProcessor(db *database.DB, eventBlocksConfig *EventProcessorConfig, shutdown context.CancelCauseFunc) (*EventProcessor, error) {
-	//创建合约处理器（核心业务逻辑）
-	treasureManager, err := contracts.NewTreasureManager(db)
+// NewEventProcessor batches 为同步器提交批次的通知，收到后立即处理到该批次末尾；
+// 按 LoopInterval 轮询 block_headers 作为兜底（重启后追赶、没有同步器通知时）。batches 为 nil 时只轮询。
+func NewEventProcessor(db *database.DB, eventBlocksConfig *EventProcessorConfig, batches <-chan synchronizer.CommittedBatch, shutdown context.CancelCauseFunc) (*EventProcessor, error) {
+	//按配置创建合约处理器（核心业务逻辑）
+	processors, err := newProcessors(eventBlocksConfig)
 	if err != nil {
-		log.Error("new treasure manager fail", "err", err)
+		log.Error("new contract processors fail", "err", err)
+		return nil, err
 	}
 	//获取最新处理的事件区块
 	latestBlockHeader, err := db.EventBlocks.LatestEventBlockHeader()
@@ -58,26 +68,51 @@ func NewEventProcessor(db *database.DB, eventBlocksConfig *EventProcessorConfig,
 			shutdown(fmt.Errorf("critical error in bridge processor: %w", err))
 		}},
 		LatestBlockHeader: latestBlockHeader,
-		TreasureManager:   treasureManager,
+		processors:        processors,
+		batches:           batches,
 	}, nil
 }
 
 func (ep *EventProcessor) Start() error {
 	log.Info("starting bridge processor...")
-	// 创建定时器，按配置间隔执行
+	// 创建定时器，按配置间隔轮询，同时接收同步器提交批次的通知
 	tickerWorker := time.NewTicker(ep.eventBlocksConfig.LoopInterval)
 	ep.tasks.Go(func() error {
-		for range tickerWorker.C {
-			err := ep.processEvent()
-			if err != nil {
+		defer tickerWorker.Stop()
+		for {
+			var toHeight *big.Int
+			select {
+			case <-ep.resourceCtx.Done():
+				return nil
+			case batch := <-ep.batches:
+				toHeight = batch.ToHeight
+			case <-tickerWorker.C:
+			}
+			if err := ep.processUntil(toHeight); err != nil {
 				return err
 			}
 		}
-		return nil
 	})
 	return nil
 }
 
+// processUntil 连续处理直到已处理高度达到 toHeight；toHeight 为 nil 时处理到没有新的区块为止
+func (ep *EventProcessor) processUntil(toHeight *big.Int) error {
+	for ep.resourceCtx.Err() == nil {
+		before := ep.LatestBlockHeader
+		if err := ep.processEvent(); err != nil {
+			return err
+		}
+		after := ep.LatestBlockHeader
+		if after == before {
+			return nil
+		} else if toHeight != nil && after.Number.Cmp(toHeight) >= 0 {
+			return nil
+		}
+	}
+	return nil
+}
+
 func (ep *EventProcessor) Close() error {
 	ep.resourceCancel()
 	return ep.tasks.Wait()
@@ -112,82 +147,35 @@ func (ep *EventProcessor) processEvent() error {
 	// 4. 计算处理范围
 	fromHeight, toHeight := new(big.Int).Add(lastBlockNumber, bigint.One), latestHeader.Number
 
-	// 5. 构建事件区块记录
-	eventBlocks := make([]event.EventBlocks, 0, toHeight.Uint64()-fromHeight.Uint64())
-
-	for index := fromHeight.Uint64(); index < toHeight.Uint64(); index++ {
-		blockHeader, err := ep.db.Blocks.BlockHeaderByNumber(big.NewInt(int64(index)))
-		if err != nil {
-			return err
-		}
-		evBlock := event.EventBlocks{
-			GUID:       uuid.New(),
-			Hash:       blockHeader.Hash,
-			ParentHash: blockHeader.ParentHash,
-			Number:     blockHeader.Number,
-			Timestamp:  blockHeader.Timestamp,
-		}
-		eventBlocks = append(eventBlocks, evBlock)
-	}
-	// 6. 处理合约事件（核心业务逻辑）
-	log.Info("parse contract event start", "fromHeight", fromHeight.String(), "toHeight", toHeight.String())
-	depositTokens, grantsRewardTokens, withdrawManagerUpdates, withdrawTokens, err := ep.TreasureManager.ProcessTreasureManagerEvents(fromHeight, toHeight)
-	if err != nil {
-		log.Error("parse treasure manager contracts events fail", "err", err)
-		return err
-	}
-	// 7. 数据库事务：保存所有处理结果
+	// 5. 数据库事务：依次调用已启用的合约处理器，并用一条 INSERT ... SELECT 保存 [fromHeight, toHeight] 的事件区块进度
+	log.Info("process contract event start", "fromHeight", fromHeight.String(), "toHeight", toHeight.String())
 	if err := ep.db.Transaction(func(tx *database.DB) error {
+		// reindex 持有锁时跳过本批次，下一轮重试
+		locked, err := tx.TryLockEventProcessing()
 		if err != nil {
-			log.Error("process delegation event fail", "err", err)
 			return err
+		} else if !locked {
+			return errReindexRunning
 		}
-
-		if len(depositTokens) > 0 {
-			err := ep.db.DepositTokens.StoreDepositTokens(depositTokens)
-			if err != nil {
-				log.Error("store deposit tokens fail", "err", err)
-				return err
-			}
-		}
-
-		if len(withdrawTokens) > 0 {
-			err := ep.db.WithdrawTokens.StoreWithdrawTokens(withdrawTokens)
-			if err != nil {
-				log.Error("store withdraw tokens fail", "err", err)
-				return err
-			}
-		}
-
-		if len(grantsRewardTokens) > 0 {
-			err := ep.db.GrantRewardTokens.StoreGrantRewardTokens(grantsRewardTokens)
-			if err != nil {
-				log.Error("store grants reward tokens fail", "err", err)
-				return err
-			}
-		}
-
-		if len(withdrawManagerUpdates) > 0 {
-			err := ep.db.WithdrawManagerUpdate.StoreWithdrawManagerUpdates(withdrawManagerUpdates)
-			if err != nil {
-				log.Error("store withdraw manager update fail", "err", err)
+		for _, processor := range ep.processors {
+			if err := processor.run(tx, fromHeight, toHeight); err != nil {
 				return err
 			}
 		}
 
-		if len(eventBlocks) > 0 {
-			err = tx.EventBlocks.StoreEventBlocks(eventBlocks)
-			if err != nil {
-				log.Error("store event block fail", "err", err)
-				return err
-			}
+		if err := tx.EventBlocks.StoreEventBlocksInRange(fromHeight, toHeight); err != nil {
+			log.Error("store event block fail", "err", err)
+			return err
 		}
 		return nil
-	}); err != nil {
+	}); errors.Is(err, errReindexRunning) {
+		log.Info("reindex is running, skip processing event", "fromHeight", fromHeight.String(), "toHeight", toHeight.String())
+		return nil
+	} else if err != nil {
 		log.Error("exec database fail", "err", err)
 		return err
 	}
-	// 8. 更新最新处理区块头
+	// 6. 更新最新处理区块头
 	ep.LatestBlockHeader = latestHeader
 	return nil
 
@@ -0,0 +1,102 @@
+package event
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+
+	"github.com/Sandwichzzy/event-sync-go/database"
+	"github.com/Sandwichzzy/event-sync-go/database/common"
+	"github.com/Sandwichzzy/event-sync-go/database/dbtest"
+	"github.com/Sandwichzzy/event-sync-go/database/event"
+	"github.com/Sandwichzzy/event-sync-go/database/utils"
+)
+
+var errBenchRollback = errors.New("rollback benchmark data")
+
+// seedBlockHeaders 写入 count 个高度远高于真实数据的连续区块头，返回区间
+func seedBlockHeaders(b *testing.B, tx *database.DB, count int) (*big.Int, *big.Int) {
+	base := uint64(1 << 40)
+	headers := make([]common.BlockHeader, 0, count)
+	parentHash := crypto.Keccak256Hash([]byte("bench"), new(big.Int).SetUint64(base-1).Bytes())
+	for i := 0; i < count; i++ {
+		number := new(big.Int).SetUint64(base + uint64(i))
+		header := &types.Header{Number: number, ParentHash: parentHash, Time: 2_100_000_000 + uint64(i), Difficulty: big.NewInt(0)}
+		headers = append(headers, common.BlockHeader{
+			Hash:       header.Hash(),
+			ParentHash: header.ParentHash,
+			Number:     number,
+			Timestamp:  header.Time,
+			RLPHeader:  (*utils.RLPHeader)(header),
+		})
+		parentHash = header.Hash()
+	}
+	if err := tx.Blocks.StoreBlockHeaders(headers); err != nil {
+		b.Fatal(err)
+	}
+	return headers[0].Number, headers[count-1].Number
+}
+
+// BenchmarkMaterializeEventBlocks 对比逐块查询区块头再写入 event_blocks 与一条 INSERT ... SELECT 的耗时。
+// 需要数据库：EVENT_SYNC_MASTER_DB_HOST=... go test -bench MaterializeEventBlocks ./event/
+// 所有数据在事务内写入，结束时回滚。
+func BenchmarkMaterializeEventBlocks(b *testing.B) {
+	db := dbtest.Open(b)
+	for _, count := range []int{100, 1000} {
+		b.Run(fmt.Sprintf("per-block/%d", count), func(b *testing.B) {
+			benchInTx(b, db, count, func(tx *database.DB, fromHeight, toHeight *big.Int) error {
+				eventBlocks := make([]event.EventBlocks, 0, count)
+				for number := new(big.Int).Set(fromHeight); number.Cmp(toHeight) <= 0; number.Add(number, big.NewInt(1)) {
+					header, err := tx.Blocks.BlockHeaderByNumber(number)
+					if err != nil {
+						return err
+					}
+					eventBlocks = append(eventBlocks, event.EventBlocks{
+						GUID:       uuid.New(),
+						Hash:       header.Hash,
+						ParentHash: header.ParentHash,
+						Number:     header.Number,
+						Timestamp:  header.Timestamp,
+					})
+				}
+				return tx.EventBlocks.StoreEventBlocks(eventBlocks)
+			})
+		})
+		b.Run(fmt.Sprintf("set-based/%d", count), func(b *testing.B) {
+			benchInTx(b, db, count, func(tx *database.DB, fromHeight, toHeight *big.Int) error {
+				if _, err := tx.Blocks.BlockHeadersInRange(fromHeight, toHeight); err != nil {
+					return err
+				}
+				return tx.EventBlocks.StoreEventBlocksInRange(fromHeight, toHeight)
+			})
+		})
+	}
+}
+
+func benchInTx(b *testing.B, db *database.DB, count int, materialize func(tx *database.DB, fromHeight, toHeight *big.Int) error) {
+	err := db.Transaction(func(tx *database.DB) error {
+		fromHeight, toHeight := seedBlockHeaders(b, tx, count)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if err := materialize(tx, fromHeight, toHeight); err != nil {
+				return err
+			}
+			b.StopTimer()
+			if err := tx.EventBlocks.DeleteEventBlocksInRange(fromHeight, toHeight); err != nil {
+				return err
+			}
+			b.StartTimer()
+		}
+		b.StopTimer()
+		b.ReportMetric(float64(count)*float64(b.N)/b.Elapsed().Seconds(), "blocks/s")
+		return errBenchRollback
+	})
+	if err != nil && !errors.Is(err, errBenchRollback) {
+		b.Fatal(err)
+	}
+}
@@ -0,0 +1,124 @@
+package event
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+
+	"github.com/Sandwichzzy/event-sync-go/config"
+	"github.com/Sandwichzzy/event-sync-go/database"
+	"github.com/Sandwichzzy/event-sync-go/event/contracts"
+)
+
+// ContractProcessor 合约事件处理器。
+// EventProcessor 每一批次在同一个数据库事务内依次调用已启用的处理器，
+// 处理器从 tx 读取 [fromHeight, toHeight] 内自己关心的 contract_events 并写入自己的表；
+// 任何一个处理器返回错误，整个批次（包括 event_blocks 进度）回滚，下一轮重试。
+type ContractProcessor interface {
+	// Name 处理器名称，用于配置启用、日志和指标
+	Name() string
+	// Contracts 处理器处理的合约地址（即配置中绑定到该处理器的地址），为空表示不限合约（例如按 ABI 目录通用解码所有同步的合约）
+	Contracts() []common.Address
+	// ProcessEvents 处理 [fromHeight, toHeight]（闭区间）内的事件，所有写入必须使用 tx
+	ProcessEvents(tx *database.DB, fromHeight *big.Int, toHeight *big.Int) error
+}
+
+// ProcessorFactory 根据 EventProcessor 配置和绑定到该处理器的合约（地址和可选的 ABI 文件）创建处理器，
+// 处理器只读取这些合约的事件；bindings 为空时由处理器决定是否允许（例如通用解码处理器处理所有同步的合约）
+type ProcessorFactory func(cfg *EventProcessorConfig, bindings []config.ContractBinding) (ContractProcessor, error)
+
+var processorFactories = map[string]ProcessorFactory{
+	contracts.TreasureManagerProcessorName: func(cfg *EventProcessorConfig, bindings []config.ContractBinding) (ContractProcessor, error) {
+		// worker 表不区分合约，只支持绑定一个 TreasureManager
+		if len(bindings) != 1 {
+			return nil, fmt.Errorf("exactly one contract must be bound to %s, got %d", contracts.TreasureManagerProcessorName, len(bindings))
+		}
+		if bindings[0].AbiFile != "" {
+			return nil, fmt.Errorf("%s uses the generated contract bindings, abi file %s is not supported", contracts.TreasureManagerProcessorName, bindings[0].AbiFile)
+		}
+		return contracts.NewTreasureManager(bindings[0].Address)
+	},
+	DecodedEventsProcessorName: func(cfg *EventProcessorConfig, bindings []config.ContractBinding) (ContractProcessor, error) {
+		return newDecodedEventsProcessor(cfg.AbiDir, bindings)
+	},
+}
+
+// RegisterProcessor 注册一个处理器，之后可以在配置中按名称启用。名称重复会 panic。
+func RegisterProcessor(name string, factory ProcessorFactory) {
+	if _, ok := processorFactories[name]; ok {
+		panic(fmt.Sprintf("contract processor %s already registered", name))
+	}
+	processorFactories[name] = factory
+}
+
+// RegisteredProcessors 返回所有已注册的处理器名称
+func RegisteredProcessors() []string {
+	names := make([]string, 0, len(processorFactories))
+	for name := range processorFactories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// processorRunner 包装一个处理器，按处理器名称记录各自的耗时和错误数
+type processorRunner struct {
+	processor ContractProcessor
+
+	timer  *metrics.Timer
+	errors *metrics.Counter
+}
+
+// newProcessors 创建 cfg.Processors 中启用的处理器，每个处理器收到 cfg.Contracts 中绑定到它的合约
+func newProcessors(cfg *EventProcessorConfig) ([]*processorRunner, error) {
+	enabled := make(map[string]bool, len(cfg.Processors))
+	for _, name := range cfg.Processors {
+		enabled[name] = true
+	}
+	bindings := make(map[string][]config.ContractBinding)
+	for _, binding := range cfg.Contracts {
+		if _, ok := processorFactories[binding.Processor]; !ok {
+			return nil, fmt.Errorf("contract %s is bound to unknown processor %q, registered: %v", binding.Address, binding.Processor, RegisteredProcessors())
+		}
+		if !enabled[binding.Processor] {
+			log.Warn("contract is bound to a disabled processor, its events are synced but not processed", "contract", binding.Address, "processor", binding.Processor)
+		}
+		bindings[binding.Processor] = append(bindings[binding.Processor], binding)
+	}
+
+	runners := make([]*processorRunner, 0, len(cfg.Processors))
+	for _, name := range cfg.Processors {
+		factory, ok := processorFactories[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown contract processor %q, registered: %v", name, RegisteredProcessors())
+		}
+		processor, err := factory(cfg, bindings[name])
+		if err != nil {
+			return nil, fmt.Errorf("create contract processor %s: %w", name, err)
+		}
+		runners = append(runners, &processorRunner{
+			processor: processor,
+			timer:     metrics.GetOrRegisterTimer(fmt.Sprintf("event/processor/%s/duration", name), nil),
+			errors:    metrics.GetOrRegisterCounter(fmt.Sprintf("event/processor/%s/errors", name), nil),
+		})
+		log.Info("contract processor enabled", "name", name, "contracts", processor.Contracts())
+	}
+	return runners, nil
+}
+
+func (r *processorRunner) run(tx *database.DB, fromHeight *big.Int, toHeight *big.Int) error {
+	start := time.Now()
+	err := r.processor.ProcessEvents(tx, fromHeight, toHeight)
+	r.timer.UpdateSince(start)
+	if err != nil {
+		r.errors.Inc(1)
+		log.Error("contract processor fail", "name", r.processor.Name(), "fromHeight", fromHeight, "toHeight", toHeight, "err", err)
+		return fmt.Errorf("contract processor %s: %w", r.processor.Name(), err)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/Sandwichzzy/event-sync-go/common/bigint"
+	"github.com/Sandwichzzy/event-sync-go/database"
+)
+
+// Reindexer 支持离线重建的合约处理器。
+// ReindexEvents 在事务 tx 内删除 [fromHeight, toHeight] 内由该处理器写入的数据并从 contract_events 重新生成，
+// 同时修正区间之后依赖这些数据的累计值（例如余额快照）。
+type Reindexer interface {
+	ReindexEvents(tx *database.DB, fromHeight *big.Int, toHeight *big.Int) error
+}
+
+// Reindex 不访问 RPC，按 cfg.EventBlockStep 分块从 contract_events 重建 [fromHeight, toHeight] 内 cfg.Processors 的数据。
+// 每一块在一个事务内删除并重建处理器数据和 event_blocks，api 服务读到的始终是某一块重建前或重建后的完整数据。
+// toHeight 为 nil 或超过 event_blocks 最新高度时取最新高度。重建期间持有与 index 共用的 advisory lock，
+// index 服务不需要停止，会在重建结束前跳过新的批次。
+func Reindex(ctx context.Context, db *database.DB, cfg *EventProcessorConfig, fromHeight *big.Int, toHeight *big.Int) error {
+	processors, err := newProcessors(cfg)
+	if err != nil {
+		return err
+	}
+	reindexers := make([]Reindexer, 0, len(processors))
+	for _, runner := range processors {
+		reindexer, ok := runner.processor.(Reindexer)
+		if !ok {
+			return fmt.Errorf("contract processor %s does not support reindex", runner.processor.Name())
+		}
+		reindexers = append(reindexers, reindexer)
+	}
+
+	latestEventBlock, err := db.EventBlocks.LatestEventBlockHeader()
+	if err != nil {
+		return err
+	} else if latestEventBlock == nil {
+		return fmt.Errorf("no event blocks processed yet, nothing to reindex")
+	}
+	if toHeight == nil || toHeight.Cmp(latestEventBlock.Number) > 0 {
+		if toHeight != nil {
+			log.Warn("reindex toHeight is beyond the latest event block, clamped", "toHeight", toHeight, "latestEventBlock", latestEventBlock.Number)
+		}
+		toHeight = latestEventBlock.Number
+	}
+	if fromHeight.Cmp(toHeight) > 0 {
+		return fmt.Errorf("invalid reindex range [%s, %s]", fromHeight, toHeight)
+	}
+	step := cfg.EventBlockStep
+	if step == 0 {
+		step = 1
+	}
+
+	total := new(big.Int).Sub(toHeight, fromHeight)
+	total.Add(total, bigint.One)
+	return db.WithEventProcessingLock(ctx, func() error {
+		start := time.Now()
+		log.Info("reindex start", "fromHeight", fromHeight, "toHeight", toHeight, "processors", cfg.Processors, "step", step)
+		for from := new(big.Int).Set(fromHeight); from.Cmp(toHeight) <= 0; {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			to := bigint.Clamp(from, toHeight, step)
+			err := db.Transaction(func(tx *database.DB) error {
+				if err := tx.EventBlocks.DeleteEventBlocksInRange(from, to); err != nil {
+					return err
+				}
+				for i, reindexer := range reindexers {
+					if err := reindexer.ReindexEvents(tx, from, to); err != nil {
+						return fmt.Errorf("contract processor %s: %w", processors[i].processor.Name(), err)
+					}
+				}
+				return tx.EventBlocks.StoreEventBlocksInRange(from, to)
+			})
+			if err != nil {
+				log.Error("reindex fail", "fromHeight", from, "toHeight", to, "err", err)
+				return err
+			}
+
+			done := new(big.Int).Sub(to, fromHeight)
+			done.Add(done, bigint.One)
+			percent, _ := new(big.Float).Quo(new(big.Float).SetInt(done), new(big.Float).SetInt(total)).Float64()
+			log.Info("reindex progress", "fromHeight", from, "toHeight", to,
+				"blocks", done, "total", total, "percent", fmt.Sprintf("%.1f%%", percent*100), "elapsed", time.Since(start).Round(time.Millisecond))
+			from = new(big.Int).Add(to, bigint.One)
+		}
+		log.Info("reindex done", "fromHeight", fromHeight, "toHeight", toHeight, "elapsed", time.Since(start).Round(time.Millisecond))
+		return nil
+	})
+}
@@ -0,0 +1,262 @@
+package export
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/Sandwichzzy/event-sync-go/common/bigint"
+	common2 "github.com/Sandwichzzy/event-sync-go/database/common"
+	"github.com/Sandwichzzy/event-sync-go/database/worker"
+)
+
+const (
+	EntityDeposits       = "deposits"
+	EntityWithdrawals    = "withdrawals"
+	EntityGrants         = "grants"
+	EntityManagerUpdates = "manager-updates"
+
+	FormatCSV    = "csv"
+	FormatNDJSON = "ndjson"
+)
+
+// Entities 支持导出的实体，名称与 REST 列表路径一致
+var Entities = []string{EntityDeposits, EntityWithdrawals, EntityGrants, EntityManagerUpdates}
+
+// eventColumns 所有实体共有的列
+var eventColumns = []string{"guid", "block_number", "block_hash", "transaction_hash", "log_index", "timestamp"}
+
+// tokenColumns 带金额的实体在参与方之后的列：代币、原始金额（最小单位）和按精度换算的十进制金额，代币元数据未知时后三列为空
+var tokenColumns = []string{"token_address", "token_symbol", "token_decimals", "amount", "amount_decimal"}
+
+// Columns 返回实体的列，列的顺序是稳定的，新增列只会追加在末尾
+func Columns(entity string) ([]string, error) {
+	var columns []string
+	switch entity {
+	case EntityDeposits:
+		columns = append([]string{"sender"}, tokenColumns...)
+	case EntityWithdrawals:
+		columns = append([]string{"sender", "receiver"}, tokenColumns...)
+	case EntityGrants:
+		columns = append([]string{"granter"}, tokenColumns...)
+	case EntityManagerUpdates:
+		columns = []string{"withdraw_manager"}
+	default:
+		return nil, fmt.Errorf("invalid entity %q, expected one of %s", entity, strings.Join(Entities, ", "))
+	}
+	return append(append([]string{}, eventColumns...), columns...), nil
+}
+
+// ValidateFormat 校验导出格式
+func ValidateFormat(format string) error {
+	if format != FormatCSV && format != FormatNDJSON {
+		return fmt.Errorf("invalid format %q, expected csv or ndjson", format)
+	}
+	return nil
+}
+
+// ContentType 导出格式对应的 HTTP Content-Type
+func ContentType(format string) string {
+	if format == FormatNDJSON {
+		return "application/x-ndjson"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// ParseTime 解析导出的时间范围参数，接受 unix 秒、RFC 3339 或 UTC 日期（2006-01-02）。
+// 日期作为结束时间（end 为 true）时表示当天的最后一秒，这样 --from 2024-01-01 --to 2024-01-31 包含整个一月
+func ParseTime(value string, end bool) (uint64, error) {
+	if seconds, err := strconv.ParseUint(value, 10, 64); err == nil {
+		return seconds, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil && t.Unix() >= 0 {
+		return uint64(t.Unix()), nil
+	}
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil || t.Unix() < 0 {
+		return 0, fmt.Errorf("invalid time %q, expected unix seconds, RFC 3339 or YYYY-MM-DD", value)
+	}
+	if end {
+		t = t.Add(24*time.Hour - time.Second)
+	}
+	return uint64(t.Unix()), nil
+}
+
+// Options 导出参数，Filter 的时间和区块范围均包含边界
+type Options struct {
+	Entity string
+	Format string
+	Filter worker.EventFilter
+}
+
+// Export 按 (block_number, log_index) 升序把实体的记录逐行写入 w，返回写入的行数（不含 CSV 表头）。
+// 记录从数据库逐行读取，内存占用与导出的行数无关；ctx 结束时取消查询并停止导出
+func Export(ctx context.Context, db worker.EventExportView, tokens common2.TokensView, w io.Writer, opts Options) (int, error) {
+	columns, err := Columns(opts.Entity)
+	if err != nil {
+		return 0, err
+	}
+	if err := ValidateFormat(opts.Format); err != nil {
+		return 0, err
+	}
+
+	out := newRowWriter(w, opts.Format, columns)
+	if err := out.header(); err != nil {
+		return 0, err
+	}
+	cache := &tokenCache{view: tokens, tokens: make(map[common.Address]*common2.Token)}
+	count := 0
+	write := func(values []string) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		count++
+		return out.row(values)
+	}
+
+	switch opts.Entity {
+	case EntityDeposits:
+		err = db.StreamDepositTokens(ctx, opts.Filter, func(dt worker.DepositTokens) error {
+			values := eventValues(dt.GUID.String(), dt.BlockNumber, dt.BlockHash, dt.TransactionHash, dt.LogIndex, dt.Timestamp)
+			values = append(values, dt.Sender.String())
+			return writeTokenRow(write, cache, values, dt.TokenAddress, dt.Amount)
+		})
+	case EntityWithdrawals:
+		err = db.StreamWithdrawTokens(ctx, opts.Filter, func(wt worker.WithdrawTokens) error {
+			values := eventValues(wt.GUID.String(), wt.BlockNumber, wt.BlockHash, wt.TransactionHash, wt.LogIndex, wt.Timestamp)
+			values = append(values, wt.Sender.String(), wt.Receiver.String())
+			return writeTokenRow(write, cache, values, wt.TokenAddress, wt.Amount)
+		})
+	case EntityGrants:
+		err = db.StreamGrantRewardTokens(ctx, opts.Filter, func(gr worker.GrantRewardTokens) error {
+			values := eventValues(gr.GUID.String(), gr.BlockNumber, gr.BlockHash, gr.TransactionHash, gr.LogIndex, gr.Timestamp)
+			values = append(values, gr.Granter.String())
+			return writeTokenRow(write, cache, values, gr.TokenAddress, gr.Amount)
+		})
+	case EntityManagerUpdates:
+		err = db.StreamWithdrawManagerUpdates(ctx, opts.Filter, func(update worker.WithdrawManagerUpdate) error {
+			values := eventValues(update.GUID.String(), update.BlockNumber, update.BlockHash, update.TransactionHash, update.LogIndex, update.Timestamp)
+			return write(append(values, update.WithdrawManager.String()))
+		})
+	}
+	if err != nil {
+		return count, err
+	}
+	return count, out.flush()
+}
+
+// eventValues 所有实体共有的列的值，时间为 UTC 的 ISO 8601（RFC 3339）
+func eventValues(guid string, blockNumber *big.Int, blockHash common.Hash, transactionHash common.Hash, logIndex uint64, timestamp uint64) []string {
+	return []string{
+		guid,
+		blockNumber.String(),
+		blockHash.String(),
+		transactionHash.String(),
+		strconv.FormatUint(logIndex, 10),
+		time.Unix(int64(timestamp), 0).UTC().Format(time.RFC3339),
+	}
+}
+
+func writeTokenRow(write func([]string) error, cache *tokenCache, values []string, tokenAddress common.Address, amount *big.Int) error {
+	token, err := cache.get(tokenAddress)
+	if err != nil {
+		return err
+	}
+	if amount == nil {
+		amount = bigint.Zero
+	}
+	symbol, decimals, decimal := "", "", ""
+	if token != nil {
+		symbol = token.Symbol
+		decimals = strconv.Itoa(int(token.Decimals))
+		decimal = bigint.FormatUnits(amount, token.Decimals)
+	}
+	return write(append(values, tokenAddress.String(), symbol, decimals, amount.String(), decimal))
+}
+
+// tokenCache 导出过程中按需查询代币元数据，每个代币只查询一次
+type tokenCache struct {
+	view   common2.TokensView
+	tokens map[common.Address]*common2.Token
+}
+
+func (c *tokenCache) get(address common.Address) (*common2.Token, error) {
+	if token, ok := c.tokens[address]; ok {
+		return token, nil
+	}
+	tokens, err := c.view.TokensByAddresses([]common.Address{address})
+	if err != nil {
+		return nil, err
+	}
+	var token *common2.Token
+	if t, ok := tokens[address]; ok {
+		token = &t
+	}
+	c.tokens[address] = token
+	return token, nil
+}
+
+// rowWriter 把一行值写成 CSV 行或 NDJSON 对象，NDJSON 中空值写为 null，字段顺序与列顺序一致
+type rowWriter struct {
+	format  string
+	columns []string
+	csv     *csv.Writer
+	buf     *bufio.Writer
+}
+
+func newRowWriter(w io.Writer, format string, columns []string) *rowWriter {
+	if format == FormatCSV {
+		return &rowWriter{format: format, columns: columns, csv: csv.NewWriter(w)}
+	}
+	return &rowWriter{format: format, columns: columns, buf: bufio.NewWriter(w)}
+}
+
+func (rw *rowWriter) header() error {
+	if rw.csv != nil {
+		return rw.csv.Write(rw.columns)
+	}
+	return nil
+}
+
+func (rw *rowWriter) row(values []string) error {
+	if rw.csv != nil {
+		return rw.csv.Write(values)
+	}
+	rw.buf.WriteByte('{')
+	for i, column := range rw.columns {
+		if i > 0 {
+			rw.buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(column)
+		rw.buf.Write(key)
+		rw.buf.WriteByte(':')
+		if values[i] == "" {
+			rw.buf.WriteString("null")
+			continue
+		}
+		value, err := json.Marshal(values[i])
+		if err != nil {
+			return err
+		}
+		rw.buf.Write(value)
+	}
+	rw.buf.WriteString("}\n")
+	return nil
+}
+
+func (rw *rowWriter) flush() error {
+	if rw.csv != nil {
+		rw.csv.Flush()
+		return rw.csv.Error()
+	}
+	return rw.buf.Flush()
+}
@@ -0,0 +1,97 @@
+package export
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	common2 "github.com/Sandwichzzy/event-sync-go/database/common"
+	"github.com/Sandwichzzy/event-sync-go/database/worker"
+)
+
+type fakeExport struct {
+	worker.EventExportView
+	deposits []worker.DepositTokens
+}
+
+func (f fakeExport) StreamDepositTokens(ctx context.Context, filter worker.EventFilter, fn func(worker.DepositTokens) error) error {
+	for _, dt := range f.deposits {
+		if err := fn(dt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type fakeTokens struct {
+	common2.TokensView
+	calls  int
+	tokens map[common.Address]common2.Token
+}
+
+func (f *fakeTokens) TokensByAddresses(addresses []common.Address) (map[common.Address]common2.Token, error) {
+	f.calls++
+	result := make(map[common.Address]common2.Token)
+	for _, address := range addresses {
+		if token, ok := f.tokens[address]; ok {
+			result[address] = token
+		}
+	}
+	return result, nil
+}
+
+func TestExportDeposits(t *testing.T) {
+	usdt := common.HexToAddress("0x1")
+	unknown := common.HexToAddress("0x2")
+	guid := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	amount, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	db := fakeExport{deposits: []worker.DepositTokens{
+		{GUID: guid, BlockNumber: big.NewInt(10), LogIndex: 1, TokenAddress: usdt, Sender: common.HexToAddress("0x3"), Amount: amount, Timestamp: 1704067200},
+		{GUID: guid, BlockNumber: big.NewInt(11), LogIndex: 0, TokenAddress: unknown, Sender: common.HexToAddress("0x3"), Amount: big.NewInt(7), Timestamp: 1704067201},
+		{GUID: guid, BlockNumber: big.NewInt(12), LogIndex: 0, TokenAddress: usdt, Sender: common.HexToAddress("0x3"), Amount: big.NewInt(1), Timestamp: 1704067202},
+	}}
+	tokens := &fakeTokens{tokens: map[common.Address]common2.Token{usdt: {Address: usdt, Symbol: "USDT", Decimals: 6}}}
+
+	var csvOut strings.Builder
+	count, err := Export(context.Background(), db, tokens, &csvOut, Options{Entity: EntityDeposits, Format: FormatCSV})
+	require.NoError(t, err)
+	require.Equal(t, 3, count)
+	lines := strings.Split(strings.TrimSpace(csvOut.String()), "\n")
+	require.Len(t, lines, 4)
+	require.Equal(t, "guid,block_number,block_hash,transaction_hash,log_index,timestamp,sender,token_address,token_symbol,token_decimals,amount,amount_decimal", lines[0])
+	require.Contains(t, lines[1], ",2024-01-01T00:00:00Z,")
+	require.True(t, strings.HasSuffix(lines[1], ",USDT,6,123456789012345678901234567890,123456789012345678901234.56789"), lines[1])
+	require.True(t, strings.HasSuffix(lines[2], ",,,7,"), lines[2])
+	// 每个代币的元数据只查询一次
+	require.Equal(t, 2, tokens.calls)
+
+	var ndjsonOut strings.Builder
+	_, err = Export(context.Background(), db, tokens, &ndjsonOut, Options{Entity: EntityDeposits, Format: FormatNDJSON})
+	require.NoError(t, err)
+	lines = strings.Split(strings.TrimSpace(ndjsonOut.String()), "\n")
+	require.Len(t, lines, 3)
+	require.True(t, strings.HasPrefix(lines[1], `{"guid":"00000000-0000-0000-0000-000000000001","block_number":"11",`), lines[1])
+	require.True(t, strings.HasSuffix(lines[1], `"token_symbol":null,"token_decimals":null,"amount":"7","amount_decimal":null}`), lines[1])
+
+	_, err = Export(context.Background(), db, tokens, &ndjsonOut, Options{Entity: "balances", Format: FormatCSV})
+	require.Error(t, err)
+}
+
+func TestParseTime(t *testing.T) {
+	from, err := ParseTime("2024-01-01", false)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1704067200), from)
+	to, err := ParseTime("2024-01-01", true)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1704153599), to)
+	to, err = ParseTime("2024-01-01T12:00:00Z", true)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1704110400), to)
+	_, err = ParseTime("yesterday", false)
+	require.Error(t, err)
+}
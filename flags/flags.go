@@ -15,10 +15,30 @@ func prefixEnvVars(name string) []string {
 var (
 	MigrationsFlag = &cli.StringFlag{
 		Name:    "migrations-dir",
-		Value:   "./migrations",
-		Usage:   "path to migrations folder",
+		Usage:   "path to migrations folder, the migrations embedded in the binary are used when empty",
 		EnvVars: prefixEnvVars("MIGRATIONS_DIR"),
 	}
+	OutboxSinksFlag = &cli.StringSliceFlag{
+		Name:    "outbox-sinks",
+		Usage:   "outbox sinks the indexer relays events to: stdout, file:///path, kafka://broker/topic, nats://host:port/subject, redis://host:port/0?stream=name",
+		EnvVars: prefixEnvVars("OUTBOX_SINKS"),
+	}
+	WebhookMaxAttemptsFlag = &cli.IntFlag{
+		Name:    "webhook-max-attempts",
+		Usage:   "attempts per webhook delivery before it is moved to the dead letter state",
+		EnvVars: prefixEnvVars("WEBHOOK_MAX_ATTEMPTS"),
+		Value:   8,
+	}
+	WebhookAllowPrivateTargetsFlag = &cli.BoolFlag{
+		Name:    "webhook-allow-private-targets",
+		Usage:   "allow webhook urls that resolve to loopback, private or link-local addresses, for local development only",
+		EnvVars: prefixEnvVars("WEBHOOK_ALLOW_PRIVATE_TARGETS"),
+	}
+	AutoMigrateFlag = &cli.BoolFlag{
+		Name:    "auto-migrate",
+		Usage:   "apply pending database migrations before starting the indexer",
+		EnvVars: prefixEnvVars("AUTO_MIGRATE"),
+	}
 
 	ChainIdFlag = &cli.UintFlag{
 		Name:     "chain-id",
@@ -55,6 +75,34 @@ var (
 		EnvVars: prefixEnvVars("LOOP_INTERVAL"),
 		Value:   time.Second * 5,
 	}
+	// 链上对账间隔
+	ReconcileIntervalFlag = &cli.DurationFlag{
+		Name:    "reconcile-interval",
+		Usage:   "The interval of on-chain reconciliation",
+		EnvVars: prefixEnvVars("RECONCILE_INTERVAL"),
+		Value:   time.Minute,
+	}
+	// 通用事件解码使用的 ABI 目录
+	AbiDirFlag = &cli.StringFlag{
+		Name:    "abi-dir",
+		Value:   "./abis",
+		Usage:   "path to the folder of ABI json files used for generic event decoding",
+		EnvVars: prefixEnvVars("ABI_DIR"),
+	}
+	// 启用的合约事件处理器
+	ProcessorsFlag = &cli.StringSliceFlag{
+		Name:    "processors",
+		Value:   cli.NewStringSlice("treasure-manager", "decoded-events"),
+		Usage:   "comma separated list of enabled contract event processors",
+		EnvVars: prefixEnvVars("PROCESSORS"),
+	}
+	// 合约地址与处理器的绑定，同步器只拉取这些合约的日志
+	ContractsFlag = &cli.StringSliceFlag{
+		Name:    "contracts",
+		Value:   cli.NewStringSlice("treasure-manager=0x388fF618Ca5c1b8F28D4E845B431Ca3D4200140e"),
+		Usage:   "comma separated list of <processor>=<address>[@<abi file>] contract bindings",
+		EnvVars: prefixEnvVars("CONTRACTS"),
+	}
 	BlocksStepFlag = &cli.UintFlag{
 		Name:    "blocks-step",
 		Usage:   "Scanner blocks step",
@@ -120,6 +168,92 @@ var (
 		EnvVars: prefixEnvVars("GRPC_PORT"),
 		Value:   8987,
 	}
+	GrpcApiKeysFlag = &cli.StringSliceFlag{
+		Name:    "grpc-api-keys",
+		Usage:   "api keys accepted by the grpc server in the x-api-key metadata, authentication is disabled when empty",
+		EnvVars: prefixEnvVars("GRPC_API_KEYS"),
+	}
+	GrpcRequestTimeoutFlag = &cli.DurationFlag{
+		Name:    "grpc-request-timeout",
+		Usage:   "deadline applied to unary grpc requests that do not carry a shorter one",
+		EnvVars: prefixEnvVars("GRPC_REQUEST_TIMEOUT"),
+		Value:   30 * time.Second,
+	}
+	GrpcTlsCertFlag = &cli.StringFlag{
+		Name:    "grpc-tls-cert",
+		Usage:   "certificate file of the grpc server, tls is enabled when set together with grpc-tls-key",
+		EnvVars: prefixEnvVars("GRPC_TLS_CERT"),
+	}
+	GrpcTlsKeyFlag = &cli.StringFlag{
+		Name:    "grpc-tls-key",
+		Usage:   "private key file of the grpc server",
+		EnvVars: prefixEnvVars("GRPC_TLS_KEY"),
+	}
+	GrpcTlsClientCAFlag = &cli.StringFlag{
+		Name:    "grpc-tls-client-ca",
+		Usage:   "ca file used to verify grpc client certificates, enables mutual tls when set",
+		EnvVars: prefixEnvVars("GRPC_TLS_CLIENT_CA"),
+	}
+	// ApiCacheEnableFlag API 响应缓存 flags
+	ApiCacheEnableFlag = &cli.BoolFlag{
+		Name:    "api-cache-enable",
+		Usage:   "cache api responses, keyed on the normalized query and versioned by the indexed height",
+		EnvVars: prefixEnvVars("API_CACHE_ENABLE"),
+	}
+	ApiCacheRedisFlag = &cli.StringFlag{
+		Name:    "api-cache-redis",
+		Usage:   "redis url of the api response cache, e.g. redis://127.0.0.1:6379/0, an in-memory lru is used when empty",
+		EnvVars: prefixEnvVars("API_CACHE_REDIS"),
+	}
+	ApiCacheSizeFlag = &cli.IntFlag{
+		Name:    "api-cache-size",
+		Usage:   "maximum number of responses kept by the in-memory lru",
+		EnvVars: prefixEnvVars("API_CACHE_SIZE"),
+		Value:   10000,
+	}
+	ApiCacheTTLFlag = &cli.DurationFlag{
+		Name:    "api-cache-ttl",
+		Usage:   "ttl of cached responses that may change when the indexed height advances",
+		EnvVars: prefixEnvVars("API_CACHE_TTL"),
+		Value:   30 * time.Second,
+	}
+	ApiCacheFinalizedTTLFlag = &cli.DurationFlag{
+		Name:    "api-cache-finalized-ttl",
+		Usage:   "ttl of cached responses whose block or time range ends at or below the indexed height",
+		EnvVars: prefixEnvVars("API_CACHE_FINALIZED_TTL"),
+		Value:   time.Hour,
+	}
+	// ApiKeyRateLimitFlag HTTP API key 和限流 flags，key 自身配置的限额为 0 时使用这里的默认值
+	ApiKeyRateLimitFlag = &cli.Float64Flag{
+		Name:    "api-key-rate-limit",
+		Usage:   "default requests per second allowed for an api key, 0 means unlimited",
+		EnvVars: prefixEnvVars("API_KEY_RATE_LIMIT"),
+		Value:   10,
+	}
+	ApiKeyBurstFlag = &cli.IntFlag{
+		Name:    "api-key-burst",
+		Usage:   "default token bucket size of an api key",
+		EnvVars: prefixEnvVars("API_KEY_BURST"),
+		Value:   20,
+	}
+	ApiKeyDailyQuotaFlag = &cli.Int64Flag{
+		Name:    "api-key-daily-quota",
+		Usage:   "default number of requests an api key may make per UTC day, 0 means unlimited; counts are flushed every second, so the quota may be exceeded slightly across instances",
+		EnvVars: prefixEnvVars("API_KEY_DAILY_QUOTA"),
+		Value:   100000,
+	}
+	ApiAnonymousRateLimitFlag = &cli.Float64Flag{
+		Name:    "api-anonymous-rate-limit",
+		Usage:   "requests per second allowed per client address without an api key, 0 requires an api key",
+		EnvVars: prefixEnvVars("API_ANONYMOUS_RATE_LIMIT"),
+		Value:   1,
+	}
+	ApiAnonymousBurstFlag = &cli.IntFlag{
+		Name:    "api-anonymous-burst",
+		Usage:   "token bucket size per client address without an api key",
+		EnvVars: prefixEnvVars("API_ANONYMOUS_BURST"),
+		Value:   5,
+	}
 
 	SlaveDbEnableFlag = &cli.BoolFlag{
 		Name:     "slave-db-enable",
@@ -157,7 +291,6 @@ var (
 )
 
 var requiredFlags = []cli.Flag{
-	MigrationsFlag,
 	ChainIdFlag,
 	ChainRpcFlag,
 	MasterDbHostFlag,
@@ -173,6 +306,8 @@ var requiredFlags = []cli.Flag{
 }
 
 var optionalFlags = []cli.Flag{
+	MigrationsFlag,
+	AutoMigrateFlag,
 	StartingHeightFlag,
 	ConfirmationsFlag,
 	SlaveDbHostFlag,
@@ -182,6 +317,28 @@ var optionalFlags = []cli.Flag{
 	SlaveDbNameFlag,
 	GrpcHostFlag,
 	GrpcPortFlag,
+	GrpcApiKeysFlag,
+	GrpcRequestTimeoutFlag,
+	GrpcTlsCertFlag,
+	GrpcTlsKeyFlag,
+	GrpcTlsClientCAFlag,
+	ReconcileIntervalFlag,
+	AbiDirFlag,
+	ProcessorsFlag,
+	ContractsFlag,
+	OutboxSinksFlag,
+	WebhookMaxAttemptsFlag,
+	WebhookAllowPrivateTargetsFlag,
+	ApiCacheEnableFlag,
+	ApiCacheRedisFlag,
+	ApiCacheSizeFlag,
+	ApiCacheTTLFlag,
+	ApiCacheFinalizedTTLFlag,
+	ApiKeyRateLimitFlag,
+	ApiKeyBurstFlag,
+	ApiKeyDailyQuotaFlag,
+	ApiAnonymousRateLimitFlag,
+	ApiAnonymousBurstFlag,
 }
 
 var Flags []cli.Flag
@@ -0,0 +1,9 @@
+-- 回滚 0001：按依赖的相反顺序删除基础表和 UINT256 域
+DROP TABLE IF EXISTS withdraw_manager_update;
+DROP TABLE IF EXISTS grant_reward_tokens;
+DROP TABLE IF EXISTS withdraw_tokens;
+DROP TABLE IF EXISTS deposit_tokens;
+DROP TABLE IF EXISTS contract_events;
+DROP TABLE IF EXISTS event_blocks;
+DROP TABLE IF EXISTS block_headers;
+DROP DOMAIN IF EXISTS UINT256;
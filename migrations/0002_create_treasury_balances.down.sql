@@ -0,0 +1,3 @@
+-- 回滚 0002
+DROP TABLE IF EXISTS reconcile_mismatches;
+DROP TABLE IF EXISTS treasury_balances;
@@ -0,0 +1,34 @@
+-- treasury_balances表：
+-- 由 DepositToken / WithdrawToken 事件增量计算的每个代币余额快照，每个有余额变化的区块一条记录。
+-- balance 使用 NUMERIC（允许为负），从非合约创建高度开始同步时投影值可能小于0，由对账任务发现差异。
+-- reconciled 标记该快照是否已经与链上 tokenBalances(token) 对账。
+CREATE TABLE IF NOT EXISTS treasury_balances (
+                                                 guid                          VARCHAR PRIMARY KEY,
+                                                 token_address                 VARCHAR NOT NULL,
+                                                 block_number                  UINT256 NOT NULL,
+                                                 block_hash                    VARCHAR NOT NULL,
+                                                 balance                       NUMERIC NOT NULL,
+                                                 reconciled                    BOOLEAN NOT NULL DEFAULT FALSE,
+                                                 timestamp                     INTEGER NOT NULL CHECK (timestamp > 0),
+                                                 UNIQUE (token_address, block_number)
+);
+CREATE INDEX IF NOT EXISTS treasury_balances_token_address ON treasury_balances(token_address);
+CREATE INDEX IF NOT EXISTS treasury_balances_block_number ON treasury_balances(block_number);
+
+-- reconcile_mismatches表：
+-- 记录本地投影值与链上合约读取值不一致的情况，带上区块上下文。
+-- kind 区分对账类型（如 treasury_balance），account 为与该对账相关的用户地址（没有则为空字符串）。
+CREATE TABLE IF NOT EXISTS reconcile_mismatches (
+                                                    guid                          VARCHAR PRIMARY KEY,
+                                                    kind                          VARCHAR NOT NULL,
+                                                    token_address                 VARCHAR NOT NULL,
+                                                    account                       VARCHAR NOT NULL DEFAULT '',
+                                                    block_number                  UINT256 NOT NULL,
+                                                    block_hash                    VARCHAR NOT NULL,
+                                                    projected                     NUMERIC NOT NULL,
+                                                    on_chain                      NUMERIC NOT NULL,
+                                                    timestamp                     INTEGER NOT NULL CHECK (timestamp > 0)
+);
+CREATE INDEX IF NOT EXISTS reconcile_mismatches_kind ON reconcile_mismatches(kind);
+CREATE INDEX IF NOT EXISTS reconcile_mismatches_token_address ON reconcile_mismatches(token_address);
+CREATE INDEX IF NOT EXISTS reconcile_mismatches_block_number ON reconcile_mismatches(block_number);
@@ -0,0 +1,3 @@
+-- 回滚 0003
+DROP TABLE IF EXISTS reward_balances;
+DROP TABLE IF EXISTS reward_ledger_entries;
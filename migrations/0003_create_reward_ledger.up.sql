@@ -0,0 +1,33 @@
+-- reward_ledger_entries表：
+-- 每个用户、每个代币的奖励流水。grant 来自 GrantRewardTokenAmount 事件，
+-- claim 由对账任务根据链上 userRewardAmounts 的减少量推导得出（合约领取时没有单独的事件）。
+CREATE TABLE IF NOT EXISTS reward_ledger_entries (
+                                                     guid                          VARCHAR PRIMARY KEY,
+                                                     user_address                  VARCHAR NOT NULL,
+                                                     token_address                 VARCHAR NOT NULL,
+                                                     entry_type                    VARCHAR NOT NULL,
+                                                     amount                        UINT256 NOT NULL,
+                                                     block_number                  UINT256 NOT NULL,
+                                                     block_hash                    VARCHAR NOT NULL,
+                                                     timestamp                     INTEGER NOT NULL CHECK (timestamp > 0)
+);
+CREATE INDEX IF NOT EXISTS reward_ledger_entries_user_address ON reward_ledger_entries(user_address);
+CREATE INDEX IF NOT EXISTS reward_ledger_entries_token_address ON reward_ledger_entries(token_address);
+CREATE INDEX IF NOT EXISTS reward_ledger_entries_block_number ON reward_ledger_entries(block_number);
+
+-- reward_balances表：
+-- 每个用户、每个代币的奖励汇总：累计发放 granted、累计领取 claimed、当前可领取 claimable。
+-- reconciled_block_number 为最近一次与链上对账的区块高度。
+CREATE TABLE IF NOT EXISTS reward_balances (
+                                               user_address                  VARCHAR NOT NULL,
+                                               token_address                 VARCHAR NOT NULL,
+                                               granted                       UINT256 NOT NULL,
+                                               claimed                       UINT256 NOT NULL,
+                                               claimable                     UINT256 NOT NULL,
+                                               block_number                  UINT256 NOT NULL,
+                                               reconciled_block_number       UINT256 NOT NULL DEFAULT 0,
+                                               timestamp                     INTEGER NOT NULL CHECK (timestamp > 0),
+                                               PRIMARY KEY (user_address, token_address)
+);
+CREATE INDEX IF NOT EXISTS reward_balances_token_address ON reward_balances(token_address);
+CREATE INDEX IF NOT EXISTS reward_balances_reconciled_block_number ON reward_balances(reconciled_block_number);
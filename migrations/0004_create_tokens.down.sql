@@ -0,0 +1,2 @@
+-- 回滚 0004
+DROP TABLE IF EXISTS tokens;
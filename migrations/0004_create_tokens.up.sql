@@ -0,0 +1,11 @@
+-- tokens表：
+-- ERC-20 代币元数据缓存（name / symbol / decimals），第一次在事件中见到某个 token_address 时通过 eth_call 读取。
+-- is_native 标记合约 ethAddress() 返回的 ETH 占位地址，它不是合约，元数据固定为 Ether / ETH / 18。
+CREATE TABLE IF NOT EXISTS tokens (
+                                      address                       VARCHAR PRIMARY KEY,
+                                      name                          VARCHAR NOT NULL DEFAULT '',
+                                      symbol                        VARCHAR NOT NULL DEFAULT '',
+                                      decimals                      SMALLINT NOT NULL CHECK (decimals >= 0),
+                                      is_native                     BOOLEAN NOT NULL DEFAULT FALSE,
+                                      timestamp                     INTEGER NOT NULL CHECK (timestamp > 0)
+);
@@ -0,0 +1,2 @@
+-- 回滚 0005
+DROP TABLE IF EXISTS decoded_events;
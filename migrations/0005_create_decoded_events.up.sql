@@ -0,0 +1,24 @@
+-- decoded_events表：
+-- 根据 ABI 目录（如 abis/）中加载的 ABI，把 contract_events 中的原始日志通用解码后的结果。
+-- args 为参数名到值的 JSONB：整数为 JSON 数字（不丢精度），address/bytes 为小写 hex 字符串；arg_types 为参数名到 Solidity 类型。
+-- 可以直接用 SQL 查询新合约的事件，例如 args->>'sender'、(args->>'amount')::NUMERIC。
+-- contract_event_guid 对应 contract_events.guid，区块被删除（回滚）时级联删除。
+CREATE TABLE IF NOT EXISTS decoded_events (
+                                              guid                          VARCHAR PRIMARY KEY,
+                                              contract_event_guid           VARCHAR NOT NULL UNIQUE REFERENCES contract_events(guid) ON DELETE CASCADE,
+                                              contract_address              VARCHAR NOT NULL,
+                                              contract_name                 VARCHAR NOT NULL,
+                                              event_name                    VARCHAR NOT NULL,
+                                              event_signature               VARCHAR NOT NULL,
+                                              block_number                  UINT256 NOT NULL,
+                                              block_hash                    VARCHAR NOT NULL,
+                                              transaction_hash              VARCHAR NOT NULL,
+                                              log_index                     INTEGER NOT NULL,
+                                              args                          JSONB NOT NULL,
+                                              arg_types                     JSONB NOT NULL,
+                                              timestamp                     INTEGER NOT NULL CHECK (timestamp > 0)
+);
+CREATE INDEX IF NOT EXISTS decoded_events_contract_address ON decoded_events(contract_address);
+CREATE INDEX IF NOT EXISTS decoded_events_event_name ON decoded_events(event_name);
+CREATE INDEX IF NOT EXISTS decoded_events_block_number ON decoded_events(block_number);
+CREATE INDEX IF NOT EXISTS decoded_events_args ON decoded_events USING GIN (args);
@@ -0,0 +1,25 @@
+-- 回滚 0006：删除事件位置列和索引。guid 仍然是 v2 的确定性 GUID，timestamp 仍然是区块时间。
+
+DROP INDEX IF EXISTS deposit_tokens_transaction_hash;
+DROP INDEX IF EXISTS deposit_tokens_block_hash_log_index;
+ALTER TABLE deposit_tokens DROP COLUMN IF EXISTS log_index;
+ALTER TABLE deposit_tokens DROP COLUMN IF EXISTS transaction_hash;
+ALTER TABLE deposit_tokens DROP COLUMN IF EXISTS block_hash;
+
+DROP INDEX IF EXISTS withdraw_tokens_transaction_hash;
+DROP INDEX IF EXISTS withdraw_tokens_block_hash_log_index;
+ALTER TABLE withdraw_tokens DROP COLUMN IF EXISTS log_index;
+ALTER TABLE withdraw_tokens DROP COLUMN IF EXISTS transaction_hash;
+ALTER TABLE withdraw_tokens DROP COLUMN IF EXISTS block_hash;
+
+DROP INDEX IF EXISTS grant_reward_tokens_transaction_hash;
+DROP INDEX IF EXISTS grant_reward_tokens_block_hash_log_index;
+ALTER TABLE grant_reward_tokens DROP COLUMN IF EXISTS log_index;
+ALTER TABLE grant_reward_tokens DROP COLUMN IF EXISTS transaction_hash;
+ALTER TABLE grant_reward_tokens DROP COLUMN IF EXISTS block_hash;
+
+DROP INDEX IF EXISTS withdraw_manager_update_transaction_hash;
+DROP INDEX IF EXISTS withdraw_manager_update_block_hash_log_index;
+ALTER TABLE withdraw_manager_update DROP COLUMN IF EXISTS log_index;
+ALTER TABLE withdraw_manager_update DROP COLUMN IF EXISTS transaction_hash;
+ALTER TABLE withdraw_manager_update DROP COLUMN IF EXISTS block_hash;
@@ -0,0 +1,30 @@
+-- worker 表 schema v2：
+-- 为 deposit_tokens / withdraw_tokens / grant_reward_tokens / withdraw_manager_update 增加事件在链上的位置：
+-- block_hash、transaction_hash、log_index，timestamp 改为区块时间，guid 由 (block_hash, log_index) 确定性生成。
+-- 写入按 (block_hash, log_index) 做 upsert，重复处理同一区块范围不会产生重复记录。
+-- v1 的旧记录（block_hash 为 NULL）无法在 SQL 里关联回原始日志，由 `event-sync migrate` 在执行完 SQL 之后
+-- 从 contract_events 重新解析生成 v2 记录并删除旧记录（见 contracts.MigrateWorkerRowsV2）。
+
+ALTER TABLE deposit_tokens ADD COLUMN IF NOT EXISTS block_hash VARCHAR;
+ALTER TABLE deposit_tokens ADD COLUMN IF NOT EXISTS transaction_hash VARCHAR;
+ALTER TABLE deposit_tokens ADD COLUMN IF NOT EXISTS log_index INTEGER;
+CREATE UNIQUE INDEX IF NOT EXISTS deposit_tokens_block_hash_log_index ON deposit_tokens(block_hash, log_index);
+CREATE INDEX IF NOT EXISTS deposit_tokens_transaction_hash ON deposit_tokens(transaction_hash);
+
+ALTER TABLE withdraw_tokens ADD COLUMN IF NOT EXISTS block_hash VARCHAR;
+ALTER TABLE withdraw_tokens ADD COLUMN IF NOT EXISTS transaction_hash VARCHAR;
+ALTER TABLE withdraw_tokens ADD COLUMN IF NOT EXISTS log_index INTEGER;
+CREATE UNIQUE INDEX IF NOT EXISTS withdraw_tokens_block_hash_log_index ON withdraw_tokens(block_hash, log_index);
+CREATE INDEX IF NOT EXISTS withdraw_tokens_transaction_hash ON withdraw_tokens(transaction_hash);
+
+ALTER TABLE grant_reward_tokens ADD COLUMN IF NOT EXISTS block_hash VARCHAR;
+ALTER TABLE grant_reward_tokens ADD COLUMN IF NOT EXISTS transaction_hash VARCHAR;
+ALTER TABLE grant_reward_tokens ADD COLUMN IF NOT EXISTS log_index INTEGER;
+CREATE UNIQUE INDEX IF NOT EXISTS grant_reward_tokens_block_hash_log_index ON grant_reward_tokens(block_hash, log_index);
+CREATE INDEX IF NOT EXISTS grant_reward_tokens_transaction_hash ON grant_reward_tokens(transaction_hash);
+
+ALTER TABLE withdraw_manager_update ADD COLUMN IF NOT EXISTS block_hash VARCHAR;
+ALTER TABLE withdraw_manager_update ADD COLUMN IF NOT EXISTS transaction_hash VARCHAR;
+ALTER TABLE withdraw_manager_update ADD COLUMN IF NOT EXISTS log_index INTEGER;
+CREATE UNIQUE INDEX IF NOT EXISTS withdraw_manager_update_block_hash_log_index ON withdraw_manager_update(block_hash, log_index);
+CREATE INDEX IF NOT EXISTS withdraw_manager_update_transaction_hash ON withdraw_manager_update(transaction_hash);
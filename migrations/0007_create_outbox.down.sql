@@ -0,0 +1,5 @@
+-- 回滚 0007
+DROP TRIGGER IF EXISTS contract_events_outbox_removed ON contract_events;
+DROP FUNCTION IF EXISTS outbox_contract_event_removed();
+DROP TABLE IF EXISTS outbox_offsets;
+DROP TABLE IF EXISTS outbox;
@@ -0,0 +1,45 @@
+-- outbox表：
+-- 事务性发件箱。EventProcessor 在写入 worker 表的同一个事务内为每个 TreasureManager 事件写入一条 added 消息，
+-- 由 relay 至少一次地投递到 Kafka / NATS / Redis Streams / 文件等 sink。
+-- id 全局递增，同一合约内按 id 顺序投递即为事件顺序；action 为 added 或 removed（回滚、重建时的补偿消息）。
+CREATE TABLE IF NOT EXISTS outbox (
+                                      id                            BIGSERIAL PRIMARY KEY,
+                                      contract_address              VARCHAR NOT NULL,
+                                      event_type                    VARCHAR NOT NULL,
+                                      action                        VARCHAR NOT NULL,
+                                      block_number                  UINT256 NOT NULL,
+                                      block_hash                    VARCHAR NOT NULL,
+                                      transaction_hash              VARCHAR NOT NULL,
+                                      log_index                     INTEGER NOT NULL,
+                                      payload                       JSONB NOT NULL,
+                                      timestamp                     INTEGER NOT NULL CHECK (timestamp > 0)
+);
+CREATE INDEX IF NOT EXISTS outbox_contract_address_id ON outbox(contract_address, id);
+CREATE INDEX IF NOT EXISTS outbox_event_position ON outbox(block_hash, log_index);
+CREATE INDEX IF NOT EXISTS outbox_block_number ON outbox(block_number);
+
+-- outbox_offsets表：
+-- 每个 sink、每个合约已经投递成功的最大 outbox.id。
+CREATE TABLE IF NOT EXISTS outbox_offsets (
+                                              sink                          VARCHAR NOT NULL,
+                                              contract_address              VARCHAR NOT NULL,
+                                              last_id                       BIGINT NOT NULL,
+                                              updated_at                    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                                              PRIMARY KEY (sink, contract_address)
+);
+
+-- 区块回滚时 block_headers 级联删除 contract_events，为已经发出 added 消息的事件补发 removed 消息
+CREATE OR REPLACE FUNCTION outbox_contract_event_removed() RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO outbox (contract_address, event_type, action, block_number, block_hash, transaction_hash, log_index, payload, timestamp)
+    SELECT o.contract_address, o.event_type, 'removed', o.block_number, o.block_hash, o.transaction_hash, o.log_index, o.payload, o.timestamp
+    FROM outbox o
+    WHERE o.id = (SELECT MAX(id) FROM outbox WHERE block_hash = OLD.block_hash AND log_index = OLD.log_index)
+      AND o.action = 'added';
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS contract_events_outbox_removed ON contract_events;
+CREATE TRIGGER contract_events_outbox_removed AFTER DELETE ON contract_events
+    FOR EACH ROW EXECUTE FUNCTION outbox_contract_event_removed();
@@ -0,0 +1,3 @@
+-- 回滚 0008
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhook_subscriptions;
@@ -0,0 +1,35 @@
+-- webhook_subscriptions表：
+-- 合作方通过 API 注册的 HTTP 回调。event_types 为空数组时接收所有事件类型，
+-- token_address / address 为空时不过滤；address 匹配事件的 sender / receiver / granter。
+-- secret 用于对投递内容做 HMAC-SHA256 签名。
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+                                                     guid                          VARCHAR PRIMARY KEY,
+                                                     url                           VARCHAR NOT NULL,
+                                                     secret                        VARCHAR NOT NULL,
+                                                     event_types                   JSONB NOT NULL DEFAULT '[]',
+                                                     token_address                 VARCHAR,
+                                                     address                       VARCHAR,
+                                                     active                        BOOLEAN NOT NULL DEFAULT TRUE,
+                                                     created_at                    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                                                     updated_at                    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- webhook_deliveries表：
+-- 每条 outbox 消息对每个匹配订阅的一次投递。status 为 pending / delivered / dead，
+-- 超过最大重试次数仍失败的投递进入 dead（死信），可以通过 API 重新投递。
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+                                                  guid                          VARCHAR PRIMARY KEY,
+                                                  subscription_guid             VARCHAR NOT NULL REFERENCES webhook_subscriptions(guid) ON DELETE CASCADE,
+                                                  outbox_id                     BIGINT NOT NULL,
+                                                  event_type                    VARCHAR NOT NULL,
+                                                  action                        VARCHAR NOT NULL,
+                                                  payload                       JSONB NOT NULL,
+                                                  status                        VARCHAR NOT NULL,
+                                                  attempts                      INTEGER NOT NULL DEFAULT 0,
+                                                  last_error                    TEXT NOT NULL DEFAULT '',
+                                                  created_at                    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                                                  updated_at                    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                                                  UNIQUE (subscription_guid, outbox_id)
+);
+CREATE INDEX IF NOT EXISTS webhook_deliveries_status ON webhook_deliveries(status, outbox_id);
+CREATE INDEX IF NOT EXISTS webhook_deliveries_subscription ON webhook_deliveries(subscription_guid, outbox_id);
@@ -0,0 +1,9 @@
+-- 回滚 0009
+DROP INDEX IF EXISTS deposit_tokens_block_number_log_index;
+DROP INDEX IF EXISTS deposit_tokens_token_address;
+DROP INDEX IF EXISTS withdraw_tokens_block_number_log_index;
+DROP INDEX IF EXISTS withdraw_tokens_sender;
+DROP INDEX IF EXISTS withdraw_tokens_receiver;
+DROP INDEX IF EXISTS grant_reward_tokens_block_number_log_index;
+DROP INDEX IF EXISTS grant_reward_tokens_granter;
+DROP INDEX IF EXISTS withdraw_manager_update_block_number_log_index;
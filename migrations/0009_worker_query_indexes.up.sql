@@ -0,0 +1,14 @@
+-- worker 表查询索引：
+-- 列表按 (block_number, log_index) 排序，并可以按代币、发起方、接收方、区块范围过滤。
+
+CREATE INDEX IF NOT EXISTS deposit_tokens_block_number_log_index ON deposit_tokens(block_number, log_index);
+CREATE INDEX IF NOT EXISTS deposit_tokens_token_address ON deposit_tokens(token_address);
+
+CREATE INDEX IF NOT EXISTS withdraw_tokens_block_number_log_index ON withdraw_tokens(block_number, log_index);
+CREATE INDEX IF NOT EXISTS withdraw_tokens_sender ON withdraw_tokens(sender);
+CREATE INDEX IF NOT EXISTS withdraw_tokens_receiver ON withdraw_tokens(receiver);
+
+CREATE INDEX IF NOT EXISTS grant_reward_tokens_block_number_log_index ON grant_reward_tokens(block_number, log_index);
+CREATE INDEX IF NOT EXISTS grant_reward_tokens_granter ON grant_reward_tokens(granter);
+
+CREATE INDEX IF NOT EXISTS withdraw_manager_update_block_number_log_index ON withdraw_manager_update(block_number, log_index);
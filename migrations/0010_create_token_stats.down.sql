@@ -0,0 +1,3 @@
+-- 回滚 0010
+DROP TABLE IF EXISTS granter_stats;
+DROP TABLE IF EXISTS token_stats;
@@ -0,0 +1,57 @@
+-- token_stats 表：
+-- 每个代币按小时 / 天（bucket_size 为 hour / day，按 UTC 对齐）汇总的充值、提现、奖励发放笔数和金额，以及充值的独立地址数。
+-- bucket_start 为桶的起始时间（unix 秒）。由 EventProcessor 在写入 worker 表的同一事务内按受影响的桶从 worker 表重新计算，
+-- 重建区间（reindex）时同样重新计算。链重组只通过 reindex 处理：直接删除 block_headers 不会级联删除 worker 记录，
+-- 也不会重新计算统计，此时需要对受影响的区间执行 reindex。
+CREATE TABLE IF NOT EXISTS token_stats (
+                                           bucket_size                   VARCHAR NOT NULL,
+                                           bucket_start                  INTEGER NOT NULL,
+                                           token_address                 VARCHAR NOT NULL,
+                                           deposit_count                 INTEGER NOT NULL DEFAULT 0,
+                                           deposit_volume                NUMERIC NOT NULL DEFAULT 0,
+                                           unique_depositors             INTEGER NOT NULL DEFAULT 0,
+                                           withdraw_count                INTEGER NOT NULL DEFAULT 0,
+                                           withdraw_volume               NUMERIC NOT NULL DEFAULT 0,
+                                           grant_count                   INTEGER NOT NULL DEFAULT 0,
+                                           grant_volume                  NUMERIC NOT NULL DEFAULT 0,
+                                           PRIMARY KEY (bucket_size, bucket_start, token_address)
+);
+
+-- granter_stats 表：每个发放者在每个桶内每个代币的奖励发放笔数和金额，用于统计发放最多的地址
+CREATE TABLE IF NOT EXISTS granter_stats (
+                                             bucket_size                   VARCHAR NOT NULL,
+                                             bucket_start                  INTEGER NOT NULL,
+                                             token_address                 VARCHAR NOT NULL,
+                                             granter                       VARCHAR NOT NULL,
+                                             grant_count                   INTEGER NOT NULL DEFAULT 0,
+                                             grant_volume                  NUMERIC NOT NULL DEFAULT 0,
+                                             PRIMARY KEY (bucket_size, bucket_start, token_address, granter)
+);
+
+-- 回填已经写入的 worker 记录
+INSERT INTO token_stats (bucket_size, bucket_start, token_address, deposit_count, deposit_volume, unique_depositors, withdraw_count, withdraw_volume, grant_count, grant_volume)
+SELECT b.bucket_size, s.bucket_start, s.token_address,
+       SUM(s.deposit_count), SUM(s.deposit_volume), SUM(s.unique_depositors),
+       SUM(s.withdraw_count), SUM(s.withdraw_volume), SUM(s.grant_count), SUM(s.grant_volume)
+FROM (VALUES ('hour', 3600), ('day', 86400)) AS b(bucket_size, seconds)
+CROSS JOIN LATERAL (
+    SELECT timestamp - timestamp % b.seconds AS bucket_start, token_address,
+           COUNT(*) AS deposit_count, COALESCE(SUM(amount), 0) AS deposit_volume, COUNT(DISTINCT sender) AS unique_depositors,
+           0 AS withdraw_count, 0 AS withdraw_volume, 0 AS grant_count, 0 AS grant_volume
+    FROM deposit_tokens GROUP BY 1, 2
+    UNION ALL
+    SELECT timestamp - timestamp % b.seconds, token_address, 0, 0, 0, COUNT(*), COALESCE(SUM(amount), 0), 0, 0
+    FROM withdraw_tokens GROUP BY 1, 2
+    UNION ALL
+    SELECT timestamp - timestamp % b.seconds, token_address, 0, 0, 0, 0, 0, COUNT(*), COALESCE(SUM(amount), 0)
+    FROM grant_reward_tokens GROUP BY 1, 2
+) AS s
+GROUP BY b.bucket_size, s.bucket_start, s.token_address
+ON CONFLICT DO NOTHING;
+
+INSERT INTO granter_stats (bucket_size, bucket_start, token_address, granter, grant_count, grant_volume)
+SELECT b.bucket_size, timestamp - timestamp % b.seconds, token_address, granter, COUNT(*), COALESCE(SUM(amount), 0)
+FROM (VALUES ('hour', 3600), ('day', 86400)) AS b(bucket_size, seconds)
+CROSS JOIN grant_reward_tokens
+GROUP BY 1, 2, 3, 4
+ON CONFLICT DO NOTHING;
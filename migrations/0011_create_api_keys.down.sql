@@ -0,0 +1,3 @@
+-- 回滚 0011
+DROP TABLE IF EXISTS api_key_usage;
+DROP TABLE IF EXISTS api_keys;
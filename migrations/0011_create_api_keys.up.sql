@@ -0,0 +1,22 @@
+-- api_keys 表：
+-- HTTP API 的访问密钥。只保存密钥的 SHA-256（key_hash，小写 hex）和用于识别的前缀（key_prefix），明文只在创建时显示一次。
+-- rate_limit（每秒请求数）/ burst / daily_quota 为 0 时使用 API 服务配置的默认值；revoked_at 不为空的密钥不能再使用。
+CREATE TABLE IF NOT EXISTS api_keys (
+                                        guid                          VARCHAR PRIMARY KEY,
+                                        name                          VARCHAR NOT NULL,
+                                        key_prefix                    VARCHAR NOT NULL,
+                                        key_hash                      VARCHAR NOT NULL UNIQUE,
+                                        rate_limit                    DOUBLE PRECISION NOT NULL DEFAULT 0,
+                                        burst                         INTEGER NOT NULL DEFAULT 0,
+                                        daily_quota                   BIGINT NOT NULL DEFAULT 0,
+                                        revoked_at                    TIMESTAMPTZ,
+                                        created_at                    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- api_key_usage 表：每个密钥每个 UTC 自然日的请求数，用于每日配额。多个 API 实例共享同一个计数
+CREATE TABLE IF NOT EXISTS api_key_usage (
+                                             api_key_guid                  VARCHAR NOT NULL REFERENCES api_keys(guid) ON DELETE CASCADE,
+                                             day                           DATE NOT NULL,
+                                             requests                      BIGINT NOT NULL DEFAULT 0,
+                                             PRIMARY KEY (api_key_guid, day)
+);
@@ -0,0 +1,2 @@
+-- 回滚 0012
+DROP TABLE IF EXISTS token_metadata_failures;
@@ -0,0 +1,11 @@
+-- token_metadata_failures表：
+-- 读取代币元数据（decimals）失败的地址。attempts 为连续失败次数，next_attempt_at（unix 秒）之前不再重试，
+-- 重试间隔随失败次数指数增长，避免永久失败的地址每轮都被读取、挤占新代币的批次。读取成功后删除对应记录。
+CREATE TABLE IF NOT EXISTS token_metadata_failures (
+                                                       address                       VARCHAR PRIMARY KEY,
+                                                       attempts                      INTEGER NOT NULL,
+                                                       last_error                    TEXT NOT NULL DEFAULT '',
+                                                       failed_at                     INTEGER NOT NULL,
+                                                       next_attempt_at               INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS token_metadata_failures_next_attempt_at ON token_metadata_failures(next_attempt_at);
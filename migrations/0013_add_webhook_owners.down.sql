@@ -0,0 +1,4 @@
+-- 回滚 0013
+DROP INDEX IF EXISTS webhook_subscriptions_owner;
+ALTER TABLE webhook_subscriptions DROP COLUMN IF EXISTS owner_guid;
+ALTER TABLE api_keys DROP COLUMN IF EXISTS scopes;
@@ -0,0 +1,7 @@
+-- api_keys.scopes：密钥可以访问的受限接口，例如 "webhooks"（管理 webhook 订阅）。默认为空，只能访问公开查询接口
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS scopes JSONB NOT NULL DEFAULT '[]';
+
+-- webhook_subscriptions.owner_guid：创建订阅的 API key，每个密钥只能查看和管理自己的订阅。
+-- 本迁移之前创建的订阅没有所有者，调度器继续投递，但不能再通过 API 管理，需要时手动设置 owner_guid
+ALTER TABLE webhook_subscriptions ADD COLUMN IF NOT EXISTS owner_guid VARCHAR REFERENCES api_keys(guid);
+CREATE INDEX IF NOT EXISTS webhook_subscriptions_owner ON webhook_subscriptions(owner_guid, created_at);
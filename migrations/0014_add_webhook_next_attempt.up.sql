@@ -0,0 +1,5 @@
+-- webhook_deliveries.next_attempt_at：待投递记录最早的下次投递时间。
+-- 每次投递后立即记录 attempts / last_error，失败时按指数退避推迟 next_attempt_at，调度器每轮只投递到期的记录，
+-- 同一订阅中较早的记录未到期时，之后的记录也不投递，保持按 outbox_id 的顺序
+ALTER TABLE webhook_deliveries ADD COLUMN IF NOT EXISTS next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+CREATE INDEX IF NOT EXISTS webhook_deliveries_due ON webhook_deliveries(status, next_attempt_at);
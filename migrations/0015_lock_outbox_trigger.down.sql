@@ -0,0 +1,11 @@
+-- 回滚 0015：恢复 0007 中不加锁的触发器函数
+CREATE OR REPLACE FUNCTION outbox_contract_event_removed() RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO outbox (contract_address, event_type, action, block_number, block_hash, transaction_hash, log_index, payload, timestamp)
+    SELECT o.contract_address, o.event_type, 'removed', o.block_number, o.block_hash, o.transaction_hash, o.log_index, o.payload, o.timestamp
+    FROM outbox o
+    WHERE o.id = (SELECT MAX(id) FROM outbox WHERE block_hash = OLD.block_hash AND log_index = OLD.log_index)
+      AND o.action = 'added';
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,15 @@
+-- outbox_contract_event_removed：
+-- 补发 removed 消息前获取与 Go 写入者相同的 advisory lock（database/event/outbox.go 中的 outboxLockKey，
+-- 0x6f7574626f787772 = 8031453476610930546），与 EventProcessor、reindex 的写入串行化，
+-- 保证 id 按分配顺序可见，按 id > 游标 读取的 relay、webhook 和订阅流不会跳过补偿消息
+CREATE OR REPLACE FUNCTION outbox_contract_event_removed() RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM pg_advisory_xact_lock(8031453476610930546);
+    INSERT INTO outbox (contract_address, event_type, action, block_number, block_hash, transaction_hash, log_index, payload, timestamp)
+    SELECT o.contract_address, o.event_type, 'removed', o.block_number, o.block_hash, o.transaction_hash, o.log_index, o.payload, o.timestamp
+    FROM outbox o
+    WHERE o.id = (SELECT MAX(id) FROM outbox WHERE block_hash = OLD.block_hash AND log_index = OLD.log_index)
+      AND o.action = 'added';
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,8 @@
+// Package migrations 内嵌到二进制中的 SQL 迁移文件。
+// 文件命名为 <版本号>_<名称>.up.sql / <版本号>_<名称>.down.sql，版本号按数字顺序执行。
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,57 @@
+package outbox
+
+import (
+	"bufio"
+	"context"
+	"os"
+
+	"github.com/Sandwichzzy/event-sync-go/database/event"
+)
+
+// fileSink 把消息以 NDJSON 追加写入文件或标准输出，用于测试和调试
+type fileSink struct {
+	name string
+	file *os.File
+}
+
+func newFileSink(name string, path string) (*fileSink, error) {
+	if path == "" {
+		return &fileSink{name: name, file: os.Stdout}, nil
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &fileSink{name: name, file: file}, nil
+}
+
+func (s *fileSink) Name() string {
+	return s.name
+}
+
+func (s *fileSink) Send(_ context.Context, messages []event.OutboxMessage) error {
+	writer := bufio.NewWriter(s.file)
+	for _, message := range messages {
+		data, err := encodeMessage(message)
+		if err != nil {
+			return err
+		}
+		if _, err := writer.Write(append(data, '\n')); err != nil {
+			return err
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+	if s.file == os.Stdout {
+		return nil
+	}
+	return s.file.Sync()
+}
+
+func (s *fileSink) Close() error {
+	if s.file == os.Stdout {
+		return nil
+	}
+	return s.file.Close()
+}
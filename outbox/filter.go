@@ -0,0 +1,71 @@
+package outbox
+
+import (
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/Sandwichzzy/event-sync-go/database/event"
+)
+
+// KnownEventTypes outbox 中可能出现的事件类型，用于校验订阅的过滤条件
+var KnownEventTypes = map[string]bool{
+	"DepositToken":           true,
+	"WithdrawToken":          true,
+	"GrantRewardTokenAmount": true,
+	"WithdrawManagerUpdate":  true,
+}
+
+// addressFields payload 中与 Filter.Addresses 比较的参与方字段
+var addressFields = []string{"sender", "receiver", "granter"}
+
+// Filter outbox 消息过滤条件，字段为空时不过滤。
+// Addresses 匹配事件的 sender / receiver / granter 中任意一个，TokenAddress 匹配 token_address。
+type Filter struct {
+	EventTypes   []string
+	TokenAddress *common.Address
+	Addresses    []common.Address
+}
+
+// Matches 判断消息是否满足过滤条件
+func (f Filter) Matches(message event.OutboxMessage) bool {
+	if len(f.EventTypes) > 0 && !contains(f.EventTypes, message.EventType) {
+		return false
+	}
+	if f.TokenAddress == nil && len(f.Addresses) == 0 {
+		return true
+	}
+
+	var payload map[string]interface{}
+	if err := json.Unmarshal(message.Payload, &payload); err != nil {
+		return false
+	}
+	if f.TokenAddress != nil && !addressEqual(payload["token_address"], *f.TokenAddress) {
+		return false
+	}
+	if len(f.Addresses) == 0 {
+		return true
+	}
+	for _, field := range addressFields {
+		for _, address := range f.Addresses {
+			if addressEqual(payload[field], address) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func addressEqual(value interface{}, address common.Address) bool {
+	s, ok := value.(string)
+	return ok && common.IsHexAddress(s) && common.HexToAddress(s) == address
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
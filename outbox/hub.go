@@ -0,0 +1,228 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/Sandwichzzy/event-sync-go/common/tasks"
+	"github.com/Sandwichzzy/event-sync-go/database/event"
+)
+
+const (
+	// hubBatchSize Hub 每次轮询和订阅者每次补齐历史时读取的最大消息数
+	hubBatchSize = 1000
+	// subscriberBuffer 每个订阅者最多积压的广播批次数，超过时订阅者改为从数据库补齐
+	subscriberBuffer = 16
+)
+
+// ErrHubClosed Hub 已关闭
+var ErrHubClosed = errors.New("outbox hub closed")
+
+// Hub 轮询 outbox 表并把新消息广播给实时订阅者（SSE、gRPC 流）。
+// outbox 与 worker 表在同一个事务内写入，消息 ID 即持久化的事件游标，订阅者可以从任意 ID 之后恢复。
+type Hub struct {
+	db           event.OutboxView
+	pollInterval time.Duration
+
+	mu          sync.Mutex
+	subscribers map[chan []event.OutboxMessage]struct{}
+	latest      uint64
+
+	resourceCtx    context.Context
+	resourceCancel context.CancelFunc
+	tasks          tasks.Group
+}
+
+func NewHub(db event.OutboxView, pollInterval time.Duration) *Hub {
+	resCtx, resCancel := context.WithCancel(context.Background())
+	return &Hub{
+		db:             db,
+		pollInterval:   pollInterval,
+		subscribers:    make(map[chan []event.OutboxMessage]struct{}),
+		resourceCtx:    resCtx,
+		resourceCancel: resCancel,
+		tasks: tasks.Group{HandleCrit: func(err error) {
+			log.Error("critical error in outbox hub", "err", err)
+		}},
+	}
+}
+
+func (h *Hub) Start() error {
+	latest, err := h.db.LatestOutboxID()
+	if err != nil {
+		return fmt.Errorf("failed to read latest outbox id: %w", err)
+	}
+	h.latest = latest
+	log.Info("starting outbox hub...", "latest", latest)
+
+	h.tasks.Go(func() error {
+		ticker := time.NewTicker(h.pollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-h.resourceCtx.Done():
+				return nil
+			case <-ticker.C:
+				if err := h.poll(); err != nil {
+					log.Error("outbox hub poll fail", "err", err)
+				}
+			}
+		}
+	})
+	return nil
+}
+
+func (h *Hub) Close() error {
+	h.resourceCancel()
+	err := h.tasks.Wait()
+	h.mu.Lock()
+	for ch := range h.subscribers {
+		delete(h.subscribers, ch)
+		close(ch)
+	}
+	h.mu.Unlock()
+	return err
+}
+
+// LatestID 返回 Hub 已经广播的最大消息 ID
+func (h *Hub) LatestID() uint64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.latest
+}
+
+// poll 读取上次广播之后的消息并广播给所有订阅者，积压已满的订阅者被移除，由其自行从数据库补齐
+func (h *Hub) poll() error {
+	for h.resourceCtx.Err() == nil {
+		messages, err := h.db.OutboxMessagesAfterID(h.LatestID(), hubBatchSize)
+		if err != nil {
+			return err
+		} else if len(messages) == 0 {
+			return nil
+		}
+
+		h.mu.Lock()
+		h.latest = messages[len(messages)-1].ID
+		for ch := range h.subscribers {
+			select {
+			case ch <- messages:
+			default:
+				delete(h.subscribers, ch)
+				close(ch)
+			}
+		}
+		h.mu.Unlock()
+
+		if len(messages) < hubBatchSize {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (h *Hub) subscribe() chan []event.OutboxMessage {
+	ch := make(chan []event.OutboxMessage, subscriberBuffer)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.resourceCtx.Err() != nil {
+		close(ch)
+		return ch
+	}
+	h.subscribers[ch] = struct{}{}
+	return ch
+}
+
+func (h *Hub) unsubscribe(ch chan []event.OutboxMessage) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.subscribers[ch]; ok {
+		delete(h.subscribers, ch)
+		close(ch)
+	}
+}
+
+// Stream 按 ID 顺序把 afterID 之后满足 filter 的消息交给 send：先从数据库补齐历史，再接收实时广播。
+// 订阅者处理过慢被 Hub 移除时重新从数据库补齐，不会漏掉或重复消息。
+// heartbeat 大于 0 时，连续 heartbeat 没有推送消息就调用一次 ping。ctx 结束、Hub 关闭或 send / ping 返回错误时返回。
+func (h *Hub) Stream(ctx context.Context, afterID uint64, filter Filter, heartbeat time.Duration, send func(event.OutboxMessage) error, ping func() error) error {
+	var heartbeatTicker *time.Ticker
+	var heartbeatC <-chan time.Time
+	if heartbeat > 0 {
+		heartbeatTicker = time.NewTicker(heartbeat)
+		defer heartbeatTicker.Stop()
+		heartbeatC = heartbeatTicker.C
+	}
+
+	cursor := afterID
+	deliver := func(messages []event.OutboxMessage) error {
+		for _, message := range messages {
+			if message.ID <= cursor {
+				continue
+			}
+			cursor = message.ID
+			if filter.Matches(message) {
+				if err := send(message); err != nil {
+					return err
+				}
+				if heartbeatTicker != nil {
+					heartbeatTicker.Reset(heartbeat)
+				}
+			}
+		}
+		return nil
+	}
+	catchUp := func() error {
+		for ctx.Err() == nil {
+			messages, err := h.db.OutboxMessagesAfterID(cursor, hubBatchSize)
+			if err != nil {
+				return err
+			}
+			if err := deliver(messages); err != nil {
+				return err
+			}
+			if len(messages) < hubBatchSize {
+				return nil
+			}
+		}
+		return ctx.Err()
+	}
+
+	// 先订阅再补齐：补齐期间提交的消息会出现在广播中，按 cursor 去重
+	ch := h.subscribe()
+	defer func() { h.unsubscribe(ch) }()
+	if err := catchUp(); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-h.resourceCtx.Done():
+			return ErrHubClosed
+		case messages, ok := <-ch:
+			if !ok {
+				if h.resourceCtx.Err() != nil {
+					return ErrHubClosed
+				}
+				log.Warn("outbox stream subscriber lagged, catching up from database", "cursor", cursor)
+				ch = h.subscribe()
+				if err := catchUp(); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := deliver(messages); err != nil {
+				return err
+			}
+		case <-heartbeatC:
+			if err := ping(); err != nil {
+				return err
+			}
+		}
+	}
+}
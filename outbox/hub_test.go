@@ -0,0 +1,133 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Sandwichzzy/event-sync-go/database/event"
+)
+
+// memOutbox 内存中的 outbox 表
+type memOutbox struct {
+	mu       sync.Mutex
+	messages []event.OutboxMessage
+}
+
+func (m *memOutbox) append(eventType string, sender common.Address) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	payload, _ := json.Marshal(map[string]interface{}{"sender": sender})
+	m.messages = append(m.messages, event.OutboxMessage{ID: uint64(len(m.messages) + 1), EventType: eventType, Action: event.OutboxActionAdded, Payload: payload})
+}
+
+func (m *memOutbox) OutboxContracts() ([]common.Address, error) { return nil, nil }
+
+func (m *memOutbox) OutboxMessagesAfter(common.Address, uint64, int) ([]event.OutboxMessage, error) {
+	return nil, nil
+}
+
+func (m *memOutbox) OutboxOffset(string, common.Address) (uint64, error) { return 0, nil }
+
+func (m *memOutbox) LatestOutboxID() (uint64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return uint64(len(m.messages)), nil
+}
+
+func (m *memOutbox) OutboxMessagesAfterID(afterID uint64, limit int) ([]event.OutboxMessage, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var result []event.OutboxMessage
+	for _, message := range m.messages {
+		if message.ID > afterID && len(result) < limit {
+			result = append(result, message)
+		}
+	}
+	return result, nil
+}
+
+func (m *memOutbox) OutboxIDBeforePosition(*big.Int, uint64) (uint64, error) { return 0, nil }
+
+func TestHubStream(t *testing.T) {
+	alice := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	bob := common.HexToAddress("0x2222222222222222222222222222222222222222")
+
+	db := &memOutbox{}
+	for i := 0; i < 5; i++ {
+		db.append("DepositToken", alice)
+	}
+	hub := NewHub(db, 5*time.Millisecond)
+	require.NoError(t, hub.Start())
+	defer hub.Close()
+	require.Equal(t, uint64(5), hub.LatestID())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// 从 ID 2 之后恢复：先补齐 3..5，再接收实时消息，只保留 alice 的 DepositToken
+	var received []uint64
+	done := make(chan error, 1)
+	go func() {
+		filter := Filter{EventTypes: []string{"DepositToken"}, Addresses: []common.Address{alice}}
+		done <- hub.Stream(ctx, 2, filter, 0, func(message event.OutboxMessage) error {
+			received = append(received, message.ID)
+			if len(received) == 5 {
+				cancel()
+			}
+			return nil
+		}, nil)
+	}()
+
+	db.append("WithdrawToken", alice) // 6: 类型不匹配
+	db.append("DepositToken", bob)    // 7: 地址不匹配
+	db.append("DepositToken", alice)  // 8
+	db.append("DepositToken", alice)  // 9
+
+	require.ErrorIs(t, <-done, context.Canceled)
+	require.Equal(t, []uint64{3, 4, 5, 8, 9}, received)
+}
+
+func TestHubStreamLaggingSubscriber(t *testing.T) {
+	alice := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	db := &memOutbox{}
+	hub := NewHub(db, time.Millisecond)
+	require.NoError(t, hub.Start())
+	defer hub.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// 订阅者处理缓慢，广播积压超过上限后被 Hub 移除，重新从数据库补齐，消息不丢不重
+	const total = 200
+	var received []uint64
+	done := make(chan error, 1)
+	go func() {
+		done <- hub.Stream(ctx, 0, Filter{}, 0, func(message event.OutboxMessage) error {
+			time.Sleep(100 * time.Microsecond)
+			received = append(received, message.ID)
+			if len(received) == total {
+				cancel()
+			}
+			return nil
+		}, nil)
+	}()
+	for i := 0; i < total; i++ {
+		db.append("DepositToken", alice)
+		if i%10 == 0 {
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	require.ErrorIs(t, <-done, context.Canceled)
+	require.Len(t, received, total)
+	for i, id := range received {
+		require.Equal(t, uint64(i+1), id)
+	}
+}
@@ -0,0 +1,62 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/segmentio/kafka-go"
+
+	"github.com/Sandwichzzy/event-sync-go/database/event"
+)
+
+// kafkaSink 以合约地址为 key 写入 Kafka topic，同一合约的消息落在同一分区，保持顺序
+type kafkaSink struct {
+	name   string
+	writer *kafka.Writer
+}
+
+func newKafkaSink(sinkURL *url.URL) (*kafkaSink, error) {
+	topic := trimSlash(sinkURL.Path)
+	if sinkURL.Host == "" || topic == "" {
+		return nil, fmt.Errorf("kafka outbox sink must be kafka://broker/topic[?broker=other-broker]")
+	}
+	// 多个 broker 用重复的 broker 参数给出（sink 列表本身以逗号分隔）
+	brokers := append([]string{sinkURL.Host}, sinkURL.Query()["broker"]...)
+	writer := &kafka.Writer{
+		Addr:                   kafka.TCP(brokers...),
+		Topic:                  topic,
+		Balancer:               &kafka.Hash{},
+		RequiredAcks:           kafka.RequireAll,
+		AllowAutoTopicCreation: true,
+	}
+	return &kafkaSink{name: sinkURL.Redacted(), writer: writer}, nil
+}
+
+func (s *kafkaSink) Name() string {
+	return s.name
+}
+
+func (s *kafkaSink) Send(ctx context.Context, messages []event.OutboxMessage) error {
+	kafkaMessages := make([]kafka.Message, 0, len(messages))
+	for _, message := range messages {
+		data, err := encodeMessage(message)
+		if err != nil {
+			return err
+		}
+		kafkaMessages = append(kafkaMessages, kafka.Message{
+			Key:   []byte(message.ContractAddress.Hex()),
+			Value: data,
+			Headers: []kafka.Header{
+				{Key: "id", Value: []byte(messageKey(message))},
+				{Key: "action", Value: []byte(message.Action)},
+				{Key: "event_type", Value: []byte(message.EventType)},
+			},
+		})
+	}
+	return s.writer.WriteMessages(ctx, kafkaMessages...)
+}
+
+func (s *kafkaSink) Close() error {
+	return s.writer.Close()
+}
@@ -0,0 +1,63 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/Sandwichzzy/event-sync-go/database/event"
+)
+
+// natsSink 通过 JetStream 发布到 <prefix>.<合约地址>，按消息 ID 设置 Nats-Msg-Id 由服务端去重。
+// subject 需要被某个 stream 覆盖（例如 subjects: ["event-sync.>"]）。
+type natsSink struct {
+	name   string
+	prefix string
+	conn   *nats.Conn
+	js     nats.JetStreamContext
+}
+
+func newNatsSink(sinkURL *url.URL) (*natsSink, error) {
+	prefix := strings.ReplaceAll(trimSlash(sinkURL.Path), "/", ".")
+	if prefix == "" {
+		return nil, fmt.Errorf("nats outbox sink must be nats://host:port/subject-prefix")
+	}
+	serverURL := *sinkURL
+	serverURL.Path = ""
+	conn, err := nats.Connect(serverURL.String())
+	if err != nil {
+		return nil, err
+	}
+	js, err := conn.JetStream()
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return &natsSink{name: sinkURL.Redacted(), prefix: prefix, conn: conn, js: js}, nil
+}
+
+func (s *natsSink) Name() string {
+	return s.name
+}
+
+func (s *natsSink) Send(ctx context.Context, messages []event.OutboxMessage) error {
+	for _, message := range messages {
+		data, err := encodeMessage(message)
+		if err != nil {
+			return err
+		}
+		subject := s.prefix + "." + strings.ToLower(message.ContractAddress.Hex())
+		if _, err := s.js.Publish(subject, data, nats.MsgId(messageKey(message)), nats.Context(ctx)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *natsSink) Close() error {
+	s.conn.Close()
+	return nil
+}
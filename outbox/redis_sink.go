@@ -0,0 +1,66 @@
+package outbox
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/Sandwichzzy/event-sync-go/database/event"
+)
+
+const defaultRedisStream = "event-sync:outbox"
+
+// redisSink 用 XADD 按顺序写入一个 Redis Stream，消费者可以用 XREADGROUP 按合约过滤
+type redisSink struct {
+	name   string
+	stream string
+	client *redis.Client
+}
+
+func newRedisSink(sinkURL *url.URL) (*redisSink, error) {
+	stream := sinkURL.Query().Get("stream")
+	if stream == "" {
+		stream = defaultRedisStream
+	}
+	// stream 不是 go-redis 的连接参数，解析前去掉
+	serverURL := *sinkURL
+	query := serverURL.Query()
+	query.Del("stream")
+	serverURL.RawQuery = query.Encode()
+	options, err := redis.ParseURL(serverURL.String())
+	if err != nil {
+		return nil, err
+	}
+	return &redisSink{name: sinkURL.Redacted(), stream: stream, client: redis.NewClient(options)}, nil
+}
+
+func (s *redisSink) Name() string {
+	return s.name
+}
+
+func (s *redisSink) Send(ctx context.Context, messages []event.OutboxMessage) error {
+	pipe := s.client.TxPipeline()
+	for _, message := range messages {
+		data, err := encodeMessage(message)
+		if err != nil {
+			return err
+		}
+		pipe.XAdd(ctx, &redis.XAddArgs{
+			Stream: s.stream,
+			Values: map[string]interface{}{
+				"id":               messageKey(message),
+				"contract_address": message.ContractAddress.Hex(),
+				"action":           message.Action,
+				"event_type":       message.EventType,
+				"data":             data,
+			},
+		})
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (s *redisSink) Close() error {
+	return s.client.Close()
+}
@@ -0,0 +1,131 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/Sandwichzzy/event-sync-go/common/tasks"
+	"github.com/Sandwichzzy/event-sync-go/config"
+	"github.com/Sandwichzzy/event-sync-go/database"
+)
+
+// relayBatchSize 每次从 outbox 读取并投递的最大消息数
+const relayBatchSize = 500
+
+// Relay 把 outbox 中的消息至少一次地投递到各个 sink。
+// 每个 sink 一个协程，按合约分别记录偏移量，同一合约的消息按 outbox.id 顺序投递；
+// 投递失败时不提交偏移量，下一轮从同一位置重试。
+type Relay struct {
+	db           *database.DB
+	sinks        []Sink
+	loopInterval time.Duration
+
+	resourceCtx    context.Context
+	resourceCancel context.CancelFunc
+	tasks          tasks.Group
+}
+
+func NewRelay(cfg *config.Config, db *database.DB, shutdown context.CancelCauseFunc) (*Relay, error) {
+	sinks := make([]Sink, 0, len(cfg.OutboxSinks))
+	for _, sinkURL := range cfg.OutboxSinks {
+		sink, err := NewSink(sinkURL)
+		if err != nil {
+			for _, created := range sinks {
+				_ = created.Close()
+			}
+			log.Error("new outbox sink fail", "err", err)
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+
+	resCtx, resCancel := context.WithCancel(context.Background())
+	return &Relay{
+		db:             db,
+		sinks:          sinks,
+		loopInterval:   cfg.Chain.LoopInterval,
+		resourceCtx:    resCtx,
+		resourceCancel: resCancel,
+		tasks: tasks.Group{HandleCrit: func(err error) {
+			shutdown(fmt.Errorf("critical error in outbox relay: %w", err))
+		}},
+	}, nil
+}
+
+func (r *Relay) Start() error {
+	for _, sink := range r.sinks {
+		sink := sink
+		log.Info("starting outbox relay...", "sink", sink.Name())
+		r.tasks.Go(func() error {
+			ticker := time.NewTicker(r.loopInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-r.resourceCtx.Done():
+					return nil
+				case <-ticker.C:
+					if err := r.relay(sink); err != nil {
+						log.Error("outbox relay fail", "sink", sink.Name(), "err", err)
+					}
+				}
+			}
+		})
+	}
+	return nil
+}
+
+func (r *Relay) Close() error {
+	r.resourceCancel()
+	err := r.tasks.Wait()
+	for _, sink := range r.sinks {
+		if closeErr := sink.Close(); closeErr != nil {
+			log.Error("close outbox sink fail", "sink", sink.Name(), "err", closeErr)
+		}
+	}
+	return err
+}
+
+// relay 把每个合约偏移量之后的消息投递到 sink
+func (r *Relay) relay(sink Sink) error {
+	contracts, err := r.db.Outbox.OutboxContracts()
+	if err != nil {
+		return err
+	}
+	for _, contract := range contracts {
+		if err := r.relayContract(sink, contract); err != nil {
+			return fmt.Errorf("contract %s: %w", contract, err)
+		}
+	}
+	return nil
+}
+
+func (r *Relay) relayContract(sink Sink, contract common.Address) error {
+	offset, err := r.db.Outbox.OutboxOffset(sink.Name(), contract)
+	if err != nil {
+		return err
+	}
+	for r.resourceCtx.Err() == nil {
+		messages, err := r.db.Outbox.OutboxMessagesAfter(contract, offset, relayBatchSize)
+		if err != nil {
+			return err
+		} else if len(messages) == 0 {
+			return nil
+		}
+		if err := sink.Send(r.resourceCtx, messages); err != nil {
+			return err
+		}
+		offset = messages[len(messages)-1].ID
+		if err := r.db.Outbox.UpdateOutboxOffset(sink.Name(), contract, offset); err != nil {
+			return err
+		}
+		log.Info("outbox messages delivered", "sink", sink.Name(), "contract", contract, "count", len(messages), "offset", offset)
+		if len(messages) < relayBatchSize {
+			return nil
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/Sandwichzzy/event-sync-go/database/event"
+)
+
+// Sink outbox 消息的投递目标。Send 返回 nil 表示这一批消息全部写入成功，
+// relay 随后提交偏移量；返回错误时整批在下一轮重新投递（至少一次）。
+type Sink interface {
+	Name() string
+	Send(ctx context.Context, messages []event.OutboxMessage) error
+	Close() error
+}
+
+// NewSink 按 URL 创建 sink：
+//
+//	stdout
+//	file:///var/log/event-sync/outbox.ndjson
+//	kafka://broker1:9092/topic?broker=broker2:9092
+//	nats://127.0.0.1:4222/subject-prefix
+//	redis://:password@127.0.0.1:6379/0?stream=event-sync:outbox
+func NewSink(rawURL string) (Sink, error) {
+	if rawURL == "stdout" {
+		return newFileSink(rawURL, "")
+	}
+	sinkURL, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid outbox sink %q: %w", rawURL, err)
+	}
+	switch sinkURL.Scheme {
+	case "file":
+		return newFileSink(sinkURL.Redacted(), sinkURL.Path)
+	case "kafka":
+		return newKafkaSink(sinkURL)
+	case "nats":
+		return newNatsSink(sinkURL)
+	case "redis", "rediss":
+		return newRedisSink(sinkURL)
+	default:
+		return nil, fmt.Errorf("unsupported outbox sink scheme %q", sinkURL.Scheme)
+	}
+}
+
+// encodeMessage sink 中消息的 JSON 格式与 outbox 表一致
+func encodeMessage(message event.OutboxMessage) ([]byte, error) {
+	return json.Marshal(message)
+}
+
+// messageKey 消息的去重键，同一位置的 added / removed 消息 ID 不同
+func messageKey(message event.OutboxMessage) string {
+	return strconv.FormatUint(message.ID, 10)
+}
+
+func trimSlash(path string) string {
+	return strings.Trim(path, "/")
+}
@@ -0,0 +1,88 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/Sandwichzzy/event-sync-go/bindings"
+	"github.com/Sandwichzzy/event-sync-go/common/tasks"
+	"github.com/Sandwichzzy/event-sync-go/config"
+	"github.com/Sandwichzzy/event-sync-go/database"
+)
+
+// Reconciler 定期把本地根据事件投影出来的状态与链上合约读取的状态做对账，
+// 不一致的情况写入 reconcile_mismatches 表。
+type Reconciler struct {
+	db           *database.DB
+	ethClient    *ethclient.Client
+	tmCaller     *bindings.TreasureManagerCaller
+	loopInterval time.Duration
+
+	resourceCtx    context.Context
+	resourceCancel context.CancelFunc
+	tasks          tasks.Group
+}
+
+// NewReconciler treasureManager 为配置中绑定到 treasure-manager 处理器的合约地址
+func NewReconciler(ctx context.Context, cfg *config.Config, db *database.DB, treasureManager common.Address, shutdown context.CancelCauseFunc) (*Reconciler, error) {
+	client, err := ethclient.DialContext(ctx, cfg.Chain.ChainRpcUrl)
+	if err != nil {
+		log.Error("dial eth client for reconciler fail", "err", err)
+		return nil, err
+	}
+
+	tmCaller, err := bindings.NewTreasureManagerCaller(treasureManager, client)
+	if err != nil {
+		log.Error("new treasure manager caller fail", "err", err)
+		client.Close()
+		return nil, err
+	}
+
+	resCtx, resCancel := context.WithCancel(context.Background())
+	return &Reconciler{
+		db:             db,
+		ethClient:      client,
+		tmCaller:       tmCaller,
+		loopInterval:   cfg.ReconcileInterval,
+		resourceCtx:    resCtx,
+		resourceCancel: resCancel,
+		tasks: tasks.Group{HandleCrit: func(err error) {
+			shutdown(fmt.Errorf("critical error in reconciler: %w", err))
+		}},
+	}, nil
+}
+
+func (r *Reconciler) Start() error {
+	log.Info("starting reconciler...", "interval", r.loopInterval)
+	tickerReconcile := time.NewTicker(r.loopInterval)
+	r.tasks.Go(func() error {
+		defer tickerReconcile.Stop()
+		for {
+			select {
+			case <-r.resourceCtx.Done():
+				return nil
+			case <-tickerReconcile.C:
+				// 对账失败只记录日志，下一轮会重新对账尚未标记的快照
+				if err := r.reconcileTreasuryBalances(); err != nil {
+					log.Error("reconcile treasury balances fail", "err", err)
+				}
+				if err := r.reconcileRewards(); err != nil {
+					log.Error("reconcile rewards fail", "err", err)
+				}
+			}
+		}
+	})
+	return nil
+}
+
+func (r *Reconciler) Close() error {
+	r.resourceCancel()
+	err := r.tasks.Wait()
+	r.ethClient.Close()
+	return err
+}
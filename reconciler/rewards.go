@@ -0,0 +1,90 @@
+package reconciler
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/google/uuid"
+
+	"github.com/Sandwichzzy/event-sync-go/database"
+	"github.com/Sandwichzzy/event-sync-go/database/worker"
+)
+
+// rewardReconcileBatchSize 每一轮最多对账的 (用户, 代币) 数量
+const rewardReconcileBatchSize = 200
+
+// reconcileRewards 在最新已处理的事件区块高度，以用户身份调用合约 queryRewards(token)
+// （即 userRewardAmounts[user][token]）与本地 claimable 比较：
+//   - 链上值更小：说明用户在上次对账之后领取过奖励，差额记为一条 claim 流水（区块为本次对账高度）
+//   - 链上值更大：本地漏记了发放，写入 reconcile_mismatches
+func (r *Reconciler) reconcileRewards() error {
+	latestHeader, err := r.db.EventBlocks.LatestEventBlockHeader()
+	if err != nil {
+		return err
+	} else if latestHeader == nil {
+		return nil
+	}
+
+	balances, err := r.db.RewardLedger.RewardBalancesToReconcile(latestHeader.Number, rewardReconcileBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, balance := range balances {
+		callOpts := &bind.CallOpts{From: balance.UserAddress, BlockNumber: latestHeader.Number, Context: r.resourceCtx}
+		onChain, err := r.tmCaller.QueryRewards(callOpts, balance.TokenAddress)
+		if err != nil {
+			log.Error("call query rewards fail", "user", balance.UserAddress, "token", balance.TokenAddress, "blockNumber", latestHeader.Number, "err", err)
+			continue
+		}
+
+		if err := r.db.Transaction(func(tx *database.DB) error {
+			switch onChain.Cmp(balance.Claimable) {
+			case -1:
+				claimed := new(big.Int).Sub(balance.Claimable, onChain)
+				applied, err := tx.RewardLedger.ApplyRewardClaim(balance, claimed, latestHeader.Number)
+				if err != nil || !applied {
+					return err
+				}
+				entry := worker.RewardLedgerEntry{
+					GUID:         uuid.New(),
+					UserAddress:  balance.UserAddress,
+					TokenAddress: balance.TokenAddress,
+					EntryType:    worker.RewardEntryClaim,
+					Amount:       claimed,
+					BlockNumber:  latestHeader.Number,
+					BlockHash:    latestHeader.Hash,
+					Timestamp:    latestHeader.Timestamp,
+				}
+				return tx.RewardLedger.StoreRewardLedgerEntries([]worker.RewardLedgerEntry{entry})
+			case 1:
+				log.Warn("reward claimable mismatch",
+					"user", balance.UserAddress,
+					"token", balance.TokenAddress,
+					"blockNumber", latestHeader.Number,
+					"projected", balance.Claimable,
+					"onChain", onChain,
+				)
+				mismatch := worker.ReconcileMismatch{
+					GUID:         uuid.New(),
+					Kind:         worker.ReconcileKindRewardClaimable,
+					TokenAddress: balance.TokenAddress,
+					Account:      balance.UserAddress.String(),
+					BlockNumber:  latestHeader.Number,
+					BlockHash:    latestHeader.Hash,
+					Projected:    balance.Claimable,
+					OnChain:      onChain,
+					Timestamp:    latestHeader.Timestamp,
+				}
+				if err := tx.ReconcileMismatches.StoreReconcileMismatches([]worker.ReconcileMismatch{mismatch}); err != nil {
+					return err
+				}
+			}
+			return tx.RewardLedger.MarkRewardBalanceReconciled(balance, latestHeader.Number)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
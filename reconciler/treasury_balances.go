@@ -0,0 +1,57 @@
+package reconciler
+
+import (
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/google/uuid"
+
+	"github.com/Sandwichzzy/event-sync-go/database"
+	"github.com/Sandwichzzy/event-sync-go/database/worker"
+)
+
+// reconcileTreasuryBalances 对每个代币最新一条未对账的余额快照，在同一区块高度调用合约
+// tokenBalances(token) 比较，比较完成后把该代币在此高度及之前的快照都标记为已对账。
+func (r *Reconciler) reconcileTreasuryBalances() error {
+	balances, err := r.db.TreasuryBalances.LatestUnreconciledTreasuryBalances()
+	if err != nil {
+		return err
+	}
+
+	for _, balance := range balances {
+		callOpts := &bind.CallOpts{BlockNumber: balance.BlockNumber, Context: r.resourceCtx}
+		onChain, err := r.tmCaller.TokenBalances(callOpts, balance.TokenAddress)
+		if err != nil {
+			log.Error("call token balances fail", "token", balance.TokenAddress, "blockNumber", balance.BlockNumber, "err", err)
+			continue
+		}
+
+		if err := r.db.Transaction(func(tx *database.DB) error {
+			if onChain.Cmp(balance.Balance) != 0 {
+				log.Warn("treasury balance mismatch",
+					"token", balance.TokenAddress,
+					"blockNumber", balance.BlockNumber,
+					"blockHash", balance.BlockHash,
+					"projected", balance.Balance,
+					"onChain", onChain,
+				)
+				mismatch := worker.ReconcileMismatch{
+					GUID:         uuid.New(),
+					Kind:         worker.ReconcileKindTreasuryBalance,
+					TokenAddress: balance.TokenAddress,
+					BlockNumber:  balance.BlockNumber,
+					BlockHash:    balance.BlockHash,
+					Projected:    balance.Balance,
+					OnChain:      onChain,
+					Timestamp:    balance.Timestamp,
+				}
+				if err := tx.ReconcileMismatches.StoreReconcileMismatches([]worker.ReconcileMismatch{mismatch}); err != nil {
+					return err
+				}
+			}
+			return tx.TreasuryBalances.MarkTreasuryBalancesReconciled(balance.TokenAddress, balance.BlockNumber)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
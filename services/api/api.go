@@ -14,9 +14,12 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/go-chi/chi/v5/middleware"
 
+	"github.com/Sandwichzzy/event-sync-go/apikeys"
 	"github.com/Sandwichzzy/event-sync-go/config"
 	"github.com/Sandwichzzy/event-sync-go/database"
+	"github.com/Sandwichzzy/event-sync-go/outbox"
 	"github.com/Sandwichzzy/event-sync-go/services/api/common/httputil"
+	"github.com/Sandwichzzy/event-sync-go/services/api/graphql"
 	"github.com/Sandwichzzy/event-sync-go/services/api/routes"
 	"github.com/Sandwichzzy/event-sync-go/services/api/service"
 )
@@ -26,6 +29,33 @@ const (
 	HealthPath          = "/healthz"
 	// DepositTokensV1Path 充值代币查询API v1版本路径
 	DepositTokensV1Path = "/api/v1/deposit/tokens"
+	// RewardLedgerV1Path 用户奖励账本查询API v1版本路径
+	RewardLedgerV1Path = "/api/v1/rewards/{address}"
+	// WebhooksV1Path webhook 订阅管理API v1版本路径
+	WebhooksV1Path = "/api/v1/webhooks"
+	// StreamV1Path SSE 实时事件推送API v1版本路径
+	StreamV1Path = "/api/v1/stream"
+	// DepositsV1Path 充值记录API v1版本路径
+	DepositsV1Path = "/api/v1/deposits"
+	// WithdrawalsV1Path 提现记录API v1版本路径
+	WithdrawalsV1Path = "/api/v1/withdrawals"
+	// GrantsV1Path 奖励发放记录API v1版本路径
+	GrantsV1Path = "/api/v1/grants"
+	// ManagerUpdatesV1Path 提现管理员变更记录API v1版本路径
+	ManagerUpdatesV1Path = "/api/v1/manager-updates"
+	// ContractEventsV1Path 原始合约事件API v1版本路径
+	ContractEventsV1Path = "/api/v1/contract-events"
+	// AddressV1Path 地址时间线和代币汇总API v1版本路径
+	AddressV1Path = "/api/v1/addresses/{address}"
+	// ExportV1Path CSV / NDJSON 流式导出API v1版本路径
+	ExportV1Path = "/api/v1/export"
+	// StatsV1Path 按小时 / 天汇总的代币统计API v1版本路径
+	StatsV1Path = "/api/v1/stats"
+	// GraphQLPath GraphQL 查询端点路径
+	GraphQLPath = "/graphql"
+
+	// streamPollInterval 实时推送轮询 outbox 表的间隔
+	streamPollInterval = time.Second
 )
 
 // APIConfig API服务配置
@@ -39,6 +69,10 @@ type API struct {
 	router    *chi.Mux              // Chi路由器，处理HTTP路由
 	apiServer *httputil.HTTPServer  // HTTP服务器实例
 	db        *database.DB          // 数据库连接
+	writeDb   *database.DB          // 主库连接，用于 webhook 订阅等写操作；未启用从库时与 db 相同
+	hub       *outbox.Hub           // 轮询 outbox 表并广播给实时推送的订阅者
+	cache     service.ResponseCache // API 响应缓存，未启用时为 nil
+	guard     *apikeys.Guard        // API key 认证和限流，定期把每日请求数写入主库
 	stopped   atomic.Bool           // 原子布尔值，标记服务是否已停止
 }
 
@@ -61,16 +95,24 @@ func NewApi(ctx context.Context, cfg *config.Config) (*API, error) {
 // initFromConfig 根据配置文件初始化API服务的所有组件
 // 执行步骤:
 //   1. 初始化数据库连接
-//   2. 初始化路由和中间件
-//   3. 启动HTTP服务器
+//   2. 启动实时推送的 outbox 轮询
+//   3. 初始化路由和中间件
+//   4. 启动HTTP服务器
 func (a *API) initFromConfig(ctx context.Context, cfg *config.Config) error {
 	// 步骤1: 初始化数据库连接
 	if err := a.initDB(ctx, cfg); err != nil {
 		return fmt.Errorf("failed to init DB: %w", err)
 	}
-	// 步骤2: 初始化路由器
-	a.initRouter(cfg.HTTPServer, cfg)
-	// 步骤3: 启动HTTP服务器
+	// 步骤2: 启动 outbox 轮询，实时推送从这里接收新事件
+	a.hub = outbox.NewHub(a.db.Outbox, streamPollInterval)
+	if err := a.hub.Start(); err != nil {
+		return fmt.Errorf("failed to start outbox hub: %w", err)
+	}
+	// 步骤3: 初始化路由器
+	if err := a.initRouter(cfg.HTTPServer, cfg); err != nil {
+		return fmt.Errorf("failed to init router: %w", err)
+	}
+	// 步骤4: 启动HTTP服务器
 	if err := a.startServer(cfg.HTTPServer); err != nil {
 		return fmt.Errorf("failed to start API server: %w", err)
 	}
@@ -80,30 +122,93 @@ func (a *API) initFromConfig(ctx context.Context, cfg *config.Config) error {
 // initRouter 初始化HTTP路由器和中间件
 // 功能:
 //   1. 创建服务层实例（包含验证器和数据库访问层）
-//   2. 注册中间件（超时控制、错误恢复、健康检查）
+//   2. 注册中间件（错误恢复、健康检查、API key 限流、超时控制）
 //   3. 注册API路由端点
-func (a *API) initRouter(conf config.ServerConfig, cfg *config.Config) {
+func (a *API) initRouter(conf config.ServerConfig, cfg *config.Config) error {
 	// 创建请求参数验证器
-	v := new(service.Validator)
+	v := &service.Validator{AllowPrivateWebhookTargets: cfg.WebhookAllowPrivateTargets}
+
+	// GraphQL 查询与 REST 查询一样走只读库
+	graphqlHandler, err := graphql.NewHandler(a.db)
+	if err != nil {
+		return fmt.Errorf("failed to build graphql schema: %w", err)
+	}
 
 	// 创建服务层实例，连接验证器和数据库视图
-	svc := service.New(v, a.db.DepositTokens)
+	svc := service.New(v, a.db, a.writeDb.Webhooks, a.hub)
+	// 启用响应缓存时，只读查询先查缓存，缓存键随索引高度变化
+	if cfg.ApiCacheEnable {
+		a.cache, err = service.NewResponseCache(cfg.ApiCache)
+		if err != nil {
+			return fmt.Errorf("failed to init api cache: %w", err)
+		}
+		svc = service.NewCachedService(svc, a.cache, a.db.EventBlocks, a.hub.LatestID, cfg.ApiCache)
+	}
 	apiRouter := chi.NewRouter()
 	// 创建路由处理器实例
 	h := routes.NewRoutes(apiRouter, svc)
 
-	// 中间件1: 请求超时控制（12秒）
-	apiRouter.Use(middleware.Timeout(time.Second * 12))
-	// 中间件2: Panic恢复中间件，防止单个请求崩溃导致整个服务down掉
+	// 中间件1: Panic恢复中间件，防止单个请求崩溃导致整个服务down掉
 	apiRouter.Use(middleware.Recoverer)
 
-	// 中间件3: 健康检查心跳端点，用于负载均衡器探测服务状态
+	// 中间件2: 健康检查心跳端点，用于负载均衡器探测服务状态
 	apiRouter.Use(middleware.Heartbeat(HealthPath))
 
-	// 注册API路由: GET /api/v1/deposit/tokens - 查询充值代币列表
-	apiRouter.Get(fmt.Sprintf(DepositTokensV1Path), h.DepositTokensHandler)
+	// 中间件3: API key 认证和限流，未携带 API key 时按客户端地址使用更严格的限额。
+	// 密钥和每日请求数读写主库，多个 API 实例共享每日配额
+	a.guard = apikeys.NewGuard(a.writeDb.ApiKeys, cfg.ApiRateLimit)
+	a.guard.Start()
+	apiRouter.Use(routes.RateLimitMiddleware(a.guard))
+
+	// 未匹配的路由和方法同样返回 JSON 错误体
+	apiRouter.NotFound(routes.NotFoundHandler)
+	apiRouter.MethodNotAllowed(routes.MethodNotAllowedHandler)
+
+	// 注册API路由: GET /api/v1/stream - SSE 实时事件推送，长连接不经过超时中间件
+	apiRouter.Get(StreamV1Path, h.StreamHandler)
+	// 注册API路由: GET /api/v1/export - 流式导出，耗时与导出的行数有关，不经过超时中间件
+	apiRouter.Get(ExportV1Path, h.ExportHandler)
+
+	apiRouter.Group(func(r chi.Router) {
+		// 中间件4: 请求超时控制（12秒）
+		r.Use(middleware.Timeout(time.Second * 12))
+
+		// 注册API路由: GET /api/v1/deposit/tokens - 查询充值代币列表（与 /api/v1/deposits 相同）
+		r.Get(fmt.Sprintf(DepositTokensV1Path), h.DepositTokensHandler)
+		// 注册API路由: 充值、提现、奖励发放、提现管理员变更和原始合约事件的过滤列表与详情
+		r.Get(DepositsV1Path, h.DepositsHandler)
+		r.Get(DepositsV1Path+"/{guid}", h.DepositHandler)
+		r.Get(WithdrawalsV1Path, h.WithdrawalsHandler)
+		r.Get(WithdrawalsV1Path+"/{guid}", h.WithdrawalHandler)
+		r.Get(GrantsV1Path, h.GrantsHandler)
+		r.Get(GrantsV1Path+"/{guid}", h.GrantHandler)
+		r.Get(ManagerUpdatesV1Path, h.ManagerUpdatesHandler)
+		r.Get(ManagerUpdatesV1Path+"/{guid}", h.ManagerUpdateHandler)
+		r.Get(ContractEventsV1Path, h.ContractEventsHandler)
+		r.Get(ContractEventsV1Path+"/{guid}", h.ContractEventHandler)
+		// 注册API路由: GET / POST /graphql - GraphQL 查询
+		r.Get(GraphQLPath, graphqlHandler.ServeHTTP)
+		r.Post(GraphQLPath, graphqlHandler.ServeHTTP)
+		// 注册API路由: GET /api/v1/rewards/{address} - 查询用户奖励账本
+		r.Get(RewardLedgerV1Path, h.RewardLedgerHandler)
+		r.Get(AddressV1Path, h.AddressActivityHandler)
+		r.Get(StatsV1Path, h.StatsHandler)
+		// 注册API路由: webhook 订阅管理、投递记录查询和死信重新投递，需要带 webhooks scope 的 API key，
+		// 每个密钥只能管理自己创建的订阅
+		r.Route(WebhooksV1Path, func(r chi.Router) {
+			r.Use(routes.RequireScopeMiddleware(apikeys.ScopeWebhooks))
+			r.Post("/", h.CreateWebhookHandler)
+			r.Get("/", h.ListWebhooksHandler)
+			r.Get("/{guid}", h.GetWebhookHandler)
+			r.Patch("/{guid}", h.UpdateWebhookHandler)
+			r.Delete("/{guid}", h.DeleteWebhookHandler)
+			r.Get("/{guid}/deliveries", h.WebhookDeliveriesHandler)
+			r.Post("/deliveries/{guid}/redeliver", h.RedeliverWebhookHandler)
+		})
+	})
 
 	a.router = apiRouter
+	return nil
 }
 
 // initDB 初始化数据库连接
@@ -111,6 +216,7 @@ func (a *API) initRouter(conf config.ServerConfig, cfg *config.Config) {
 //   - 根据配置决定连接主库还是从库
 //   - 如果启用了从库，则连接从库（用于读操作，减轻主库压力）
 //   - 否则连接主库
+//   - 写操作（webhook 订阅管理）始终使用主库
 func (a *API) initDB(ctx context.Context, cfg *config.Config) error {
 	var initDb *database.DB
 	var err error
@@ -121,6 +227,7 @@ func (a *API) initDB(ctx context.Context, cfg *config.Config) error {
 			log.Error("failed to connect to master database", "err", err)
 			return err
 		}
+		a.writeDb = initDb
 	} else {
 		// 从库已启用，连接从数据库（读写分离架构）
 		initDb, err = database.NewDB(ctx, cfg.SlaveDB)
@@ -128,6 +235,12 @@ func (a *API) initDB(ctx context.Context, cfg *config.Config) error {
 			log.Error("failed to connect to slave database", "err", err)
 			return err
 		}
+		a.db = initDb // 先赋值，主库连接失败时由 Stop 关闭从库连接
+		a.writeDb, err = database.NewDB(ctx, cfg.MasterDB)
+		if err != nil {
+			log.Error("failed to connect to master database", "err", err)
+			return err
+		}
 	}
 	a.db = initDb
 	return nil
@@ -141,7 +254,7 @@ func (a *API) Start(ctx context.Context) error {
 // Stop 优雅关闭API服务
 // 执行步骤:
 //   1. 停止HTTP服务器（等待现有请求完成）
-//   2. 关闭数据库连接
+//   2. 停止 outbox 轮询，写入剩余的 API key 请求数，关闭缓存和数据库连接
 //   3. 标记服务为已停止状态
 // 返回所有关闭过程中产生的错误（如果有）
 func (a *API) Stop(ctx context.Context) error {
@@ -152,12 +265,32 @@ func (a *API) Stop(ctx context.Context) error {
 			result = errors.Join(result, fmt.Errorf("failed to stop API server: %w", err))
 		}
 	}
-	// 步骤2: 关闭数据库连接
+	// 步骤2: 停止 outbox 轮询并关闭数据库连接
+	if a.hub != nil {
+		if err := a.hub.Close(); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to stop outbox hub: %w", err))
+		}
+	}
+	if a.guard != nil {
+		if err := a.guard.Close(); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to stop api key guard: %w", err))
+		}
+	}
+	if a.cache != nil {
+		if err := a.cache.Close(); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to close api cache: %w", err))
+		}
+	}
 	if a.db != nil {
 		if err := a.db.Close(); err != nil {
 			result = errors.Join(result, fmt.Errorf("failed to close DB: %w", err))
 		}
 	}
+	if a.writeDb != nil && a.writeDb != a.db {
+		if err := a.writeDb.Close(); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to close master DB: %w", err))
+		}
+	}
 	// 步骤3: 标记为已停止
 	a.stopped.Store(true)
 	log.Info("API service shutdown complete")
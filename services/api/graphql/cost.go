@@ -0,0 +1,110 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	common2 "github.com/Sandwichzzy/event-sync-go/database/common"
+)
+
+const (
+	// defaultMaxCost 一个请求的查询成本上限
+	defaultMaxCost = 1000
+	// lookupCost 按主键或唯一键查询一条记录的成本
+	lookupCost = 1
+	// exactCountCost COUNT(*) 需要扫描所有匹配的行，按固定成本计算
+	exactCountCost = 100
+)
+
+// queryState 一个 GraphQL 请求内共享的状态：剩余的查询成本和区块头、代币元数据的缓存。
+// 每次访问数据库前按预计读取的行数扣减成本，成本耗尽后后续查询直接返回错误，
+// 限制一个请求（包括嵌套的关联关系）对只读库造成的压力
+type queryState struct {
+	maxCost   int64
+	remaining atomic.Int64
+
+	mu     sync.Mutex
+	blocks map[common.Hash]*common2.BlockHeader // 值为 nil 表示区块头不存在
+	tokens map[common.Address]*common2.Token    // 值为 nil 表示元数据尚未缓存
+}
+
+type queryStateKey struct{}
+
+func withQueryState(ctx context.Context, maxCost int64) context.Context {
+	state := &queryState{
+		maxCost: maxCost,
+		blocks:  make(map[common.Hash]*common2.BlockHeader),
+		tokens:  make(map[common.Address]*common2.Token),
+	}
+	state.remaining.Store(maxCost)
+	return context.WithValue(ctx, queryStateKey{}, state)
+}
+
+// stateFrom 返回请求的状态，不经过 Handler 执行（如测试）时使用默认成本上限
+func stateFrom(ctx context.Context) *queryState {
+	if state, ok := ctx.Value(queryStateKey{}).(*queryState); ok {
+		return state
+	}
+	return withQueryState(ctx, defaultMaxCost).Value(queryStateKey{}).(*queryState)
+}
+
+// charge 扣减查询成本，剩余成本不足时返回错误
+func (s *queryState) charge(cost int64) error {
+	if s.remaining.Add(-cost) < 0 {
+		return fmt.Errorf("query is too expensive: cost exceeds the limit of %d", s.maxCost)
+	}
+	return nil
+}
+
+// cachedBlock 返回缓存的区块头，ok 为 false 表示尚未查询过
+func (s *queryState) cachedBlock(hash common.Hash) (header *common2.BlockHeader, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	header, ok = s.blocks[hash]
+	return header, ok
+}
+
+func (s *queryState) cacheBlock(hash common.Hash, header *common2.BlockHeader) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.blocks[hash] = header
+}
+
+// missingTokens 返回还没有查询过的代币地址（去重）
+func (s *queryState) missingTokens(addresses []common.Address) []common.Address {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	seen := make(map[common.Address]bool, len(addresses))
+	missing := make([]common.Address, 0, len(addresses))
+	for _, address := range addresses {
+		if _, ok := s.tokens[address]; ok || seen[address] {
+			continue
+		}
+		seen[address] = true
+		missing = append(missing, address)
+	}
+	return missing
+}
+
+// cacheTokens 缓存一批代币的查询结果，queried 中没有元数据的地址记为 nil
+func (s *queryState) cacheTokens(queried []common.Address, tokens map[common.Address]common2.Token) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, address := range queried {
+		if token, ok := tokens[address]; ok {
+			s.tokens[address] = &token
+		} else {
+			s.tokens[address] = nil
+		}
+	}
+}
+
+func (s *queryState) cachedToken(address common.Address) *common2.Token {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tokens[address]
+}
@@ -0,0 +1,108 @@
+// Package graphql 在 API 服务上提供 /graphql 端点，一次请求即可查询已索引的事件、区块、交易和代币及其关联关系。
+// 查询走 API 服务的只读库（启用 SlaveDbEnable 时为从库），因此每个请求都受深度、长度、并发和查询成本限制。
+package graphql
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/log"
+	gql "github.com/graph-gophers/graphql-go"
+
+	"github.com/Sandwichzzy/event-sync-go/database"
+	"github.com/Sandwichzzy/event-sync-go/services/api/service"
+)
+
+//go:embed schema.graphql
+var schemaString string
+
+const (
+	// maxDepth 查询的最大嵌套深度
+	maxDepth = 10
+	// maxQueryLength 查询字符串的最大长度
+	maxQueryLength = 10000
+	// maxParallelism 一个请求中同时执行的 resolver 数
+	maxParallelism = 8
+	// maxRequestBytes 请求体的最大字节数
+	maxRequestBytes = 1 << 20
+)
+
+// Handler 处理 GET / POST /graphql 请求
+type Handler struct {
+	schema  *gql.Schema
+	maxCost int64
+}
+
+// NewHandler 解析 schema 并绑定到数据库视图，db 应为只读库
+func NewHandler(db *database.DB) (*Handler, error) {
+	schema, err := gql.ParseSchema(schemaString, newResolver(db, new(service.Validator)),
+		gql.MaxDepth(maxDepth),
+		gql.MaxQueryLength(maxQueryLength),
+		gql.MaxParallelism(maxParallelism),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &Handler{schema: schema, maxCost: defaultMaxCost}, nil
+}
+
+// request GraphQL 请求体，GET 请求从同名查询参数读取，variables 为 JSON 字符串
+type request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req request
+	switch r.Method {
+	case http.MethodGet:
+		query := r.URL.Query()
+		req.Query = query.Get("query")
+		req.OperationName = query.Get("operationName")
+		if variables := query.Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				writeError(w, "invalid variables", http.StatusBadRequest)
+				return
+			}
+		}
+	case http.MethodPost:
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBytes)).Decode(&req); err != nil {
+			writeError(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+	default:
+		writeError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if req.Query == "" {
+		writeError(w, "query is required", http.StatusBadRequest)
+		return
+	}
+
+	ctx := withQueryState(r.Context(), h.maxCost)
+	response := h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		log.Error("Error writing graphql response", "err", err.Error())
+	}
+}
+
+// writeError 返回不是合法 GraphQL 请求时的错误，格式与 GraphQL 的 errors 字段一致
+func writeError(w http.ResponseWriter, message string, status int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]any{"errors": []map[string]string{{"message": message}}})
+}
+
+// errInternal 返回给客户端的数据库错误，具体错误只写日志
+var errInternal = errors.New("internal server error")
+
+// internalError 记录查询失败的原因，返回不包含细节的错误
+func internalError(op string, err error) error {
+	log.Error("graphql query failed", "op", op, "err", err)
+	return errInternal
+}
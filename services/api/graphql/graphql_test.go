@@ -0,0 +1,106 @@
+package graphql
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+	gql "github.com/graph-gophers/graphql-go"
+	"github.com/stretchr/testify/require"
+
+	common2 "github.com/Sandwichzzy/event-sync-go/database/common"
+	"github.com/Sandwichzzy/event-sync-go/database/utils"
+	"github.com/Sandwichzzy/event-sync-go/database/worker"
+	"github.com/Sandwichzzy/event-sync-go/services/api/service"
+)
+
+type fakeDeposits struct {
+	worker.DepositTokensView
+	rows []worker.DepositTokens
+}
+
+func (f fakeDeposits) QueryDepositTokens(filter worker.EventFilter, page utils.Page) ([]worker.DepositTokens, utils.PageInfo, error) {
+	return f.rows, utils.PageInfo{Total: uint64(len(f.rows)), Count: page.Count}, nil
+}
+
+type fakeTokens struct {
+	common2.TokensView
+	calls  int
+	tokens map[common.Address]common2.Token
+}
+
+func (f *fakeTokens) TokensByAddresses(addresses []common.Address) (map[common.Address]common2.Token, error) {
+	f.calls++
+	result := make(map[common.Address]common2.Token)
+	for _, address := range addresses {
+		if token, ok := f.tokens[address]; ok {
+			result[address] = token
+		}
+	}
+	return result, nil
+}
+
+func newTestHandler(t *testing.T, resolver *Resolver, maxCost int64) *Handler {
+	schema, err := gql.ParseSchema(schemaString, resolver, gql.MaxDepth(maxDepth), gql.MaxParallelism(maxParallelism))
+	require.NoError(t, err)
+	return &Handler{schema: schema, maxCost: maxCost}
+}
+
+func execute(t *testing.T, h *Handler, query string) map[string]any {
+	body, err := json.Marshal(map[string]string{"query": query})
+	require.NoError(t, err)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body))))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var response map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	return response
+}
+
+func TestDepositsWithTokens(t *testing.T) {
+	usdt := common.HexToAddress("0x1")
+	unknown := common.HexToAddress("0x2")
+	tokens := &fakeTokens{tokens: map[common.Address]common2.Token{usdt: {Address: usdt, Symbol: "USDT", Decimals: 6}}}
+	resolver := &Resolver{
+		v: new(service.Validator),
+		depositTokensView: fakeDeposits{rows: []worker.DepositTokens{
+			{GUID: uuid.New(), BlockNumber: big.NewInt(10), TokenAddress: usdt, Amount: big.NewInt(1500000)},
+			{GUID: uuid.New(), BlockNumber: big.NewInt(9), TokenAddress: unknown, Amount: big.NewInt(7)},
+			{GUID: uuid.New(), BlockNumber: big.NewInt(8), TokenAddress: usdt, Amount: big.NewInt(1)},
+		}},
+		tokensView: tokens,
+	}
+	h := newTestHandler(t, resolver, defaultMaxCost)
+
+	response := execute(t, h, `{ deposits(first: 3) { nodes { blockNumber amount formattedAmount token { symbol } } pageInfo { total count } } }`)
+	require.Nil(t, response["errors"])
+	deposits := response["data"].(map[string]any)["deposits"].(map[string]any)
+	nodes := deposits["nodes"].([]any)
+	require.Len(t, nodes, 3)
+	require.Equal(t, "10", nodes[0].(map[string]any)["blockNumber"])
+	require.Equal(t, "1.5", nodes[0].(map[string]any)["formattedAmount"])
+	require.Equal(t, "USDT", nodes[0].(map[string]any)["token"].(map[string]any)["symbol"])
+	require.Nil(t, nodes[1].(map[string]any)["formattedAmount"])
+	require.Nil(t, nodes[1].(map[string]any)["token"])
+	require.Equal(t, map[string]any{"total": "3", "count": "EXACT"}, deposits["pageInfo"])
+	// 本页的代币元数据一次查询
+	require.Equal(t, 1, tokens.calls)
+}
+
+func TestQueryLimits(t *testing.T) {
+	resolver := &Resolver{v: new(service.Validator), depositTokensView: fakeDeposits{}, tokensView: &fakeTokens{}}
+	h := newTestHandler(t, resolver, 150)
+
+	response := execute(t, h, `{ deposits(first: 101) { nodes { guid } } }`)
+	require.Contains(t, response["errors"].([]any)[0].(map[string]any)["message"], "first must be between")
+
+	// 两个 100 条的列表超过成本上限 150
+	response = execute(t, h, `{ a: deposits(first: 100) { nodes { guid } } b: deposits(first: 100) { nodes { guid } } }`)
+	require.Len(t, response["errors"].([]any), 1)
+	require.Contains(t, response["errors"].([]any)[0].(map[string]any)["message"], "too expensive")
+}
@@ -0,0 +1,563 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+	gql "github.com/graph-gophers/graphql-go"
+
+	"github.com/Sandwichzzy/event-sync-go/database"
+	common2 "github.com/Sandwichzzy/event-sync-go/database/common"
+	"github.com/Sandwichzzy/event-sync-go/database/event"
+	"github.com/Sandwichzzy/event-sync-go/database/utils"
+	"github.com/Sandwichzzy/event-sync-go/database/worker"
+	"github.com/Sandwichzzy/event-sync-go/services/api/service"
+)
+
+const (
+	// defaultFirst 列表默认返回的条数
+	defaultFirst = 20
+	// maxFirst 列表一次最多返回的条数
+	maxFirst = 100
+)
+
+// Resolver GraphQL 的根 resolver，持有只读库的数据访问层
+type Resolver struct {
+	v                         *service.Validator
+	depositTokensView         worker.DepositTokensView
+	withdrawTokensView        worker.WithdrawTokensView
+	grantRewardTokensView     worker.GrantRewardTokensView
+	withdrawManagerUpdateView worker.WithdrawManagerUpdateView
+	contractEventsView        event.ContractEventsView
+	rewardLedgerView          worker.RewardLedgerView
+	blocksView                common2.BlocksView
+	tokensView                common2.TokensView
+}
+
+func newResolver(db *database.DB, v *service.Validator) *Resolver {
+	return &Resolver{
+		v:                         v,
+		depositTokensView:         db.DepositTokens,
+		withdrawTokensView:        db.WithdrawTokens,
+		grantRewardTokensView:     db.GrantRewardTokens,
+		withdrawManagerUpdateView: db.WithdrawManagerUpdate,
+		contractEventsView:        db.ContractEvent,
+		rewardLedgerView:          db.RewardLedger,
+		blocksView:                db.Blocks,
+		tokensView:                db.Tokens,
+	}
+}
+
+// pageArgs 列表的分页参数
+type pageArgs struct {
+	First  *int32
+	Cursor *string
+	Order  *string
+	Count  *string
+}
+
+// page 验证分页参数。没有指定 count 时，选择了 pageInfo.total 且不带游标时精确统计，否则不统计
+func (a pageArgs) page(ctx context.Context) (utils.Page, error) {
+	page := utils.Page{Page: 1, PageSize: defaultFirst}
+	if a.First != nil {
+		if *a.First < 1 || *a.First > maxFirst {
+			return page, fmt.Errorf("first must be between 1 and %d", maxFirst)
+		}
+		page.PageSize = int(*a.First)
+	}
+	if a.Order != nil {
+		page.Ascending = *a.Order == "ASC"
+	}
+	if a.Cursor != nil {
+		cursor, err := utils.ParseCursor(*a.Cursor)
+		if err != nil {
+			return page, err
+		}
+		page.Cursor = cursor
+	}
+
+	fallback := utils.CountNone
+	if page.Cursor == nil && gql.HasSelectedField(ctx, "pageInfo.total") {
+		fallback = utils.CountExact
+	}
+	var count string
+	if a.Count != nil {
+		count = *a.Count
+	}
+	mode, err := utils.ParseCountMode(count, fallback)
+	if err != nil {
+		return page, err
+	}
+	page.Count = mode
+	return page, nil
+}
+
+// cost 一次列表查询的成本：读取的行数，精确统计总数另外计算
+func (a pageArgs) cost(page utils.Page) int64 {
+	cost := int64(page.PageSize) + 1
+	if page.Count == utils.CountExact {
+		cost += exactCountCost
+	}
+	return cost
+}
+
+// eventFilterInput 对应 schema 中的 EventFilter
+type eventFilterInput struct {
+	Token     *string
+	Sender    *string
+	Receiver  *string
+	Address   *string
+	TxHash    *string
+	FromBlock *BigInt
+	ToBlock   *BigInt
+	FromTime  *string
+	ToTime    *string
+}
+
+// contractEventFilterInput 对应 schema 中的 ContractEventFilter
+type contractEventFilterInput struct {
+	Contract  *string
+	Signature *string
+	TxHash    *string
+	FromBlock *BigInt
+	ToBlock   *BigInt
+	FromTime  *string
+	ToTime    *string
+}
+
+type eventListArgs struct {
+	Filter *eventFilterInput
+	pageArgs
+}
+
+type contractEventListArgs struct {
+	Filter *contractEventFilterInput
+	pageArgs
+}
+
+type guidArgs struct {
+	Guid gql.ID
+}
+
+// eventFilter 把输入的过滤条件转换为 worker.EventFilter
+func (r *Resolver) eventFilter(input *eventFilterInput) (worker.EventFilter, error) {
+	var filter worker.EventFilter
+	if input == nil {
+		return filter, nil
+	}
+	var err error
+	for _, address := range []struct {
+		name  string
+		value *string
+		dst   **common.Address
+	}{
+		{"token", input.Token, &filter.TokenAddress},
+		{"sender", input.Sender, &filter.Sender},
+		{"receiver", input.Receiver, &filter.Receiver},
+		{"address", input.Address, &filter.Address},
+	} {
+		if *address.dst, err = r.optionalAddress(address.name, address.value); err != nil {
+			return filter, err
+		}
+	}
+	if filter.TransactionHash, err = r.optionalHash("txHash", input.TxHash); err != nil {
+		return filter, err
+	}
+	if filter.FromBlock, err = bigIntArg("fromBlock", input.FromBlock); err != nil {
+		return filter, err
+	}
+	if filter.ToBlock, err = bigIntArg("toBlock", input.ToBlock); err != nil {
+		return filter, err
+	}
+	if filter.FromTimestamp, err = r.optionalTime("fromTime", input.FromTime); err != nil {
+		return filter, err
+	}
+	if filter.ToTimestamp, err = r.optionalTime("toTime", input.ToTime); err != nil {
+		return filter, err
+	}
+	return filter, nil
+}
+
+// contractEventFilter 把输入的过滤条件转换为 event.ContractEventQuery
+func (r *Resolver) contractEventFilter(input *contractEventFilterInput) (event.ContractEventQuery, error) {
+	var filter event.ContractEventQuery
+	if input == nil {
+		return filter, nil
+	}
+	var err error
+	if filter.ContractAddress, err = r.optionalAddress("contract", input.Contract); err != nil {
+		return filter, err
+	}
+	if filter.EventSignature, err = r.optionalHash("signature", input.Signature); err != nil {
+		return filter, err
+	}
+	if filter.TransactionHash, err = r.optionalHash("txHash", input.TxHash); err != nil {
+		return filter, err
+	}
+	if filter.FromBlock, err = bigIntArg("fromBlock", input.FromBlock); err != nil {
+		return filter, err
+	}
+	if filter.ToBlock, err = bigIntArg("toBlock", input.ToBlock); err != nil {
+		return filter, err
+	}
+	if filter.FromTimestamp, err = r.optionalTime("fromTime", input.FromTime); err != nil {
+		return filter, err
+	}
+	if filter.ToTimestamp, err = r.optionalTime("toTime", input.ToTime); err != nil {
+		return filter, err
+	}
+	return filter, nil
+}
+
+func (r *Resolver) optionalAddress(name string, value *string) (*common.Address, error) {
+	if value == nil {
+		return nil, nil
+	}
+	address, err := r.v.ParseValidateAddress(*value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", name, err)
+	}
+	return &address, nil
+}
+
+func (r *Resolver) optionalHash(name string, value *string) (*common.Hash, error) {
+	if value == nil {
+		return nil, nil
+	}
+	hash, err := r.v.ParseValidateHash(*value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", name, err)
+	}
+	return &hash, nil
+}
+
+func (r *Resolver) optionalTime(name string, value *string) (uint64, error) {
+	if value == nil {
+		return 0, nil
+	}
+	seconds, err := r.v.ParseValidateTime(*value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", name, err)
+	}
+	return seconds, nil
+}
+
+// listEvents 验证分页参数、扣减查询成本后执行列表查询。tokenOf 不为空且选择了代币相关字段时，
+// 一次查询本页涉及的所有代币元数据，避免逐条查询
+func listEvents[T any, N any](ctx context.Context, r *Resolver, args pageArgs, query func(utils.Page) ([]T, utils.PageInfo, error), node func(T) N, tokenOf func(T) common.Address) (*connection[N], error) {
+	page, err := args.page(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := stateFrom(ctx).charge(args.cost(page)); err != nil {
+		return nil, err
+	}
+	rows, info, err := query(page)
+	if errors.Is(err, worker.ErrUnsupportedFilter) {
+		return nil, err
+	} else if err != nil {
+		return nil, internalError("list events", err)
+	}
+
+	if tokenOf != nil && (gql.HasSelectedField(ctx, "nodes.token") || gql.HasSelectedField(ctx, "nodes.formattedAmount")) {
+		addresses := make([]common.Address, 0, len(rows))
+		for _, row := range rows {
+			addresses = append(addresses, tokenOf(row))
+		}
+		if err := r.loadTokens(ctx, addresses); err != nil {
+			return nil, err
+		}
+	}
+
+	nodes := make([]N, 0, len(rows))
+	for _, row := range rows {
+		nodes = append(nodes, node(row))
+	}
+	return &connection[N]{nodes: nodes, info: info}, nil
+}
+
+// loadTokens 把还没有缓存的代币元数据一次查询出来
+func (r *Resolver) loadTokens(ctx context.Context, addresses []common.Address) error {
+	state := stateFrom(ctx)
+	missing := state.missingTokens(addresses)
+	if len(missing) == 0 {
+		return nil
+	}
+	if err := state.charge(int64(len(missing))); err != nil {
+		return err
+	}
+	tokens, err := r.tokensView.TokensByAddresses(missing)
+	if err != nil {
+		return internalError("load tokens", err)
+	}
+	state.cacheTokens(missing, tokens)
+	return nil
+}
+
+// token 返回代币元数据，尚未缓存时返回 nil
+func (r *Resolver) token(ctx context.Context, address common.Address) (*tokenResolver, error) {
+	if err := r.loadTokens(ctx, []common.Address{address}); err != nil {
+		return nil, err
+	}
+	if token := stateFrom(ctx).cachedToken(address); token != nil {
+		return &tokenResolver{token: *token}, nil
+	}
+	return nil, nil
+}
+
+// blockByHash 按哈希查询区块头，同一请求内只查询一次
+func (r *Resolver) blockByHash(ctx context.Context, hash common.Hash) (*blockResolver, error) {
+	state := stateFrom(ctx)
+	header, ok := state.cachedBlock(hash)
+	if !ok {
+		if err := state.charge(lookupCost); err != nil {
+			return nil, err
+		}
+		var err error
+		if header, err = r.blocksView.BlockHeader(hash); err != nil {
+			return nil, internalError("block by hash", err)
+		}
+		state.cacheBlock(hash, header)
+	}
+	if header == nil {
+		return nil, nil
+	}
+	return &blockResolver{r: r, header: *header}, nil
+}
+
+func (r *Resolver) Deposits(ctx context.Context, args eventListArgs) (*connection[*depositResolver], error) {
+	filter, err := r.eventFilter(args.Filter)
+	if err != nil {
+		return nil, err
+	}
+	return r.deposits(ctx, filter, args.pageArgs)
+}
+
+func (r *Resolver) Deposit(ctx context.Context, args guidArgs) (*depositResolver, error) {
+	guid, err := lookupGUID(ctx, args.Guid)
+	if err != nil {
+		return nil, err
+	}
+	dt, err := r.depositTokensView.DepositTokensByGUID(guid.String())
+	if err != nil {
+		return nil, internalError("deposit", err)
+	} else if dt == nil {
+		return nil, nil
+	}
+	return r.deposit(*dt), nil
+}
+
+func (r *Resolver) Withdrawals(ctx context.Context, args eventListArgs) (*connection[*withdrawalResolver], error) {
+	filter, err := r.eventFilter(args.Filter)
+	if err != nil {
+		return nil, err
+	}
+	return r.withdrawals(ctx, filter, args.pageArgs)
+}
+
+func (r *Resolver) Withdrawal(ctx context.Context, args guidArgs) (*withdrawalResolver, error) {
+	guid, err := lookupGUID(ctx, args.Guid)
+	if err != nil {
+		return nil, err
+	}
+	wt, err := r.withdrawTokensView.WithdrawTokensByGUID(guid.String())
+	if err != nil {
+		return nil, internalError("withdrawal", err)
+	} else if wt == nil {
+		return nil, nil
+	}
+	return r.withdrawal(*wt), nil
+}
+
+func (r *Resolver) Grants(ctx context.Context, args eventListArgs) (*connection[*grantResolver], error) {
+	filter, err := r.eventFilter(args.Filter)
+	if err != nil {
+		return nil, err
+	}
+	return r.grants(ctx, filter, args.pageArgs)
+}
+
+func (r *Resolver) Grant(ctx context.Context, args guidArgs) (*grantResolver, error) {
+	guid, err := lookupGUID(ctx, args.Guid)
+	if err != nil {
+		return nil, err
+	}
+	gr, err := r.grantRewardTokensView.GrantRewardTokensByGUID(guid.String())
+	if err != nil {
+		return nil, internalError("grant", err)
+	} else if gr == nil {
+		return nil, nil
+	}
+	return r.grant(*gr), nil
+}
+
+func (r *Resolver) ManagerUpdates(ctx context.Context, args eventListArgs) (*connection[*managerUpdateResolver], error) {
+	filter, err := r.eventFilter(args.Filter)
+	if err != nil {
+		return nil, err
+	}
+	return r.managerUpdates(ctx, filter, args.pageArgs)
+}
+
+func (r *Resolver) ManagerUpdate(ctx context.Context, args guidArgs) (*managerUpdateResolver, error) {
+	guid, err := lookupGUID(ctx, args.Guid)
+	if err != nil {
+		return nil, err
+	}
+	update, err := r.withdrawManagerUpdateView.WithdrawManagerUpdateByGUID(guid.String())
+	if err != nil {
+		return nil, internalError("manager update", err)
+	} else if update == nil {
+		return nil, nil
+	}
+	return r.managerUpdate(*update), nil
+}
+
+func (r *Resolver) ContractEvents(ctx context.Context, args contractEventListArgs) (*connection[*contractEventResolver], error) {
+	filter, err := r.contractEventFilter(args.Filter)
+	if err != nil {
+		return nil, err
+	}
+	return r.contractEvents(ctx, filter, args.pageArgs)
+}
+
+func (r *Resolver) ContractEvent(ctx context.Context, args guidArgs) (*contractEventResolver, error) {
+	guid, err := lookupGUID(ctx, args.Guid)
+	if err != nil {
+		return nil, err
+	}
+	e, err := r.contractEventsView.ContractEventWithBlockNumber(guid)
+	if err != nil {
+		return nil, internalError("contract event", err)
+	} else if e == nil {
+		return nil, nil
+	}
+	return r.contractEvent(*e), nil
+}
+
+type blockArgs struct {
+	Number *BigInt
+	Hash   *string
+}
+
+func (r *Resolver) Block(ctx context.Context, args blockArgs) (*blockResolver, error) {
+	if (args.Number == nil) == (args.Hash == nil) {
+		return nil, errors.New("exactly one of number and hash is required")
+	}
+	if args.Hash != nil {
+		hash, err := r.v.ParseValidateHash(*args.Hash)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hash: %w", err)
+		}
+		return r.blockByHash(ctx, hash)
+	}
+
+	number, err := bigIntArg("number", args.Number)
+	if err != nil {
+		return nil, err
+	}
+	if err := stateFrom(ctx).charge(lookupCost); err != nil {
+		return nil, err
+	}
+	header, err := r.blocksView.BlockHeaderByNumber(number)
+	if err != nil {
+		return nil, internalError("block by number", err)
+	} else if header == nil {
+		return nil, nil
+	}
+	stateFrom(ctx).cacheBlock(header.Hash, header)
+	return &blockResolver{r: r, header: *header}, nil
+}
+
+func (r *Resolver) LatestBlock(ctx context.Context) (*blockResolver, error) {
+	if err := stateFrom(ctx).charge(lookupCost); err != nil {
+		return nil, err
+	}
+	header, err := r.blocksView.LatestBlockHeader()
+	if err != nil {
+		return nil, internalError("latest block", err)
+	} else if header == nil {
+		return nil, nil
+	}
+	stateFrom(ctx).cacheBlock(header.Hash, header)
+	return &blockResolver{r: r, header: *header}, nil
+}
+
+// Transaction 按哈希查询交易，交易中没有已索引的事件时返回 null
+func (r *Resolver) Transaction(ctx context.Context, args struct{ Hash string }) (*transactionResolver, error) {
+	hash, err := r.v.ParseValidateHash(args.Hash)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hash: %w", err)
+	}
+	if err := stateFrom(ctx).charge(lookupCost); err != nil {
+		return nil, err
+	}
+	events, _, err := r.contractEventsView.QueryContractEvents(event.ContractEventQuery{TransactionHash: &hash}, utils.Page{Page: 1, PageSize: 1})
+	if err != nil {
+		return nil, internalError("transaction", err)
+	} else if len(events) == 0 {
+		return nil, nil
+	}
+	return &transactionResolver{r: r, hash: hash, blockHash: events[0].BlockHash}, nil
+}
+
+func (r *Resolver) Token(ctx context.Context, args struct{ Address string }) (*tokenResolver, error) {
+	address, err := r.v.ParseValidateAddress(args.Address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address: %w", err)
+	}
+	return r.token(ctx, address)
+}
+
+func (r *Resolver) Account(ctx context.Context, args struct{ Address string }) (*accountResolver, error) {
+	address, err := r.v.ParseValidateAddress(args.Address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address: %w", err)
+	}
+	return &accountResolver{r: r, address: address}, nil
+}
+
+// lookupGUID 验证 GUID 并扣减一次查询的成本
+func lookupGUID(ctx context.Context, id gql.ID) (uuid.UUID, error) {
+	guid, err := uuid.Parse(string(id))
+	if err != nil {
+		return uuid.Nil, errors.New("invalid guid")
+	}
+	if err := stateFrom(ctx).charge(lookupCost); err != nil {
+		return uuid.Nil, err
+	}
+	return guid, nil
+}
+
+func (r *Resolver) deposits(ctx context.Context, filter worker.EventFilter, args pageArgs) (*connection[*depositResolver], error) {
+	return listEvents(ctx, r, args, func(page utils.Page) ([]worker.DepositTokens, utils.PageInfo, error) {
+		return r.depositTokensView.QueryDepositTokens(filter, page)
+	}, r.deposit, func(dt worker.DepositTokens) common.Address { return dt.TokenAddress })
+}
+
+func (r *Resolver) withdrawals(ctx context.Context, filter worker.EventFilter, args pageArgs) (*connection[*withdrawalResolver], error) {
+	return listEvents(ctx, r, args, func(page utils.Page) ([]worker.WithdrawTokens, utils.PageInfo, error) {
+		return r.withdrawTokensView.QueryWithdrawTokens(filter, page)
+	}, r.withdrawal, func(wt worker.WithdrawTokens) common.Address { return wt.TokenAddress })
+}
+
+func (r *Resolver) grants(ctx context.Context, filter worker.EventFilter, args pageArgs) (*connection[*grantResolver], error) {
+	return listEvents(ctx, r, args, func(page utils.Page) ([]worker.GrantRewardTokens, utils.PageInfo, error) {
+		return r.grantRewardTokensView.QueryGrantRewardTokens(filter, page)
+	}, r.grant, func(gr worker.GrantRewardTokens) common.Address { return gr.TokenAddress })
+}
+
+func (r *Resolver) managerUpdates(ctx context.Context, filter worker.EventFilter, args pageArgs) (*connection[*managerUpdateResolver], error) {
+	return listEvents(ctx, r, args, func(page utils.Page) ([]worker.WithdrawManagerUpdate, utils.PageInfo, error) {
+		return r.withdrawManagerUpdateView.QueryWithdrawManagerUpdates(filter, page)
+	}, r.managerUpdate, nil)
+}
+
+func (r *Resolver) contractEvents(ctx context.Context, filter event.ContractEventQuery, args pageArgs) (*connection[*contractEventResolver], error) {
+	return listEvents(ctx, r, args, func(page utils.Page) ([]event.ContractEvent, utils.PageInfo, error) {
+		return r.contractEventsView.QueryContractEvents(filter, page)
+	}, r.contractEvent, nil)
+}
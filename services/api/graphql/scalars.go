@@ -0,0 +1,66 @@
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+)
+
+// BigInt GraphQL 的 BigInt 标量，输出为十进制字符串，输入接受十进制字符串或整数
+type BigInt struct {
+	big.Int
+}
+
+func newBigInt(n *big.Int) BigInt {
+	var b BigInt
+	if n != nil {
+		b.Set(n)
+	}
+	return b
+}
+
+func uint64BigInt(n uint64) BigInt {
+	var b BigInt
+	b.SetUint64(n)
+	return b
+}
+
+func (BigInt) ImplementsGraphQLType(name string) bool {
+	return name == "BigInt"
+}
+
+func (b *BigInt) UnmarshalGraphQL(input any) error {
+	switch v := input.(type) {
+	case string:
+		if _, ok := b.SetString(v, 10); !ok {
+			return fmt.Errorf("invalid BigInt %q", v)
+		}
+	case int32:
+		b.SetInt64(int64(v))
+	case int64:
+		b.SetInt64(v)
+	case float64:
+		if v != float64(int64(v)) {
+			return fmt.Errorf("invalid BigInt %v", v)
+		}
+		b.SetInt64(int64(v))
+	default:
+		return fmt.Errorf("invalid BigInt type %T", input)
+	}
+	return nil
+}
+
+func (b BigInt) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.String())
+}
+
+// bigIntArg 把可选的 BigInt 参数转换为区块号，负数返回错误
+func bigIntArg(name string, b *BigInt) (*big.Int, error) {
+	if b == nil {
+		return nil, nil
+	}
+	if b.Sign() < 0 {
+		return nil, fmt.Errorf("invalid %s %q", name, b.String())
+	}
+	return new(big.Int).Set(&b.Int), nil
+}
@@ -0,0 +1,439 @@
+package graphql
+
+import (
+	"context"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/google/uuid"
+	gql "github.com/graph-gophers/graphql-go"
+
+	"github.com/Sandwichzzy/event-sync-go/common/bigint"
+	common2 "github.com/Sandwichzzy/event-sync-go/database/common"
+	"github.com/Sandwichzzy/event-sync-go/database/event"
+	"github.com/Sandwichzzy/event-sync-go/database/utils"
+	"github.com/Sandwichzzy/event-sync-go/database/worker"
+)
+
+// connection 分页列表，对应 schema 中的 XxxConnection
+type connection[N any] struct {
+	nodes []N
+	info  utils.PageInfo
+}
+
+func (c *connection[N]) Nodes() []N {
+	return c.nodes
+}
+
+func (c *connection[N]) PageInfo() *pageInfoResolver {
+	return &pageInfoResolver{info: c.info}
+}
+
+type pageInfoResolver struct {
+	info utils.PageInfo
+}
+
+func (p *pageInfoResolver) Total() BigInt {
+	return uint64BigInt(p.info.Total)
+}
+
+func (p *pageInfoResolver) Count() string {
+	return strings.ToUpper(p.info.Count.String())
+}
+
+func (p *pageInfoResolver) NextCursor() *string {
+	return encodeCursor(p.info.Next)
+}
+
+func (p *pageInfoResolver) PrevCursor() *string {
+	return encodeCursor(p.info.Prev)
+}
+
+func encodeCursor(cursor *utils.Cursor) *string {
+	if cursor == nil {
+		return nil
+	}
+	encoded := cursor.Encode()
+	return &encoded
+}
+
+type tokenResolver struct {
+	token common2.Token
+}
+
+func (t *tokenResolver) Address() string {
+	return t.token.Address.String()
+}
+
+func (t *tokenResolver) Name() string {
+	return t.token.Name
+}
+
+func (t *tokenResolver) Symbol() string {
+	return t.token.Symbol
+}
+
+func (t *tokenResolver) Decimals() int32 {
+	return int32(t.token.Decimals)
+}
+
+func (t *tokenResolver) IsNative() bool {
+	return t.token.IsNative
+}
+
+type blockResolver struct {
+	r      *Resolver
+	header common2.BlockHeader
+}
+
+func (b *blockResolver) Hash() string {
+	return b.header.Hash.String()
+}
+
+func (b *blockResolver) ParentHash() string {
+	return b.header.ParentHash.String()
+}
+
+func (b *blockResolver) Number() BigInt {
+	return newBigInt(b.header.Number)
+}
+
+func (b *blockResolver) Timestamp() BigInt {
+	return uint64BigInt(b.header.Timestamp)
+}
+
+// ContractEvents 区块中已索引的原始合约事件
+func (b *blockResolver) ContractEvents(ctx context.Context, args pageArgs) (*connection[*contractEventResolver], error) {
+	number := b.header.Number
+	return b.r.contractEvents(ctx, event.ContractEventQuery{FromBlock: number, ToBlock: number}, args)
+}
+
+type transactionResolver struct {
+	r         *Resolver
+	hash      common.Hash
+	blockHash common.Hash
+}
+
+func (t *transactionResolver) Hash() string {
+	return t.hash.String()
+}
+
+func (t *transactionResolver) Block(ctx context.Context) (*blockResolver, error) {
+	return t.r.blockByHash(ctx, t.blockHash)
+}
+
+func (t *transactionResolver) ContractEvents(ctx context.Context, args pageArgs) (*connection[*contractEventResolver], error) {
+	return t.r.contractEvents(ctx, event.ContractEventQuery{TransactionHash: &t.hash}, args)
+}
+
+func (t *transactionResolver) Deposits(ctx context.Context, args pageArgs) (*connection[*depositResolver], error) {
+	return t.r.deposits(ctx, worker.EventFilter{TransactionHash: &t.hash}, args)
+}
+
+func (t *transactionResolver) Withdrawals(ctx context.Context, args pageArgs) (*connection[*withdrawalResolver], error) {
+	return t.r.withdrawals(ctx, worker.EventFilter{TransactionHash: &t.hash}, args)
+}
+
+func (t *transactionResolver) Grants(ctx context.Context, args pageArgs) (*connection[*grantResolver], error) {
+	return t.r.grants(ctx, worker.EventFilter{TransactionHash: &t.hash}, args)
+}
+
+func (t *transactionResolver) ManagerUpdates(ctx context.Context, args pageArgs) (*connection[*managerUpdateResolver], error) {
+	return t.r.managerUpdates(ctx, worker.EventFilter{TransactionHash: &t.hash}, args)
+}
+
+// accountResolver 一个地址参与的事件：充值和奖励发放按发起方匹配，提现按发起方或接收方匹配，
+// 提现管理员变更按新的 withdraw_manager 匹配
+type accountResolver struct {
+	r       *Resolver
+	address common.Address
+}
+
+func (a *accountResolver) Address() string {
+	return a.address.String()
+}
+
+func (a *accountResolver) Deposits(ctx context.Context, args pageArgs) (*connection[*depositResolver], error) {
+	return a.r.deposits(ctx, worker.EventFilter{Address: &a.address}, args)
+}
+
+func (a *accountResolver) Withdrawals(ctx context.Context, args pageArgs) (*connection[*withdrawalResolver], error) {
+	return a.r.withdrawals(ctx, worker.EventFilter{Address: &a.address}, args)
+}
+
+func (a *accountResolver) Grants(ctx context.Context, args pageArgs) (*connection[*grantResolver], error) {
+	return a.r.grants(ctx, worker.EventFilter{Address: &a.address}, args)
+}
+
+func (a *accountResolver) ManagerUpdates(ctx context.Context, args pageArgs) (*connection[*managerUpdateResolver], error) {
+	return a.r.managerUpdates(ctx, worker.EventFilter{Address: &a.address}, args)
+}
+
+// RewardBalances 该地址每个代币的奖励发放 / 领取 / 可领取汇总
+func (a *accountResolver) RewardBalances(ctx context.Context) ([]*rewardBalanceResolver, error) {
+	if err := stateFrom(ctx).charge(maxFirst); err != nil {
+		return nil, err
+	}
+	balances, err := a.r.rewardLedgerView.QueryRewardBalances(a.address)
+	if err != nil {
+		return nil, internalError("reward balances", err)
+	}
+	if gql.HasSelectedField(ctx, "token") || gql.HasSelectedField(ctx, "formattedGranted") ||
+		gql.HasSelectedField(ctx, "formattedClaimed") || gql.HasSelectedField(ctx, "formattedClaimable") {
+		addresses := make([]common.Address, 0, len(balances))
+		for _, balance := range balances {
+			addresses = append(addresses, balance.TokenAddress)
+		}
+		if err := a.r.loadTokens(ctx, addresses); err != nil {
+			return nil, err
+		}
+	}
+	result := make([]*rewardBalanceResolver, 0, len(balances))
+	for _, balance := range balances {
+		result = append(result, &rewardBalanceResolver{r: a.r, balance: balance})
+	}
+	return result, nil
+}
+
+type rewardBalanceResolver struct {
+	r       *Resolver
+	balance worker.RewardBalance
+}
+
+func (b *rewardBalanceResolver) TokenAddress() string {
+	return b.balance.TokenAddress.String()
+}
+
+func (b *rewardBalanceResolver) Token(ctx context.Context) (*tokenResolver, error) {
+	return b.r.token(ctx, b.balance.TokenAddress)
+}
+
+func (b *rewardBalanceResolver) Granted() BigInt {
+	return newBigInt(b.balance.Granted)
+}
+
+func (b *rewardBalanceResolver) FormattedGranted(ctx context.Context) (*string, error) {
+	return b.r.formatAmount(ctx, b.balance.TokenAddress, b.balance.Granted)
+}
+
+func (b *rewardBalanceResolver) Claimed() BigInt {
+	return newBigInt(b.balance.Claimed)
+}
+
+func (b *rewardBalanceResolver) FormattedClaimed(ctx context.Context) (*string, error) {
+	return b.r.formatAmount(ctx, b.balance.TokenAddress, b.balance.Claimed)
+}
+
+func (b *rewardBalanceResolver) Claimable() BigInt {
+	return newBigInt(b.balance.Claimable)
+}
+
+func (b *rewardBalanceResolver) FormattedClaimable(ctx context.Context) (*string, error) {
+	return b.r.formatAmount(ctx, b.balance.TokenAddress, b.balance.Claimable)
+}
+
+func (b *rewardBalanceResolver) BlockNumber() BigInt {
+	return newBigInt(b.balance.BlockNumber)
+}
+
+func (b *rewardBalanceResolver) Timestamp() BigInt {
+	return uint64BigInt(b.balance.Timestamp)
+}
+
+// formatAmount 按代币精度格式化金额，代币元数据未知时返回 nil
+func (r *Resolver) formatAmount(ctx context.Context, tokenAddress common.Address, amount *big.Int) (*string, error) {
+	token, err := r.token(ctx, tokenAddress)
+	if err != nil || token == nil {
+		return nil, err
+	}
+	if amount == nil {
+		amount = bigint.Zero
+	}
+	formatted := bigint.FormatUnits(amount, token.token.Decimals)
+	return &formatted, nil
+}
+
+// eventResolver 所有链上事件共有的字段和到区块、交易的关联
+type eventResolver struct {
+	r               *Resolver
+	guid            uuid.UUID
+	blockNumber     *big.Int
+	blockHash       common.Hash
+	transactionHash common.Hash
+	logIndex        uint64
+	timestamp       uint64
+}
+
+func (e *eventResolver) Guid() gql.ID {
+	return gql.ID(e.guid.String())
+}
+
+func (e *eventResolver) BlockNumber() BigInt {
+	return newBigInt(e.blockNumber)
+}
+
+func (e *eventResolver) BlockHash() string {
+	return e.blockHash.String()
+}
+
+func (e *eventResolver) TransactionHash() string {
+	return e.transactionHash.String()
+}
+
+func (e *eventResolver) LogIndex() BigInt {
+	return uint64BigInt(e.logIndex)
+}
+
+func (e *eventResolver) Timestamp() BigInt {
+	return uint64BigInt(e.timestamp)
+}
+
+func (e *eventResolver) Block(ctx context.Context) (*blockResolver, error) {
+	return e.r.blockByHash(ctx, e.blockHash)
+}
+
+func (e *eventResolver) Transaction() *transactionResolver {
+	return &transactionResolver{r: e.r, hash: e.transactionHash, blockHash: e.blockHash}
+}
+
+// tokenAmountResolver 带代币金额的事件共有的字段
+type tokenAmountResolver struct {
+	r            *Resolver
+	tokenAddress common.Address
+	amount       *big.Int
+}
+
+func (t *tokenAmountResolver) TokenAddress() string {
+	return t.tokenAddress.String()
+}
+
+func (t *tokenAmountResolver) Token(ctx context.Context) (*tokenResolver, error) {
+	return t.r.token(ctx, t.tokenAddress)
+}
+
+func (t *tokenAmountResolver) Amount() BigInt {
+	return newBigInt(t.amount)
+}
+
+func (t *tokenAmountResolver) FormattedAmount(ctx context.Context) (*string, error) {
+	return t.r.formatAmount(ctx, t.tokenAddress, t.amount)
+}
+
+type depositResolver struct {
+	eventResolver
+	tokenAmountResolver
+	sender common.Address
+}
+
+func (r *Resolver) deposit(dt worker.DepositTokens) *depositResolver {
+	return &depositResolver{
+		eventResolver:       eventResolver{r: r, guid: dt.GUID, blockNumber: dt.BlockNumber, blockHash: dt.BlockHash, transactionHash: dt.TransactionHash, logIndex: dt.LogIndex, timestamp: dt.Timestamp},
+		tokenAmountResolver: tokenAmountResolver{r: r, tokenAddress: dt.TokenAddress, amount: dt.Amount},
+		sender:              dt.Sender,
+	}
+}
+
+func (d *depositResolver) Sender() string {
+	return d.sender.String()
+}
+
+type withdrawalResolver struct {
+	eventResolver
+	tokenAmountResolver
+	sender   common.Address
+	receiver common.Address
+}
+
+func (r *Resolver) withdrawal(wt worker.WithdrawTokens) *withdrawalResolver {
+	return &withdrawalResolver{
+		eventResolver:       eventResolver{r: r, guid: wt.GUID, blockNumber: wt.BlockNumber, blockHash: wt.BlockHash, transactionHash: wt.TransactionHash, logIndex: wt.LogIndex, timestamp: wt.Timestamp},
+		tokenAmountResolver: tokenAmountResolver{r: r, tokenAddress: wt.TokenAddress, amount: wt.Amount},
+		sender:              wt.Sender,
+		receiver:            wt.Receiver,
+	}
+}
+
+func (w *withdrawalResolver) Sender() string {
+	return w.sender.String()
+}
+
+func (w *withdrawalResolver) Receiver() string {
+	return w.receiver.String()
+}
+
+type grantResolver struct {
+	eventResolver
+	tokenAmountResolver
+	granter common.Address
+}
+
+func (r *Resolver) grant(gr worker.GrantRewardTokens) *grantResolver {
+	return &grantResolver{
+		eventResolver:       eventResolver{r: r, guid: gr.GUID, blockNumber: gr.BlockNumber, blockHash: gr.BlockHash, transactionHash: gr.TransactionHash, logIndex: gr.LogIndex, timestamp: gr.Timestamp},
+		tokenAmountResolver: tokenAmountResolver{r: r, tokenAddress: gr.TokenAddress, amount: gr.Amount},
+		granter:             gr.Granter,
+	}
+}
+
+func (g *grantResolver) Granter() string {
+	return g.granter.String()
+}
+
+type managerUpdateResolver struct {
+	eventResolver
+	withdrawManager common.Address
+}
+
+func (r *Resolver) managerUpdate(update worker.WithdrawManagerUpdate) *managerUpdateResolver {
+	return &managerUpdateResolver{
+		eventResolver:   eventResolver{r: r, guid: update.GUID, blockNumber: update.BlockNumber, blockHash: update.BlockHash, transactionHash: update.TransactionHash, logIndex: update.LogIndex, timestamp: update.Timestamp},
+		withdrawManager: update.WithdrawManager,
+	}
+}
+
+func (m *managerUpdateResolver) WithdrawManager() string {
+	return m.withdrawManager.String()
+}
+
+type contractEventResolver struct {
+	eventResolver
+	contractAddress common.Address
+	eventSignature  common.Hash
+	topics          []common.Hash
+	data            []byte
+}
+
+func (r *Resolver) contractEvent(e event.ContractEvent) *contractEventResolver {
+	resolver := &contractEventResolver{
+		eventResolver:   eventResolver{r: r, guid: e.GUID, blockNumber: e.BlockNumber, blockHash: e.BlockHash, transactionHash: e.TransactionHash, logIndex: e.LogIndex, timestamp: e.Timestamp},
+		contractAddress: e.ContractAddress,
+		eventSignature:  e.EventSignature,
+	}
+	if e.RLPLog != nil {
+		resolver.topics = e.RLPLog.Topics
+		resolver.data = e.RLPLog.Data
+	}
+	return resolver
+}
+
+func (c *contractEventResolver) ContractAddress() string {
+	return c.contractAddress.String()
+}
+
+func (c *contractEventResolver) EventSignature() string {
+	return c.eventSignature.String()
+}
+
+func (c *contractEventResolver) Topics() []string {
+	topics := make([]string, 0, len(c.topics))
+	for _, topic := range c.topics {
+		topics = append(topics, topic.String())
+	}
+	return topics
+}
+
+func (c *contractEventResolver) Data() string {
+	return hexutil.Encode(c.data)
+}
@@ -1,7 +1,18 @@
 // Package models 定义API层的数据模型和请求/响应结构体
 package models
 
-import "github.com/Sandwichzzy/event-sync-go/database/worker"
+import (
+	"encoding/json"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+
+	"github.com/Sandwichzzy/event-sync-go/database/event"
+	"github.com/Sandwichzzy/event-sync-go/database/utils"
+	"github.com/Sandwichzzy/event-sync-go/database/worker"
+)
 
 // QueryDTParams 充值代币列表查询参数
 // 用于分页查询时传递查询条件
@@ -14,8 +25,434 @@ type QueryDTParams struct {
 // DepositTokensResponse 充值代币列表的API响应结构
 // 采用标准的分页响应格式，包含元数据和结果列表
 type DepositTokensResponse struct {
-	Current int                    `json:"Current"` // 当前页码
-	Size    int                    `json:"Size"`    // 当前页条数
-	Total   int64                  `json:"Total"`   // 总记录数
-	Result  []worker.DepositTokens `json:"result"`  // 当前页的充值代币数据列表
+	Current int            `json:"Current"`        // 当前页码
+	Size    int            `json:"Size"`           // 当前页条数
+	Total   int64          `json:"Total"`          // 总记录数
+	Result  []DepositToken `json:"result"`         // 当前页的充值代币数据列表
+	Count   string         `json:"count"`          // Total 的统计方式：exact / approximate / none
+	Next    string         `json:"next,omitempty"` // 下一页游标，没有更多记录时为空
+	Prev    string         `json:"prev,omitempty"` // 上一页游标，没有更早记录时为空
+}
+
+// TokenInfo 代币元数据，元数据尚未缓存时 Decimals 为 nil
+type TokenInfo struct {
+	Symbol   string `json:"symbol"`   // 代币符号
+	Decimals *uint8 `json:"decimals"` // 代币精度
+}
+
+// DepositToken 充值记录的API表示
+// 金额以字符串返回原始整数（不丢精度），同时返回按代币精度格式化后的金额（元数据未知时为空字符串）
+type DepositToken struct {
+	GUID            uuid.UUID `json:"guid"`
+	BlockNumber     *big.Int  `json:"block_number"`
+	BlockHash       string    `json:"block_hash"`
+	TransactionHash string    `json:"transaction_hash"`
+	LogIndex        uint64    `json:"log_index"`
+	TokenAddress    string    `json:"token_address"`
+	Sender          string    `json:"sender"`
+	Amount          string    `json:"Amount"`           // 原始金额（最小单位）
+	FormattedAmount string    `json:"formatted_amount"` // 格式化金额
+	Token           TokenInfo `json:"token"`
+	Timestamp       uint64    `json:"Timestamp"`
+}
+
+// QueryRewardParams 用户奖励账本查询参数
+type QueryRewardParams struct {
+	Address  common.Address // 用户地址
+	Page     int            // 流水页码
+	PageSize int            // 流水每页条数
+}
+
+// RewardLedgerResponse 用户奖励账本的API响应结构
+// Balances 为该用户每个代币的发放/领取/可领取汇总，Entries 为分页后的奖励流水
+type RewardLedgerResponse struct {
+	Address  string              `json:"address"`  // 用户地址
+	Balances []RewardBalance     `json:"balances"` // 每个代币的奖励汇总
+	Current  int                 `json:"Current"`  // 流水当前页码
+	Size     int                 `json:"Size"`     // 流水每页条数
+	Total    int64               `json:"Total"`    // 流水总记录数
+	Entries  []RewardLedgerEntry `json:"entries"`  // 当前页的奖励流水
+}
+
+// RewardBalance 用户某个代币的奖励汇总，金额字段含义同 DepositToken
+type RewardBalance struct {
+	TokenAddress          string    `json:"token_address"`
+	Granted               string    `json:"granted"`
+	FormattedGranted      string    `json:"formatted_granted"`
+	Claimed               string    `json:"claimed"`
+	FormattedClaimed      string    `json:"formatted_claimed"`
+	Claimable             string    `json:"claimable"`
+	FormattedClaimable    string    `json:"formatted_claimable"`
+	Token                 TokenInfo `json:"token"`
+	BlockNumber           *big.Int  `json:"block_number"`
+	ReconciledBlockNumber *big.Int  `json:"reconciled_block_number"`
+	Timestamp             uint64    `json:"timestamp"`
+}
+
+// RewardLedgerEntry 一条奖励流水，金额字段含义同 DepositToken
+type RewardLedgerEntry struct {
+	GUID            uuid.UUID `json:"guid"`
+	TokenAddress    string    `json:"token_address"`
+	EntryType       string    `json:"entry_type"`
+	Amount          string    `json:"amount"`
+	FormattedAmount string    `json:"formatted_amount"`
+	Token           TokenInfo `json:"token"`
+	BlockNumber     *big.Int  `json:"block_number"`
+	BlockHash       string    `json:"block_hash"`
+	Timestamp       uint64    `json:"timestamp"`
+}
+
+// CreateWebhookRequest 创建 webhook 订阅的请求体，过滤字段为空表示不过滤，secret 为空时由服务端生成
+type CreateWebhookRequest struct {
+	URL          string   `json:"url"`
+	EventTypes   []string `json:"event_types"`
+	TokenAddress string   `json:"token_address"`
+	Address      string   `json:"address"`
+	Secret       string   `json:"secret"`
+}
+
+// CreateWebhookParams 验证后的创建 webhook 订阅参数
+type CreateWebhookParams struct {
+	URL          string
+	EventTypes   []string
+	TokenAddress *common.Address
+	Address      *common.Address
+	Secret       string
+}
+
+// UpdateWebhookRequest 启用或停用 webhook 订阅的请求体
+type UpdateWebhookRequest struct {
+	Active *bool `json:"active"`
+}
+
+// WebhookSubscription webhook 订阅的API表示，Secret 只在创建时返回
+type WebhookSubscription struct {
+	GUID         uuid.UUID `json:"guid"`
+	URL          string    `json:"url"`
+	EventTypes   []string  `json:"event_types"`
+	TokenAddress string    `json:"token_address,omitempty"`
+	Address      string    `json:"address,omitempty"`
+	Active       bool      `json:"active"`
+	Secret       string    `json:"secret,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// QueryWebhookDeliveriesParams webhook 投递记录查询参数
+type QueryWebhookDeliveriesParams struct {
+	SubscriptionGUID uuid.UUID // 订阅 GUID
+	Status           string    // 投递状态，为空时不过滤
+	Page             int       // 页码
+	PageSize         int       // 每页条数
+}
+
+// WebhookDeliveriesResponse webhook 投递记录的分页响应
+type WebhookDeliveriesResponse struct {
+	Current int               `json:"Current"`
+	Size    int               `json:"Size"`
+	Total   int64             `json:"Total"`
+	Result  []WebhookDelivery `json:"result"`
+}
+
+// WebhookDelivery webhook 投递记录的API表示，Payload 为发送给订阅方的请求体
+type WebhookDelivery struct {
+	GUID             uuid.UUID       `json:"guid"`
+	SubscriptionGUID uuid.UUID       `json:"subscription_guid"`
+	OutboxID         uint64          `json:"outbox_id"`
+	EventType        string          `json:"event_type"`
+	Action           string          `json:"action"`
+	Payload          json.RawMessage `json:"payload"`
+	Status           string          `json:"status"`
+	Attempts         int             `json:"attempts"`
+	LastError        string          `json:"last_error"`
+	NextAttemptAt    time.Time       `json:"next_attempt_at"`
+	CreatedAt        time.Time       `json:"created_at"`
+	UpdatedAt        time.Time       `json:"updated_at"`
+}
+
+// StreamParams SSE 实时推送参数
+type StreamParams struct {
+	AfterID      uint64           // 从该 outbox 消息 ID 之后开始推送（Last-Event-ID）
+	EventTypes   []string         // 事件类型过滤，为空时不过滤
+	Addresses    []common.Address // 参与方地址过滤（sender / receiver / granter），为空时不过滤
+	TokenAddress *common.Address  // 代币地址过滤，为 nil 时不过滤
+}
+
+// StreamEvent 实时推送的一条事件，ID 为 outbox 消息 ID，action 为 added 或 removed（回滚、重建时的补偿消息）
+type StreamEvent struct {
+	ID              uint64          `json:"id"`
+	ContractAddress string          `json:"contract_address"`
+	EventType       string          `json:"event_type"`
+	Action          string          `json:"action"`
+	BlockNumber     *big.Int        `json:"block_number"`
+	BlockHash       string          `json:"block_hash"`
+	TransactionHash string          `json:"transaction_hash"`
+	LogIndex        uint64          `json:"log_index"`
+	Payload         json.RawMessage `json:"payload"`
+	Timestamp       uint64          `json:"timestamp"`
+}
+
+// ErrorResponse 所有 JSON 接口的错误响应体
+type ErrorResponse struct {
+	Code    int    `json:"code"`    // HTTP 状态码
+	Message string `json:"message"` // 错误说明
+}
+
+// EventListRequest 事件列表的原始查询参数，空字符串表示不过滤或使用默认值。
+// 时间范围接受 unix 秒或 RFC 3339，区块和时间范围均包含边界
+type EventListRequest struct {
+	Page      string
+	PageSize  string
+	Order     string // asc / desc，按 (block_number, log_index) 排序，默认 desc
+	Cursor    string // 上一页响应中的 next / prev，与 page 互斥，游标中已包含排序方式
+	Count     string // none / exact / approximate，按页码分页时默认 exact，按游标分页时默认 none
+	Sender    string // 发起方（sender / granter / withdraw_manager）
+	Receiver  string
+	Address   string // 任一参与方
+	Token     string
+	FromBlock string
+	ToBlock   string
+	FromTime  string
+	ToTime    string
+	TxHash    string
+	Contract  string // 只用于原始合约事件
+	Signature string // 只用于原始合约事件：事件签名（topic0）
+}
+
+// QueryEventsParams 验证后的 worker 事件列表查询参数
+type QueryEventsParams struct {
+	Page     int
+	PageSize int
+	Order    string
+	Cursor   *utils.Cursor   // 不为空时按游标分页，忽略 Page 和 Order
+	Count    utils.CountMode // 总数的统计方式
+	Filter   worker.EventFilter
+}
+
+// QueryContractEventsParams 验证后的原始合约事件列表查询参数
+type QueryContractEventsParams struct {
+	Page     int
+	PageSize int
+	Order    string
+	Cursor   *utils.Cursor   // 不为空时按游标分页，忽略 Page 和 Order
+	Count    utils.CountMode // 总数的统计方式
+	Filter   event.ContractEventQuery
+}
+
+// WithdrawTokensResponse 提现记录的分页响应
+type WithdrawTokensResponse struct {
+	Current int             `json:"Current"`
+	Size    int             `json:"Size"`
+	Total   int64           `json:"Total"`
+	Result  []WithdrawToken `json:"result"`
+	Count   string          `json:"count"`          // Total 的统计方式：exact / approximate / none
+	Next    string          `json:"next,omitempty"` // 下一页游标，没有更多记录时为空
+	Prev    string          `json:"prev,omitempty"` // 上一页游标，没有更早记录时为空
+}
+
+// WithdrawToken 提现记录的API表示，金额字段含义同 DepositToken
+type WithdrawToken struct {
+	GUID            uuid.UUID `json:"guid"`
+	BlockNumber     *big.Int  `json:"block_number"`
+	BlockHash       string    `json:"block_hash"`
+	TransactionHash string    `json:"transaction_hash"`
+	LogIndex        uint64    `json:"log_index"`
+	TokenAddress    string    `json:"token_address"`
+	Sender          string    `json:"sender"`
+	Receiver        string    `json:"receiver"`
+	Amount          string    `json:"amount"`
+	FormattedAmount string    `json:"formatted_amount"`
+	Token           TokenInfo `json:"token"`
+	Timestamp       uint64    `json:"timestamp"`
+}
+
+// GrantRewardTokensResponse 奖励发放记录的分页响应
+type GrantRewardTokensResponse struct {
+	Current int                `json:"Current"`
+	Size    int                `json:"Size"`
+	Total   int64              `json:"Total"`
+	Result  []GrantRewardToken `json:"result"`
+	Count   string             `json:"count"`          // Total 的统计方式：exact / approximate / none
+	Next    string             `json:"next,omitempty"` // 下一页游标，没有更多记录时为空
+	Prev    string             `json:"prev,omitempty"` // 上一页游标，没有更早记录时为空
+}
+
+// GrantRewardToken 奖励发放记录的API表示，金额字段含义同 DepositToken
+type GrantRewardToken struct {
+	GUID            uuid.UUID `json:"guid"`
+	BlockNumber     *big.Int  `json:"block_number"`
+	BlockHash       string    `json:"block_hash"`
+	TransactionHash string    `json:"transaction_hash"`
+	LogIndex        uint64    `json:"log_index"`
+	TokenAddress    string    `json:"token_address"`
+	Granter         string    `json:"granter"`
+	Amount          string    `json:"amount"`
+	FormattedAmount string    `json:"formatted_amount"`
+	Token           TokenInfo `json:"token"`
+	Timestamp       uint64    `json:"timestamp"`
+}
+
+// WithdrawManagerUpdatesResponse 提现管理员变更记录的分页响应
+type WithdrawManagerUpdatesResponse struct {
+	Current int                     `json:"Current"`
+	Size    int                     `json:"Size"`
+	Total   int64                   `json:"Total"`
+	Result  []WithdrawManagerUpdate `json:"result"`
+	Count   string                  `json:"count"`          // Total 的统计方式：exact / approximate / none
+	Next    string                  `json:"next,omitempty"` // 下一页游标，没有更多记录时为空
+	Prev    string                  `json:"prev,omitempty"` // 上一页游标，没有更早记录时为空
+}
+
+// WithdrawManagerUpdate 提现管理员变更记录的API表示
+type WithdrawManagerUpdate struct {
+	GUID            uuid.UUID `json:"guid"`
+	BlockNumber     *big.Int  `json:"block_number"`
+	BlockHash       string    `json:"block_hash"`
+	TransactionHash string    `json:"transaction_hash"`
+	LogIndex        uint64    `json:"log_index"`
+	WithdrawManager string    `json:"withdraw_manager"`
+	Timestamp       uint64    `json:"timestamp"`
+}
+
+// ContractEventsResponse 原始合约事件的分页响应
+type ContractEventsResponse struct {
+	Current int             `json:"Current"`
+	Size    int             `json:"Size"`
+	Total   int64           `json:"Total"`
+	Result  []ContractEvent `json:"result"`
+	Count   string          `json:"count"`          // Total 的统计方式：exact / approximate / none
+	Next    string          `json:"next,omitempty"` // 下一页游标，没有更多记录时为空
+	Prev    string          `json:"prev,omitempty"` // 上一页游标，没有更早记录时为空
+}
+
+// ContractEvent 原始合约事件（未解码的日志）的API表示
+type ContractEvent struct {
+	GUID            uuid.UUID `json:"guid"`
+	BlockNumber     *big.Int  `json:"block_number"`
+	BlockHash       string    `json:"block_hash"`
+	TransactionHash string    `json:"transaction_hash"`
+	LogIndex        uint64    `json:"log_index"`
+	ContractAddress string    `json:"contract_address"`
+	EventSignature  string    `json:"event_signature"`
+	Topics          []string  `json:"topics"`
+	Data            string    `json:"data"`
+	Timestamp       uint64    `json:"timestamp"`
+}
+
+// StatsRequest 代币统计的原始查询参数，空字符串表示使用默认值
+type StatsRequest struct {
+	Bucket string // hour / day，默认 day
+	From   string // 起始时间（包含），unix 秒或 RFC 3339，向下对齐到桶的起始时间
+	To     string // 结束时间（不包含），默认当前时间
+	Token  string // 代币地址，为空时返回所有代币
+	Top    string // 每个代币返回的发放者数量，默认 10
+}
+
+// QueryStatsParams 验证后的代币统计查询参数，时间范围为 [From, To)
+type QueryStatsParams struct {
+	Bucket string
+	From   uint64
+	To     uint64
+	Token  *common.Address
+	Top    int
+}
+
+// StatsResponse 代币统计的API响应结构
+// Buckets 为每个桶每个代币的汇总（没有事件的桶不返回），TopGranters 为整个时间范围内每个代币发放金额最多的地址
+type StatsResponse struct {
+	Bucket      string         `json:"bucket"`
+	From        uint64         `json:"from"`
+	To          uint64         `json:"to"`
+	Buckets     []TokenStats   `json:"buckets"`
+	TopGranters []GranterStats `json:"top_granters"`
+}
+
+// TokenStats 一个代币在一个桶内的汇总，金额字段含义同 DepositToken
+type TokenStats struct {
+	BucketStart             uint64    `json:"bucket_start"`
+	TokenAddress            string    `json:"token_address"`
+	DepositCount            uint64    `json:"deposit_count"`
+	DepositVolume           string    `json:"deposit_volume"`
+	FormattedDepositVolume  string    `json:"formatted_deposit_volume"`
+	UniqueDepositors        uint64    `json:"unique_depositors"`
+	WithdrawCount           uint64    `json:"withdraw_count"`
+	WithdrawVolume          string    `json:"withdraw_volume"`
+	FormattedWithdrawVolume string    `json:"formatted_withdraw_volume"`
+	GrantCount              uint64    `json:"grant_count"`
+	GrantVolume             string    `json:"grant_volume"`
+	FormattedGrantVolume    string    `json:"formatted_grant_volume"`
+	Token                   TokenInfo `json:"token"`
+}
+
+// GranterStats 一个发放者在时间范围内某个代币的奖励发放合计
+type GranterStats struct {
+	TokenAddress         string    `json:"token_address"`
+	Granter              string    `json:"granter"`
+	GrantCount           uint64    `json:"grant_count"`
+	GrantVolume          string    `json:"grant_volume"`
+	FormattedGrantVolume string    `json:"formatted_grant_volume"`
+	Token                TokenInfo `json:"token"`
+}
+
+// QueryAddressActivityParams 验证后的地址时间线查询参数，Filter 中只有代币、交易、区块和时间条件
+type QueryAddressActivityParams struct {
+	Address common.Address
+	QueryEventsParams
+}
+
+// AddressActivityResponse 地址时间线的API响应结构
+// Summary 为该地址每个代币的汇总（不受过滤条件影响），Result 为按 (block_number, log_index) 排序的分页时间线
+type AddressActivityResponse struct {
+	Address string                `json:"address"`
+	Summary []AddressTokenSummary `json:"summary"`
+	Current int                   `json:"Current"`
+	Size    int                   `json:"Size"`
+	Total   int64                 `json:"Total"`
+	Result  []AddressActivity     `json:"result"`
+	Count   string                `json:"count"`          // Total 的统计方式：exact / approximate / none
+	Next    string                `json:"next,omitempty"` // 下一页游标，没有更多记录时为空
+	Prev    string                `json:"prev,omitempty"` // 上一页游标，没有更早记录时为空
+}
+
+// AddressActivity 地址时间线中的一条记录，event_type 为 deposit / withdraw / grant / manager_update。
+// sender 为发起方（sender / granter / withdraw_manager），只有提现有 receiver，提现管理员变更没有代币和金额
+type AddressActivity struct {
+	EventType       string     `json:"event_type"`
+	GUID            uuid.UUID  `json:"guid"`
+	BlockNumber     *big.Int   `json:"block_number"`
+	BlockHash       string     `json:"block_hash"`
+	TransactionHash string     `json:"transaction_hash"`
+	LogIndex        uint64     `json:"log_index"`
+	TokenAddress    string     `json:"token_address,omitempty"`
+	Sender          string     `json:"sender"`
+	Receiver        string     `json:"receiver,omitempty"`
+	Amount          string     `json:"amount,omitempty"`
+	FormattedAmount string     `json:"formatted_amount,omitempty"`
+	Token           *TokenInfo `json:"token,omitempty"`
+	Timestamp       uint64     `json:"timestamp"`
+}
+
+// AddressTokenSummary 地址在一个代币上的充值、提现（作为发起方或接收方）和奖励发放（事件中的 granter 为该地址）的笔数与合计，
+// 金额字段含义同 DepositToken
+type AddressTokenSummary struct {
+	TokenAddress           string    `json:"token_address"`
+	DepositCount           uint64    `json:"deposit_count"`
+	Deposited              string    `json:"deposited"`
+	FormattedDeposited     string    `json:"formatted_deposited"`
+	WithdrawCount          uint64    `json:"withdraw_count"`
+	Withdrawn              string    `json:"withdrawn"`
+	FormattedWithdrawn     string    `json:"formatted_withdrawn"`
+	GranterCount           uint64    `json:"granter_count"`
+	GranterAmount          string    `json:"granter_amount"`
+	FormattedGranterAmount string    `json:"formatted_granter_amount"`
+	Token                  TokenInfo `json:"token"`
+}
+
+// ExportRequest 导出的原始查询参数，from / to 接受 unix 秒、RFC 3339 或 UTC 日期（2006-01-02），均包含边界
+type ExportRequest struct {
+	Entity string // deposits / withdrawals / grants / manager-updates
+	Format string // csv / ndjson，默认 csv
+	From   string
+	To     string
+	Token  string
 }
@@ -0,0 +1,44 @@
+package routes
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/go-chi/chi/v5"
+
+	"github.com/Sandwichzzy/event-sync-go/services/api/service"
+)
+
+// AddressActivityHandler 处理地址时间线查询请求
+//
+// HTTP端点: GET /api/v1/addresses/{address}
+// 查询参数: 同事件列表的分页参数（page / pageSize / order / cursor / count）和 token / fromBlock / toBlock / fromTime / toTime / txHash 过滤，
+// 不接受 sender / receiver / address
+//
+// 响应:
+//   - 200 OK: 返回该地址每个代币的充值 / 提现 / 获得奖励汇总，以及按 (block_number, log_index) 排序、合并了充值（sender）、
+//     提现（sender 或 receiver）、奖励发放（granter）和提现管理员变更（withdraw_manager）的分页时间线
+//   - 400 Bad Request: 地址、分页或过滤参数无效
+//   - 500 Internal Server Error: 数据库查询失败
+func (h Routes) AddressActivityHandler(w http.ResponseWriter, r *http.Request) {
+	params, err := h.svc.QueryAddressActivityParams(chi.URLParam(r, "address"), eventListRequest(r))
+	if err != nil {
+		errorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	activity, err := h.svc.GetAddressActivity(params)
+	if errors.Is(err, service.ErrUnsupportedFilter) {
+		errorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	} else if err != nil {
+		errorResponse(w, InternalServerError, http.StatusInternalServerError)
+		log.Error("Unable to read address activity from DB", "err", err.Error())
+		return
+	}
+
+	if err := jsonResponse(w, activity, http.StatusOK); err != nil {
+		log.Error("Error writing response", "err", err.Error())
+	}
+}
@@ -4,6 +4,10 @@ package routes
 import (
 	"encoding/json"
 	"net/http"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/Sandwichzzy/event-sync-go/services/api/models"
 )
 
 const (
@@ -54,3 +58,20 @@ func jsonResponse(w http.ResponseWriter, data interface{}, statusCode int) error
 
 	return nil
 }
+
+// errorResponse 以统一的 JSON 格式返回错误：{"code":400,"message":"..."}
+func errorResponse(w http.ResponseWriter, message string, statusCode int) {
+	if err := jsonResponse(w, models.ErrorResponse{Code: statusCode, Message: message}, statusCode); err != nil {
+		log.Error("Error writing response", "err", err.Error())
+	}
+}
+
+// NotFoundHandler 未匹配到路由时返回 JSON 格式的 404
+func NotFoundHandler(w http.ResponseWriter, r *http.Request) {
+	errorResponse(w, "route not found", http.StatusNotFound)
+}
+
+// MethodNotAllowedHandler 路由不支持请求方法时返回 JSON 格式的 405
+func MethodNotAllowedHandler(w http.ResponseWriter, r *http.Request) {
+	errorResponse(w, "method not allowed", http.StatusMethodNotAllowed)
+}
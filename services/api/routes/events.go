@@ -0,0 +1,166 @@
+package routes
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/Sandwichzzy/event-sync-go/services/api/models"
+	"github.com/Sandwichzzy/event-sync-go/services/api/service"
+)
+
+// 事件列表的通用查询参数:
+//   - page / pageSize: 页码（默认为1）和每页条数（默认为20，最大1000）
+//   - order: asc / desc，按 (block_number, log_index) 排序，默认 desc
+//   - cursor: 上一页响应中的 next / prev，按游标（keyset）分页，与 page 互斥，游标中已包含排序方式
+//   - count: none / exact / approximate，按页码分页时默认 exact，按游标分页时默认 none（Total 为 0）
+//   - sender / receiver / address / token: 发起方、接收方、任一参与方、代币地址
+//   - fromBlock / toBlock: 区块范围（包含边界）
+//   - fromTime / toTime: 时间范围（包含边界），unix 秒或 RFC 3339
+//   - txHash: 交易哈希
+//
+// 实体没有对应字段的过滤条件（如奖励发放的 receiver）返回 400
+
+// DepositsHandler 按过滤条件分页查询充值记录
+//
+// HTTP端点: GET /api/v1/deposits（兼容 GET /api/v1/deposit/tokens）
+func (h Routes) DepositsHandler(w http.ResponseWriter, r *http.Request) {
+	listEvents(w, r, h.svc.QueryEventListParams, h.svc.GetDepositTokens)
+}
+
+// DepositHandler 查询一条充值记录
+//
+// HTTP端点: GET /api/v1/deposits/{guid}
+func (h Routes) DepositHandler(w http.ResponseWriter, r *http.Request) {
+	eventDetail(w, r, "deposit", h.svc.GetDepositToken)
+}
+
+// WithdrawalsHandler 按过滤条件分页查询提现记录
+//
+// HTTP端点: GET /api/v1/withdrawals
+func (h Routes) WithdrawalsHandler(w http.ResponseWriter, r *http.Request) {
+	listEvents(w, r, h.svc.QueryEventListParams, h.svc.GetWithdrawTokens)
+}
+
+// WithdrawalHandler 查询一条提现记录
+//
+// HTTP端点: GET /api/v1/withdrawals/{guid}
+func (h Routes) WithdrawalHandler(w http.ResponseWriter, r *http.Request) {
+	eventDetail(w, r, "withdrawal", h.svc.GetWithdrawToken)
+}
+
+// GrantsHandler 按过滤条件分页查询奖励发放记录，sender 匹配 granter
+//
+// HTTP端点: GET /api/v1/grants
+func (h Routes) GrantsHandler(w http.ResponseWriter, r *http.Request) {
+	listEvents(w, r, h.svc.QueryEventListParams, h.svc.GetGrantRewardTokens)
+}
+
+// GrantHandler 查询一条奖励发放记录
+//
+// HTTP端点: GET /api/v1/grants/{guid}
+func (h Routes) GrantHandler(w http.ResponseWriter, r *http.Request) {
+	eventDetail(w, r, "grant", h.svc.GetGrantRewardToken)
+}
+
+// ManagerUpdatesHandler 按过滤条件分页查询提现管理员变更记录，sender 匹配 withdraw_manager
+//
+// HTTP端点: GET /api/v1/manager-updates
+func (h Routes) ManagerUpdatesHandler(w http.ResponseWriter, r *http.Request) {
+	listEvents(w, r, h.svc.QueryEventListParams, h.svc.GetWithdrawManagerUpdates)
+}
+
+// ManagerUpdateHandler 查询一条提现管理员变更记录
+//
+// HTTP端点: GET /api/v1/manager-updates/{guid}
+func (h Routes) ManagerUpdateHandler(w http.ResponseWriter, r *http.Request) {
+	eventDetail(w, r, "manager update", h.svc.GetWithdrawManagerUpdate)
+}
+
+// ContractEventsHandler 按过滤条件分页查询原始合约事件
+//
+// HTTP端点: GET /api/v1/contract-events
+// 查询参数: page / pageSize / order / fromBlock / toBlock / fromTime / toTime / txHash 同上，
+// 另外支持 contract（合约地址）和 signature（事件签名 topic0），不支持参与方和代币过滤
+func (h Routes) ContractEventsHandler(w http.ResponseWriter, r *http.Request) {
+	listEvents(w, r, h.svc.QueryContractEventListParams, h.svc.GetContractEvents)
+}
+
+// ContractEventHandler 查询一条原始合约事件
+//
+// HTTP端点: GET /api/v1/contract-events/{guid}
+func (h Routes) ContractEventHandler(w http.ResponseWriter, r *http.Request) {
+	eventDetail(w, r, "contract event", h.svc.GetContractEvent)
+}
+
+// eventListRequest 从URL查询参数中提取事件列表的过滤、分页和排序参数
+func eventListRequest(r *http.Request) *models.EventListRequest {
+	query := r.URL.Query()
+	return &models.EventListRequest{
+		Page:      query.Get("page"),
+		PageSize:  query.Get("pageSize"),
+		Order:     query.Get("order"),
+		Cursor:    query.Get("cursor"),
+		Count:     query.Get("count"),
+		Sender:    query.Get("sender"),
+		Receiver:  query.Get("receiver"),
+		Address:   query.Get("address"),
+		Token:     query.Get("token"),
+		FromBlock: query.Get("fromBlock"),
+		ToBlock:   query.Get("toBlock"),
+		FromTime:  query.Get("fromTime"),
+		ToTime:    query.Get("toTime"),
+		TxHash:    query.Get("txHash"),
+		Contract:  query.Get("contract"),
+		Signature: query.Get("signature"),
+	}
+}
+
+// listEvents 验证查询参数并返回分页列表：参数无效或过滤条件不适用时返回 400，查询失败时返回 500
+func listEvents[P any, R any](w http.ResponseWriter, r *http.Request, parse func(*models.EventListRequest) (*P, error), query func(*P) (*R, error)) {
+	params, err := parse(eventListRequest(r))
+	if err != nil {
+		errorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result, err := query(params)
+	if errors.Is(err, service.ErrUnsupportedFilter) {
+		errorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	} else if err != nil {
+		errorResponse(w, InternalServerError, http.StatusInternalServerError)
+		log.Error("Unable to read events from DB", "path", r.URL.Path, "err", err.Error())
+		return
+	}
+
+	if err := jsonResponse(w, result, http.StatusOK); err != nil {
+		log.Error("Error writing response", "err", err.Error())
+	}
+}
+
+// eventDetail 按路径中的 guid 查询一条记录：guid 无效时返回 400，不存在时返回 404
+func eventDetail[R any](w http.ResponseWriter, r *http.Request, name string, get func(uuid.UUID) (*R, error)) {
+	guid, err := uuid.Parse(chi.URLParam(r, "guid"))
+	if err != nil {
+		errorResponse(w, "invalid guid", http.StatusBadRequest)
+		return
+	}
+
+	result, err := get(guid)
+	if err != nil {
+		errorResponse(w, InternalServerError, http.StatusInternalServerError)
+		log.Error("Unable to read event from DB", "path", r.URL.Path, "err", err.Error())
+		return
+	} else if result == nil {
+		errorResponse(w, name+" not found", http.StatusNotFound)
+		return
+	}
+
+	if err := jsonResponse(w, result, http.StatusOK); err != nil {
+		log.Error("Error writing response", "err", err.Error())
+	}
+}
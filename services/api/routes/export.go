@@ -0,0 +1,69 @@
+package routes
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/Sandwichzzy/event-sync-go/export"
+	"github.com/Sandwichzzy/event-sync-go/services/api/models"
+)
+
+// exportWriteTimeout 导出时每次写入响应的超时时间，客户端长时间不读取时断开
+const exportWriteTimeout = 30 * time.Second
+
+// ExportHandler 把一个实体在时间范围内的记录流式导出为 CSV 或 NDJSON
+//
+// HTTP端点: GET /api/v1/export
+// 查询参数:
+//   - entity: deposits / withdrawals / grants / manager-updates（必填）
+//   - format: csv / ndjson（默认为 csv）
+//   - from / to: 时间范围（包含边界），unix 秒、RFC 3339 或 UTC 日期（YYYY-MM-DD，作为 to 时包含当天）。
+//     from 必填，to 默认当前时间，范围最多 31 天，更大的范围使用 export 命令
+//   - token: 代币地址
+//
+// 记录按 (block_number, log_index) 升序逐行从数据库读取并写出，不会把结果集加载到内存。
+// 金额为原始整数和按代币精度换算的十进制字符串，时间为 UTC 的 ISO 8601，列的顺序固定（CSV 第一行为表头）。
+//
+// 响应:
+//   - 200 OK: text/csv 或 application/x-ndjson 附件；开始写出后出错时响应被截断并记录日志
+//   - 400 Bad Request: 参数无效
+func (h Routes) ExportHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	opts, err := h.svc.QueryExportParams(&models.ExportRequest{
+		Entity: query.Get("entity"),
+		Format: query.Get("format"),
+		From:   query.Get("from"),
+		To:     query.Get("to"),
+		Token:  query.Get("token"),
+	})
+	if err != nil {
+		errorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", export.ContentType(opts.Format))
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, opts.Entity, opts.Format))
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+
+	out := &deadlineWriter{w: w, rc: http.NewResponseController(w)}
+	count, err := h.svc.ExportEvents(r.Context(), opts, out)
+	if err != nil {
+		log.Error("export failed", "entity", opts.Entity, "rows", count, "err", err)
+	}
+}
+
+// deadlineWriter 每次写入前延长写超时，导出总耗时不受 HTTP 服务器写超时限制
+type deadlineWriter struct {
+	w  http.ResponseWriter
+	rc *http.ResponseController
+}
+
+func (dw *deadlineWriter) Write(p []byte) (int, error) {
+	// 不支持设置超时的 ResponseWriter 仍按服务器的写超时处理
+	_ = dw.rc.SetWriteDeadline(time.Now().Add(exportWriteTimeout))
+	return dw.w.Write(p)
+}
@@ -0,0 +1,83 @@
+package routes
+
+import (
+	"context"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/Sandwichzzy/event-sync-go/apikeys"
+	"github.com/Sandwichzzy/event-sync-go/database/event"
+)
+
+const (
+	// ApiKeyHeader 携带 API key 的请求头，也可以使用 apiKey 查询参数（会出现在访问日志中，建议使用请求头）
+	ApiKeyHeader = "X-API-Key"
+	apiKeyQuery  = "apiKey"
+)
+
+// apiKeyContextKey 请求 context 中保存 RateLimitMiddleware 验证通过的密钥的键
+type apiKeyContextKey struct{}
+
+// requestApiKey 返回请求携带的已验证密钥，匿名请求为 nil
+func requestApiKey(r *http.Request) *event.ApiKey {
+	key, _ := r.Context().Value(apiKeyContextKey{}).(*event.ApiKey)
+	return key
+}
+
+// RateLimitMiddleware 按请求携带的 API key 或客户端地址限流，密钥无效时返回 401，
+// 超过令牌桶或每日配额时返回 429 并在 Retry-After 中给出需要等待的秒数。验证通过的密钥保存在请求 context 中
+func RateLimitMiddleware(guard *apikeys.Guard) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(ApiKeyHeader)
+			if key == "" {
+				key = r.URL.Query().Get(apiKeyQuery)
+			}
+			client, _, err := net.SplitHostPort(r.RemoteAddr)
+			if err != nil {
+				client = r.RemoteAddr
+			}
+
+			decision, err := guard.Check(key, client)
+			if err != nil {
+				log.Error("failed to check api key", "err", err)
+				errorResponse(w, InternalServerError, http.StatusInternalServerError)
+				return
+			}
+			if decision.Status != 0 {
+				if decision.RetryAfter > 0 {
+					w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(decision.RetryAfter.Seconds()))))
+				}
+				errorResponse(w, decision.Message, decision.Status)
+				return
+			}
+			if decision.Key != nil {
+				r = r.WithContext(context.WithValue(r.Context(), apiKeyContextKey{}, decision.Key))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireScopeMiddleware 只允许携带了 scope 的密钥访问，必须在 RateLimitMiddleware 之后使用。
+// 未携带密钥时返回 401，密钥没有该 scope 时返回 403
+func RequireScopeMiddleware(scope string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := requestApiKey(r)
+			if key == nil {
+				errorResponse(w, "api key required", http.StatusUnauthorized)
+				return
+			}
+			if !key.HasScope(scope) {
+				errorResponse(w, "api key does not have the "+scope+" scope", http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/go-chi/chi/v5"
+)
+
+// RewardLedgerHandler 处理用户奖励账本查询请求
+//
+// HTTP端点: GET /api/v1/rewards/{address}
+// 查询参数:
+//   - page: 流水页码（默认为1）
+//   - pageSize: 流水每页条数（默认为20，最大1000）
+//
+// 响应:
+//   - 200 OK: 返回每个代币的 granted/claimed/claimable 汇总和分页后的奖励流水
+//   - 400 Bad Request: 地址或分页参数无效
+//   - 500 Internal Server Error: 数据库查询失败
+func (h Routes) RewardLedgerHandler(w http.ResponseWriter, r *http.Request) {
+	address := chi.URLParam(r, "address")
+	pageQuery := r.URL.Query().Get("page")
+	pageSizeQuery := r.URL.Query().Get("pageSize")
+
+	params, err := h.svc.QueryRewardParams(address, pageQuery, pageSizeQuery)
+	if err != nil {
+		errorResponse(w, err.Error(), http.StatusBadRequest)
+		log.Error("error reading request params", "err", err.Error())
+		return
+	}
+
+	rewardLedger, err := h.svc.GetRewardLedger(params)
+	if err != nil {
+		errorResponse(w, "Internal server error reading reward ledger", http.StatusInternalServerError)
+		log.Error("Unable to read reward ledger from DB", "err", err.Error())
+		return
+	}
+
+	err = jsonResponse(w, rewardLedger, http.StatusOK)
+	if err != nil {
+		log.Error("Error writing response", "err", err.Error())
+	}
+}
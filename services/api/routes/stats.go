@@ -0,0 +1,49 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/Sandwichzzy/event-sync-go/services/api/models"
+)
+
+// StatsHandler 处理按小时 / 天汇总的代币统计查询请求
+//
+// HTTP端点: GET /api/v1/stats
+// 查询参数:
+//   - bucket: hour / day（默认为 day），桶按 UTC 对齐
+//   - from / to: 时间范围 [from, to)，unix 秒或 RFC 3339；to 默认为当前时间，from 默认为 to 之前 24 小时（hour）或 30 天（day）。
+//     hour 最多查询 31 天，day 最多查询 366 天
+//   - token: 代币地址，为空时返回所有代币
+//   - top: 每个代币返回发放金额最多的地址数量（默认为10，最大100，0 表示不返回）
+//
+// 响应:
+//   - 200 OK: 返回每个桶每个代币的充值 / 提现 / 奖励发放笔数和金额、充值独立地址数，以及发放金额最多的地址
+//   - 400 Bad Request: 参数无效或时间范围过大
+//   - 500 Internal Server Error: 数据库查询失败
+func (h Routes) StatsHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	params, err := h.svc.QueryStatsParams(&models.StatsRequest{
+		Bucket: query.Get("bucket"),
+		From:   query.Get("from"),
+		To:     query.Get("to"),
+		Token:  query.Get("token"),
+		Top:    query.Get("top"),
+	})
+	if err != nil {
+		errorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	stats, err := h.svc.GetTokenStats(params)
+	if err != nil {
+		errorResponse(w, InternalServerError, http.StatusInternalServerError)
+		log.Error("Unable to read token stats from DB", "err", err.Error())
+		return
+	}
+
+	if err := jsonResponse(w, stats, http.StatusOK); err != nil {
+		log.Error("Error writing response", "err", err.Error())
+	}
+}
@@ -0,0 +1,80 @@
+package routes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/Sandwichzzy/event-sync-go/services/api/models"
+)
+
+// streamRetry 断线后客户端重连前等待的毫秒数（SSE retry 字段）
+const streamRetry = 3000
+
+// StreamHandler 通过 Server-Sent Events 推送 EventProcessor 新提交的充值、提现、奖励发放和提现管理员变更事件
+//
+// HTTP端点: GET /api/v1/stream
+// 查询参数:
+//   - types: 逗号分隔的事件类型（DepositToken / WithdrawToken / GrantRewardTokenAmount / WithdrawManagerUpdate），为空时推送全部
+//   - address: 逗号分隔的参与方地址，匹配 sender / receiver / granter
+//   - token: 代币地址
+//   - lastEventId: 与 Last-Event-ID 请求头相同，供无法设置请求头的客户端使用
+//
+// 每条事件的 id 为持久化的 outbox 消息 ID，断线重连时浏览器自动带上 Last-Event-ID，从该事件之后继续推送；
+// 不带 Last-Event-ID 时从当前最新事件之后开始。event 为事件类型，data 中 action=removed 表示该事件已被回滚。
+//
+// 响应:
+//   - 200 OK: text/event-stream，空闲时每 15 秒发送一次心跳注释
+//   - 400 Bad Request: 查询参数无效
+func (h Routes) StreamHandler(w http.ResponseWriter, r *http.Request) {
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = r.URL.Query().Get("lastEventId")
+	}
+	params, err := h.svc.QueryStreamParams(r.URL.Query().Get("types"), r.URL.Query().Get("address"), r.URL.Query().Get("token"), lastEventID)
+	if err != nil {
+		errorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// 长连接不受 HTTP 服务器写超时限制
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		log.Warn("unable to clear write deadline for event stream", "err", err)
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	write := func(format string, args ...interface{}) error {
+		if _, err := fmt.Fprintf(w, format, args...); err != nil {
+			return err
+		}
+		return rc.Flush()
+	}
+	if err := write("retry: %d\n\n", streamRetry); err != nil {
+		return
+	}
+
+	err = h.svc.StreamEvents(r.Context(), params, func(e models.StreamEvent) error {
+		data, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		return write("id: %s\nevent: %s\ndata: %s\n\n", strconv.FormatUint(e.ID, 10), e.EventType, data)
+	}, func() error {
+		return write(": ping\n\n")
+	})
+	if err != nil && !errors.Is(err, context.Canceled) {
+		log.Warn("event stream closed", "err", err)
+	}
+}
@@ -0,0 +1,225 @@
+package routes
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/Sandwichzzy/event-sync-go/services/api/models"
+	"github.com/Sandwichzzy/event-sync-go/services/api/service"
+)
+
+// maxWebhookRequestBody 创建 / 更新订阅请求体的最大字节数
+const maxWebhookRequestBody = 1 << 16
+
+// 以下处理器都挂在 RequireScopeMiddleware(apikeys.ScopeWebhooks) 之后，请求一定携带了密钥。
+// 每个密钥只能看到和管理自己创建的订阅，其他密钥的订阅按不存在处理
+
+// CreateWebhookHandler 创建 webhook 订阅
+//
+// HTTP端点: POST /api/v1/webhooks
+// 请求体: {"url":"https://...","event_types":["DepositToken"],"token_address":"0x...","address":"0x...","secret":"..."}
+//   - event_types / token_address / address 为空时不过滤，address 匹配事件的 sender / receiver / granter
+//   - secret 为空时由服务端生成
+//
+// 响应:
+//   - 201 Created: 返回订阅，secret 只在此时返回
+//   - 400 Bad Request: 请求体无效
+//   - 500 Internal Server Error: 数据库写入失败
+func (h Routes) CreateWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateWebhookRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxWebhookRequestBody)).Decode(&req); err != nil {
+		errorResponse(w, "invalid request body", http.StatusBadRequest)
+		log.Error("error decoding webhook request", "err", err.Error())
+		return
+	}
+	params, err := h.svc.QueryCreateWebhookParams(&req)
+	if err != nil {
+		errorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	subscription, err := h.svc.CreateWebhookSubscription(requestApiKey(r).GUID, params)
+	if err != nil {
+		errorResponse(w, "Internal server error creating webhook", http.StatusInternalServerError)
+		log.Error("Unable to store webhook subscription", "err", err.Error())
+		return
+	}
+
+	if err := jsonResponse(w, subscription, http.StatusCreated); err != nil {
+		log.Error("Error writing response", "err", err.Error())
+	}
+}
+
+// ListWebhooksHandler 查询请求密钥创建的所有 webhook 订阅
+//
+// HTTP端点: GET /api/v1/webhooks
+func (h Routes) ListWebhooksHandler(w http.ResponseWriter, r *http.Request) {
+	subscriptions, err := h.svc.GetWebhookSubscriptions(requestApiKey(r).GUID)
+	if err != nil {
+		errorResponse(w, "Internal server error reading webhooks", http.StatusInternalServerError)
+		log.Error("Unable to read webhook subscriptions from DB", "err", err.Error())
+		return
+	}
+
+	if err := jsonResponse(w, subscriptions, http.StatusOK); err != nil {
+		log.Error("Error writing response", "err", err.Error())
+	}
+}
+
+// GetWebhookHandler 查询一个 webhook 订阅
+//
+// HTTP端点: GET /api/v1/webhooks/{guid}
+//
+// 响应:
+//   - 200 OK: 返回订阅
+//   - 400 Bad Request: guid 无效
+//   - 404 Not Found: 订阅不存在
+func (h Routes) GetWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	guid, ok := webhookGUID(w, r, "guid")
+	if !ok {
+		return
+	}
+	subscription, err := h.svc.GetWebhookSubscription(requestApiKey(r).GUID, guid)
+	if err != nil {
+		errorResponse(w, "Internal server error reading webhook", http.StatusInternalServerError)
+		log.Error("Unable to read webhook subscription from DB", "err", err.Error())
+		return
+	} else if subscription == nil {
+		errorResponse(w, "webhook not found", http.StatusNotFound)
+		return
+	}
+
+	if err := jsonResponse(w, subscription, http.StatusOK); err != nil {
+		log.Error("Error writing response", "err", err.Error())
+	}
+}
+
+// UpdateWebhookHandler 启用或停用 webhook 订阅，停用期间产生的投递保持待投递，重新启用后继续投递
+//
+// HTTP端点: PATCH /api/v1/webhooks/{guid}
+// 请求体: {"active":false}
+func (h Routes) UpdateWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	guid, ok := webhookGUID(w, r, "guid")
+	if !ok {
+		return
+	}
+	var req models.UpdateWebhookRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxWebhookRequestBody)).Decode(&req); err != nil || req.Active == nil {
+		errorResponse(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	subscription, err := h.svc.SetWebhookSubscriptionActive(requestApiKey(r).GUID, guid, *req.Active)
+	if err != nil {
+		errorResponse(w, "Internal server error updating webhook", http.StatusInternalServerError)
+		log.Error("Unable to update webhook subscription", "err", err.Error())
+		return
+	} else if subscription == nil {
+		errorResponse(w, "webhook not found", http.StatusNotFound)
+		return
+	}
+
+	if err := jsonResponse(w, subscription, http.StatusOK); err != nil {
+		log.Error("Error writing response", "err", err.Error())
+	}
+}
+
+// DeleteWebhookHandler 删除 webhook 订阅及其投递记录
+//
+// HTTP端点: DELETE /api/v1/webhooks/{guid}
+//
+// 响应:
+//   - 204 No Content: 删除成功
+//   - 404 Not Found: 订阅不存在
+func (h Routes) DeleteWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	guid, ok := webhookGUID(w, r, "guid")
+	if !ok {
+		return
+	}
+	deleted, err := h.svc.DeleteWebhookSubscription(requestApiKey(r).GUID, guid)
+	if err != nil {
+		errorResponse(w, "Internal server error deleting webhook", http.StatusInternalServerError)
+		log.Error("Unable to delete webhook subscription", "err", err.Error())
+		return
+	} else if !deleted {
+		errorResponse(w, "webhook not found", http.StatusNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// WebhookDeliveriesHandler 分页查询订阅的投递记录
+//
+// HTTP端点: GET /api/v1/webhooks/{guid}/deliveries
+// 查询参数:
+//   - status: pending / delivered / dead，为空时返回全部
+//   - page: 页码（默认为1）
+//   - pageSize: 每页条数（默认为20，最大1000）
+func (h Routes) WebhookDeliveriesHandler(w http.ResponseWriter, r *http.Request) {
+	params, err := h.svc.QueryWebhookDeliveriesParams(chi.URLParam(r, "guid"), r.URL.Query().Get("status"), r.URL.Query().Get("page"), r.URL.Query().Get("pageSize"))
+	if err != nil {
+		errorResponse(w, err.Error(), http.StatusBadRequest)
+		log.Error("error reading request params", "err", err.Error())
+		return
+	}
+
+	deliveries, err := h.svc.GetWebhookDeliveries(requestApiKey(r).GUID, params)
+	if err != nil {
+		errorResponse(w, "Internal server error reading webhook deliveries", http.StatusInternalServerError)
+		log.Error("Unable to read webhook deliveries from DB", "err", err.Error())
+		return
+	} else if deliveries == nil {
+		errorResponse(w, "webhook not found", http.StatusNotFound)
+		return
+	}
+
+	if err := jsonResponse(w, deliveries, http.StatusOK); err != nil {
+		log.Error("Error writing response", "err", err.Error())
+	}
+}
+
+// RedeliverWebhookHandler 重新投递一条死信
+//
+// HTTP端点: POST /api/v1/webhooks/deliveries/{guid}/redeliver
+//
+// 响应:
+//   - 202 Accepted: 投递已重置为 pending，由调度器在下一轮投递
+//   - 404 Not Found: 投递不存在
+//   - 409 Conflict: 投递不是死信
+func (h Routes) RedeliverWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	guid, ok := webhookGUID(w, r, "guid")
+	if !ok {
+		return
+	}
+	delivery, err := h.svc.RedeliverWebhookDelivery(requestApiKey(r).GUID, guid)
+	if errors.Is(err, service.ErrNotDeadLetter) {
+		errorResponse(w, err.Error(), http.StatusConflict)
+		return
+	} else if err != nil {
+		errorResponse(w, "Internal server error redelivering webhook", http.StatusInternalServerError)
+		log.Error("Unable to redeliver webhook delivery", "err", err.Error())
+		return
+	} else if delivery == nil {
+		errorResponse(w, "delivery not found", http.StatusNotFound)
+		return
+	}
+
+	if err := jsonResponse(w, delivery, http.StatusAccepted); err != nil {
+		log.Error("Error writing response", "err", err.Error())
+	}
+}
+
+// webhookGUID 解析路径参数中的 GUID，无效时写入 400 响应
+func webhookGUID(w http.ResponseWriter, r *http.Request, param string) (uuid.UUID, bool) {
+	guid, err := uuid.Parse(chi.URLParam(r, param))
+	if err != nil {
+		errorResponse(w, "invalid guid", http.StatusBadRequest)
+		return uuid.UUID{}, false
+	}
+	return guid, true
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	common2 "github.com/Sandwichzzy/event-sync-go/database/common"
+	"github.com/Sandwichzzy/event-sync-go/database/worker"
+	"github.com/Sandwichzzy/event-sync-go/services/api/models"
+)
+
+// QueryAddressActivityParams 验证并构建地址时间线查询参数，参与方由路径中的地址决定，不接受 sender / receiver / address 过滤
+func (h HandlerSvc) QueryAddressActivityParams(address string, req *models.EventListRequest) (*models.QueryAddressActivityParams, error) {
+	addr, err := h.v.ParseValidateAddress(address)
+	if err != nil {
+		return nil, err
+	}
+	if req.Sender != "" || req.Receiver != "" || req.Address != "" {
+		return nil, fmt.Errorf("sender, receiver and address filters do not apply to address activity")
+	}
+	params, err := h.QueryEventListParams(req)
+	if err != nil {
+		return nil, err
+	}
+	return &models.QueryAddressActivityParams{Address: addr, QueryEventsParams: *params}, nil
+}
+
+// GetAddressActivity 查询地址每个代币的汇总和分页后的合并时间线
+func (h HandlerSvc) GetAddressActivity(params *models.QueryAddressActivityParams) (*models.AddressActivityResponse, error) {
+	summaries, err := h.addressActivityView.QueryAddressTokenSummaries(params.Address)
+	if err != nil {
+		return nil, err
+	}
+	activity, page, err := h.addressActivityView.QueryAddressActivity(params.Address, params.Filter, eventsPage(&params.QueryEventsParams))
+	if err != nil {
+		return nil, err
+	}
+
+	tokenAddresses := make([]common.Address, 0, len(summaries)+len(activity))
+	for _, s := range summaries {
+		tokenAddresses = append(tokenAddresses, s.TokenAddress)
+	}
+	for _, a := range activity {
+		if a.TokenAddress != nil {
+			tokenAddresses = append(tokenAddresses, *a.TokenAddress)
+		}
+	}
+	tokens, err := h.tokensView.TokensByAddresses(tokenAddresses)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.AddressActivityResponse{
+		Address: params.Address.String(),
+		Summary: toAddressTokenSummaries(summaries, tokens),
+		Current: params.Page,
+		Size:    params.PageSize,
+		Total:   int64(page.Total),
+		Count:   page.Count.String(),
+		Next:    encodeCursor(page.Next),
+		Prev:    encodeCursor(page.Prev),
+		Result:  toAddressActivity(activity, tokens),
+	}, nil
+}
+
+func toAddressActivity(activity []worker.AddressActivity, tokens map[common.Address]common2.Token) []models.AddressActivity {
+	result := make([]models.AddressActivity, 0, len(activity))
+	for _, a := range activity {
+		item := models.AddressActivity{
+			EventType:       a.EventType,
+			GUID:            a.GUID,
+			BlockNumber:     a.BlockNumber,
+			BlockHash:       a.BlockHash.String(),
+			TransactionHash: a.TransactionHash.String(),
+			LogIndex:        a.LogIndex,
+			Sender:          a.Sender.String(),
+			Timestamp:       a.Timestamp,
+		}
+		if a.TokenAddress != nil {
+			info := tokenInfo(tokens, *a.TokenAddress)
+			item.TokenAddress = a.TokenAddress.String()
+			item.Amount = rawAmount(a.Amount)
+			item.FormattedAmount = formatAmount(a.Amount, info)
+			item.Token = &info
+		}
+		if a.Receiver != nil {
+			item.Receiver = a.Receiver.String()
+		}
+		result = append(result, item)
+	}
+	return result
+}
+
+func toAddressTokenSummaries(summaries []worker.AddressTokenSummary, tokens map[common.Address]common2.Token) []models.AddressTokenSummary {
+	result := make([]models.AddressTokenSummary, 0, len(summaries))
+	for _, s := range summaries {
+		info := tokenInfo(tokens, s.TokenAddress)
+		result = append(result, models.AddressTokenSummary{
+			TokenAddress:           s.TokenAddress.String(),
+			DepositCount:           s.DepositCount,
+			Deposited:              rawAmount(s.Deposited),
+			FormattedDeposited:     formatAmount(s.Deposited, info),
+			WithdrawCount:          s.WithdrawCount,
+			Withdrawn:              rawAmount(s.Withdrawn),
+			FormattedWithdrawn:     formatAmount(s.Withdrawn, info),
+			GranterCount:           s.GranterCount,
+			GranterAmount:          rawAmount(s.GranterAmount),
+			FormattedGranterAmount: formatAmount(s.GranterAmount, info),
+			Token:                  info,
+		})
+	}
+	return result
+}
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/Sandwichzzy/event-sync-go/common/bigint"
+	common2 "github.com/Sandwichzzy/event-sync-go/database/common"
+	"github.com/Sandwichzzy/event-sync-go/database/event"
+	"github.com/Sandwichzzy/event-sync-go/database/worker"
+	"github.com/Sandwichzzy/event-sync-go/services/api/models"
+)
+
+// tokenInfo 返回代币的符号和精度，元数据尚未缓存时精度为 nil
+func tokenInfo(tokens map[common.Address]common2.Token, address common.Address) models.TokenInfo {
+	token, ok := tokens[address]
+	if !ok {
+		return models.TokenInfo{}
+	}
+	decimals := token.Decimals
+	return models.TokenInfo{Symbol: token.Symbol, Decimals: &decimals}
+}
+
+// rawAmount 原始整数金额的十进制字符串
+func rawAmount(amount *big.Int) string {
+	if amount == nil {
+		return "0"
+	}
+	return amount.String()
+}
+
+// formatAmount 按代币精度格式化金额，元数据未知时返回空字符串
+func formatAmount(amount *big.Int, info models.TokenInfo) string {
+	if info.Decimals == nil {
+		return ""
+	}
+	if amount == nil {
+		amount = bigint.Zero
+	}
+	return bigint.FormatUnits(amount, *info.Decimals)
+}
+
+func toDepositTokens(depositTokens []worker.DepositTokens, tokens map[common.Address]common2.Token) []models.DepositToken {
+	result := make([]models.DepositToken, 0, len(depositTokens))
+	for _, dt := range depositTokens {
+		info := tokenInfo(tokens, dt.TokenAddress)
+		result = append(result, models.DepositToken{
+			GUID:            dt.GUID,
+			BlockNumber:     dt.BlockNumber,
+			BlockHash:       dt.BlockHash.String(),
+			TransactionHash: dt.TransactionHash.String(),
+			LogIndex:        dt.LogIndex,
+			TokenAddress:    dt.TokenAddress.String(),
+			Sender:          dt.Sender.String(),
+			Amount:          rawAmount(dt.Amount),
+			FormattedAmount: formatAmount(dt.Amount, info),
+			Token:           info,
+			Timestamp:       dt.Timestamp,
+		})
+	}
+	return result
+}
+
+func toRewardBalances(balances []worker.RewardBalance, tokens map[common.Address]common2.Token) []models.RewardBalance {
+	result := make([]models.RewardBalance, 0, len(balances))
+	for _, balance := range balances {
+		info := tokenInfo(tokens, balance.TokenAddress)
+		result = append(result, models.RewardBalance{
+			TokenAddress:          balance.TokenAddress.String(),
+			Granted:               rawAmount(balance.Granted),
+			FormattedGranted:      formatAmount(balance.Granted, info),
+			Claimed:               rawAmount(balance.Claimed),
+			FormattedClaimed:      formatAmount(balance.Claimed, info),
+			Claimable:             rawAmount(balance.Claimable),
+			FormattedClaimable:    formatAmount(balance.Claimable, info),
+			Token:                 info,
+			BlockNumber:           balance.BlockNumber,
+			ReconciledBlockNumber: balance.ReconciledBlockNumber,
+			Timestamp:             balance.Timestamp,
+		})
+	}
+	return result
+}
+
+func toRewardLedgerEntries(entries []worker.RewardLedgerEntry, tokens map[common.Address]common2.Token) []models.RewardLedgerEntry {
+	result := make([]models.RewardLedgerEntry, 0, len(entries))
+	for _, entry := range entries {
+		info := tokenInfo(tokens, entry.TokenAddress)
+		result = append(result, models.RewardLedgerEntry{
+			GUID:            entry.GUID,
+			TokenAddress:    entry.TokenAddress.String(),
+			EntryType:       entry.EntryType,
+			Amount:          rawAmount(entry.Amount),
+			FormattedAmount: formatAmount(entry.Amount, info),
+			Token:           info,
+			BlockNumber:     entry.BlockNumber,
+			BlockHash:       entry.BlockHash.String(),
+			Timestamp:       entry.Timestamp,
+		})
+	}
+	return result
+}
+
+func toWithdrawTokens(withdrawTokens []worker.WithdrawTokens, tokens map[common.Address]common2.Token) []models.WithdrawToken {
+	result := make([]models.WithdrawToken, 0, len(withdrawTokens))
+	for _, wt := range withdrawTokens {
+		info := tokenInfo(tokens, wt.TokenAddress)
+		result = append(result, models.WithdrawToken{
+			GUID:            wt.GUID,
+			BlockNumber:     wt.BlockNumber,
+			BlockHash:       wt.BlockHash.String(),
+			TransactionHash: wt.TransactionHash.String(),
+			LogIndex:        wt.LogIndex,
+			TokenAddress:    wt.TokenAddress.String(),
+			Sender:          wt.Sender.String(),
+			Receiver:        wt.Receiver.String(),
+			Amount:          rawAmount(wt.Amount),
+			FormattedAmount: formatAmount(wt.Amount, info),
+			Token:           info,
+			Timestamp:       wt.Timestamp,
+		})
+	}
+	return result
+}
+
+func toGrantRewardTokens(grants []worker.GrantRewardTokens, tokens map[common.Address]common2.Token) []models.GrantRewardToken {
+	result := make([]models.GrantRewardToken, 0, len(grants))
+	for _, gr := range grants {
+		info := tokenInfo(tokens, gr.TokenAddress)
+		result = append(result, models.GrantRewardToken{
+			GUID:            gr.GUID,
+			BlockNumber:     gr.BlockNumber,
+			BlockHash:       gr.BlockHash.String(),
+			TransactionHash: gr.TransactionHash.String(),
+			LogIndex:        gr.LogIndex,
+			TokenAddress:    gr.TokenAddress.String(),
+			Granter:         gr.Granter.String(),
+			Amount:          rawAmount(gr.Amount),
+			FormattedAmount: formatAmount(gr.Amount, info),
+			Token:           info,
+			Timestamp:       gr.Timestamp,
+		})
+	}
+	return result
+}
+
+func toWithdrawManagerUpdates(updates []worker.WithdrawManagerUpdate) []models.WithdrawManagerUpdate {
+	result := make([]models.WithdrawManagerUpdate, 0, len(updates))
+	for _, update := range updates {
+		result = append(result, models.WithdrawManagerUpdate{
+			GUID:            update.GUID,
+			BlockNumber:     update.BlockNumber,
+			BlockHash:       update.BlockHash.String(),
+			TransactionHash: update.TransactionHash.String(),
+			LogIndex:        update.LogIndex,
+			WithdrawManager: update.WithdrawManager.String(),
+			Timestamp:       update.Timestamp,
+		})
+	}
+	return result
+}
+
+func toContractEvents(events []event.ContractEvent) []models.ContractEvent {
+	result := make([]models.ContractEvent, 0, len(events))
+	for _, e := range events {
+		topics := make([]string, 0, len(e.RLPLog.Topics))
+		for _, topic := range e.RLPLog.Topics {
+			topics = append(topics, topic.String())
+		}
+		result = append(result, models.ContractEvent{
+			GUID:            e.GUID,
+			BlockNumber:     e.BlockNumber,
+			BlockHash:       e.BlockHash.String(),
+			TransactionHash: e.TransactionHash.String(),
+			LogIndex:        e.LogIndex,
+			ContractAddress: e.ContractAddress.String(),
+			EventSignature:  e.EventSignature.String(),
+			Topics:          topics,
+			Data:            hexutil.Encode(e.RLPLog.Data),
+			Timestamp:       e.Timestamp,
+		})
+	}
+	return result
+}
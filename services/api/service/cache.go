@@ -0,0 +1,121 @@
+package service
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/Sandwichzzy/event-sync-go/config"
+)
+
+// ResponseCache 缓存序列化后的 API 响应
+type ResponseCache interface {
+	// Get 返回 key 对应的响应，不存在或已过期时 ok 为 false
+	Get(ctx context.Context, key string) (value []byte, ok bool, err error)
+	// Set 缓存响应，ttl 后过期
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Close() error
+}
+
+// NewResponseCache 按配置创建响应缓存：设置了 RedisURL 时使用 Redis（多个 API 实例共享），否则使用进程内 LRU
+func NewResponseCache(cfg config.ApiCacheConfig) (ResponseCache, error) {
+	if cfg.RedisURL == "" {
+		return NewLRUCache(cfg.Size), nil
+	}
+	options, err := redis.ParseURL(cfg.RedisURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid api cache redis url: %w", err)
+	}
+	return &redisCache{client: redis.NewClient(options)}, nil
+}
+
+// lruCache 进程内 LRU，超过容量时淘汰最久未访问的响应
+type lruCache struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	order   *list.List // 队首为最近访问
+	now     func() time.Time
+}
+
+type lruEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+// NewLRUCache 创建最多缓存 size 个响应的进程内 LRU，size <= 0 时为 10000
+func NewLRUCache(size int) ResponseCache {
+	if size <= 0 {
+		size = 10000
+	}
+	return &lruCache{size: size, entries: make(map[string]*list.Element), order: list.New(), now: time.Now}
+}
+
+func (c *lruCache) Get(_ context.Context, key string) ([]byte, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	entry := element.Value.(*lruEntry)
+	if !c.now().Before(entry.expires) {
+		c.order.Remove(element)
+		delete(c.entries, key)
+		return nil, false, nil
+	}
+	c.order.MoveToFront(element)
+	return entry.value, true, nil
+}
+
+func (c *lruCache) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	expires := c.now().Add(ttl)
+	if element, ok := c.entries[key]; ok {
+		entry := element.Value.(*lruEntry)
+		entry.value, entry.expires = value, expires
+		c.order.MoveToFront(element)
+		return nil
+	}
+	c.entries[key] = c.order.PushFront(&lruEntry{key: key, value: value, expires: expires})
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry).key)
+	}
+	return nil
+}
+
+func (c *lruCache) Close() error {
+	return nil
+}
+
+// redisCache 使用 Redis 的 GET / SET EX，过期由 Redis 处理
+type redisCache struct {
+	client *redis.Client
+}
+
+func (c *redisCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, err := c.client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+func (c *redisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return c.client.Set(ctx, key, value, ttl).Err()
+}
+
+func (c *redisCache) Close() error {
+	return c.client.Close()
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/Sandwichzzy/event-sync-go/config"
+	common2 "github.com/Sandwichzzy/event-sync-go/database/common"
+	"github.com/Sandwichzzy/event-sync-go/database/event"
+	"github.com/Sandwichzzy/event-sync-go/database/worker"
+	"github.com/Sandwichzzy/event-sync-go/services/api/models"
+)
+
+func TestLRUCache(t *testing.T) {
+	now := time.Unix(1704067200, 0)
+	cache := NewLRUCache(2).(*lruCache)
+	cache.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	require.NoError(t, cache.Set(ctx, "a", []byte("1"), time.Minute))
+	require.NoError(t, cache.Set(ctx, "b", []byte("2"), time.Minute))
+	// 访问 a 后 b 成为最久未访问的条目，写入 c 时被淘汰
+	_, ok, _ := cache.Get(ctx, "a")
+	require.True(t, ok)
+	require.NoError(t, cache.Set(ctx, "c", []byte("3"), time.Second))
+	_, ok, _ = cache.Get(ctx, "b")
+	require.False(t, ok)
+
+	now = now.Add(time.Second)
+	_, ok, _ = cache.Get(ctx, "c")
+	require.False(t, ok)
+	value, ok, _ := cache.Get(ctx, "a")
+	require.True(t, ok)
+	require.Equal(t, []byte("1"), value)
+}
+
+type fakeBlocks struct {
+	event.BlocksView
+	head *common2.BlockHeader
+}
+
+func (f *fakeBlocks) LatestEventBlockHeader() (*common2.BlockHeader, error) {
+	return f.head, nil
+}
+
+type countingService struct {
+	Service
+	calls int
+}
+
+func (s *countingService) GetDepositTokens(params *models.QueryEventsParams) (*models.DepositTokensResponse, error) {
+	s.calls++
+	return &models.DepositTokensResponse{Current: params.Page, Total: int64(s.calls)}, nil
+}
+
+func TestCachedService(t *testing.T) {
+	inner := &countingService{}
+	blocks := &fakeBlocks{head: &common2.BlockHeader{Number: big.NewInt(100), Timestamp: 1704067200}}
+	outboxID := uint64(1)
+	cfg := config.ApiCacheConfig{TTL: time.Minute, FinalizedTTL: time.Hour}
+	svc := NewCachedService(inner, NewLRUCache(10), blocks, func() uint64 { return outboxID }, cfg).(*cachedService)
+
+	latest := &models.QueryEventsParams{Page: 1}
+	finalized := &models.QueryEventsParams{Page: 1, Filter: worker.EventFilter{ToBlock: big.NewInt(90)}}
+	for i := 0; i < 2; i++ {
+		resp, err := svc.GetDepositTokens(latest)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), resp.Total)
+		resp, err = svc.GetDepositTokens(finalized)
+		require.NoError(t, err)
+		require.Equal(t, int64(2), resp.Total)
+	}
+	require.Equal(t, 2, inner.calls)
+
+	// 索引高度前进后，未完成区间的查询重新读取数据库，已完成区间的查询仍然命中
+	blocks.head = &common2.BlockHeader{Number: big.NewInt(101), Timestamp: 1704067212}
+	svc.headAt = time.Time{}
+	resp, err := svc.GetDepositTokens(latest)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), resp.Total)
+	resp, err = svc.GetDepositTokens(finalized)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), resp.Total)
+
+	// 重建区间会写入新的 outbox 消息，同一高度下缓存也会失效，包括已完成区间的查询
+	outboxID++
+	resp, err = svc.GetDepositTokens(latest)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), resp.Total)
+	resp, err = svc.GetDepositTokens(finalized)
+	require.NoError(t, err)
+	require.Equal(t, int64(5), resp.Total)
+
+	// 时间上界等于索引高度的区块时间时，之后的区块仍可能有相同的时间，不按已完成缓存
+	head := blocks.head.Timestamp
+	require.False(t, eventsFinalized(nil, head)(blocks.head))
+	require.True(t, eventsFinalized(nil, head-1)(blocks.head))
+}
@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/google/uuid"
+
+	"github.com/Sandwichzzy/event-sync-go/config"
+	common2 "github.com/Sandwichzzy/event-sync-go/database/common"
+	"github.com/Sandwichzzy/event-sync-go/database/event"
+	"github.com/Sandwichzzy/event-sync-go/services/api/models"
+)
+
+const (
+	cacheKeyPrefix = "event-sync:api:"
+	// cacheTimeout 读写缓存的超时时间，缓存不可用时直接查询数据库
+	cacheTimeout = 200 * time.Millisecond
+	// headRefreshInterval 重新读取索引高度的最小间隔
+	headRefreshInterval = time.Second
+)
+
+// cachedService 在 Service 外层缓存只读查询的响应（read-through）。
+// 缓存键由方法名和验证后的查询参数（已归一化默认值）计算，并带上当前索引高度和 outbox 消息 ID 作为版本：
+// EventProcessor 提交新的区间或重建区间后版本变化，旧的缓存不再命中，由 TTL 淘汰。
+// 区块或时间范围的上界已经在索引高度以内的查询结果不会再随高度变化，只带 outbox 消息 ID 作为版本并使用更长的 FinalizedTTL，
+// 重建区间写入的 outbox 消息同样使其失效。
+// webhook 管理、实时推送和导出不经过缓存。
+type cachedService struct {
+	Service
+	cache        ResponseCache
+	blocks       event.BlocksView
+	outboxID     func() uint64
+	ttl          time.Duration
+	finalizedTTL time.Duration
+
+	mu     sync.Mutex
+	head   *common2.BlockHeader
+	headAt time.Time
+}
+
+// NewCachedService 为 svc 增加响应缓存，blocks 提供索引高度，outboxID 返回最新的 outbox 消息 ID（重建区间时也会增加）
+func NewCachedService(svc Service, cache ResponseCache, blocks event.BlocksView, outboxID func() uint64, cfg config.ApiCacheConfig) Service {
+	return &cachedService{
+		Service:      svc,
+		cache:        cache,
+		blocks:       blocks,
+		outboxID:     outboxID,
+		ttl:          cfg.TTL,
+		finalizedTTL: cfg.FinalizedTTL,
+	}
+}
+
+// indexedHead 返回已处理的最新事件区块，最多每 headRefreshInterval 读取一次数据库
+func (c *cachedService) indexedHead() (*common2.BlockHeader, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.head != nil && time.Since(c.headAt) < headRefreshInterval {
+		return c.head, nil
+	}
+	head, err := c.blocks.LatestEventBlockHeader()
+	if err != nil {
+		return nil, err
+	}
+	c.head, c.headAt = head, time.Now()
+	return head, nil
+}
+
+// cachedCall 先查缓存，未命中时调用 load 并缓存结果。finalized 判断查询结果在当前索引高度下是否已经不会再变化，为 nil 时总是带版本缓存。
+// 无法读取索引高度或缓存出错时直接调用 load
+func cachedCall[P any, R any](c *cachedService, method string, params P, finalized func(head *common2.BlockHeader) bool, load func(P) (R, error)) (R, error) {
+	head, err := c.indexedHead()
+	if err != nil {
+		log.Warn("unable to read indexed height, api cache bypassed", "err", err)
+		return load(params)
+	} else if head == nil {
+		return load(params)
+	}
+	raw, err := json.Marshal(params)
+	if err != nil {
+		return load(params)
+	}
+
+	outboxID := c.outboxID()
+	version, ttl := fmt.Sprintf("%s.%d", head.Number, outboxID), c.ttl
+	if finalized != nil && finalized(head) {
+		version, ttl = fmt.Sprintf("final.%d", outboxID), c.finalizedTTL
+	}
+	key := fmt.Sprintf("%s%s:%s:%x", cacheKeyPrefix, method, version, sha256.Sum256(raw))
+
+	getCtx, cancel := context.WithTimeout(context.Background(), cacheTimeout)
+	value, ok, err := c.cache.Get(getCtx, key)
+	cancel()
+	if err != nil {
+		log.Warn("api cache get failed", "method", method, "err", err)
+	} else if ok {
+		var result R
+		if err := json.Unmarshal(value, &result); err == nil {
+			return result, nil
+		}
+	}
+
+	result, err := load(params)
+	if err != nil {
+		return result, err
+	}
+	if value, err := json.Marshal(result); err == nil {
+		setCtx, cancel := context.WithTimeout(context.Background(), cacheTimeout)
+		if err := c.cache.Set(setCtx, key, value, ttl); err != nil {
+			log.Warn("api cache set failed", "method", method, "err", err)
+		}
+		cancel()
+	}
+	return result, nil
+}
+
+// eventsFinalized 区块范围的上界不超过索引高度，或时间范围的上界早于索引高度的区块时间时，满足过滤条件的记录已经全部写入。
+// 之后的区块可能与索引高度的区块时间相同，所以时间上界等于区块时间时仍未完成
+func eventsFinalized(toBlock *big.Int, toTimestamp uint64) func(head *common2.BlockHeader) bool {
+	return func(head *common2.BlockHeader) bool {
+		return (toBlock != nil && toBlock.Cmp(head.Number) <= 0) ||
+			(toTimestamp > 0 && toTimestamp < head.Timestamp)
+	}
+}
+
+func (c *cachedService) GetDepositTokensList(params *models.QueryDTParams) (*models.DepositTokensResponse, error) {
+	return cachedCall(c, "deposit_tokens_list", params, nil, c.Service.GetDepositTokensList)
+}
+
+func (c *cachedService) GetRewardLedger(params *models.QueryRewardParams) (*models.RewardLedgerResponse, error) {
+	return cachedCall(c, "reward_ledger", params, nil, c.Service.GetRewardLedger)
+}
+
+func (c *cachedService) GetDepositTokens(params *models.QueryEventsParams) (*models.DepositTokensResponse, error) {
+	return cachedCall(c, "deposits", params, eventsFinalized(params.Filter.ToBlock, params.Filter.ToTimestamp), c.Service.GetDepositTokens)
+}
+
+func (c *cachedService) GetDepositToken(guid uuid.UUID) (*models.DepositToken, error) {
+	return cachedCall(c, "deposit", guid, nil, c.Service.GetDepositToken)
+}
+
+func (c *cachedService) GetWithdrawTokens(params *models.QueryEventsParams) (*models.WithdrawTokensResponse, error) {
+	return cachedCall(c, "withdrawals", params, eventsFinalized(params.Filter.ToBlock, params.Filter.ToTimestamp), c.Service.GetWithdrawTokens)
+}
+
+func (c *cachedService) GetWithdrawToken(guid uuid.UUID) (*models.WithdrawToken, error) {
+	return cachedCall(c, "withdrawal", guid, nil, c.Service.GetWithdrawToken)
+}
+
+func (c *cachedService) GetGrantRewardTokens(params *models.QueryEventsParams) (*models.GrantRewardTokensResponse, error) {
+	return cachedCall(c, "grants", params, eventsFinalized(params.Filter.ToBlock, params.Filter.ToTimestamp), c.Service.GetGrantRewardTokens)
+}
+
+func (c *cachedService) GetGrantRewardToken(guid uuid.UUID) (*models.GrantRewardToken, error) {
+	return cachedCall(c, "grant", guid, nil, c.Service.GetGrantRewardToken)
+}
+
+func (c *cachedService) GetWithdrawManagerUpdates(params *models.QueryEventsParams) (*models.WithdrawManagerUpdatesResponse, error) {
+	return cachedCall(c, "manager_updates", params, eventsFinalized(params.Filter.ToBlock, params.Filter.ToTimestamp), c.Service.GetWithdrawManagerUpdates)
+}
+
+func (c *cachedService) GetWithdrawManagerUpdate(guid uuid.UUID) (*models.WithdrawManagerUpdate, error) {
+	return cachedCall(c, "manager_update", guid, nil, c.Service.GetWithdrawManagerUpdate)
+}
+
+func (c *cachedService) GetContractEvents(params *models.QueryContractEventsParams) (*models.ContractEventsResponse, error) {
+	return cachedCall(c, "contract_events", params, eventsFinalized(params.Filter.ToBlock, params.Filter.ToTimestamp), c.Service.GetContractEvents)
+}
+
+func (c *cachedService) GetContractEvent(guid uuid.UUID) (*models.ContractEvent, error) {
+	return cachedCall(c, "contract_event", guid, nil, c.Service.GetContractEvent)
+}
+
+// GetAddressActivity 汇总部分不受过滤条件限制，总是带版本缓存
+func (c *cachedService) GetAddressActivity(params *models.QueryAddressActivityParams) (*models.AddressActivityResponse, error) {
+	return cachedCall(c, "address_activity", params, nil, c.Service.GetAddressActivity)
+}
+
+// GetTokenStats 时间范围 [From, To) 早于索引高度的区块时间时，涉及的统计桶已经不会再变化
+func (c *cachedService) GetTokenStats(params *models.QueryStatsParams) (*models.StatsResponse, error) {
+	finalized := func(head *common2.BlockHeader) bool {
+		return params.To < head.Timestamp
+	}
+	return cachedCall(c, "stats", params, finalized, c.Service.GetTokenStats)
+}
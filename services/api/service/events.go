@@ -0,0 +1,358 @@
+package service
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+
+	"github.com/Sandwichzzy/event-sync-go/database/event"
+	"github.com/Sandwichzzy/event-sync-go/database/utils"
+	"github.com/Sandwichzzy/event-sync-go/database/worker"
+	"github.com/Sandwichzzy/event-sync-go/services/api/models"
+	"github.com/Sandwichzzy/event-sync-go/services/eventfilter"
+)
+
+// ErrUnsupportedFilter 查询的实体没有过滤条件对应的字段（如奖励发放记录没有接收方）
+var ErrUnsupportedFilter = worker.ErrUnsupportedFilter
+
+// QueryEventListParams 验证并构建 worker 事件列表的查询参数
+func (h HandlerSvc) QueryEventListParams(req *models.EventListRequest) (*models.QueryEventsParams, error) {
+	if req.Contract != "" || req.Signature != "" {
+		return nil, fmt.Errorf("contract and signature filters only apply to contract events")
+	}
+	paging, err := h.parseListParams(req)
+	if err != nil {
+		return nil, err
+	}
+
+	fromBlock, toBlock, err := h.parseBlockRange(req.FromBlock, req.ToBlock)
+	if err != nil {
+		return nil, err
+	}
+	fromTime, toTime, err := h.parseTimeRange(req.FromTime, req.ToTime)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := eventfilter.Parse(eventfilter.Request{
+		Sender:    eventfilter.Field{Name: "sender", Value: req.Sender},
+		Receiver:  eventfilter.Field{Name: "receiver", Value: req.Receiver},
+		Address:   eventfilter.Field{Name: "address", Value: req.Address},
+		Token:     eventfilter.Field{Name: "token", Value: req.Token},
+		TxHash:    eventfilter.Field{Name: "txHash", Value: req.TxHash},
+		FromBlock: fromBlock,
+		ToBlock:   toBlock,
+		FromTime:  fromTime,
+		ToTime:    toTime,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.QueryEventsParams{Page: paging.Page, PageSize: paging.PageSize, Order: paging.Order, Cursor: paging.Cursor, Count: paging.Count, Filter: filter}, nil
+}
+
+// QueryContractEventListParams 验证并构建原始合约事件列表的查询参数，只支持合约地址、事件签名、交易哈希、区块和时间范围
+func (h HandlerSvc) QueryContractEventListParams(req *models.EventListRequest) (*models.QueryContractEventsParams, error) {
+	if req.Sender != "" || req.Receiver != "" || req.Address != "" || req.Token != "" {
+		return nil, fmt.Errorf("sender, receiver, address and token filters do not apply to contract events")
+	}
+	paging, err := h.parseListParams(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var filter event.ContractEventQuery
+	if req.Contract != "" {
+		address, err := h.v.ParseValidateAddress(req.Contract)
+		if err != nil {
+			return nil, fmt.Errorf("invalid contract: %w", err)
+		}
+		filter.ContractAddress = &address
+	}
+	if req.Signature != "" {
+		signature, err := h.v.ParseValidateHash(req.Signature)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signature: %w", err)
+		}
+		filter.EventSignature = &signature
+	}
+	if req.TxHash != "" {
+		hash, err := h.v.ParseValidateHash(req.TxHash)
+		if err != nil {
+			return nil, fmt.Errorf("invalid txHash: %w", err)
+		}
+		filter.TransactionHash = &hash
+	}
+	if filter.FromBlock, filter.ToBlock, err = h.parseBlockRange(req.FromBlock, req.ToBlock); err != nil {
+		return nil, err
+	}
+	if filter.FromTimestamp, filter.ToTimestamp, err = h.parseTimeRange(req.FromTime, req.ToTime); err != nil {
+		return nil, err
+	}
+
+	return &models.QueryContractEventsParams{Page: paging.Page, PageSize: paging.PageSize, Order: paging.Order, Cursor: paging.Cursor, Count: paging.Count, Filter: filter}, nil
+}
+
+// listParams 验证后的分页、排序和计数参数
+type listParams struct {
+	Page     int
+	PageSize int
+	Order    string
+	Cursor   *utils.Cursor
+	Count    utils.CountMode
+}
+
+// parseListParams 验证分页参数：cursor 与 page 互斥；按页码分页时默认精确计数（兼容原有接口），按游标分页时默认不计数
+func (h HandlerSvc) parseListParams(req *models.EventListRequest) (*listParams, error) {
+	if req.Cursor != "" && req.Page != "" {
+		return nil, fmt.Errorf("page and cursor cannot be used together")
+	}
+	pageInt, pageSizeInt, err := parsePageParams(req.Page, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	order, err := h.v.ParseValidateOrder(req.Order)
+	if err != nil {
+		return nil, err
+	}
+	params := &listParams{Page: h.v.ValidatePage(pageInt), PageSize: h.v.ValidatePageSize(pageSizeInt), Order: order}
+
+	defaultCount := utils.CountExact
+	if req.Cursor != "" {
+		if params.Cursor, err = utils.ParseCursor(req.Cursor); err != nil {
+			return nil, err
+		}
+		params.Page = 0
+		params.Order = "desc"
+		if params.Cursor.Ascending {
+			params.Order = "asc"
+		}
+		defaultCount = utils.CountNone
+	}
+	if params.Count, err = utils.ParseCountMode(req.Count, defaultCount); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
+func (h HandlerSvc) parseBlockRange(from string, to string) (*big.Int, *big.Int, error) {
+	var fromBlock, toBlock *big.Int
+	var err error
+	if from != "" {
+		if fromBlock, err = h.v.ParseValidateBlockNumber(from); err != nil {
+			return nil, nil, fmt.Errorf("invalid fromBlock: %w", err)
+		}
+	}
+	if to != "" {
+		if toBlock, err = h.v.ParseValidateBlockNumber(to); err != nil {
+			return nil, nil, fmt.Errorf("invalid toBlock: %w", err)
+		}
+	}
+	if err := eventfilter.CheckBlockRange(fromBlock, toBlock); err != nil {
+		return nil, nil, err
+	}
+	return fromBlock, toBlock, nil
+}
+
+func (h HandlerSvc) parseTimeRange(from string, to string) (uint64, uint64, error) {
+	var fromTime, toTime uint64
+	var err error
+	if from != "" {
+		if fromTime, err = h.v.ParseValidateTime(from); err != nil {
+			return 0, 0, fmt.Errorf("invalid fromTime: %w", err)
+		}
+	}
+	if to != "" {
+		if toTime, err = h.v.ParseValidateTime(to); err != nil {
+			return 0, 0, fmt.Errorf("invalid toTime: %w", err)
+		}
+	}
+	if err := eventfilter.CheckTimeRange(fromTime, toTime); err != nil {
+		return 0, 0, err
+	}
+	return fromTime, toTime, nil
+}
+
+func eventPage(page, pageSize int, order string, cursor *utils.Cursor, count utils.CountMode) utils.Page {
+	return utils.Page{Page: page, PageSize: pageSize, Ascending: order == "asc", Cursor: cursor, Count: count}
+}
+
+func eventsPage(params *models.QueryEventsParams) utils.Page {
+	return eventPage(params.Page, params.PageSize, params.Order, params.Cursor, params.Count)
+}
+
+// encodeCursor 把分页游标编码为响应中的 next / prev，没有游标时返回空字符串
+func encodeCursor(cursor *utils.Cursor) string {
+	if cursor == nil {
+		return ""
+	}
+	return cursor.Encode()
+}
+
+// GetDepositTokens 按过滤条件分页查询充值记录
+func (h HandlerSvc) GetDepositTokens(params *models.QueryEventsParams) (*models.DepositTokensResponse, error) {
+	dtList, page, err := h.depositTokensView.QueryDepositTokens(params.Filter, eventsPage(params))
+	if err != nil {
+		return nil, err
+	}
+	tokenAddresses := make([]common.Address, 0, len(dtList))
+	for _, dt := range dtList {
+		tokenAddresses = append(tokenAddresses, dt.TokenAddress)
+	}
+	tokens, err := h.tokensView.TokensByAddresses(tokenAddresses)
+	if err != nil {
+		return nil, err
+	}
+	return &models.DepositTokensResponse{
+		Current: params.Page,
+		Size:    params.PageSize,
+		Total:   int64(page.Total),
+		Count:   page.Count.String(),
+		Next:    encodeCursor(page.Next),
+		Prev:    encodeCursor(page.Prev),
+		Result:  toDepositTokens(dtList, tokens),
+	}, nil
+}
+
+// GetDepositToken 查询一条充值记录，不存在时返回 nil
+func (h HandlerSvc) GetDepositToken(guid uuid.UUID) (*models.DepositToken, error) {
+	dt, err := h.depositTokensView.DepositTokensByGUID(guid.String())
+	if err != nil || dt == nil {
+		return nil, err
+	}
+	tokens, err := h.tokensView.TokensByAddresses([]common.Address{dt.TokenAddress})
+	if err != nil {
+		return nil, err
+	}
+	return &toDepositTokens([]worker.DepositTokens{*dt}, tokens)[0], nil
+}
+
+// GetWithdrawTokens 按过滤条件分页查询提现记录
+func (h HandlerSvc) GetWithdrawTokens(params *models.QueryEventsParams) (*models.WithdrawTokensResponse, error) {
+	wtList, page, err := h.withdrawTokensView.QueryWithdrawTokens(params.Filter, eventsPage(params))
+	if err != nil {
+		return nil, err
+	}
+	tokenAddresses := make([]common.Address, 0, len(wtList))
+	for _, wt := range wtList {
+		tokenAddresses = append(tokenAddresses, wt.TokenAddress)
+	}
+	tokens, err := h.tokensView.TokensByAddresses(tokenAddresses)
+	if err != nil {
+		return nil, err
+	}
+	return &models.WithdrawTokensResponse{
+		Current: params.Page,
+		Size:    params.PageSize,
+		Total:   int64(page.Total),
+		Count:   page.Count.String(),
+		Next:    encodeCursor(page.Next),
+		Prev:    encodeCursor(page.Prev),
+		Result:  toWithdrawTokens(wtList, tokens),
+	}, nil
+}
+
+// GetWithdrawToken 查询一条提现记录，不存在时返回 nil
+func (h HandlerSvc) GetWithdrawToken(guid uuid.UUID) (*models.WithdrawToken, error) {
+	wt, err := h.withdrawTokensView.WithdrawTokensByGUID(guid.String())
+	if err != nil || wt == nil {
+		return nil, err
+	}
+	tokens, err := h.tokensView.TokensByAddresses([]common.Address{wt.TokenAddress})
+	if err != nil {
+		return nil, err
+	}
+	return &toWithdrawTokens([]worker.WithdrawTokens{*wt}, tokens)[0], nil
+}
+
+// GetGrantRewardTokens 按过滤条件分页查询奖励发放记录
+func (h HandlerSvc) GetGrantRewardTokens(params *models.QueryEventsParams) (*models.GrantRewardTokensResponse, error) {
+	grList, page, err := h.grantRewardTokensView.QueryGrantRewardTokens(params.Filter, eventsPage(params))
+	if err != nil {
+		return nil, err
+	}
+	tokenAddresses := make([]common.Address, 0, len(grList))
+	for _, gr := range grList {
+		tokenAddresses = append(tokenAddresses, gr.TokenAddress)
+	}
+	tokens, err := h.tokensView.TokensByAddresses(tokenAddresses)
+	if err != nil {
+		return nil, err
+	}
+	return &models.GrantRewardTokensResponse{
+		Current: params.Page,
+		Size:    params.PageSize,
+		Total:   int64(page.Total),
+		Count:   page.Count.String(),
+		Next:    encodeCursor(page.Next),
+		Prev:    encodeCursor(page.Prev),
+		Result:  toGrantRewardTokens(grList, tokens),
+	}, nil
+}
+
+// GetGrantRewardToken 查询一条奖励发放记录，不存在时返回 nil
+func (h HandlerSvc) GetGrantRewardToken(guid uuid.UUID) (*models.GrantRewardToken, error) {
+	gr, err := h.grantRewardTokensView.GrantRewardTokensByGUID(guid.String())
+	if err != nil || gr == nil {
+		return nil, err
+	}
+	tokens, err := h.tokensView.TokensByAddresses([]common.Address{gr.TokenAddress})
+	if err != nil {
+		return nil, err
+	}
+	return &toGrantRewardTokens([]worker.GrantRewardTokens{*gr}, tokens)[0], nil
+}
+
+// GetWithdrawManagerUpdates 按过滤条件分页查询提现管理员变更记录
+func (h HandlerSvc) GetWithdrawManagerUpdates(params *models.QueryEventsParams) (*models.WithdrawManagerUpdatesResponse, error) {
+	updates, page, err := h.withdrawManagerUpdateView.QueryWithdrawManagerUpdates(params.Filter, eventsPage(params))
+	if err != nil {
+		return nil, err
+	}
+	return &models.WithdrawManagerUpdatesResponse{
+		Current: params.Page,
+		Size:    params.PageSize,
+		Total:   int64(page.Total),
+		Count:   page.Count.String(),
+		Next:    encodeCursor(page.Next),
+		Prev:    encodeCursor(page.Prev),
+		Result:  toWithdrawManagerUpdates(updates),
+	}, nil
+}
+
+// GetWithdrawManagerUpdate 查询一条提现管理员变更记录，不存在时返回 nil
+func (h HandlerSvc) GetWithdrawManagerUpdate(guid uuid.UUID) (*models.WithdrawManagerUpdate, error) {
+	update, err := h.withdrawManagerUpdateView.WithdrawManagerUpdateByGUID(guid.String())
+	if err != nil || update == nil {
+		return nil, err
+	}
+	return &toWithdrawManagerUpdates([]worker.WithdrawManagerUpdate{*update})[0], nil
+}
+
+// GetContractEvents 按过滤条件分页查询原始合约事件
+func (h HandlerSvc) GetContractEvents(params *models.QueryContractEventsParams) (*models.ContractEventsResponse, error) {
+	events, page, err := h.contractEventsView.QueryContractEvents(params.Filter, eventPage(params.Page, params.PageSize, params.Order, params.Cursor, params.Count))
+	if err != nil {
+		return nil, err
+	}
+	return &models.ContractEventsResponse{
+		Current: params.Page,
+		Size:    params.PageSize,
+		Total:   int64(page.Total),
+		Count:   page.Count.String(),
+		Next:    encodeCursor(page.Next),
+		Prev:    encodeCursor(page.Prev),
+		Result:  toContractEvents(events),
+	}, nil
+}
+
+// GetContractEvent 查询一条原始合约事件，不存在时返回 nil
+func (h HandlerSvc) GetContractEvent(guid uuid.UUID) (*models.ContractEvent, error) {
+	contractEvent, err := h.contractEventsView.ContractEventWithBlockNumber(guid)
+	if err != nil || contractEvent == nil {
+		return nil, err
+	}
+	return &toContractEvents([]event.ContractEvent{*contractEvent})[0], nil
+}
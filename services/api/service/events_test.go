@@ -0,0 +1,68 @@
+package service
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Sandwichzzy/event-sync-go/services/api/models"
+)
+
+func TestQueryEventListParams(t *testing.T) {
+	h := HandlerSvc{v: new(Validator)}
+
+	params, err := h.QueryEventListParams(&models.EventListRequest{
+		Order:     "ASC",
+		Receiver:  "0x2222222222222222222222222222222222222222",
+		FromBlock: "100",
+		ToBlock:   "200",
+		FromTime:  "2024-01-01T00:00:00Z",
+		TxHash:    "0x1111111111111111111111111111111111111111111111111111111111111111",
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, params.Page)
+	require.Equal(t, 20, params.PageSize)
+	require.Equal(t, "asc", params.Order)
+	require.Equal(t, common.HexToAddress("0x2222222222222222222222222222222222222222"), *params.Filter.Receiver)
+	require.Equal(t, big.NewInt(100), params.Filter.FromBlock)
+	require.Equal(t, big.NewInt(200), params.Filter.ToBlock)
+	require.Equal(t, uint64(1704067200), params.Filter.FromTimestamp)
+	require.NotNil(t, params.Filter.TransactionHash)
+
+	invalid := []models.EventListRequest{
+		{Order: "sideways"},
+		{Sender: "0x1234"},
+		{FromBlock: "200", ToBlock: "100"},
+		{FromTime: "yesterday"},
+		{TxHash: "0x1234"},
+		{Contract: "0x1111111111111111111111111111111111111111"},
+	}
+	for _, req := range invalid {
+		_, err := h.QueryEventListParams(&req)
+		require.Error(t, err, "%+v", req)
+	}
+
+	_, err = h.QueryContractEventListParams(&models.EventListRequest{Token: "0x1111111111111111111111111111111111111111"})
+	require.Error(t, err)
+}
+
+func TestQueryAddressActivityParams(t *testing.T) {
+	h := HandlerSvc{v: new(Validator)}
+
+	params, err := h.QueryAddressActivityParams("0x2222222222222222222222222222222222222222", &models.EventListRequest{
+		Token:    "0x1111111111111111111111111111111111111111",
+		FromTime: "1704067200",
+	})
+	require.NoError(t, err)
+	require.Equal(t, common.HexToAddress("0x2222222222222222222222222222222222222222"), params.Address)
+	require.Equal(t, common.HexToAddress("0x1111111111111111111111111111111111111111"), *params.Filter.TokenAddress)
+	require.Equal(t, uint64(1704067200), params.Filter.FromTimestamp)
+	require.Equal(t, 20, params.PageSize)
+
+	_, err = h.QueryAddressActivityParams("0x1234", &models.EventListRequest{})
+	require.Error(t, err)
+	_, err = h.QueryAddressActivityParams("0x2222222222222222222222222222222222222222", &models.EventListRequest{Sender: "0x1111111111111111111111111111111111111111"})
+	require.Error(t, err)
+}
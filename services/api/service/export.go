@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/Sandwichzzy/event-sync-go/export"
+	"github.com/Sandwichzzy/event-sync-go/services/api/models"
+)
+
+// maxExportRange 一次导出允许的最大时间范围（秒），更大的范围使用 export 命令
+const maxExportRange = 31 * 86400
+
+// QueryExportParams 验证并构建导出参数，格式为空时默认 csv，日期作为结束时间时包含当天。
+// from 必填，to 默认当前时间，时间范围最多 31 天
+func (h HandlerSvc) QueryExportParams(req *models.ExportRequest) (*export.Options, error) {
+	opts := &export.Options{Entity: req.Entity, Format: req.Format}
+	if opts.Format == "" {
+		opts.Format = export.FormatCSV
+	}
+	if _, err := export.Columns(opts.Entity); err != nil {
+		return nil, err
+	}
+	if err := export.ValidateFormat(opts.Format); err != nil {
+		return nil, err
+	}
+
+	if req.From == "" {
+		return nil, fmt.Errorf("from is required")
+	}
+	var err error
+	if opts.Filter.FromTimestamp, err = export.ParseTime(req.From, false); err != nil {
+		return nil, fmt.Errorf("invalid from: %w", err)
+	}
+	opts.Filter.ToTimestamp = uint64(time.Now().Unix())
+	if req.To != "" {
+		if opts.Filter.ToTimestamp, err = export.ParseTime(req.To, true); err != nil {
+			return nil, fmt.Errorf("invalid to: %w", err)
+		}
+	}
+	if opts.Filter.ToTimestamp < opts.Filter.FromTimestamp {
+		return nil, fmt.Errorf("to %d is before from %d", opts.Filter.ToTimestamp, opts.Filter.FromTimestamp)
+	}
+	if opts.Filter.ToTimestamp-opts.Filter.FromTimestamp >= maxExportRange {
+		return nil, fmt.Errorf("time range too large, at most %d days", maxExportRange/86400)
+	}
+	if req.Token != "" {
+		token, err := h.v.ParseValidateAddress(req.Token)
+		if err != nil {
+			return nil, fmt.Errorf("invalid token: %w", err)
+		}
+		opts.Filter.TokenAddress = &token
+	}
+	return opts, nil
+}
+
+// ExportEvents 把满足条件的记录逐行写入 w，返回写入的行数
+func (h HandlerSvc) ExportEvents(ctx context.Context, opts *export.Options, w io.Writer) (int, error) {
+	return export.Export(ctx, h.eventExportView, h.tokensView, w, *opts)
+}
@@ -0,0 +1,31 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/Sandwichzzy/event-sync-go/services/api/models"
+)
+
+func TestQueryExportParams(t *testing.T) {
+	h := HandlerSvc{v: new(Validator)}
+
+	opts, err := h.QueryExportParams(&models.ExportRequest{Entity: "deposits", From: "2024-01-01", To: "2024-01-31"})
+	require.NoError(t, err)
+	require.Equal(t, "csv", opts.Format)
+	require.Equal(t, uint64(1704067200), opts.Filter.FromTimestamp)
+	require.Equal(t, uint64(1706745599), opts.Filter.ToTimestamp)
+
+	invalid := []models.ExportRequest{
+		{Entity: "deposits"},
+		{Entity: "deposits", From: "2024-01-01", To: "2024-02-01"},
+		{Entity: "deposits", From: "2024-01-01"},
+		{Entity: "deposits", From: "2024-01-31", To: "2024-01-01"},
+		{Entity: "unknown", From: "2024-01-01", To: "2024-01-02"},
+	}
+	for _, req := range invalid {
+		_, err := h.QueryExportParams(&req)
+		require.Error(t, err, "%+v", req)
+	}
+}
@@ -2,9 +2,20 @@
 package service
 
 import (
+	"context"
+	"fmt"
+	"io"
 	"strconv"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+
+	"github.com/Sandwichzzy/event-sync-go/database"
+	common2 "github.com/Sandwichzzy/event-sync-go/database/common"
+	"github.com/Sandwichzzy/event-sync-go/database/event"
 	"github.com/Sandwichzzy/event-sync-go/database/worker"
+	"github.com/Sandwichzzy/event-sync-go/export"
+	"github.com/Sandwichzzy/event-sync-go/outbox"
 	"github.com/Sandwichzzy/event-sync-go/services/api/models"
 )
 
@@ -20,36 +31,156 @@ type Service interface {
 	// 参数: 页码字符串、每页条数字符串、排序方式字符串
 	// 返回: 验证后的查询参数对象和可能的错误
 	QueryDTListParams(page string, pageSize string, order string) (*models.QueryDTParams, error)
+
+	// GetRewardLedger 获取用户的奖励账本
+	// 参数: 查询参数（用户地址、流水页码、每页条数）
+	// 返回: 每个代币的奖励汇总、分页流水和可能的错误
+	GetRewardLedger(*models.QueryRewardParams) (*models.RewardLedgerResponse, error)
+
+	// QueryEventListParams 验证并构建充值、提现、奖励发放、提现管理员变更列表的过滤、分页和排序参数
+	QueryEventListParams(*models.EventListRequest) (*models.QueryEventsParams, error)
+
+	// QueryContractEventListParams 验证并构建原始合约事件列表的过滤、分页和排序参数
+	QueryContractEventListParams(*models.EventListRequest) (*models.QueryContractEventsParams, error)
+
+	// GetDepositTokens 按过滤条件分页查询充值记录，表中没有过滤字段时返回 ErrUnsupportedFilter
+	GetDepositTokens(*models.QueryEventsParams) (*models.DepositTokensResponse, error)
+
+	// GetDepositToken 查询一条充值记录，不存在时返回 nil
+	GetDepositToken(uuid.UUID) (*models.DepositToken, error)
+
+	// GetWithdrawTokens 按过滤条件分页查询提现记录
+	GetWithdrawTokens(*models.QueryEventsParams) (*models.WithdrawTokensResponse, error)
+
+	// GetWithdrawToken 查询一条提现记录，不存在时返回 nil
+	GetWithdrawToken(uuid.UUID) (*models.WithdrawToken, error)
+
+	// GetGrantRewardTokens 按过滤条件分页查询奖励发放记录
+	GetGrantRewardTokens(*models.QueryEventsParams) (*models.GrantRewardTokensResponse, error)
+
+	// GetGrantRewardToken 查询一条奖励发放记录，不存在时返回 nil
+	GetGrantRewardToken(uuid.UUID) (*models.GrantRewardToken, error)
+
+	// GetWithdrawManagerUpdates 按过滤条件分页查询提现管理员变更记录
+	GetWithdrawManagerUpdates(*models.QueryEventsParams) (*models.WithdrawManagerUpdatesResponse, error)
+
+	// GetWithdrawManagerUpdate 查询一条提现管理员变更记录，不存在时返回 nil
+	GetWithdrawManagerUpdate(uuid.UUID) (*models.WithdrawManagerUpdate, error)
+
+	// GetContractEvents 按过滤条件分页查询原始合约事件
+	GetContractEvents(*models.QueryContractEventsParams) (*models.ContractEventsResponse, error)
+
+	// GetContractEvent 查询一条原始合约事件，不存在时返回 nil
+	GetContractEvent(uuid.UUID) (*models.ContractEvent, error)
+
+	// QueryRewardParams 验证并构建奖励账本查询参数
+	// 参数: 用户地址字符串、页码字符串、每页条数字符串（页码参数可以为空）
+	// 返回: 验证后的查询参数对象和可能的错误
+	QueryRewardParams(address string, page string, pageSize string) (*models.QueryRewardParams, error)
+
+	// QueryCreateWebhookParams 验证创建 webhook 订阅的请求体
+	QueryCreateWebhookParams(*models.CreateWebhookRequest) (*models.CreateWebhookParams, error)
+
+	// CreateWebhookSubscription 为 API key owner 创建 webhook 订阅，返回值包含签名密钥
+	CreateWebhookSubscription(owner uuid.UUID, params *models.CreateWebhookParams) (*models.WebhookSubscription, error)
+
+	// GetWebhookSubscriptions 查询 owner 的所有 webhook 订阅
+	GetWebhookSubscriptions(owner uuid.UUID) ([]models.WebhookSubscription, error)
+
+	// GetWebhookSubscription 查询 owner 的一个 webhook 订阅，不存在或属于其他 API key 时返回 nil
+	GetWebhookSubscription(owner uuid.UUID, guid uuid.UUID) (*models.WebhookSubscription, error)
+
+	// SetWebhookSubscriptionActive 启用或停用 owner 的 webhook 订阅，不存在时返回 nil
+	SetWebhookSubscriptionActive(owner uuid.UUID, guid uuid.UUID, active bool) (*models.WebhookSubscription, error)
+
+	// DeleteWebhookSubscription 删除 owner 的 webhook 订阅，不存在时返回 false
+	DeleteWebhookSubscription(owner uuid.UUID, guid uuid.UUID) (bool, error)
+
+	// QueryWebhookDeliveriesParams 验证并构建投递记录查询参数
+	QueryWebhookDeliveriesParams(guid string, status string, page string, pageSize string) (*models.QueryWebhookDeliveriesParams, error)
+
+	// GetWebhookDeliveries 分页查询 owner 的订阅的投递记录，订阅不存在时返回 nil
+	GetWebhookDeliveries(owner uuid.UUID, params *models.QueryWebhookDeliveriesParams) (*models.WebhookDeliveriesResponse, error)
+
+	// RedeliverWebhookDelivery 重新投递 owner 的订阅的一条死信，不存在时返回 nil，不是死信时返回 ErrNotDeadLetter
+	RedeliverWebhookDelivery(owner uuid.UUID, guid uuid.UUID) (*models.WebhookDelivery, error)
+
+	// QueryAddressActivityParams 验证并构建地址时间线的分页和过滤参数
+	QueryAddressActivityParams(address string, req *models.EventListRequest) (*models.QueryAddressActivityParams, error)
+
+	// GetAddressActivity 查询地址每个代币的汇总和合并后的充值、提现、奖励发放、提现管理员变更时间线
+	GetAddressActivity(*models.QueryAddressActivityParams) (*models.AddressActivityResponse, error)
+
+	// QueryExportParams 验证并构建导出参数
+	QueryExportParams(*models.ExportRequest) (*export.Options, error)
+
+	// ExportEvents 把一个实体的记录按 (block_number, log_index) 升序流式写入 w，返回写入的行数
+	ExportEvents(ctx context.Context, opts *export.Options, w io.Writer) (int, error)
+
+	// QueryStatsParams 验证并构建代币统计查询参数
+	QueryStatsParams(*models.StatsRequest) (*models.QueryStatsParams, error)
+
+	// GetTokenStats 查询按小时 / 天汇总的代币统计和发放金额最多的地址
+	GetTokenStats(*models.QueryStatsParams) (*models.StatsResponse, error)
+
+	// QueryStreamParams 验证并构建实时推送参数
+	// 参数: 逗号分隔的事件类型、逗号分隔的参与方地址、代币地址、Last-Event-ID（可以为空）
+	QueryStreamParams(types string, addresses string, token string, lastEventID string) (*models.StreamParams, error)
+
+	// StreamEvents 推送满足过滤条件的事件，直到 ctx 结束或 send / ping 返回错误
+	StreamEvents(ctx context.Context, params *models.StreamParams, send func(models.StreamEvent) error, ping func() error) error
 }
 
 // HandlerSvc 业务服务实现结构体
 // 它组合了验证器和数据访问层，实现Service接口
 type HandlerSvc struct {
-	v                 *Validator                // 参数验证器
-	depositTokensView worker.DepositTokensView  // 充值代币数据访问层
+	v                         *Validator                       // 参数验证器
+	depositTokensView         worker.DepositTokensView         // 充值代币数据访问层
+	withdrawTokensView        worker.WithdrawTokensView        // 提现数据访问层
+	grantRewardTokensView     worker.GrantRewardTokensView     // 奖励发放数据访问层
+	withdrawManagerUpdateView worker.WithdrawManagerUpdateView // 提现管理员变更数据访问层
+	contractEventsView        event.ContractEventsView         // 原始合约事件数据访问层
+	rewardLedgerView          worker.RewardLedgerView          // 奖励账本数据访问层
+	tokenStatsView            worker.TokenStatsView            // 代币统计数据访问层
+	addressActivityView       worker.AddressActivityView       // 地址时间线数据访问层
+	eventExportView           worker.EventExportView           // 导出数据访问层
+	tokensView                common2.TokensView               // 代币元数据访问层
+	webhooksDB                event.WebhooksDB                 // webhook 订阅和投递记录（写主库）
+	hub                       *outbox.Hub                      // 实时推送的 outbox 广播
 }
 
 // GetDepositTokensList 获取充值代币分页列表
 // 功能:
 //   1. 调用数据访问层查询数据库
-//   2. 构建分页响应对象
+//   2. 查询涉及代币的元数据，把金额转换为字符串和格式化金额
+//   3. 构建分页响应对象
 //
 // 参数:
 //   - params: 已验证的查询参数（页码、每页条数、排序方式）
 //
 // 返回:
 //   - *models.DepositTokensResponse: 包含当前页码、每页条数、总记录数和结果列表的响应对象
-//   - error: 如果查询失败，返回错误（当前实现总是返回nil）
+//   - error: 如果查询代币元数据失败，返回错误
 func (h HandlerSvc) GetDepositTokensList(params *models.QueryDTParams) (*models.DepositTokensResponse, error) {
 	// 调用数据访问层查询数据库
 	dtList, totalCount := h.depositTokensView.QueryDepositTokensList(params.Page, params.PageSize)
 
+	// 查询本页涉及的代币元数据
+	tokenAddresses := make([]common.Address, 0, len(dtList))
+	for _, dt := range dtList {
+		tokenAddresses = append(tokenAddresses, dt.TokenAddress)
+	}
+	tokens, err := h.tokensView.TokensByAddresses(tokenAddresses)
+	if err != nil {
+		return nil, err
+	}
+
 	// 构建并返回分页响应对象
 	return &models.DepositTokensResponse{
 		Current: params.Page,         // 当前页码
 		Size:    params.PageSize,     // 每页条数
 		Total:   int64(totalCount),   // 总记录数
-		Result:  dtList,              // 当前页的数据列表
+		Result:  toDepositTokens(dtList, tokens), // 当前页的数据列表
 	}, nil
 }
 
@@ -98,24 +229,102 @@ func (h HandlerSvc) QueryDTListParams(page string, pageSize string, order string
 // New 创建一个新的业务服务实例
 // 参数:
 //   - v: 参数验证器实例
-//   - dtv: 充值代币数据访问层接口
+//   - db: 只读数据库（充值、提现、奖励发放、提现管理员变更、原始合约事件、奖励账本、代币统计、地址时间线、导出、代币元数据）
+//   - whdb: webhook 订阅和投递记录访问层接口（需要写权限）
+//   - hub: 实时推送的 outbox 广播
 // 返回:
 //   - Service: 业务服务接口的实现
-func New(v *Validator, dtv worker.DepositTokensView) Service {
+func New(v *Validator, db *database.DB, whdb event.WebhooksDB, hub *outbox.Hub) Service {
 	return &HandlerSvc{
-		v:                 v,
-		depositTokensView: dtv,
+		v:                         v,
+		depositTokensView:         db.DepositTokens,
+		withdrawTokensView:        db.WithdrawTokens,
+		grantRewardTokensView:     db.GrantRewardTokens,
+		withdrawManagerUpdateView: db.WithdrawManagerUpdate,
+		contractEventsView:        db.ContractEvent,
+		rewardLedgerView:          db.RewardLedger,
+		tokenStatsView:            db.TokenStats,
+		addressActivityView:       db.AddressActivity,
+		eventExportView:           db.EventExport,
+		tokensView:                db.Tokens,
+		webhooksDB:                whdb,
+		hub:                       hub,
+	}
+}
+
+// GetRewardLedger 获取用户的奖励账本
+// 功能:
+//   1. 查询该用户每个代币的发放/领取/可领取汇总
+//   2. 分页查询该用户的奖励流水
+//   3. 查询涉及代币的元数据，把金额转换为字符串和格式化金额
+func (h HandlerSvc) GetRewardLedger(params *models.QueryRewardParams) (*models.RewardLedgerResponse, error) {
+	balances, err := h.rewardLedgerView.QueryRewardBalances(params.Address)
+	if err != nil {
+		return nil, err
+	}
+	entries, total := h.rewardLedgerView.QueryRewardLedgerEntries(params.Address, params.Page, params.PageSize)
+
+	tokenAddresses := make([]common.Address, 0, len(balances)+len(entries))
+	for _, balance := range balances {
+		tokenAddresses = append(tokenAddresses, balance.TokenAddress)
+	}
+	for _, entry := range entries {
+		tokenAddresses = append(tokenAddresses, entry.TokenAddress)
+	}
+	tokens, err := h.tokensView.TokensByAddresses(tokenAddresses)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.RewardLedgerResponse{
+		Address:  params.Address.String(),
+		Balances: toRewardBalances(balances, tokens),
+		Current:  params.Page,
+		Size:     params.PageSize,
+		Total:    int64(total),
+		Entries:  toRewardLedgerEntries(entries, tokens),
+	}, nil
+}
+
+// QueryRewardParams 验证并构建奖励账本查询参数
+// 页码参数为空时使用默认值（第1页，每页20条）
+func (h HandlerSvc) QueryRewardParams(address string, page string, pageSize string) (*models.QueryRewardParams, error) {
+	addr, err := h.v.ParseValidateAddress(address)
+	if err != nil {
+		return nil, err
+	}
+
+	pageInt, pageSizeInt, err := parsePageParams(page, pageSize)
+	if err != nil {
+		return nil, err
 	}
+
+	return &models.QueryRewardParams{
+		Address:  addr,
+		Page:     h.v.ValidatePage(pageInt),
+		PageSize: h.v.ValidatePageSize(pageSizeInt),
+	}, nil
+}
+
+// parsePageParams 将页码和每页条数字符串转换为整数，空字符串视为0（由验证器替换为默认值）
+func parsePageParams(page string, pageSize string) (int, int, error) {
+	var pageInt, pageSizeInt int
+	var err error
+	if page != "" {
+		if pageInt, err = strconv.Atoi(page); err != nil {
+			return 0, 0, fmt.Errorf("invalid page: %w", err)
+		}
+	}
+	if pageSize != "" {
+		if pageSizeInt, err = strconv.Atoi(pageSize); err != nil {
+			return 0, 0, fmt.Errorf("invalid pageSize: %w", err)
+		}
+	}
+	return pageInt, pageSizeInt, nil
 }
 
 // GetDepositList 获取充值列表（与GetDepositTokensList功能相同）
 // 注意: 这个方法似乎是GetDepositTokensList的重复实现，可能需要重构
 func (h HandlerSvc) GetDepositList(params *models.QueryDTParams) (*models.DepositTokensResponse, error) {
-	depositList, total := h.depositTokensView.QueryDepositTokensList(params.Page, params.PageSize)
-	return &models.DepositTokensResponse{
-		Current: params.Page,
-		Size:    params.PageSize,
-		Total:   int64(total),
-		Result:  depositList,
-	}, nil
+	return h.GetDepositTokensList(params)
 }
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	common2 "github.com/Sandwichzzy/event-sync-go/database/common"
+	"github.com/Sandwichzzy/event-sync-go/database/worker"
+	"github.com/Sandwichzzy/event-sync-go/services/api/models"
+)
+
+const (
+	defaultTopGranters = 10
+	maxTopGranters     = 100
+)
+
+// statsWindows 每种桶未指定起始时间时的默认时间范围和允许查询的最大时间范围（秒）
+var statsWindows = map[string]struct{ def, max uint64 }{
+	worker.StatsBucketHour: {def: 24 * 3600, max: 31 * 86400},
+	worker.StatsBucketDay:  {def: 30 * 86400, max: 366 * 86400},
+}
+
+// QueryStatsParams 验证并构建代币统计查询参数
+// 起始时间向下对齐到桶的起始时间，结束时间默认当前桶的结束时间（同一个桶内的默认查询参数相同，便于缓存），
+// 起始时间默认结束时间之前的默认窗口（小时桶 24 小时，天桶 30 天）
+func (h HandlerSvc) QueryStatsParams(req *models.StatsRequest) (*models.QueryStatsParams, error) {
+	bucket := req.Bucket
+	if bucket == "" {
+		bucket = worker.StatsBucketDay
+	}
+	window, ok := statsWindows[bucket]
+	if !ok {
+		return nil, fmt.Errorf("invalid bucket %q, expected hour or day", req.Bucket)
+	}
+	seconds := worker.StatsBucketSeconds[bucket]
+
+	now := uint64(time.Now().Unix())
+	to := now - now%seconds + seconds
+	if req.To != "" {
+		value, err := h.v.ParseValidateTime(req.To)
+		if err != nil {
+			return nil, fmt.Errorf("invalid to: %w", err)
+		}
+		to = value
+	}
+	var from uint64
+	if req.From != "" {
+		value, err := h.v.ParseValidateTime(req.From)
+		if err != nil {
+			return nil, fmt.Errorf("invalid from: %w", err)
+		}
+		from = value
+	} else if to > window.def {
+		from = to - window.def
+	}
+	from -= from % seconds
+	if from >= to {
+		return nil, fmt.Errorf("from %d must be before to %d", from, to)
+	}
+	if to-from > window.max {
+		return nil, fmt.Errorf("time range too large for %s buckets, at most %d days", bucket, window.max/86400)
+	}
+
+	params := &models.QueryStatsParams{Bucket: bucket, From: from, To: to, Top: defaultTopGranters}
+	if req.Token != "" {
+		address, err := h.v.ParseValidateAddress(req.Token)
+		if err != nil {
+			return nil, fmt.Errorf("invalid token: %w", err)
+		}
+		params.Token = &address
+	}
+	if req.Top != "" {
+		top, err := strconv.Atoi(req.Top)
+		if err != nil || top < 0 || top > maxTopGranters {
+			return nil, fmt.Errorf("invalid top %q, expected 0 to %d", req.Top, maxTopGranters)
+		}
+		params.Top = top
+	}
+	return params, nil
+}
+
+// GetTokenStats 查询时间范围内每个桶每个代币的汇总，以及每个代币发放金额最多的地址
+func (h HandlerSvc) GetTokenStats(params *models.QueryStatsParams) (*models.StatsResponse, error) {
+	stats, err := h.tokenStatsView.QueryTokenStats(params.Bucket, params.From, params.To, params.Token)
+	if err != nil {
+		return nil, err
+	}
+	var granters []worker.GranterStats
+	if params.Top > 0 {
+		granters, err = h.tokenStatsView.QueryTopGranters(params.Bucket, params.From, params.To, params.Token, params.Top)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tokenAddresses := make([]common.Address, 0, len(stats)+len(granters))
+	for _, s := range stats {
+		tokenAddresses = append(tokenAddresses, s.TokenAddress)
+	}
+	for _, g := range granters {
+		tokenAddresses = append(tokenAddresses, g.TokenAddress)
+	}
+	tokens, err := h.tokensView.TokensByAddresses(tokenAddresses)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.StatsResponse{
+		Bucket:      params.Bucket,
+		From:        params.From,
+		To:          params.To,
+		Buckets:     toTokenStats(stats, tokens),
+		TopGranters: toGranterStats(granters, tokens),
+	}, nil
+}
+
+func toTokenStats(stats []worker.TokenStats, tokens map[common.Address]common2.Token) []models.TokenStats {
+	result := make([]models.TokenStats, 0, len(stats))
+	for _, s := range stats {
+		info := tokenInfo(tokens, s.TokenAddress)
+		result = append(result, models.TokenStats{
+			BucketStart:             s.BucketStart,
+			TokenAddress:            s.TokenAddress.String(),
+			DepositCount:            s.DepositCount,
+			DepositVolume:           rawAmount(s.DepositVolume),
+			FormattedDepositVolume:  formatAmount(s.DepositVolume, info),
+			UniqueDepositors:        s.UniqueDepositors,
+			WithdrawCount:           s.WithdrawCount,
+			WithdrawVolume:          rawAmount(s.WithdrawVolume),
+			FormattedWithdrawVolume: formatAmount(s.WithdrawVolume, info),
+			GrantCount:              s.GrantCount,
+			GrantVolume:             rawAmount(s.GrantVolume),
+			FormattedGrantVolume:    formatAmount(s.GrantVolume, info),
+			Token:                   info,
+		})
+	}
+	return result
+}
+
+func toGranterStats(granters []worker.GranterStats, tokens map[common.Address]common2.Token) []models.GranterStats {
+	result := make([]models.GranterStats, 0, len(granters))
+	for _, g := range granters {
+		info := tokenInfo(tokens, g.TokenAddress)
+		result = append(result, models.GranterStats{
+			TokenAddress:         g.TokenAddress.String(),
+			Granter:              g.Granter.String(),
+			GrantCount:           g.GrantCount,
+			GrantVolume:          rawAmount(g.GrantVolume),
+			FormattedGrantVolume: formatAmount(g.GrantVolume, info),
+			Token:                info,
+		})
+	}
+	return result
+}
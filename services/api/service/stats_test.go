@@ -0,0 +1,39 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/Sandwichzzy/event-sync-go/services/api/models"
+)
+
+func TestQueryStatsParams(t *testing.T) {
+	h := HandlerSvc{v: new(Validator)}
+
+	params, err := h.QueryStatsParams(&models.StatsRequest{Bucket: "hour", From: "2024-01-01T00:30:00Z", To: "2024-01-02T00:00:00Z", Top: "5"})
+	require.NoError(t, err)
+	require.Equal(t, "hour", params.Bucket)
+	require.Equal(t, uint64(1704067200), params.From)
+	require.Equal(t, uint64(1704153600), params.To)
+	require.Equal(t, 5, params.Top)
+	require.Nil(t, params.Token)
+
+	params, err = h.QueryStatsParams(&models.StatsRequest{To: "1704153600"})
+	require.NoError(t, err)
+	require.Equal(t, "day", params.Bucket)
+	require.Equal(t, uint64(1704153600-30*86400), params.From)
+	require.Equal(t, 10, params.Top)
+
+	invalid := []models.StatsRequest{
+		{Bucket: "week"},
+		{From: "2024-01-02T00:00:00Z", To: "2024-01-01T00:00:00Z"},
+		{Bucket: "hour", From: "2024-01-01T00:00:00Z", To: "2024-03-01T00:00:00Z"},
+		{Token: "0x1234"},
+		{Top: "1000"},
+	}
+	for _, req := range invalid {
+		_, err := h.QueryStatsParams(&req)
+		require.Error(t, err, "%+v", req)
+	}
+}
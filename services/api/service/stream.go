@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Sandwichzzy/event-sync-go/database/event"
+	"github.com/Sandwichzzy/event-sync-go/outbox"
+	"github.com/Sandwichzzy/event-sync-go/services/api/models"
+)
+
+// streamHeartbeat 实时推送空闲时发送心跳的间隔，防止代理断开长连接
+const streamHeartbeat = 15 * time.Second
+
+// QueryStreamParams 验证并构建实时推送参数
+// 参数:
+//   - types: 逗号分隔的事件类型
+//   - addresses: 逗号分隔的参与方地址
+//   - token: 代币地址
+//   - lastEventID: 客户端最后收到的事件 ID，为空时从当前最新事件之后开始
+func (h HandlerSvc) QueryStreamParams(types string, addresses string, token string, lastEventID string) (*models.StreamParams, error) {
+	params := &models.StreamParams{EventTypes: splitList(types)}
+	if err := h.v.ValidateEventTypes(params.EventTypes); err != nil {
+		return nil, err
+	}
+	for _, address := range splitList(addresses) {
+		addr, err := h.v.ParseValidateAddress(address)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address: %w", err)
+		}
+		params.Addresses = append(params.Addresses, addr)
+	}
+	if token != "" {
+		tokenAddr, err := h.v.ParseValidateAddress(token)
+		if err != nil {
+			return nil, fmt.Errorf("invalid token: %w", err)
+		}
+		params.TokenAddress = &tokenAddr
+	}
+	if lastEventID != "" {
+		afterID, err := strconv.ParseUint(lastEventID, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Last-Event-ID: %w", err)
+		}
+		params.AfterID = afterID
+	} else {
+		params.AfterID = h.hub.LatestID()
+	}
+	return params, nil
+}
+
+// StreamEvents 推送 params.AfterID 之后满足过滤条件的事件，先补齐历史再推送实时事件，
+// 空闲时调用 ping 发送心跳。直到 ctx 结束或 send / ping 返回错误才返回。
+func (h HandlerSvc) StreamEvents(ctx context.Context, params *models.StreamParams, send func(models.StreamEvent) error, ping func() error) error {
+	filter := outbox.Filter{EventTypes: params.EventTypes, TokenAddress: params.TokenAddress, Addresses: params.Addresses}
+	return h.hub.Stream(ctx, params.AfterID, filter, streamHeartbeat, func(message event.OutboxMessage) error {
+		return send(toStreamEvent(message))
+	}, ping)
+}
+
+func toStreamEvent(message event.OutboxMessage) models.StreamEvent {
+	return models.StreamEvent{
+		ID:              message.ID,
+		ContractAddress: message.ContractAddress.String(),
+		EventType:       message.EventType,
+		Action:          message.Action,
+		BlockNumber:     message.BlockNumber,
+		BlockHash:       message.BlockHash.String(),
+		TransactionHash: message.TransactionHash.String(),
+		LogIndex:        message.LogIndex,
+		Payload:         message.Payload,
+		Timestamp:       message.Timestamp,
+	}
+}
+
+// splitList 拆分逗号分隔的查询参数，忽略空项
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -3,13 +3,26 @@ package service
 
 import (
 	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/Sandwichzzy/event-sync-go/outbox"
+	"github.com/Sandwichzzy/event-sync-go/services/eventfilter"
+	"github.com/Sandwichzzy/event-sync-go/webhooks"
 )
 
 // Validator 验证器结构体，提供各种参数验证方法
 // 用于在业务逻辑层验证和标准化用户输入
-type Validator struct{}
+type Validator struct {
+	// AllowPrivateWebhookTargets 允许 webhook 回调地址使用回环、私有和链路本地 IP，只用于本地开发
+	AllowPrivateWebhookTargets bool
+}
 
 // ParseValidateAddress 解析并验证以太坊地址
 // 功能:
@@ -24,25 +37,11 @@ type Validator struct{}
 //   - common.Address: 解析后的地址对象
 //   - error: 如果地址格式无效或为零地址，返回错误
 func (v *Validator) ParseValidateAddress(addr string) (common.Address, error) {
-	var parsedAddr common.Address
-
 	// 特殊情况: 允许"0x00"作为有效输入
-	if addr != "0x00" {
-		// 验证是否为有效的十六进制地址格式
-		if !common.IsHexAddress(addr) {
-			return common.Address{}, errors.New("address must be represented as a valid hexadecimal string")
-		}
-
-		// 将十六进制字符串转换为Address对象
-		parsedAddr = common.HexToAddress(addr)
-
-		// 拒绝零地址
-		if parsedAddr == common.HexToAddress("0x0") {
-			return common.Address{}, errors.New("address cannot be the zero address")
-		}
+	if addr == "0x00" {
+		return common.Address{}, nil
 	}
-
-	return parsedAddr, nil
+	return eventfilter.ParseAddress(addr)
 }
 
 // ValidatePage 验证并标准化页码参数
@@ -122,3 +121,69 @@ func (v *Validator) ValidateIdOrIndex(idOrIndex uint64) error {
 	}
 	return nil
 }
+
+// ValidateWebhookURL 验证 webhook 回调地址，必须是带主机名的 http 或 https URL，
+// 主机不能是 localhost 或回环、私有、链路本地 IP（域名解析后的地址在投递时校验）
+func (v *Validator) ValidateWebhookURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid url: %w", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return errors.New("url must be an absolute http or https url")
+	}
+	if !v.AllowPrivateWebhookTargets {
+		if err := webhooks.CheckTargetHost(u.Hostname()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateEventTypes 验证 webhook 订阅和实时推送的事件类型过滤条件
+func (v *Validator) ValidateEventTypes(eventTypes []string) error {
+	for _, eventType := range eventTypes {
+		if !outbox.KnownEventTypes[eventType] {
+			return fmt.Errorf("unknown event type %q", eventType)
+		}
+	}
+	return nil
+}
+
+// ParseValidateHash 解析并验证 32 字节的十六进制哈希（交易哈希、事件签名）
+func (v *Validator) ParseValidateHash(hash string) (common.Hash, error) {
+	return eventfilter.ParseHash(hash)
+}
+
+// ParseValidateBlockNumber 解析十进制区块号
+func (v *Validator) ParseValidateBlockNumber(number string) (*big.Int, error) {
+	n, ok := new(big.Int).SetString(number, 10)
+	if !ok || n.Sign() < 0 {
+		return nil, fmt.Errorf("invalid block number %q", number)
+	}
+	return n, nil
+}
+
+// ParseValidateTime 解析时间参数，接受 unix 秒或 RFC 3339，返回 unix 秒
+func (v *Validator) ParseValidateTime(value string) (uint64, error) {
+	if seconds, err := strconv.ParseUint(value, 10, 64); err == nil {
+		return seconds, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil || t.Unix() < 0 {
+		return 0, fmt.Errorf("invalid time %q, expected unix seconds or RFC 3339", value)
+	}
+	return uint64(t.Unix()), nil
+}
+
+// ParseValidateOrder 验证排序方式，空字符串为默认降序，其他不被 ValidateOrder 接受的值返回错误
+func (v *Validator) ParseValidateOrder(order string) (string, error) {
+	if order == "" {
+		return "desc", nil
+	}
+	validOrder := v.ValidateOrder(order)
+	if !strings.EqualFold(validOrder, order) {
+		return "", fmt.Errorf("invalid order %q, expected asc or desc", order)
+	}
+	return strings.ToLower(validOrder), nil
+}
@@ -0,0 +1,227 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/Sandwichzzy/event-sync-go/database/event"
+	"github.com/Sandwichzzy/event-sync-go/services/api/models"
+	"github.com/Sandwichzzy/event-sync-go/webhooks"
+)
+
+// ErrNotDeadLetter 只有死信状态的投递可以重新投递
+var ErrNotDeadLetter = errors.New("only dead deliveries can be redelivered")
+
+// QueryCreateWebhookParams 验证创建 webhook 订阅的请求体，地址过滤条件必须是非零地址
+func (h HandlerSvc) QueryCreateWebhookParams(req *models.CreateWebhookRequest) (*models.CreateWebhookParams, error) {
+	if err := h.v.ValidateWebhookURL(req.URL); err != nil {
+		return nil, err
+	}
+	if err := h.v.ValidateEventTypes(req.EventTypes); err != nil {
+		return nil, err
+	}
+	params := &models.CreateWebhookParams{URL: req.URL, EventTypes: req.EventTypes, Secret: req.Secret}
+	if params.EventTypes == nil {
+		params.EventTypes = []string{}
+	}
+	if req.TokenAddress != "" {
+		token, err := h.v.ParseValidateAddress(req.TokenAddress)
+		if err != nil {
+			return nil, fmt.Errorf("invalid token_address: %w", err)
+		}
+		params.TokenAddress = &token
+	}
+	if req.Address != "" {
+		address, err := h.v.ParseValidateAddress(req.Address)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address: %w", err)
+		}
+		params.Address = &address
+	}
+	return params, nil
+}
+
+// CreateWebhookSubscription 为 API key owner 创建 webhook 订阅，返回的订阅包含签名密钥，之后的查询不再返回密钥
+func (h HandlerSvc) CreateWebhookSubscription(owner uuid.UUID, params *models.CreateWebhookParams) (*models.WebhookSubscription, error) {
+	secret := params.Secret
+	if secret == "" {
+		var err error
+		if secret, err = webhooks.NewSecret(); err != nil {
+			return nil, err
+		}
+	}
+	subscription := &event.WebhookSubscription{
+		GUID:         uuid.New(),
+		OwnerGUID:    owner,
+		URL:          params.URL,
+		Secret:       secret,
+		EventTypes:   params.EventTypes,
+		TokenAddress: params.TokenAddress,
+		Address:      params.Address,
+		Active:       true,
+	}
+	if err := h.webhooksDB.StoreWebhookSubscription(subscription); err != nil {
+		return nil, err
+	}
+	result := toWebhookSubscription(*subscription)
+	result.Secret = secret
+	return &result, nil
+}
+
+// GetWebhookSubscriptions 返回 API key owner 创建的所有 webhook 订阅
+func (h HandlerSvc) GetWebhookSubscriptions(owner uuid.UUID) ([]models.WebhookSubscription, error) {
+	subscriptions, err := h.webhooksDB.WebhookSubscriptionsByOwner(owner)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]models.WebhookSubscription, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		result = append(result, toWebhookSubscription(subscription))
+	}
+	return result, nil
+}
+
+// ownedSubscription 返回 owner 创建的订阅，不存在或属于其他 API key 时返回 nil，不暴露其他密钥的订阅是否存在
+func (h HandlerSvc) ownedSubscription(owner uuid.UUID, guid uuid.UUID) (*event.WebhookSubscription, error) {
+	subscription, err := h.webhooksDB.WebhookSubscription(guid)
+	if err != nil || subscription == nil || subscription.OwnerGUID != owner {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// GetWebhookSubscription 返回 owner 的一个 webhook 订阅，不存在时返回 nil
+func (h HandlerSvc) GetWebhookSubscription(owner uuid.UUID, guid uuid.UUID) (*models.WebhookSubscription, error) {
+	subscription, err := h.ownedSubscription(owner, guid)
+	if err != nil || subscription == nil {
+		return nil, err
+	}
+	result := toWebhookSubscription(*subscription)
+	return &result, nil
+}
+
+// SetWebhookSubscriptionActive 启用或停用 owner 的 webhook 订阅，不存在时返回 nil
+func (h HandlerSvc) SetWebhookSubscriptionActive(owner uuid.UUID, guid uuid.UUID, active bool) (*models.WebhookSubscription, error) {
+	subscription, err := h.ownedSubscription(owner, guid)
+	if err != nil || subscription == nil {
+		return nil, err
+	}
+	found, err := h.webhooksDB.SetWebhookSubscriptionActive(guid, active)
+	if err != nil || !found {
+		return nil, err
+	}
+	return h.GetWebhookSubscription(owner, guid)
+}
+
+// DeleteWebhookSubscription 删除 owner 的 webhook 订阅及其投递记录，不存在时返回 false
+func (h HandlerSvc) DeleteWebhookSubscription(owner uuid.UUID, guid uuid.UUID) (bool, error) {
+	subscription, err := h.ownedSubscription(owner, guid)
+	if err != nil || subscription == nil {
+		return false, err
+	}
+	return h.webhooksDB.DeleteWebhookSubscription(guid)
+}
+
+// QueryWebhookDeliveriesParams 验证并构建投递记录查询参数，status 只接受 pending / delivered / dead 或空
+func (h HandlerSvc) QueryWebhookDeliveriesParams(guid string, status string, page string, pageSize string) (*models.QueryWebhookDeliveriesParams, error) {
+	subscriptionGUID, err := uuid.Parse(guid)
+	if err != nil {
+		return nil, fmt.Errorf("invalid subscription guid: %w", err)
+	}
+	switch status {
+	case "", event.WebhookDeliveryPending, event.WebhookDeliveryDelivered, event.WebhookDeliveryDead:
+	default:
+		return nil, fmt.Errorf("invalid status %q", status)
+	}
+	pageInt, pageSizeInt, err := parsePageParams(page, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	return &models.QueryWebhookDeliveriesParams{
+		SubscriptionGUID: subscriptionGUID,
+		Status:           status,
+		Page:             h.v.ValidatePage(pageInt),
+		PageSize:         h.v.ValidatePageSize(pageSizeInt),
+	}, nil
+}
+
+// GetWebhookDeliveries 分页查询 owner 的订阅的投递记录，订阅不存在时返回 nil
+func (h HandlerSvc) GetWebhookDeliveries(owner uuid.UUID, params *models.QueryWebhookDeliveriesParams) (*models.WebhookDeliveriesResponse, error) {
+	subscription, err := h.ownedSubscription(owner, params.SubscriptionGUID)
+	if err != nil || subscription == nil {
+		return nil, err
+	}
+	deliveries, total := h.webhooksDB.QueryWebhookDeliveries(params.SubscriptionGUID, params.Status, params.Page, params.PageSize)
+	result := make([]models.WebhookDelivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		result = append(result, toWebhookDelivery(delivery))
+	}
+	return &models.WebhookDeliveriesResponse{
+		Current: params.Page,
+		Size:    params.PageSize,
+		Total:   int64(total),
+		Result:  result,
+	}, nil
+}
+
+// RedeliverWebhookDelivery 把 owner 的订阅的死信投递重置为待投递，由调度器在下一轮重新投递。
+// 投递不存在时返回 nil；投递不是死信时返回 ErrNotDeadLetter。
+func (h HandlerSvc) RedeliverWebhookDelivery(owner uuid.UUID, guid uuid.UUID) (*models.WebhookDelivery, error) {
+	delivery, err := h.webhooksDB.WebhookDelivery(guid)
+	if err != nil || delivery == nil {
+		return nil, err
+	}
+	subscription, err := h.ownedSubscription(owner, delivery.SubscriptionGUID)
+	if err != nil || subscription == nil {
+		return nil, err
+	}
+	redelivered, err := h.webhooksDB.RedeliverWebhookDelivery(guid)
+	if err != nil {
+		return nil, err
+	}
+	if delivery, err = h.webhooksDB.WebhookDelivery(guid); err != nil || delivery == nil {
+		return nil, err
+	}
+	result := toWebhookDelivery(*delivery)
+	if !redelivered {
+		return &result, ErrNotDeadLetter
+	}
+	return &result, nil
+}
+
+func toWebhookSubscription(subscription event.WebhookSubscription) models.WebhookSubscription {
+	result := models.WebhookSubscription{
+		GUID:       subscription.GUID,
+		URL:        subscription.URL,
+		EventTypes: subscription.EventTypes,
+		Active:     subscription.Active,
+		CreatedAt:  subscription.CreatedAt,
+		UpdatedAt:  subscription.UpdatedAt,
+	}
+	if subscription.TokenAddress != nil {
+		result.TokenAddress = subscription.TokenAddress.String()
+	}
+	if subscription.Address != nil {
+		result.Address = subscription.Address.String()
+	}
+	return result
+}
+
+func toWebhookDelivery(delivery event.WebhookDelivery) models.WebhookDelivery {
+	return models.WebhookDelivery{
+		GUID:             delivery.GUID,
+		SubscriptionGUID: delivery.SubscriptionGUID,
+		OutboxID:         delivery.OutboxID,
+		EventType:        delivery.EventType,
+		Action:           delivery.Action,
+		Payload:          delivery.Payload,
+		Status:           delivery.Status,
+		Attempts:         delivery.Attempts,
+		LastError:        delivery.LastError,
+		NextAttemptAt:    delivery.NextAttemptAt,
+		CreatedAt:        delivery.CreatedAt,
+		UpdatedAt:        delivery.UpdatedAt,
+	}
+}
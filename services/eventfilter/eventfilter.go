@@ -0,0 +1,110 @@
+// Package eventfilter REST 与 gRPC 接口共用的事件过滤条件解析，保证两种接口对同一条件的校验规则一致
+package eventfilter
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/Sandwichzzy/event-sync-go/database/worker"
+)
+
+// Field 一个过滤参数，Name 为参数在所属接口中的名称，只用于错误信息；Value 为空表示不过滤
+type Field struct {
+	Name  string
+	Value string
+}
+
+// Request 列表请求中的过滤条件。区块号和时间戳由各接口按自己的参数格式解析后传入，nil 或 0 表示不限制
+type Request struct {
+	Sender   Field
+	Receiver Field
+	Address  Field
+	Token    Field
+	TxHash   Field
+
+	FromBlock *big.Int
+	ToBlock   *big.Int
+	FromTime  uint64
+	ToTime    uint64
+}
+
+// Parse 校验过滤条件并转换为 worker 表的查询条件
+func Parse(req Request) (worker.EventFilter, error) {
+	var filter worker.EventFilter
+	addresses := []struct {
+		field Field
+		dest  **common.Address
+	}{
+		{req.Sender, &filter.Sender},
+		{req.Receiver, &filter.Receiver},
+		{req.Address, &filter.Address},
+		{req.Token, &filter.TokenAddress},
+	}
+	for _, a := range addresses {
+		if a.field.Value == "" {
+			continue
+		}
+		address, err := ParseAddress(a.field.Value)
+		if err != nil {
+			return filter, fmt.Errorf("invalid %s: %w", a.field.Name, err)
+		}
+		*a.dest = &address
+	}
+	if req.TxHash.Value != "" {
+		hash, err := ParseHash(req.TxHash.Value)
+		if err != nil {
+			return filter, fmt.Errorf("invalid %s: %w", req.TxHash.Name, err)
+		}
+		filter.TransactionHash = &hash
+	}
+	if err := CheckBlockRange(req.FromBlock, req.ToBlock); err != nil {
+		return filter, err
+	}
+	if err := CheckTimeRange(req.FromTime, req.ToTime); err != nil {
+		return filter, err
+	}
+	filter.FromBlock, filter.ToBlock = req.FromBlock, req.ToBlock
+	filter.FromTimestamp, filter.ToTimestamp = req.FromTime, req.ToTime
+	return filter, nil
+}
+
+// ParseAddress 解析十六进制地址，拒绝零地址
+func ParseAddress(value string) (common.Address, error) {
+	if !common.IsHexAddress(value) {
+		return common.Address{}, errors.New("address must be represented as a valid hexadecimal string")
+	}
+	address := common.HexToAddress(value)
+	if address == (common.Address{}) {
+		return common.Address{}, errors.New("address cannot be the zero address")
+	}
+	return address, nil
+}
+
+// ParseHash 解析 0x 开头的 32 字节十六进制哈希（交易哈希、事件签名）
+func ParseHash(value string) (common.Hash, error) {
+	b, err := hexutil.Decode(value)
+	if err != nil || len(b) != common.HashLength {
+		return common.Hash{}, errors.New("hash must be a 0x-prefixed 32 byte hexadecimal string")
+	}
+	return common.BytesToHash(b), nil
+}
+
+// CheckBlockRange 两端都指定时要求起始区块不大于结束区块
+func CheckBlockRange(from, to *big.Int) error {
+	if from != nil && to != nil && from.Cmp(to) > 0 {
+		return fmt.Errorf("from block %s is greater than to block %s", from, to)
+	}
+	return nil
+}
+
+// CheckTimeRange 指定结束时间时要求起始时间不大于结束时间
+func CheckTimeRange(from, to uint64) error {
+	if to > 0 && from > to {
+		return fmt.Errorf("from time %d is greater than to time %d", from, to)
+	}
+	return nil
+}
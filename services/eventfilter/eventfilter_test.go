@@ -0,0 +1,39 @@
+package eventfilter
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	hash := "0x" + common.Bytes2Hex(common.HexToHash("0xabc").Bytes())
+	filter, err := Parse(Request{
+		Token:     Field{"token", "0x1111111111111111111111111111111111111111"},
+		TxHash:    Field{"txHash", hash},
+		FromBlock: big.NewInt(10),
+		ToBlock:   big.NewInt(20),
+		ToTime:    100,
+	})
+	require.NoError(t, err)
+	require.Equal(t, common.HexToAddress("0x1111111111111111111111111111111111111111"), *filter.TokenAddress)
+	require.Equal(t, common.HexToHash("0xabc"), *filter.TransactionHash)
+	require.Nil(t, filter.Sender)
+	require.Equal(t, big.NewInt(10), filter.FromBlock)
+	require.Equal(t, uint64(100), filter.ToTimestamp)
+
+	invalid := []Request{
+		{Sender: Field{"sender", "0x1234"}},
+		{Receiver: Field{"receiver", "0x0000000000000000000000000000000000000000"}},
+		{TxHash: Field{"txHash", "0x1234"}},
+		{TxHash: Field{"txHash", "not-a-hash-but-exactly-32-bytes!"}},
+		{FromBlock: big.NewInt(20), ToBlock: big.NewInt(10)},
+		{FromTime: 20, ToTime: 10},
+	}
+	for _, req := range invalid {
+		_, err := Parse(req)
+		require.Error(t, err, "%+v", req)
+	}
+}
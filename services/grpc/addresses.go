@@ -0,0 +1,98 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Sandwichzzy/event-sync-go/services/grpc/eventpb"
+)
+
+// GetAddressActivity 返回地址每个代币的汇总和合并后的充值、提现、奖励发放、提现管理员变更时间线，
+// 参与方由 address 决定，filter 中的 sender / receiver / address 返回 InvalidArgument
+func (rs *RpcService) GetAddressActivity(ctx context.Context, request *eventpb.AddressActivityReq) (*eventpb.AddressActivityRep, error) {
+	if !common.IsHexAddress(request.Address) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid address %q", request.Address)
+	}
+	address := common.HexToAddress(request.Address)
+	filter, page, err := listQuery(request)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries, err := rs.db.AddressActivity.QueryAddressTokenSummaries(address)
+	if err != nil {
+		return nil, queryError(err, "query address token summaries fail")
+	}
+	activity, pageInfo, err := rs.db.AddressActivity.QueryAddressActivity(address, filter, page)
+	if err != nil {
+		return nil, queryError(err, "query address activity fail")
+	}
+
+	tokenAddresses := make([]common.Address, 0, len(summaries)+len(activity))
+	for _, s := range summaries {
+		tokenAddresses = append(tokenAddresses, s.TokenAddress)
+	}
+	for _, a := range activity {
+		if a.TokenAddress != nil {
+			tokenAddresses = append(tokenAddresses, *a.TokenAddress)
+		}
+	}
+	tokens := rs.tokenMetadata(tokenAddresses)
+
+	summaryList := make([]*eventpb.AddressTokenSummary, 0, len(summaries))
+	for _, s := range summaries {
+		token, known := tokens[s.TokenAddress]
+		summaryList = append(summaryList, &eventpb.AddressTokenSummary{
+			TokenAddress:           s.TokenAddress.String(),
+			DepositCount:           s.DepositCount,
+			Deposited:              s.Deposited.String(),
+			DepositedFormatted:     formatTokenAmount(s.Deposited, token, known),
+			WithdrawCount:          s.WithdrawCount,
+			Withdrawn:              s.Withdrawn.String(),
+			WithdrawnFormatted:     formatTokenAmount(s.Withdrawn, token, known),
+			GranterCount:           s.GranterCount,
+			GranterAmount:          s.GranterAmount.String(),
+			GranterAmountFormatted: formatTokenAmount(s.GranterAmount, token, known),
+			Symbol:                 token.Symbol,
+			Decimals:               uint32(token.Decimals),
+		})
+	}
+	activityList := make([]*eventpb.AddressActivity, 0, len(activity))
+	for _, a := range activity {
+		item := &eventpb.AddressActivity{
+			EventType:       a.EventType,
+			Guid:            a.GUID.String(),
+			BlockNumber:     a.BlockNumber.Uint64(),
+			BlockHash:       a.BlockHash.String(),
+			TransactionHash: a.TransactionHash.String(),
+			LogIndex:        a.LogIndex,
+			Sender:          a.Sender.String(),
+			Timestamp:       a.Timestamp,
+		}
+		if a.TokenAddress != nil {
+			token, known := tokens[*a.TokenAddress]
+			item.TokenAddress = a.TokenAddress.String()
+			item.AmountRaw = a.Amount.String()
+			item.AmountFormatted = formatTokenAmount(a.Amount, token, known)
+			item.Symbol = token.Symbol
+			item.Decimals = uint32(token.Decimals)
+		}
+		if a.Receiver != nil {
+			item.Receiver = a.Receiver.String()
+		}
+		activityList = append(activityList, item)
+	}
+	return &eventpb.AddressActivityRep{
+		Code:       eventpb.ReturnCode_SUCCESS,
+		Message:    "get data success",
+		Summary:    summaryList,
+		Activity:   activityList,
+		Total:      pageInfo.Total,
+		NextCursor: encodeCursor(pageInfo.Next),
+		PrevCursor: encodeCursor(pageInfo.Prev),
+		Count:      pageInfo.Count.String(),
+	}, nil
+}
@@ -67,21 +67,187 @@ func (ReturnCode) EnumDescriptor() ([]byte, []int) {
 	return file_services_grpc_protobuf_event_sync_proto_rawDescGZIP(), []int{0}
 }
 
+type StreamMessageType int32
+
+const (
+	StreamMessageType_STREAM_EVENT_ADDED   StreamMessageType = 0
+	StreamMessageType_STREAM_EVENT_REMOVED StreamMessageType = 1 // 事件所在区块被回滚或区间被重建，客户端应撤销之前收到的同一位置的事件
+	StreamMessageType_STREAM_HEARTBEAT     StreamMessageType = 2
+)
+
+// Enum value maps for StreamMessageType.
+var (
+	StreamMessageType_name = map[int32]string{
+		0: "STREAM_EVENT_ADDED",
+		1: "STREAM_EVENT_REMOVED",
+		2: "STREAM_HEARTBEAT",
+	}
+	StreamMessageType_value = map[string]int32{
+		"STREAM_EVENT_ADDED":   0,
+		"STREAM_EVENT_REMOVED": 1,
+		"STREAM_HEARTBEAT":     2,
+	}
+)
+
+func (x StreamMessageType) Enum() *StreamMessageType {
+	p := new(StreamMessageType)
+	*p = x
+	return p
+}
+
+func (x StreamMessageType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StreamMessageType) Descriptor() protoreflect.EnumDescriptor {
+	return file_services_grpc_protobuf_event_sync_proto_enumTypes[1].Descriptor()
+}
+
+func (StreamMessageType) Type() protoreflect.EnumType {
+	return &file_services_grpc_protobuf_event_sync_proto_enumTypes[1]
+}
+
+func (x StreamMessageType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StreamMessageType.Descriptor instead.
+func (StreamMessageType) EnumDescriptor() ([]byte, []int) {
+	return file_services_grpc_protobuf_event_sync_proto_rawDescGZIP(), []int{1}
+}
+
+// EventFilter 事件列表的过滤条件，字段为空时不过滤，区块和时间范围均包含边界
+type EventFilter struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TokenAddress    string                 `protobuf:"bytes,1,opt,name=token_address,json=tokenAddress,proto3" json:"token_address,omitempty"`
+	Address         string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`   // 任一参与方（sender / receiver / granter / withdraw_manager）
+	Sender          string                 `protobuf:"bytes,3,opt,name=sender,proto3" json:"sender,omitempty"`     // 发起方（sender / granter / withdraw_manager）
+	Receiver        string                 `protobuf:"bytes,4,opt,name=receiver,proto3" json:"receiver,omitempty"` // 只有提现记录有接收方
+	TransactionHash string                 `protobuf:"bytes,5,opt,name=transaction_hash,json=transactionHash,proto3" json:"transaction_hash,omitempty"`
+	FromBlock       uint64                 `protobuf:"varint,6,opt,name=from_block,json=fromBlock,proto3" json:"from_block,omitempty"`
+	ToBlock         uint64                 `protobuf:"varint,7,opt,name=to_block,json=toBlock,proto3" json:"to_block,omitempty"` // 为 0 时不限制
+	FromTimestamp   uint64                 `protobuf:"varint,8,opt,name=from_timestamp,json=fromTimestamp,proto3" json:"from_timestamp,omitempty"`
+	ToTimestamp     uint64                 `protobuf:"varint,9,opt,name=to_timestamp,json=toTimestamp,proto3" json:"to_timestamp,omitempty"` // 为 0 时不限制
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *EventFilter) Reset() {
+	*x = EventFilter{}
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventFilter) ProtoMessage() {}
+
+func (x *EventFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventFilter.ProtoReflect.Descriptor instead.
+func (*EventFilter) Descriptor() ([]byte, []int) {
+	return file_services_grpc_protobuf_event_sync_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *EventFilter) GetTokenAddress() string {
+	if x != nil {
+		return x.TokenAddress
+	}
+	return ""
+}
+
+func (x *EventFilter) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *EventFilter) GetSender() string {
+	if x != nil {
+		return x.Sender
+	}
+	return ""
+}
+
+func (x *EventFilter) GetReceiver() string {
+	if x != nil {
+		return x.Receiver
+	}
+	return ""
+}
+
+func (x *EventFilter) GetTransactionHash() string {
+	if x != nil {
+		return x.TransactionHash
+	}
+	return ""
+}
+
+func (x *EventFilter) GetFromBlock() uint64 {
+	if x != nil {
+		return x.FromBlock
+	}
+	return 0
+}
+
+func (x *EventFilter) GetToBlock() uint64 {
+	if x != nil {
+		return x.ToBlock
+	}
+	return 0
+}
+
+func (x *EventFilter) GetFromTimestamp() uint64 {
+	if x != nil {
+		return x.FromTimestamp
+	}
+	return 0
+}
+
+func (x *EventFilter) GetToTimestamp() uint64 {
+	if x != nil {
+		return x.ToTimestamp
+	}
+	return 0
+}
+
 type DepositToken struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Guid          string                 `protobuf:"bytes,1,opt,name=guid,proto3" json:"guid,omitempty"`
-	BlockNumber   uint64                 `protobuf:"varint,2,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
-	TokenAddress  string                 `protobuf:"bytes,3,opt,name=token_address,json=tokenAddress,proto3" json:"token_address,omitempty"`
-	Sender        string                 `protobuf:"bytes,4,opt,name=sender,proto3" json:"sender,omitempty"`
-	Amount        uint64                 `protobuf:"varint,5,opt,name=amount,proto3" json:"amount,omitempty"`
-	Timestamp     uint64                 `protobuf:"varint,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Guid         string                 `protobuf:"bytes,1,opt,name=guid,proto3" json:"guid,omitempty"`
+	BlockNumber  uint64                 `protobuf:"varint,2,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	TokenAddress string                 `protobuf:"bytes,3,opt,name=token_address,json=tokenAddress,proto3" json:"token_address,omitempty"`
+	Sender       string                 `protobuf:"bytes,4,opt,name=sender,proto3" json:"sender,omitempty"`
+	// Deprecated: Marked as deprecated in services/grpc/protobuf/event_sync.proto.
+	Amount          uint64 `protobuf:"varint,5,opt,name=amount,proto3" json:"amount,omitempty"` // 已废弃：超过 uint64 时为 0，请使用 amount_raw
+	Timestamp       uint64 `protobuf:"varint,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	AmountRaw       string `protobuf:"bytes,7,opt,name=amount_raw,json=amountRaw,proto3" json:"amount_raw,omitempty"`                   // 原始金额（最小单位）的十进制字符串
+	AmountFormatted string `protobuf:"bytes,8,opt,name=amount_formatted,json=amountFormatted,proto3" json:"amount_formatted,omitempty"` // 按代币精度格式化后的金额，代币元数据未知时为空
+	Symbol          string `protobuf:"bytes,9,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Decimals        uint32 `protobuf:"varint,10,opt,name=decimals,proto3" json:"decimals,omitempty"`
+	BlockHash       string `protobuf:"bytes,11,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	TransactionHash string `protobuf:"bytes,12,opt,name=transaction_hash,json=transactionHash,proto3" json:"transaction_hash,omitempty"`
+	LogIndex        uint64 `protobuf:"varint,13,opt,name=log_index,json=logIndex,proto3" json:"log_index,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *DepositToken) Reset() {
 	*x = DepositToken{}
-	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[0]
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -93,7 +259,7 @@ func (x *DepositToken) String() string {
 func (*DepositToken) ProtoMessage() {}
 
 func (x *DepositToken) ProtoReflect() protoreflect.Message {
-	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[0]
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -106,7 +272,7 @@ func (x *DepositToken) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DepositToken.ProtoReflect.Descriptor instead.
 func (*DepositToken) Descriptor() ([]byte, []int) {
-	return file_services_grpc_protobuf_event_sync_proto_rawDescGZIP(), []int{0}
+	return file_services_grpc_protobuf_event_sync_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *DepositToken) GetGuid() string {
@@ -137,6 +303,7 @@ func (x *DepositToken) GetSender() string {
 	return ""
 }
 
+// Deprecated: Marked as deprecated in services/grpc/protobuf/event_sync.proto.
 func (x *DepositToken) GetAmount() uint64 {
 	if x != nil {
 		return x.Amount
@@ -151,18 +318,71 @@ func (x *DepositToken) GetTimestamp() uint64 {
 	return 0
 }
 
+func (x *DepositToken) GetAmountRaw() string {
+	if x != nil {
+		return x.AmountRaw
+	}
+	return ""
+}
+
+func (x *DepositToken) GetAmountFormatted() string {
+	if x != nil {
+		return x.AmountFormatted
+	}
+	return ""
+}
+
+func (x *DepositToken) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *DepositToken) GetDecimals() uint32 {
+	if x != nil {
+		return x.Decimals
+	}
+	return 0
+}
+
+func (x *DepositToken) GetBlockHash() string {
+	if x != nil {
+		return x.BlockHash
+	}
+	return ""
+}
+
+func (x *DepositToken) GetTransactionHash() string {
+	if x != nil {
+		return x.TransactionHash
+	}
+	return ""
+}
+
+func (x *DepositToken) GetLogIndex() uint64 {
+	if x != nil {
+		return x.LogIndex
+	}
+	return 0
+}
+
 type DepositTokenListReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ConsumerToken string                 `protobuf:"bytes,1,opt,name=consumer_token,json=consumerToken,proto3" json:"consumer_token,omitempty"`
 	Page          uint64                 `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
 	PageSize      uint64                 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Order         string                 `protobuf:"bytes,4,opt,name=order,proto3" json:"order,omitempty"` // asc / desc，按 (block_number, log_index) 排序，默认 desc
+	Filter        *EventFilter           `protobuf:"bytes,5,opt,name=filter,proto3" json:"filter,omitempty"`
+	Cursor        string                 `protobuf:"bytes,6,opt,name=cursor,proto3" json:"cursor,omitempty"` // 上一页响应中的 next_cursor / prev_cursor，按游标（keyset）分页，忽略 page 和 order
+	Count         string                 `protobuf:"bytes,7,opt,name=count,proto3" json:"count,omitempty"`   // none / exact / approximate，按页码分页时默认 exact，按游标分页时默认 none
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *DepositTokenListReq) Reset() {
 	*x = DepositTokenListReq{}
-	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[1]
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -174,7 +394,7 @@ func (x *DepositTokenListReq) String() string {
 func (*DepositTokenListReq) ProtoMessage() {}
 
 func (x *DepositTokenListReq) ProtoReflect() protoreflect.Message {
-	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[1]
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -187,7 +407,7 @@ func (x *DepositTokenListReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DepositTokenListReq.ProtoReflect.Descriptor instead.
 func (*DepositTokenListReq) Descriptor() ([]byte, []int) {
-	return file_services_grpc_protobuf_event_sync_proto_rawDescGZIP(), []int{1}
+	return file_services_grpc_protobuf_event_sync_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *DepositTokenListReq) GetConsumerToken() string {
@@ -211,18 +431,50 @@ func (x *DepositTokenListReq) GetPageSize() uint64 {
 	return 0
 }
 
+func (x *DepositTokenListReq) GetOrder() string {
+	if x != nil {
+		return x.Order
+	}
+	return ""
+}
+
+func (x *DepositTokenListReq) GetFilter() *EventFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *DepositTokenListReq) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *DepositTokenListReq) GetCount() string {
+	if x != nil {
+		return x.Count
+	}
+	return ""
+}
+
 type DepositTokenListRep struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Code          ReturnCode             `protobuf:"varint,1,opt,name=code,proto3,enum=theweb3.event.ReturnCode" json:"code,omitempty"`
 	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
 	DepositToken  []*DepositToken        `protobuf:"bytes,3,rep,name=deposit_token,json=depositToken,proto3" json:"deposit_token,omitempty"`
+	Total         uint64                 `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`                            // 满足过滤条件的总数
+	NextCursor    string                 `protobuf:"bytes,5,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // 下一页游标，没有更多记录时为空
+	PrevCursor    string                 `protobuf:"bytes,6,opt,name=prev_cursor,json=prevCursor,proto3" json:"prev_cursor,omitempty"` // 上一页游标，没有更早记录时为空
+	Count         string                 `protobuf:"bytes,7,opt,name=count,proto3" json:"count,omitempty"`                             // total 的统计方式：exact / approximate / none
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *DepositTokenListRep) Reset() {
 	*x = DepositTokenListRep{}
-	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[2]
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -234,7 +486,7 @@ func (x *DepositTokenListRep) String() string {
 func (*DepositTokenListRep) ProtoMessage() {}
 
 func (x *DepositTokenListRep) ProtoReflect() protoreflect.Message {
-	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[2]
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -247,7 +499,7 @@ func (x *DepositTokenListRep) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DepositTokenListRep.ProtoReflect.Descriptor instead.
 func (*DepositTokenListRep) Descriptor() ([]byte, []int) {
-	return file_services_grpc_protobuf_event_sync_proto_rawDescGZIP(), []int{2}
+	return file_services_grpc_protobuf_event_sync_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *DepositTokenListRep) GetCode() ReturnCode {
@@ -271,6 +523,34 @@ func (x *DepositTokenListRep) GetDepositToken() []*DepositToken {
 	return nil
 }
 
+func (x *DepositTokenListRep) GetTotal() uint64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *DepositTokenListRep) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *DepositTokenListRep) GetPrevCursor() string {
+	if x != nil {
+		return x.PrevCursor
+	}
+	return ""
+}
+
+func (x *DepositTokenListRep) GetCount() string {
+	if x != nil {
+		return x.Count
+	}
+	return ""
+}
+
 type DepositTokenDetailReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ConsumerToken string                 `protobuf:"bytes,1,opt,name=consumer_token,json=consumerToken,proto3" json:"consumer_token,omitempty"` //类似JWT
@@ -281,7 +561,7 @@ type DepositTokenDetailReq struct {
 
 func (x *DepositTokenDetailReq) Reset() {
 	*x = DepositTokenDetailReq{}
-	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[3]
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -293,7 +573,7 @@ func (x *DepositTokenDetailReq) String() string {
 func (*DepositTokenDetailReq) ProtoMessage() {}
 
 func (x *DepositTokenDetailReq) ProtoReflect() protoreflect.Message {
-	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[3]
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -306,7 +586,7 @@ func (x *DepositTokenDetailReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DepositTokenDetailReq.ProtoReflect.Descriptor instead.
 func (*DepositTokenDetailReq) Descriptor() ([]byte, []int) {
-	return file_services_grpc_protobuf_event_sync_proto_rawDescGZIP(), []int{3}
+	return file_services_grpc_protobuf_event_sync_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *DepositTokenDetailReq) GetConsumerToken() string {
@@ -324,22 +604,30 @@ func (x *DepositTokenDetailReq) GetGuid() string {
 }
 
 type DepositTokenDetailRep struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Code          ReturnCode             `protobuf:"varint,1,opt,name=code,proto3,enum=theweb3.event.ReturnCode" json:"code,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Guid          string                 `protobuf:"bytes,3,opt,name=guid,proto3" json:"guid,omitempty"`
-	BlockNumber   uint64                 `protobuf:"varint,4,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
-	TokenAddress  string                 `protobuf:"bytes,5,opt,name=token_address,json=tokenAddress,proto3" json:"token_address,omitempty"`
-	Sender        string                 `protobuf:"bytes,6,opt,name=sender,proto3" json:"sender,omitempty"`
-	Amount        uint64                 `protobuf:"varint,7,opt,name=amount,proto3" json:"amount,omitempty"`
-	Timestamp     uint64                 `protobuf:"varint,8,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Code         ReturnCode             `protobuf:"varint,1,opt,name=code,proto3,enum=theweb3.event.ReturnCode" json:"code,omitempty"`
+	Message      string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Guid         string                 `protobuf:"bytes,3,opt,name=guid,proto3" json:"guid,omitempty"`
+	BlockNumber  uint64                 `protobuf:"varint,4,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	TokenAddress string                 `protobuf:"bytes,5,opt,name=token_address,json=tokenAddress,proto3" json:"token_address,omitempty"`
+	Sender       string                 `protobuf:"bytes,6,opt,name=sender,proto3" json:"sender,omitempty"`
+	// Deprecated: Marked as deprecated in services/grpc/protobuf/event_sync.proto.
+	Amount          uint64 `protobuf:"varint,7,opt,name=amount,proto3" json:"amount,omitempty"` // 已废弃：超过 uint64 时为 0，请使用 amount_raw
+	Timestamp       uint64 `protobuf:"varint,8,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	AmountRaw       string `protobuf:"bytes,9,opt,name=amount_raw,json=amountRaw,proto3" json:"amount_raw,omitempty"`
+	AmountFormatted string `protobuf:"bytes,10,opt,name=amount_formatted,json=amountFormatted,proto3" json:"amount_formatted,omitempty"`
+	Symbol          string `protobuf:"bytes,11,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Decimals        uint32 `protobuf:"varint,12,opt,name=decimals,proto3" json:"decimals,omitempty"`
+	BlockHash       string `protobuf:"bytes,13,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	TransactionHash string `protobuf:"bytes,14,opt,name=transaction_hash,json=transactionHash,proto3" json:"transaction_hash,omitempty"`
+	LogIndex        uint64 `protobuf:"varint,15,opt,name=log_index,json=logIndex,proto3" json:"log_index,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *DepositTokenDetailRep) Reset() {
 	*x = DepositTokenDetailRep{}
-	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[4]
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -351,7 +639,7 @@ func (x *DepositTokenDetailRep) String() string {
 func (*DepositTokenDetailRep) ProtoMessage() {}
 
 func (x *DepositTokenDetailRep) ProtoReflect() protoreflect.Message {
-	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[4]
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -364,7 +652,7 @@ func (x *DepositTokenDetailRep) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DepositTokenDetailRep.ProtoReflect.Descriptor instead.
 func (*DepositTokenDetailRep) Descriptor() ([]byte, []int) {
-	return file_services_grpc_protobuf_event_sync_proto_rawDescGZIP(), []int{4}
+	return file_services_grpc_protobuf_event_sync_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *DepositTokenDetailRep) GetCode() ReturnCode {
@@ -409,6 +697,7 @@ func (x *DepositTokenDetailRep) GetSender() string {
 	return ""
 }
 
+// Deprecated: Marked as deprecated in services/grpc/protobuf/event_sync.proto.
 func (x *DepositTokenDetailRep) GetAmount() uint64 {
 	if x != nil {
 		return x.Amount
@@ -423,45 +712,2822 @@ func (x *DepositTokenDetailRep) GetTimestamp() uint64 {
 	return 0
 }
 
-var File_services_grpc_protobuf_event_sync_proto protoreflect.FileDescriptor
+func (x *DepositTokenDetailRep) GetAmountRaw() string {
+	if x != nil {
+		return x.AmountRaw
+	}
+	return ""
+}
 
-const file_services_grpc_protobuf_event_sync_proto_rawDesc = "" +
-	"\n" +
-	"'services/grpc/protobuf/event_sync.proto\x12\rtheweb3.event\"\xb8\x01\n" +
-	"\fDepositToken\x12\x12\n" +
-	"\x04guid\x18\x01 \x01(\tR\x04guid\x12!\n" +
-	"\fblock_number\x18\x02 \x01(\x04R\vblockNumber\x12#\n" +
-	"\rtoken_address\x18\x03 \x01(\tR\ftokenAddress\x12\x16\n" +
-	"\x06sender\x18\x04 \x01(\tR\x06sender\x12\x16\n" +
-	"\x06amount\x18\x05 \x01(\x04R\x06amount\x12\x1c\n" +
-	"\ttimestamp\x18\x06 \x01(\x04R\ttimestamp\"m\n" +
-	"\x13DepositTokenListReq\x12%\n" +
-	"\x0econsumer_token\x18\x01 \x01(\tR\rconsumerToken\x12\x12\n" +
-	"\x04page\x18\x02 \x01(\x04R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x03 \x01(\x04R\bpageSize\"\xa0\x01\n" +
-	"\x13DepositTokenListRep\x12-\n" +
-	"\x04code\x18\x01 \x01(\x0e2\x19.theweb3.event.ReturnCodeR\x04code\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\x12@\n" +
-	"\rdeposit_token\x18\x03 \x03(\v2\x1b.theweb3.event.DepositTokenR\fdepositToken\"R\n" +
-	"\x15DepositTokenDetailReq\x12%\n" +
-	"\x0econsumer_token\x18\x01 \x01(\tR\rconsumerToken\x12\x12\n" +
-	"\x04guid\x18\x02 \x01(\tR\x04guid\"\x8a\x02\n" +
-	"\x15DepositTokenDetailRep\x12-\n" +
-	"\x04code\x18\x01 \x01(\x0e2\x19.theweb3.event.ReturnCodeR\x04code\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\x12\x12\n" +
-	"\x04guid\x18\x03 \x01(\tR\x04guid\x12!\n" +
-	"\fblock_number\x18\x04 \x01(\x04R\vblockNumber\x12#\n" +
-	"\rtoken_address\x18\x05 \x01(\tR\ftokenAddress\x12\x16\n" +
-	"\x06sender\x18\x06 \x01(\tR\x06sender\x12\x16\n" +
-	"\x06amount\x18\a \x01(\x04R\x06amount\x12\x1c\n" +
-	"\ttimestamp\x18\b \x01(\x04R\ttimestamp*$\n" +
+func (x *DepositTokenDetailRep) GetAmountFormatted() string {
+	if x != nil {
+		return x.AmountFormatted
+	}
+	return ""
+}
+
+func (x *DepositTokenDetailRep) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *DepositTokenDetailRep) GetDecimals() uint32 {
+	if x != nil {
+		return x.Decimals
+	}
+	return 0
+}
+
+func (x *DepositTokenDetailRep) GetBlockHash() string {
+	if x != nil {
+		return x.BlockHash
+	}
+	return ""
+}
+
+func (x *DepositTokenDetailRep) GetTransactionHash() string {
+	if x != nil {
+		return x.TransactionHash
+	}
+	return ""
+}
+
+func (x *DepositTokenDetailRep) GetLogIndex() uint64 {
+	if x != nil {
+		return x.LogIndex
+	}
+	return 0
+}
+
+type WithdrawToken struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Guid            string                 `protobuf:"bytes,1,opt,name=guid,proto3" json:"guid,omitempty"`
+	BlockNumber     uint64                 `protobuf:"varint,2,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	BlockHash       string                 `protobuf:"bytes,3,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	TransactionHash string                 `protobuf:"bytes,4,opt,name=transaction_hash,json=transactionHash,proto3" json:"transaction_hash,omitempty"`
+	LogIndex        uint64                 `protobuf:"varint,5,opt,name=log_index,json=logIndex,proto3" json:"log_index,omitempty"`
+	TokenAddress    string                 `protobuf:"bytes,6,opt,name=token_address,json=tokenAddress,proto3" json:"token_address,omitempty"`
+	Sender          string                 `protobuf:"bytes,7,opt,name=sender,proto3" json:"sender,omitempty"`
+	Receiver        string                 `protobuf:"bytes,8,opt,name=receiver,proto3" json:"receiver,omitempty"`
+	AmountRaw       string                 `protobuf:"bytes,9,opt,name=amount_raw,json=amountRaw,proto3" json:"amount_raw,omitempty"`                    // 原始金额（最小单位）的十进制字符串
+	AmountFormatted string                 `protobuf:"bytes,10,opt,name=amount_formatted,json=amountFormatted,proto3" json:"amount_formatted,omitempty"` // 按代币精度格式化后的金额，代币元数据未知时为空
+	Symbol          string                 `protobuf:"bytes,11,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Decimals        uint32                 `protobuf:"varint,12,opt,name=decimals,proto3" json:"decimals,omitempty"`
+	Timestamp       uint64                 `protobuf:"varint,13,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *WithdrawToken) Reset() {
+	*x = WithdrawToken{}
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WithdrawToken) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithdrawToken) ProtoMessage() {}
+
+func (x *WithdrawToken) ProtoReflect() protoreflect.Message {
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithdrawToken.ProtoReflect.Descriptor instead.
+func (*WithdrawToken) Descriptor() ([]byte, []int) {
+	return file_services_grpc_protobuf_event_sync_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *WithdrawToken) GetGuid() string {
+	if x != nil {
+		return x.Guid
+	}
+	return ""
+}
+
+func (x *WithdrawToken) GetBlockNumber() uint64 {
+	if x != nil {
+		return x.BlockNumber
+	}
+	return 0
+}
+
+func (x *WithdrawToken) GetBlockHash() string {
+	if x != nil {
+		return x.BlockHash
+	}
+	return ""
+}
+
+func (x *WithdrawToken) GetTransactionHash() string {
+	if x != nil {
+		return x.TransactionHash
+	}
+	return ""
+}
+
+func (x *WithdrawToken) GetLogIndex() uint64 {
+	if x != nil {
+		return x.LogIndex
+	}
+	return 0
+}
+
+func (x *WithdrawToken) GetTokenAddress() string {
+	if x != nil {
+		return x.TokenAddress
+	}
+	return ""
+}
+
+func (x *WithdrawToken) GetSender() string {
+	if x != nil {
+		return x.Sender
+	}
+	return ""
+}
+
+func (x *WithdrawToken) GetReceiver() string {
+	if x != nil {
+		return x.Receiver
+	}
+	return ""
+}
+
+func (x *WithdrawToken) GetAmountRaw() string {
+	if x != nil {
+		return x.AmountRaw
+	}
+	return ""
+}
+
+func (x *WithdrawToken) GetAmountFormatted() string {
+	if x != nil {
+		return x.AmountFormatted
+	}
+	return ""
+}
+
+func (x *WithdrawToken) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *WithdrawToken) GetDecimals() uint32 {
+	if x != nil {
+		return x.Decimals
+	}
+	return 0
+}
+
+func (x *WithdrawToken) GetTimestamp() uint64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+type WithdrawTokenListReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ConsumerToken string                 `protobuf:"bytes,1,opt,name=consumer_token,json=consumerToken,proto3" json:"consumer_token,omitempty"`
+	Page          uint64                 `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      uint64                 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Order         string                 `protobuf:"bytes,4,opt,name=order,proto3" json:"order,omitempty"`
+	Filter        *EventFilter           `protobuf:"bytes,5,opt,name=filter,proto3" json:"filter,omitempty"`
+	Cursor        string                 `protobuf:"bytes,6,opt,name=cursor,proto3" json:"cursor,omitempty"` // 上一页响应中的 next_cursor / prev_cursor，按游标（keyset）分页，忽略 page 和 order
+	Count         string                 `protobuf:"bytes,7,opt,name=count,proto3" json:"count,omitempty"`   // none / exact / approximate，按页码分页时默认 exact，按游标分页时默认 none
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WithdrawTokenListReq) Reset() {
+	*x = WithdrawTokenListReq{}
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WithdrawTokenListReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithdrawTokenListReq) ProtoMessage() {}
+
+func (x *WithdrawTokenListReq) ProtoReflect() protoreflect.Message {
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithdrawTokenListReq.ProtoReflect.Descriptor instead.
+func (*WithdrawTokenListReq) Descriptor() ([]byte, []int) {
+	return file_services_grpc_protobuf_event_sync_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *WithdrawTokenListReq) GetConsumerToken() string {
+	if x != nil {
+		return x.ConsumerToken
+	}
+	return ""
+}
+
+func (x *WithdrawTokenListReq) GetPage() uint64 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *WithdrawTokenListReq) GetPageSize() uint64 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *WithdrawTokenListReq) GetOrder() string {
+	if x != nil {
+		return x.Order
+	}
+	return ""
+}
+
+func (x *WithdrawTokenListReq) GetFilter() *EventFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *WithdrawTokenListReq) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *WithdrawTokenListReq) GetCount() string {
+	if x != nil {
+		return x.Count
+	}
+	return ""
+}
+
+type WithdrawTokenListRep struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          ReturnCode             `protobuf:"varint,1,opt,name=code,proto3,enum=theweb3.event.ReturnCode" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	WithdrawToken []*WithdrawToken       `protobuf:"bytes,3,rep,name=withdraw_token,json=withdrawToken,proto3" json:"withdraw_token,omitempty"`
+	Total         uint64                 `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,5,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // 下一页游标，没有更多记录时为空
+	PrevCursor    string                 `protobuf:"bytes,6,opt,name=prev_cursor,json=prevCursor,proto3" json:"prev_cursor,omitempty"` // 上一页游标，没有更早记录时为空
+	Count         string                 `protobuf:"bytes,7,opt,name=count,proto3" json:"count,omitempty"`                             // total 的统计方式：exact / approximate / none
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WithdrawTokenListRep) Reset() {
+	*x = WithdrawTokenListRep{}
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WithdrawTokenListRep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithdrawTokenListRep) ProtoMessage() {}
+
+func (x *WithdrawTokenListRep) ProtoReflect() protoreflect.Message {
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithdrawTokenListRep.ProtoReflect.Descriptor instead.
+func (*WithdrawTokenListRep) Descriptor() ([]byte, []int) {
+	return file_services_grpc_protobuf_event_sync_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *WithdrawTokenListRep) GetCode() ReturnCode {
+	if x != nil {
+		return x.Code
+	}
+	return ReturnCode_ERROR
+}
+
+func (x *WithdrawTokenListRep) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *WithdrawTokenListRep) GetWithdrawToken() []*WithdrawToken {
+	if x != nil {
+		return x.WithdrawToken
+	}
+	return nil
+}
+
+func (x *WithdrawTokenListRep) GetTotal() uint64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *WithdrawTokenListRep) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *WithdrawTokenListRep) GetPrevCursor() string {
+	if x != nil {
+		return x.PrevCursor
+	}
+	return ""
+}
+
+func (x *WithdrawTokenListRep) GetCount() string {
+	if x != nil {
+		return x.Count
+	}
+	return ""
+}
+
+type WithdrawTokenDetailReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ConsumerToken string                 `protobuf:"bytes,1,opt,name=consumer_token,json=consumerToken,proto3" json:"consumer_token,omitempty"`
+	Guid          string                 `protobuf:"bytes,2,opt,name=guid,proto3" json:"guid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WithdrawTokenDetailReq) Reset() {
+	*x = WithdrawTokenDetailReq{}
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WithdrawTokenDetailReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithdrawTokenDetailReq) ProtoMessage() {}
+
+func (x *WithdrawTokenDetailReq) ProtoReflect() protoreflect.Message {
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithdrawTokenDetailReq.ProtoReflect.Descriptor instead.
+func (*WithdrawTokenDetailReq) Descriptor() ([]byte, []int) {
+	return file_services_grpc_protobuf_event_sync_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *WithdrawTokenDetailReq) GetConsumerToken() string {
+	if x != nil {
+		return x.ConsumerToken
+	}
+	return ""
+}
+
+func (x *WithdrawTokenDetailReq) GetGuid() string {
+	if x != nil {
+		return x.Guid
+	}
+	return ""
+}
+
+type WithdrawTokenDetailRep struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          ReturnCode             `protobuf:"varint,1,opt,name=code,proto3,enum=theweb3.event.ReturnCode" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	WithdrawToken *WithdrawToken         `protobuf:"bytes,3,opt,name=withdraw_token,json=withdrawToken,proto3" json:"withdraw_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WithdrawTokenDetailRep) Reset() {
+	*x = WithdrawTokenDetailRep{}
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WithdrawTokenDetailRep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithdrawTokenDetailRep) ProtoMessage() {}
+
+func (x *WithdrawTokenDetailRep) ProtoReflect() protoreflect.Message {
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithdrawTokenDetailRep.ProtoReflect.Descriptor instead.
+func (*WithdrawTokenDetailRep) Descriptor() ([]byte, []int) {
+	return file_services_grpc_protobuf_event_sync_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *WithdrawTokenDetailRep) GetCode() ReturnCode {
+	if x != nil {
+		return x.Code
+	}
+	return ReturnCode_ERROR
+}
+
+func (x *WithdrawTokenDetailRep) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *WithdrawTokenDetailRep) GetWithdrawToken() *WithdrawToken {
+	if x != nil {
+		return x.WithdrawToken
+	}
+	return nil
+}
+
+type GrantRewardToken struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Guid            string                 `protobuf:"bytes,1,opt,name=guid,proto3" json:"guid,omitempty"`
+	BlockNumber     uint64                 `protobuf:"varint,2,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	BlockHash       string                 `protobuf:"bytes,3,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	TransactionHash string                 `protobuf:"bytes,4,opt,name=transaction_hash,json=transactionHash,proto3" json:"transaction_hash,omitempty"`
+	LogIndex        uint64                 `protobuf:"varint,5,opt,name=log_index,json=logIndex,proto3" json:"log_index,omitempty"`
+	TokenAddress    string                 `protobuf:"bytes,6,opt,name=token_address,json=tokenAddress,proto3" json:"token_address,omitempty"`
+	Granter         string                 `protobuf:"bytes,7,opt,name=granter,proto3" json:"granter,omitempty"`
+	AmountRaw       string                 `protobuf:"bytes,8,opt,name=amount_raw,json=amountRaw,proto3" json:"amount_raw,omitempty"`
+	AmountFormatted string                 `protobuf:"bytes,9,opt,name=amount_formatted,json=amountFormatted,proto3" json:"amount_formatted,omitempty"`
+	Symbol          string                 `protobuf:"bytes,10,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Decimals        uint32                 `protobuf:"varint,11,opt,name=decimals,proto3" json:"decimals,omitempty"`
+	Timestamp       uint64                 `protobuf:"varint,12,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GrantRewardToken) Reset() {
+	*x = GrantRewardToken{}
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GrantRewardToken) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GrantRewardToken) ProtoMessage() {}
+
+func (x *GrantRewardToken) ProtoReflect() protoreflect.Message {
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GrantRewardToken.ProtoReflect.Descriptor instead.
+func (*GrantRewardToken) Descriptor() ([]byte, []int) {
+	return file_services_grpc_protobuf_event_sync_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GrantRewardToken) GetGuid() string {
+	if x != nil {
+		return x.Guid
+	}
+	return ""
+}
+
+func (x *GrantRewardToken) GetBlockNumber() uint64 {
+	if x != nil {
+		return x.BlockNumber
+	}
+	return 0
+}
+
+func (x *GrantRewardToken) GetBlockHash() string {
+	if x != nil {
+		return x.BlockHash
+	}
+	return ""
+}
+
+func (x *GrantRewardToken) GetTransactionHash() string {
+	if x != nil {
+		return x.TransactionHash
+	}
+	return ""
+}
+
+func (x *GrantRewardToken) GetLogIndex() uint64 {
+	if x != nil {
+		return x.LogIndex
+	}
+	return 0
+}
+
+func (x *GrantRewardToken) GetTokenAddress() string {
+	if x != nil {
+		return x.TokenAddress
+	}
+	return ""
+}
+
+func (x *GrantRewardToken) GetGranter() string {
+	if x != nil {
+		return x.Granter
+	}
+	return ""
+}
+
+func (x *GrantRewardToken) GetAmountRaw() string {
+	if x != nil {
+		return x.AmountRaw
+	}
+	return ""
+}
+
+func (x *GrantRewardToken) GetAmountFormatted() string {
+	if x != nil {
+		return x.AmountFormatted
+	}
+	return ""
+}
+
+func (x *GrantRewardToken) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *GrantRewardToken) GetDecimals() uint32 {
+	if x != nil {
+		return x.Decimals
+	}
+	return 0
+}
+
+func (x *GrantRewardToken) GetTimestamp() uint64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+type GrantRewardTokenListReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ConsumerToken string                 `protobuf:"bytes,1,opt,name=consumer_token,json=consumerToken,proto3" json:"consumer_token,omitempty"`
+	Page          uint64                 `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      uint64                 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Order         string                 `protobuf:"bytes,4,opt,name=order,proto3" json:"order,omitempty"`
+	Filter        *EventFilter           `protobuf:"bytes,5,opt,name=filter,proto3" json:"filter,omitempty"` // receiver 过滤不适用于奖励发放记录
+	Cursor        string                 `protobuf:"bytes,6,opt,name=cursor,proto3" json:"cursor,omitempty"` // 上一页响应中的 next_cursor / prev_cursor，按游标（keyset）分页，忽略 page 和 order
+	Count         string                 `protobuf:"bytes,7,opt,name=count,proto3" json:"count,omitempty"`   // none / exact / approximate，按页码分页时默认 exact，按游标分页时默认 none
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GrantRewardTokenListReq) Reset() {
+	*x = GrantRewardTokenListReq{}
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GrantRewardTokenListReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GrantRewardTokenListReq) ProtoMessage() {}
+
+func (x *GrantRewardTokenListReq) ProtoReflect() protoreflect.Message {
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GrantRewardTokenListReq.ProtoReflect.Descriptor instead.
+func (*GrantRewardTokenListReq) Descriptor() ([]byte, []int) {
+	return file_services_grpc_protobuf_event_sync_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GrantRewardTokenListReq) GetConsumerToken() string {
+	if x != nil {
+		return x.ConsumerToken
+	}
+	return ""
+}
+
+func (x *GrantRewardTokenListReq) GetPage() uint64 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GrantRewardTokenListReq) GetPageSize() uint64 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GrantRewardTokenListReq) GetOrder() string {
+	if x != nil {
+		return x.Order
+	}
+	return ""
+}
+
+func (x *GrantRewardTokenListReq) GetFilter() *EventFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *GrantRewardTokenListReq) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *GrantRewardTokenListReq) GetCount() string {
+	if x != nil {
+		return x.Count
+	}
+	return ""
+}
+
+type GrantRewardTokenListRep struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Code             ReturnCode             `protobuf:"varint,1,opt,name=code,proto3,enum=theweb3.event.ReturnCode" json:"code,omitempty"`
+	Message          string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	GrantRewardToken []*GrantRewardToken    `protobuf:"bytes,3,rep,name=grant_reward_token,json=grantRewardToken,proto3" json:"grant_reward_token,omitempty"`
+	Total            uint64                 `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	NextCursor       string                 `protobuf:"bytes,5,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // 下一页游标，没有更多记录时为空
+	PrevCursor       string                 `protobuf:"bytes,6,opt,name=prev_cursor,json=prevCursor,proto3" json:"prev_cursor,omitempty"` // 上一页游标，没有更早记录时为空
+	Count            string                 `protobuf:"bytes,7,opt,name=count,proto3" json:"count,omitempty"`                             // total 的统计方式：exact / approximate / none
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GrantRewardTokenListRep) Reset() {
+	*x = GrantRewardTokenListRep{}
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GrantRewardTokenListRep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GrantRewardTokenListRep) ProtoMessage() {}
+
+func (x *GrantRewardTokenListRep) ProtoReflect() protoreflect.Message {
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GrantRewardTokenListRep.ProtoReflect.Descriptor instead.
+func (*GrantRewardTokenListRep) Descriptor() ([]byte, []int) {
+	return file_services_grpc_protobuf_event_sync_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GrantRewardTokenListRep) GetCode() ReturnCode {
+	if x != nil {
+		return x.Code
+	}
+	return ReturnCode_ERROR
+}
+
+func (x *GrantRewardTokenListRep) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GrantRewardTokenListRep) GetGrantRewardToken() []*GrantRewardToken {
+	if x != nil {
+		return x.GrantRewardToken
+	}
+	return nil
+}
+
+func (x *GrantRewardTokenListRep) GetTotal() uint64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *GrantRewardTokenListRep) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *GrantRewardTokenListRep) GetPrevCursor() string {
+	if x != nil {
+		return x.PrevCursor
+	}
+	return ""
+}
+
+func (x *GrantRewardTokenListRep) GetCount() string {
+	if x != nil {
+		return x.Count
+	}
+	return ""
+}
+
+type GrantRewardTokenDetailReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ConsumerToken string                 `protobuf:"bytes,1,opt,name=consumer_token,json=consumerToken,proto3" json:"consumer_token,omitempty"`
+	Guid          string                 `protobuf:"bytes,2,opt,name=guid,proto3" json:"guid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GrantRewardTokenDetailReq) Reset() {
+	*x = GrantRewardTokenDetailReq{}
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GrantRewardTokenDetailReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GrantRewardTokenDetailReq) ProtoMessage() {}
+
+func (x *GrantRewardTokenDetailReq) ProtoReflect() protoreflect.Message {
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GrantRewardTokenDetailReq.ProtoReflect.Descriptor instead.
+func (*GrantRewardTokenDetailReq) Descriptor() ([]byte, []int) {
+	return file_services_grpc_protobuf_event_sync_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GrantRewardTokenDetailReq) GetConsumerToken() string {
+	if x != nil {
+		return x.ConsumerToken
+	}
+	return ""
+}
+
+func (x *GrantRewardTokenDetailReq) GetGuid() string {
+	if x != nil {
+		return x.Guid
+	}
+	return ""
+}
+
+type GrantRewardTokenDetailRep struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Code             ReturnCode             `protobuf:"varint,1,opt,name=code,proto3,enum=theweb3.event.ReturnCode" json:"code,omitempty"`
+	Message          string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	GrantRewardToken *GrantRewardToken      `protobuf:"bytes,3,opt,name=grant_reward_token,json=grantRewardToken,proto3" json:"grant_reward_token,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GrantRewardTokenDetailRep) Reset() {
+	*x = GrantRewardTokenDetailRep{}
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GrantRewardTokenDetailRep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GrantRewardTokenDetailRep) ProtoMessage() {}
+
+func (x *GrantRewardTokenDetailRep) ProtoReflect() protoreflect.Message {
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GrantRewardTokenDetailRep.ProtoReflect.Descriptor instead.
+func (*GrantRewardTokenDetailRep) Descriptor() ([]byte, []int) {
+	return file_services_grpc_protobuf_event_sync_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GrantRewardTokenDetailRep) GetCode() ReturnCode {
+	if x != nil {
+		return x.Code
+	}
+	return ReturnCode_ERROR
+}
+
+func (x *GrantRewardTokenDetailRep) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GrantRewardTokenDetailRep) GetGrantRewardToken() *GrantRewardToken {
+	if x != nil {
+		return x.GrantRewardToken
+	}
+	return nil
+}
+
+type WithdrawManagerUpdate struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Guid            string                 `protobuf:"bytes,1,opt,name=guid,proto3" json:"guid,omitempty"`
+	BlockNumber     uint64                 `protobuf:"varint,2,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	BlockHash       string                 `protobuf:"bytes,3,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	TransactionHash string                 `protobuf:"bytes,4,opt,name=transaction_hash,json=transactionHash,proto3" json:"transaction_hash,omitempty"`
+	LogIndex        uint64                 `protobuf:"varint,5,opt,name=log_index,json=logIndex,proto3" json:"log_index,omitempty"`
+	WithdrawManager string                 `protobuf:"bytes,6,opt,name=withdraw_manager,json=withdrawManager,proto3" json:"withdraw_manager,omitempty"`
+	Timestamp       uint64                 `protobuf:"varint,7,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *WithdrawManagerUpdate) Reset() {
+	*x = WithdrawManagerUpdate{}
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WithdrawManagerUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithdrawManagerUpdate) ProtoMessage() {}
+
+func (x *WithdrawManagerUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithdrawManagerUpdate.ProtoReflect.Descriptor instead.
+func (*WithdrawManagerUpdate) Descriptor() ([]byte, []int) {
+	return file_services_grpc_protobuf_event_sync_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *WithdrawManagerUpdate) GetGuid() string {
+	if x != nil {
+		return x.Guid
+	}
+	return ""
+}
+
+func (x *WithdrawManagerUpdate) GetBlockNumber() uint64 {
+	if x != nil {
+		return x.BlockNumber
+	}
+	return 0
+}
+
+func (x *WithdrawManagerUpdate) GetBlockHash() string {
+	if x != nil {
+		return x.BlockHash
+	}
+	return ""
+}
+
+func (x *WithdrawManagerUpdate) GetTransactionHash() string {
+	if x != nil {
+		return x.TransactionHash
+	}
+	return ""
+}
+
+func (x *WithdrawManagerUpdate) GetLogIndex() uint64 {
+	if x != nil {
+		return x.LogIndex
+	}
+	return 0
+}
+
+func (x *WithdrawManagerUpdate) GetWithdrawManager() string {
+	if x != nil {
+		return x.WithdrawManager
+	}
+	return ""
+}
+
+func (x *WithdrawManagerUpdate) GetTimestamp() uint64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+type WithdrawManagerUpdateListReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ConsumerToken string                 `protobuf:"bytes,1,opt,name=consumer_token,json=consumerToken,proto3" json:"consumer_token,omitempty"`
+	Page          uint64                 `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      uint64                 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Order         string                 `protobuf:"bytes,4,opt,name=order,proto3" json:"order,omitempty"`
+	Filter        *EventFilter           `protobuf:"bytes,5,opt,name=filter,proto3" json:"filter,omitempty"` // token_address / receiver 过滤不适用于提现管理员变更记录
+	Cursor        string                 `protobuf:"bytes,6,opt,name=cursor,proto3" json:"cursor,omitempty"` // 上一页响应中的 next_cursor / prev_cursor，按游标（keyset）分页，忽略 page 和 order
+	Count         string                 `protobuf:"bytes,7,opt,name=count,proto3" json:"count,omitempty"`   // none / exact / approximate，按页码分页时默认 exact，按游标分页时默认 none
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WithdrawManagerUpdateListReq) Reset() {
+	*x = WithdrawManagerUpdateListReq{}
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WithdrawManagerUpdateListReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithdrawManagerUpdateListReq) ProtoMessage() {}
+
+func (x *WithdrawManagerUpdateListReq) ProtoReflect() protoreflect.Message {
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithdrawManagerUpdateListReq.ProtoReflect.Descriptor instead.
+func (*WithdrawManagerUpdateListReq) Descriptor() ([]byte, []int) {
+	return file_services_grpc_protobuf_event_sync_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *WithdrawManagerUpdateListReq) GetConsumerToken() string {
+	if x != nil {
+		return x.ConsumerToken
+	}
+	return ""
+}
+
+func (x *WithdrawManagerUpdateListReq) GetPage() uint64 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *WithdrawManagerUpdateListReq) GetPageSize() uint64 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *WithdrawManagerUpdateListReq) GetOrder() string {
+	if x != nil {
+		return x.Order
+	}
+	return ""
+}
+
+func (x *WithdrawManagerUpdateListReq) GetFilter() *EventFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *WithdrawManagerUpdateListReq) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *WithdrawManagerUpdateListReq) GetCount() string {
+	if x != nil {
+		return x.Count
+	}
+	return ""
+}
+
+type WithdrawManagerUpdateListRep struct {
+	state                 protoimpl.MessageState   `protogen:"open.v1"`
+	Code                  ReturnCode               `protobuf:"varint,1,opt,name=code,proto3,enum=theweb3.event.ReturnCode" json:"code,omitempty"`
+	Message               string                   `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	WithdrawManagerUpdate []*WithdrawManagerUpdate `protobuf:"bytes,3,rep,name=withdraw_manager_update,json=withdrawManagerUpdate,proto3" json:"withdraw_manager_update,omitempty"`
+	Total                 uint64                   `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	NextCursor            string                   `protobuf:"bytes,5,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // 下一页游标，没有更多记录时为空
+	PrevCursor            string                   `protobuf:"bytes,6,opt,name=prev_cursor,json=prevCursor,proto3" json:"prev_cursor,omitempty"` // 上一页游标，没有更早记录时为空
+	Count                 string                   `protobuf:"bytes,7,opt,name=count,proto3" json:"count,omitempty"`                             // total 的统计方式：exact / approximate / none
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *WithdrawManagerUpdateListRep) Reset() {
+	*x = WithdrawManagerUpdateListRep{}
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WithdrawManagerUpdateListRep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithdrawManagerUpdateListRep) ProtoMessage() {}
+
+func (x *WithdrawManagerUpdateListRep) ProtoReflect() protoreflect.Message {
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithdrawManagerUpdateListRep.ProtoReflect.Descriptor instead.
+func (*WithdrawManagerUpdateListRep) Descriptor() ([]byte, []int) {
+	return file_services_grpc_protobuf_event_sync_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *WithdrawManagerUpdateListRep) GetCode() ReturnCode {
+	if x != nil {
+		return x.Code
+	}
+	return ReturnCode_ERROR
+}
+
+func (x *WithdrawManagerUpdateListRep) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *WithdrawManagerUpdateListRep) GetWithdrawManagerUpdate() []*WithdrawManagerUpdate {
+	if x != nil {
+		return x.WithdrawManagerUpdate
+	}
+	return nil
+}
+
+func (x *WithdrawManagerUpdateListRep) GetTotal() uint64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *WithdrawManagerUpdateListRep) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *WithdrawManagerUpdateListRep) GetPrevCursor() string {
+	if x != nil {
+		return x.PrevCursor
+	}
+	return ""
+}
+
+func (x *WithdrawManagerUpdateListRep) GetCount() string {
+	if x != nil {
+		return x.Count
+	}
+	return ""
+}
+
+type WithdrawManagerUpdateDetailReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ConsumerToken string                 `protobuf:"bytes,1,opt,name=consumer_token,json=consumerToken,proto3" json:"consumer_token,omitempty"`
+	Guid          string                 `protobuf:"bytes,2,opt,name=guid,proto3" json:"guid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WithdrawManagerUpdateDetailReq) Reset() {
+	*x = WithdrawManagerUpdateDetailReq{}
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WithdrawManagerUpdateDetailReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithdrawManagerUpdateDetailReq) ProtoMessage() {}
+
+func (x *WithdrawManagerUpdateDetailReq) ProtoReflect() protoreflect.Message {
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithdrawManagerUpdateDetailReq.ProtoReflect.Descriptor instead.
+func (*WithdrawManagerUpdateDetailReq) Descriptor() ([]byte, []int) {
+	return file_services_grpc_protobuf_event_sync_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *WithdrawManagerUpdateDetailReq) GetConsumerToken() string {
+	if x != nil {
+		return x.ConsumerToken
+	}
+	return ""
+}
+
+func (x *WithdrawManagerUpdateDetailReq) GetGuid() string {
+	if x != nil {
+		return x.Guid
+	}
+	return ""
+}
+
+type WithdrawManagerUpdateDetailRep struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Code                  ReturnCode             `protobuf:"varint,1,opt,name=code,proto3,enum=theweb3.event.ReturnCode" json:"code,omitempty"`
+	Message               string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	WithdrawManagerUpdate *WithdrawManagerUpdate `protobuf:"bytes,3,opt,name=withdraw_manager_update,json=withdrawManagerUpdate,proto3" json:"withdraw_manager_update,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *WithdrawManagerUpdateDetailRep) Reset() {
+	*x = WithdrawManagerUpdateDetailRep{}
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WithdrawManagerUpdateDetailRep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithdrawManagerUpdateDetailRep) ProtoMessage() {}
+
+func (x *WithdrawManagerUpdateDetailRep) ProtoReflect() protoreflect.Message {
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithdrawManagerUpdateDetailRep.ProtoReflect.Descriptor instead.
+func (*WithdrawManagerUpdateDetailRep) Descriptor() ([]byte, []int) {
+	return file_services_grpc_protobuf_event_sync_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *WithdrawManagerUpdateDetailRep) GetCode() ReturnCode {
+	if x != nil {
+		return x.Code
+	}
+	return ReturnCode_ERROR
+}
+
+func (x *WithdrawManagerUpdateDetailRep) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *WithdrawManagerUpdateDetailRep) GetWithdrawManagerUpdate() *WithdrawManagerUpdate {
+	if x != nil {
+		return x.WithdrawManagerUpdate
+	}
+	return nil
+}
+
+type RewardBalance struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	UserAddress           string                 `protobuf:"bytes,1,opt,name=user_address,json=userAddress,proto3" json:"user_address,omitempty"`
+	TokenAddress          string                 `protobuf:"bytes,2,opt,name=token_address,json=tokenAddress,proto3" json:"token_address,omitempty"`
+	Granted               string                 `protobuf:"bytes,3,opt,name=granted,proto3" json:"granted,omitempty"`
+	Claimed               string                 `protobuf:"bytes,4,opt,name=claimed,proto3" json:"claimed,omitempty"`
+	Claimable             string                 `protobuf:"bytes,5,opt,name=claimable,proto3" json:"claimable,omitempty"`
+	BlockNumber           uint64                 `protobuf:"varint,6,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	ReconciledBlockNumber uint64                 `protobuf:"varint,7,opt,name=reconciled_block_number,json=reconciledBlockNumber,proto3" json:"reconciled_block_number,omitempty"`
+	Timestamp             uint64                 `protobuf:"varint,8,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	GrantedFormatted      string                 `protobuf:"bytes,9,opt,name=granted_formatted,json=grantedFormatted,proto3" json:"granted_formatted,omitempty"`
+	ClaimedFormatted      string                 `protobuf:"bytes,10,opt,name=claimed_formatted,json=claimedFormatted,proto3" json:"claimed_formatted,omitempty"`
+	ClaimableFormatted    string                 `protobuf:"bytes,11,opt,name=claimable_formatted,json=claimableFormatted,proto3" json:"claimable_formatted,omitempty"`
+	Symbol                string                 `protobuf:"bytes,12,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Decimals              uint32                 `protobuf:"varint,13,opt,name=decimals,proto3" json:"decimals,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *RewardBalance) Reset() {
+	*x = RewardBalance{}
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RewardBalance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RewardBalance) ProtoMessage() {}
+
+func (x *RewardBalance) ProtoReflect() protoreflect.Message {
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RewardBalance.ProtoReflect.Descriptor instead.
+func (*RewardBalance) Descriptor() ([]byte, []int) {
+	return file_services_grpc_protobuf_event_sync_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *RewardBalance) GetUserAddress() string {
+	if x != nil {
+		return x.UserAddress
+	}
+	return ""
+}
+
+func (x *RewardBalance) GetTokenAddress() string {
+	if x != nil {
+		return x.TokenAddress
+	}
+	return ""
+}
+
+func (x *RewardBalance) GetGranted() string {
+	if x != nil {
+		return x.Granted
+	}
+	return ""
+}
+
+func (x *RewardBalance) GetClaimed() string {
+	if x != nil {
+		return x.Claimed
+	}
+	return ""
+}
+
+func (x *RewardBalance) GetClaimable() string {
+	if x != nil {
+		return x.Claimable
+	}
+	return ""
+}
+
+func (x *RewardBalance) GetBlockNumber() uint64 {
+	if x != nil {
+		return x.BlockNumber
+	}
+	return 0
+}
+
+func (x *RewardBalance) GetReconciledBlockNumber() uint64 {
+	if x != nil {
+		return x.ReconciledBlockNumber
+	}
+	return 0
+}
+
+func (x *RewardBalance) GetTimestamp() uint64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *RewardBalance) GetGrantedFormatted() string {
+	if x != nil {
+		return x.GrantedFormatted
+	}
+	return ""
+}
+
+func (x *RewardBalance) GetClaimedFormatted() string {
+	if x != nil {
+		return x.ClaimedFormatted
+	}
+	return ""
+}
+
+func (x *RewardBalance) GetClaimableFormatted() string {
+	if x != nil {
+		return x.ClaimableFormatted
+	}
+	return ""
+}
+
+func (x *RewardBalance) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *RewardBalance) GetDecimals() uint32 {
+	if x != nil {
+		return x.Decimals
+	}
+	return 0
+}
+
+type RewardLedgerEntry struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Guid            string                 `protobuf:"bytes,1,opt,name=guid,proto3" json:"guid,omitempty"`
+	UserAddress     string                 `protobuf:"bytes,2,opt,name=user_address,json=userAddress,proto3" json:"user_address,omitempty"`
+	TokenAddress    string                 `protobuf:"bytes,3,opt,name=token_address,json=tokenAddress,proto3" json:"token_address,omitempty"`
+	EntryType       string                 `protobuf:"bytes,4,opt,name=entry_type,json=entryType,proto3" json:"entry_type,omitempty"` // grant / claim
+	Amount          string                 `protobuf:"bytes,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	BlockNumber     uint64                 `protobuf:"varint,6,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	BlockHash       string                 `protobuf:"bytes,7,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	Timestamp       uint64                 `protobuf:"varint,8,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	AmountFormatted string                 `protobuf:"bytes,9,opt,name=amount_formatted,json=amountFormatted,proto3" json:"amount_formatted,omitempty"`
+	Symbol          string                 `protobuf:"bytes,10,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Decimals        uint32                 `protobuf:"varint,11,opt,name=decimals,proto3" json:"decimals,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RewardLedgerEntry) Reset() {
+	*x = RewardLedgerEntry{}
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RewardLedgerEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RewardLedgerEntry) ProtoMessage() {}
+
+func (x *RewardLedgerEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RewardLedgerEntry.ProtoReflect.Descriptor instead.
+func (*RewardLedgerEntry) Descriptor() ([]byte, []int) {
+	return file_services_grpc_protobuf_event_sync_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *RewardLedgerEntry) GetGuid() string {
+	if x != nil {
+		return x.Guid
+	}
+	return ""
+}
+
+func (x *RewardLedgerEntry) GetUserAddress() string {
+	if x != nil {
+		return x.UserAddress
+	}
+	return ""
+}
+
+func (x *RewardLedgerEntry) GetTokenAddress() string {
+	if x != nil {
+		return x.TokenAddress
+	}
+	return ""
+}
+
+func (x *RewardLedgerEntry) GetEntryType() string {
+	if x != nil {
+		return x.EntryType
+	}
+	return ""
+}
+
+func (x *RewardLedgerEntry) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *RewardLedgerEntry) GetBlockNumber() uint64 {
+	if x != nil {
+		return x.BlockNumber
+	}
+	return 0
+}
+
+func (x *RewardLedgerEntry) GetBlockHash() string {
+	if x != nil {
+		return x.BlockHash
+	}
+	return ""
+}
+
+func (x *RewardLedgerEntry) GetTimestamp() uint64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *RewardLedgerEntry) GetAmountFormatted() string {
+	if x != nil {
+		return x.AmountFormatted
+	}
+	return ""
+}
+
+func (x *RewardLedgerEntry) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *RewardLedgerEntry) GetDecimals() uint32 {
+	if x != nil {
+		return x.Decimals
+	}
+	return 0
+}
+
+type RewardLedgerReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ConsumerToken string                 `protobuf:"bytes,1,opt,name=consumer_token,json=consumerToken,proto3" json:"consumer_token,omitempty"`
+	Address       string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Page          uint64                 `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      uint64                 `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RewardLedgerReq) Reset() {
+	*x = RewardLedgerReq{}
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RewardLedgerReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RewardLedgerReq) ProtoMessage() {}
+
+func (x *RewardLedgerReq) ProtoReflect() protoreflect.Message {
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RewardLedgerReq.ProtoReflect.Descriptor instead.
+func (*RewardLedgerReq) Descriptor() ([]byte, []int) {
+	return file_services_grpc_protobuf_event_sync_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *RewardLedgerReq) GetConsumerToken() string {
+	if x != nil {
+		return x.ConsumerToken
+	}
+	return ""
+}
+
+func (x *RewardLedgerReq) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *RewardLedgerReq) GetPage() uint64 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *RewardLedgerReq) GetPageSize() uint64 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type RewardLedgerRep struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          ReturnCode             `protobuf:"varint,1,opt,name=code,proto3,enum=theweb3.event.ReturnCode" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Balance       []*RewardBalance       `protobuf:"bytes,3,rep,name=balance,proto3" json:"balance,omitempty"`
+	Entry         []*RewardLedgerEntry   `protobuf:"bytes,4,rep,name=entry,proto3" json:"entry,omitempty"`
+	Total         uint64                 `protobuf:"varint,5,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RewardLedgerRep) Reset() {
+	*x = RewardLedgerRep{}
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RewardLedgerRep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RewardLedgerRep) ProtoMessage() {}
+
+func (x *RewardLedgerRep) ProtoReflect() protoreflect.Message {
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RewardLedgerRep.ProtoReflect.Descriptor instead.
+func (*RewardLedgerRep) Descriptor() ([]byte, []int) {
+	return file_services_grpc_protobuf_event_sync_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *RewardLedgerRep) GetCode() ReturnCode {
+	if x != nil {
+		return x.Code
+	}
+	return ReturnCode_ERROR
+}
+
+func (x *RewardLedgerRep) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RewardLedgerRep) GetBalance() []*RewardBalance {
+	if x != nil {
+		return x.Balance
+	}
+	return nil
+}
+
+func (x *RewardLedgerRep) GetEntry() []*RewardLedgerEntry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+func (x *RewardLedgerRep) GetTotal() uint64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type AddressActivity struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	EventType       string                 `protobuf:"bytes,1,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"` // deposit / withdraw / grant / manager_update
+	Guid            string                 `protobuf:"bytes,2,opt,name=guid,proto3" json:"guid,omitempty"`
+	BlockNumber     uint64                 `protobuf:"varint,3,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	BlockHash       string                 `protobuf:"bytes,4,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	TransactionHash string                 `protobuf:"bytes,5,opt,name=transaction_hash,json=transactionHash,proto3" json:"transaction_hash,omitempty"`
+	LogIndex        uint64                 `protobuf:"varint,6,opt,name=log_index,json=logIndex,proto3" json:"log_index,omitempty"`
+	TokenAddress    string                 `protobuf:"bytes,7,opt,name=token_address,json=tokenAddress,proto3" json:"token_address,omitempty"` // 提现管理员变更为空
+	Sender          string                 `protobuf:"bytes,8,opt,name=sender,proto3" json:"sender,omitempty"`                                 // 发起方（sender / granter / withdraw_manager）
+	Receiver        string                 `protobuf:"bytes,9,opt,name=receiver,proto3" json:"receiver,omitempty"`                             // 只有提现记录有接收方
+	AmountRaw       string                 `protobuf:"bytes,10,opt,name=amount_raw,json=amountRaw,proto3" json:"amount_raw,omitempty"`         // 提现管理员变更为空
+	AmountFormatted string                 `protobuf:"bytes,11,opt,name=amount_formatted,json=amountFormatted,proto3" json:"amount_formatted,omitempty"`
+	Symbol          string                 `protobuf:"bytes,12,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Decimals        uint32                 `protobuf:"varint,13,opt,name=decimals,proto3" json:"decimals,omitempty"`
+	Timestamp       uint64                 `protobuf:"varint,14,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *AddressActivity) Reset() {
+	*x = AddressActivity{}
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddressActivity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddressActivity) ProtoMessage() {}
+
+func (x *AddressActivity) ProtoReflect() protoreflect.Message {
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddressActivity.ProtoReflect.Descriptor instead.
+func (*AddressActivity) Descriptor() ([]byte, []int) {
+	return file_services_grpc_protobuf_event_sync_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *AddressActivity) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *AddressActivity) GetGuid() string {
+	if x != nil {
+		return x.Guid
+	}
+	return ""
+}
+
+func (x *AddressActivity) GetBlockNumber() uint64 {
+	if x != nil {
+		return x.BlockNumber
+	}
+	return 0
+}
+
+func (x *AddressActivity) GetBlockHash() string {
+	if x != nil {
+		return x.BlockHash
+	}
+	return ""
+}
+
+func (x *AddressActivity) GetTransactionHash() string {
+	if x != nil {
+		return x.TransactionHash
+	}
+	return ""
+}
+
+func (x *AddressActivity) GetLogIndex() uint64 {
+	if x != nil {
+		return x.LogIndex
+	}
+	return 0
+}
+
+func (x *AddressActivity) GetTokenAddress() string {
+	if x != nil {
+		return x.TokenAddress
+	}
+	return ""
+}
+
+func (x *AddressActivity) GetSender() string {
+	if x != nil {
+		return x.Sender
+	}
+	return ""
+}
+
+func (x *AddressActivity) GetReceiver() string {
+	if x != nil {
+		return x.Receiver
+	}
+	return ""
+}
+
+func (x *AddressActivity) GetAmountRaw() string {
+	if x != nil {
+		return x.AmountRaw
+	}
+	return ""
+}
+
+func (x *AddressActivity) GetAmountFormatted() string {
+	if x != nil {
+		return x.AmountFormatted
+	}
+	return ""
+}
+
+func (x *AddressActivity) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *AddressActivity) GetDecimals() uint32 {
+	if x != nil {
+		return x.Decimals
+	}
+	return 0
+}
+
+func (x *AddressActivity) GetTimestamp() uint64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+type AddressTokenSummary struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	TokenAddress           string                 `protobuf:"bytes,1,opt,name=token_address,json=tokenAddress,proto3" json:"token_address,omitempty"`
+	DepositCount           uint64                 `protobuf:"varint,2,opt,name=deposit_count,json=depositCount,proto3" json:"deposit_count,omitempty"`
+	Deposited              string                 `protobuf:"bytes,3,opt,name=deposited,proto3" json:"deposited,omitempty"`
+	DepositedFormatted     string                 `protobuf:"bytes,4,opt,name=deposited_formatted,json=depositedFormatted,proto3" json:"deposited_formatted,omitempty"`
+	WithdrawCount          uint64                 `protobuf:"varint,5,opt,name=withdraw_count,json=withdrawCount,proto3" json:"withdraw_count,omitempty"` // 作为发起方或接收方的提现
+	Withdrawn              string                 `protobuf:"bytes,6,opt,name=withdrawn,proto3" json:"withdrawn,omitempty"`
+	WithdrawnFormatted     string                 `protobuf:"bytes,7,opt,name=withdrawn_formatted,json=withdrawnFormatted,proto3" json:"withdrawn_formatted,omitempty"`
+	GranterCount           uint64                 `protobuf:"varint,8,opt,name=granter_count,json=granterCount,proto3" json:"granter_count,omitempty"` // 事件中的 granter 为该地址的奖励发放
+	GranterAmount          string                 `protobuf:"bytes,9,opt,name=granter_amount,json=granterAmount,proto3" json:"granter_amount,omitempty"`
+	GranterAmountFormatted string                 `protobuf:"bytes,10,opt,name=granter_amount_formatted,json=granterAmountFormatted,proto3" json:"granter_amount_formatted,omitempty"`
+	Symbol                 string                 `protobuf:"bytes,11,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Decimals               uint32                 `protobuf:"varint,12,opt,name=decimals,proto3" json:"decimals,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *AddressTokenSummary) Reset() {
+	*x = AddressTokenSummary{}
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddressTokenSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddressTokenSummary) ProtoMessage() {}
+
+func (x *AddressTokenSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddressTokenSummary.ProtoReflect.Descriptor instead.
+func (*AddressTokenSummary) Descriptor() ([]byte, []int) {
+	return file_services_grpc_protobuf_event_sync_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *AddressTokenSummary) GetTokenAddress() string {
+	if x != nil {
+		return x.TokenAddress
+	}
+	return ""
+}
+
+func (x *AddressTokenSummary) GetDepositCount() uint64 {
+	if x != nil {
+		return x.DepositCount
+	}
+	return 0
+}
+
+func (x *AddressTokenSummary) GetDeposited() string {
+	if x != nil {
+		return x.Deposited
+	}
+	return ""
+}
+
+func (x *AddressTokenSummary) GetDepositedFormatted() string {
+	if x != nil {
+		return x.DepositedFormatted
+	}
+	return ""
+}
+
+func (x *AddressTokenSummary) GetWithdrawCount() uint64 {
+	if x != nil {
+		return x.WithdrawCount
+	}
+	return 0
+}
+
+func (x *AddressTokenSummary) GetWithdrawn() string {
+	if x != nil {
+		return x.Withdrawn
+	}
+	return ""
+}
+
+func (x *AddressTokenSummary) GetWithdrawnFormatted() string {
+	if x != nil {
+		return x.WithdrawnFormatted
+	}
+	return ""
+}
+
+func (x *AddressTokenSummary) GetGranterCount() uint64 {
+	if x != nil {
+		return x.GranterCount
+	}
+	return 0
+}
+
+func (x *AddressTokenSummary) GetGranterAmount() string {
+	if x != nil {
+		return x.GranterAmount
+	}
+	return ""
+}
+
+func (x *AddressTokenSummary) GetGranterAmountFormatted() string {
+	if x != nil {
+		return x.GranterAmountFormatted
+	}
+	return ""
+}
+
+func (x *AddressTokenSummary) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *AddressTokenSummary) GetDecimals() uint32 {
+	if x != nil {
+		return x.Decimals
+	}
+	return 0
+}
+
+type AddressActivityReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ConsumerToken string                 `protobuf:"bytes,1,opt,name=consumer_token,json=consumerToken,proto3" json:"consumer_token,omitempty"`
+	Address       string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Page          uint64                 `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      uint64                 `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Order         string                 `protobuf:"bytes,5,opt,name=order,proto3" json:"order,omitempty"`   // asc / desc，按 (block_number, log_index) 排序，默认 desc
+	Filter        *EventFilter           `protobuf:"bytes,6,opt,name=filter,proto3" json:"filter,omitempty"` // 只支持 token_address / transaction_hash / 区块和时间范围
+	Cursor        string                 `protobuf:"bytes,7,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Count         string                 `protobuf:"bytes,8,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddressActivityReq) Reset() {
+	*x = AddressActivityReq{}
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddressActivityReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddressActivityReq) ProtoMessage() {}
+
+func (x *AddressActivityReq) ProtoReflect() protoreflect.Message {
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddressActivityReq.ProtoReflect.Descriptor instead.
+func (*AddressActivityReq) Descriptor() ([]byte, []int) {
+	return file_services_grpc_protobuf_event_sync_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *AddressActivityReq) GetConsumerToken() string {
+	if x != nil {
+		return x.ConsumerToken
+	}
+	return ""
+}
+
+func (x *AddressActivityReq) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *AddressActivityReq) GetPage() uint64 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *AddressActivityReq) GetPageSize() uint64 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *AddressActivityReq) GetOrder() string {
+	if x != nil {
+		return x.Order
+	}
+	return ""
+}
+
+func (x *AddressActivityReq) GetFilter() *EventFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *AddressActivityReq) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *AddressActivityReq) GetCount() string {
+	if x != nil {
+		return x.Count
+	}
+	return ""
+}
+
+type AddressActivityRep struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          ReturnCode             `protobuf:"varint,1,opt,name=code,proto3,enum=theweb3.event.ReturnCode" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Summary       []*AddressTokenSummary `protobuf:"bytes,3,rep,name=summary,proto3" json:"summary,omitempty"`   // 每个代币的汇总，不受过滤条件影响
+	Activity      []*AddressActivity     `protobuf:"bytes,4,rep,name=activity,proto3" json:"activity,omitempty"` // 合并后的充值、提现、奖励发放和提现管理员变更时间线
+	Total         uint64                 `protobuf:"varint,5,opt,name=total,proto3" json:"total,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,6,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	PrevCursor    string                 `protobuf:"bytes,7,opt,name=prev_cursor,json=prevCursor,proto3" json:"prev_cursor,omitempty"`
+	Count         string                 `protobuf:"bytes,8,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddressActivityRep) Reset() {
+	*x = AddressActivityRep{}
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddressActivityRep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddressActivityRep) ProtoMessage() {}
+
+func (x *AddressActivityRep) ProtoReflect() protoreflect.Message {
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddressActivityRep.ProtoReflect.Descriptor instead.
+func (*AddressActivityRep) Descriptor() ([]byte, []int) {
+	return file_services_grpc_protobuf_event_sync_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *AddressActivityRep) GetCode() ReturnCode {
+	if x != nil {
+		return x.Code
+	}
+	return ReturnCode_ERROR
+}
+
+func (x *AddressActivityRep) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *AddressActivityRep) GetSummary() []*AddressTokenSummary {
+	if x != nil {
+		return x.Summary
+	}
+	return nil
+}
+
+func (x *AddressActivityRep) GetActivity() []*AddressActivity {
+	if x != nil {
+		return x.Activity
+	}
+	return nil
+}
+
+func (x *AddressActivityRep) GetTotal() uint64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *AddressActivityRep) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *AddressActivityRep) GetPrevCursor() string {
+	if x != nil {
+		return x.PrevCursor
+	}
+	return ""
+}
+
+func (x *AddressActivityRep) GetCount() string {
+	if x != nil {
+		return x.Count
+	}
+	return ""
+}
+
+type SubscribeEventsReq struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ConsumerToken    string                 `protobuf:"bytes,1,opt,name=consumer_token,json=consumerToken,proto3" json:"consumer_token,omitempty"`
+	FromBlockNumber  uint64                 `protobuf:"varint,2,opt,name=from_block_number,json=fromBlockNumber,proto3" json:"from_block_number,omitempty"` // 游标：从 (from_block_number, from_log_index) 之后的事件开始回放
+	FromLogIndex     uint64                 `protobuf:"varint,3,opt,name=from_log_index,json=fromLogIndex,proto3" json:"from_log_index,omitempty"`
+	FromLatest       bool                   `protobuf:"varint,4,opt,name=from_latest,json=fromLatest,proto3" json:"from_latest,omitempty"` // 为 true 时忽略游标，只推送订阅之后的新事件
+	EventTypes       []string               `protobuf:"bytes,5,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`  // DepositToken / WithdrawToken / GrantRewardTokenAmount / WithdrawManagerUpdate，为空时不过滤
+	Addresses        []string               `protobuf:"bytes,6,rep,name=addresses,proto3" json:"addresses,omitempty"`                      // 参与方地址（sender / receiver / granter），为空时不过滤
+	TokenAddress     string                 `protobuf:"bytes,7,opt,name=token_address,json=tokenAddress,proto3" json:"token_address,omitempty"`
+	HeartbeatSeconds uint64                 `protobuf:"varint,8,opt,name=heartbeat_seconds,json=heartbeatSeconds,proto3" json:"heartbeat_seconds,omitempty"` // 心跳间隔，为 0 时默认 15 秒
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *SubscribeEventsReq) Reset() {
+	*x = SubscribeEventsReq{}
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeEventsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeEventsReq) ProtoMessage() {}
+
+func (x *SubscribeEventsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeEventsReq.ProtoReflect.Descriptor instead.
+func (*SubscribeEventsReq) Descriptor() ([]byte, []int) {
+	return file_services_grpc_protobuf_event_sync_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *SubscribeEventsReq) GetConsumerToken() string {
+	if x != nil {
+		return x.ConsumerToken
+	}
+	return ""
+}
+
+func (x *SubscribeEventsReq) GetFromBlockNumber() uint64 {
+	if x != nil {
+		return x.FromBlockNumber
+	}
+	return 0
+}
+
+func (x *SubscribeEventsReq) GetFromLogIndex() uint64 {
+	if x != nil {
+		return x.FromLogIndex
+	}
+	return 0
+}
+
+func (x *SubscribeEventsReq) GetFromLatest() bool {
+	if x != nil {
+		return x.FromLatest
+	}
+	return false
+}
+
+func (x *SubscribeEventsReq) GetEventTypes() []string {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+func (x *SubscribeEventsReq) GetAddresses() []string {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+func (x *SubscribeEventsReq) GetTokenAddress() string {
+	if x != nil {
+		return x.TokenAddress
+	}
+	return ""
+}
+
+func (x *SubscribeEventsReq) GetHeartbeatSeconds() uint64 {
+	if x != nil {
+		return x.HeartbeatSeconds
+	}
+	return 0
+}
+
+type StreamEvent struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Sequence        uint64                 `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"` // outbox 消息 ID，全局递增
+	ContractAddress string                 `protobuf:"bytes,2,opt,name=contract_address,json=contractAddress,proto3" json:"contract_address,omitempty"`
+	EventType       string                 `protobuf:"bytes,3,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	BlockNumber     uint64                 `protobuf:"varint,4,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	BlockHash       string                 `protobuf:"bytes,5,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	TransactionHash string                 `protobuf:"bytes,6,opt,name=transaction_hash,json=transactionHash,proto3" json:"transaction_hash,omitempty"`
+	LogIndex        uint64                 `protobuf:"varint,7,opt,name=log_index,json=logIndex,proto3" json:"log_index,omitempty"`
+	Payload         string                 `protobuf:"bytes,8,opt,name=payload,proto3" json:"payload,omitempty"` // 事件字段的 JSON，金额为十进制字符串
+	Timestamp       uint64                 `protobuf:"varint,9,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *StreamEvent) Reset() {
+	*x = StreamEvent{}
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamEvent) ProtoMessage() {}
+
+func (x *StreamEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamEvent.ProtoReflect.Descriptor instead.
+func (*StreamEvent) Descriptor() ([]byte, []int) {
+	return file_services_grpc_protobuf_event_sync_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *StreamEvent) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *StreamEvent) GetContractAddress() string {
+	if x != nil {
+		return x.ContractAddress
+	}
+	return ""
+}
+
+func (x *StreamEvent) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *StreamEvent) GetBlockNumber() uint64 {
+	if x != nil {
+		return x.BlockNumber
+	}
+	return 0
+}
+
+func (x *StreamEvent) GetBlockHash() string {
+	if x != nil {
+		return x.BlockHash
+	}
+	return ""
+}
+
+func (x *StreamEvent) GetTransactionHash() string {
+	if x != nil {
+		return x.TransactionHash
+	}
+	return ""
+}
+
+func (x *StreamEvent) GetLogIndex() uint64 {
+	if x != nil {
+		return x.LogIndex
+	}
+	return 0
+}
+
+func (x *StreamEvent) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *StreamEvent) GetTimestamp() uint64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+type SubscribeEventsRep struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Code           ReturnCode             `protobuf:"varint,1,opt,name=code,proto3,enum=theweb3.event.ReturnCode" json:"code,omitempty"`
+	Message        string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Type           StreamMessageType      `protobuf:"varint,3,opt,name=type,proto3,enum=theweb3.event.StreamMessageType" json:"type,omitempty"`
+	Event          *StreamEvent           `protobuf:"bytes,4,opt,name=event,proto3" json:"event,omitempty"`                                          // type 为 STREAM_HEARTBEAT 时为空
+	LatestSequence uint64                 `protobuf:"varint,5,opt,name=latest_sequence,json=latestSequence,proto3" json:"latest_sequence,omitempty"` // 服务端已知的最新 outbox 消息 ID
+	ServerTime     uint64                 `protobuf:"varint,6,opt,name=server_time,json=serverTime,proto3" json:"server_time,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SubscribeEventsRep) Reset() {
+	*x = SubscribeEventsRep{}
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeEventsRep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeEventsRep) ProtoMessage() {}
+
+func (x *SubscribeEventsRep) ProtoReflect() protoreflect.Message {
+	mi := &file_services_grpc_protobuf_event_sync_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeEventsRep.ProtoReflect.Descriptor instead.
+func (*SubscribeEventsRep) Descriptor() ([]byte, []int) {
+	return file_services_grpc_protobuf_event_sync_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *SubscribeEventsRep) GetCode() ReturnCode {
+	if x != nil {
+		return x.Code
+	}
+	return ReturnCode_ERROR
+}
+
+func (x *SubscribeEventsRep) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SubscribeEventsRep) GetType() StreamMessageType {
+	if x != nil {
+		return x.Type
+	}
+	return StreamMessageType_STREAM_EVENT_ADDED
+}
+
+func (x *SubscribeEventsRep) GetEvent() *StreamEvent {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *SubscribeEventsRep) GetLatestSequence() uint64 {
+	if x != nil {
+		return x.LatestSequence
+	}
+	return 0
+}
+
+func (x *SubscribeEventsRep) GetServerTime() uint64 {
+	if x != nil {
+		return x.ServerTime
+	}
+	return 0
+}
+
+var File_services_grpc_protobuf_event_sync_proto protoreflect.FileDescriptor
+
+const file_services_grpc_protobuf_event_sync_proto_rawDesc = "" +
+	"\n" +
+	"'services/grpc/protobuf/event_sync.proto\x12\rtheweb3.event\"\xaf\x02\n" +
+	"\vEventFilter\x12#\n" +
+	"\rtoken_address\x18\x01 \x01(\tR\ftokenAddress\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\tR\aaddress\x12\x16\n" +
+	"\x06sender\x18\x03 \x01(\tR\x06sender\x12\x1a\n" +
+	"\breceiver\x18\x04 \x01(\tR\breceiver\x12)\n" +
+	"\x10transaction_hash\x18\x05 \x01(\tR\x0ftransactionHash\x12\x1d\n" +
+	"\n" +
+	"from_block\x18\x06 \x01(\x04R\tfromBlock\x12\x19\n" +
+	"\bto_block\x18\a \x01(\x04R\atoBlock\x12%\n" +
+	"\x0efrom_timestamp\x18\b \x01(\x04R\rfromTimestamp\x12!\n" +
+	"\fto_timestamp\x18\t \x01(\x04R\vtoTimestamp\"\xa1\x03\n" +
+	"\fDepositToken\x12\x12\n" +
+	"\x04guid\x18\x01 \x01(\tR\x04guid\x12!\n" +
+	"\fblock_number\x18\x02 \x01(\x04R\vblockNumber\x12#\n" +
+	"\rtoken_address\x18\x03 \x01(\tR\ftokenAddress\x12\x16\n" +
+	"\x06sender\x18\x04 \x01(\tR\x06sender\x12\x1a\n" +
+	"\x06amount\x18\x05 \x01(\x04B\x02\x18\x01R\x06amount\x12\x1c\n" +
+	"\ttimestamp\x18\x06 \x01(\x04R\ttimestamp\x12\x1d\n" +
+	"\n" +
+	"amount_raw\x18\a \x01(\tR\tamountRaw\x12)\n" +
+	"\x10amount_formatted\x18\b \x01(\tR\x0famountFormatted\x12\x16\n" +
+	"\x06symbol\x18\t \x01(\tR\x06symbol\x12\x1a\n" +
+	"\bdecimals\x18\n" +
+	" \x01(\rR\bdecimals\x12\x1d\n" +
+	"\n" +
+	"block_hash\x18\v \x01(\tR\tblockHash\x12)\n" +
+	"\x10transaction_hash\x18\f \x01(\tR\x0ftransactionHash\x12\x1b\n" +
+	"\tlog_index\x18\r \x01(\x04R\blogIndex\"\xe5\x01\n" +
+	"\x13DepositTokenListReq\x12%\n" +
+	"\x0econsumer_token\x18\x01 \x01(\tR\rconsumerToken\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x04R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x04R\bpageSize\x12\x14\n" +
+	"\x05order\x18\x04 \x01(\tR\x05order\x122\n" +
+	"\x06filter\x18\x05 \x01(\v2\x1a.theweb3.event.EventFilterR\x06filter\x12\x16\n" +
+	"\x06cursor\x18\x06 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05count\x18\a \x01(\tR\x05count\"\x8e\x02\n" +
+	"\x13DepositTokenListRep\x12-\n" +
+	"\x04code\x18\x01 \x01(\x0e2\x19.theweb3.event.ReturnCodeR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12@\n" +
+	"\rdeposit_token\x18\x03 \x03(\v2\x1b.theweb3.event.DepositTokenR\fdepositToken\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x04R\x05total\x12\x1f\n" +
+	"\vnext_cursor\x18\x05 \x01(\tR\n" +
+	"nextCursor\x12\x1f\n" +
+	"\vprev_cursor\x18\x06 \x01(\tR\n" +
+	"prevCursor\x12\x14\n" +
+	"\x05count\x18\a \x01(\tR\x05count\"R\n" +
+	"\x15DepositTokenDetailReq\x12%\n" +
+	"\x0econsumer_token\x18\x01 \x01(\tR\rconsumerToken\x12\x12\n" +
+	"\x04guid\x18\x02 \x01(\tR\x04guid\"\xf3\x03\n" +
+	"\x15DepositTokenDetailRep\x12-\n" +
+	"\x04code\x18\x01 \x01(\x0e2\x19.theweb3.event.ReturnCodeR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x12\n" +
+	"\x04guid\x18\x03 \x01(\tR\x04guid\x12!\n" +
+	"\fblock_number\x18\x04 \x01(\x04R\vblockNumber\x12#\n" +
+	"\rtoken_address\x18\x05 \x01(\tR\ftokenAddress\x12\x16\n" +
+	"\x06sender\x18\x06 \x01(\tR\x06sender\x12\x1a\n" +
+	"\x06amount\x18\a \x01(\x04B\x02\x18\x01R\x06amount\x12\x1c\n" +
+	"\ttimestamp\x18\b \x01(\x04R\ttimestamp\x12\x1d\n" +
+	"\n" +
+	"amount_raw\x18\t \x01(\tR\tamountRaw\x12)\n" +
+	"\x10amount_formatted\x18\n" +
+	" \x01(\tR\x0famountFormatted\x12\x16\n" +
+	"\x06symbol\x18\v \x01(\tR\x06symbol\x12\x1a\n" +
+	"\bdecimals\x18\f \x01(\rR\bdecimals\x12\x1d\n" +
+	"\n" +
+	"block_hash\x18\r \x01(\tR\tblockHash\x12)\n" +
+	"\x10transaction_hash\x18\x0e \x01(\tR\x0ftransactionHash\x12\x1b\n" +
+	"\tlog_index\x18\x0f \x01(\x04R\blogIndex\"\xa2\x03\n" +
+	"\rWithdrawToken\x12\x12\n" +
+	"\x04guid\x18\x01 \x01(\tR\x04guid\x12!\n" +
+	"\fblock_number\x18\x02 \x01(\x04R\vblockNumber\x12\x1d\n" +
+	"\n" +
+	"block_hash\x18\x03 \x01(\tR\tblockHash\x12)\n" +
+	"\x10transaction_hash\x18\x04 \x01(\tR\x0ftransactionHash\x12\x1b\n" +
+	"\tlog_index\x18\x05 \x01(\x04R\blogIndex\x12#\n" +
+	"\rtoken_address\x18\x06 \x01(\tR\ftokenAddress\x12\x16\n" +
+	"\x06sender\x18\a \x01(\tR\x06sender\x12\x1a\n" +
+	"\breceiver\x18\b \x01(\tR\breceiver\x12\x1d\n" +
+	"\n" +
+	"amount_raw\x18\t \x01(\tR\tamountRaw\x12)\n" +
+	"\x10amount_formatted\x18\n" +
+	" \x01(\tR\x0famountFormatted\x12\x16\n" +
+	"\x06symbol\x18\v \x01(\tR\x06symbol\x12\x1a\n" +
+	"\bdecimals\x18\f \x01(\rR\bdecimals\x12\x1c\n" +
+	"\ttimestamp\x18\r \x01(\x04R\ttimestamp\"\xe6\x01\n" +
+	"\x14WithdrawTokenListReq\x12%\n" +
+	"\x0econsumer_token\x18\x01 \x01(\tR\rconsumerToken\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x04R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x04R\bpageSize\x12\x14\n" +
+	"\x05order\x18\x04 \x01(\tR\x05order\x122\n" +
+	"\x06filter\x18\x05 \x01(\v2\x1a.theweb3.event.EventFilterR\x06filter\x12\x16\n" +
+	"\x06cursor\x18\x06 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05count\x18\a \x01(\tR\x05count\"\x92\x02\n" +
+	"\x14WithdrawTokenListRep\x12-\n" +
+	"\x04code\x18\x01 \x01(\x0e2\x19.theweb3.event.ReturnCodeR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12C\n" +
+	"\x0ewithdraw_token\x18\x03 \x03(\v2\x1c.theweb3.event.WithdrawTokenR\rwithdrawToken\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x04R\x05total\x12\x1f\n" +
+	"\vnext_cursor\x18\x05 \x01(\tR\n" +
+	"nextCursor\x12\x1f\n" +
+	"\vprev_cursor\x18\x06 \x01(\tR\n" +
+	"prevCursor\x12\x14\n" +
+	"\x05count\x18\a \x01(\tR\x05count\"S\n" +
+	"\x16WithdrawTokenDetailReq\x12%\n" +
+	"\x0econsumer_token\x18\x01 \x01(\tR\rconsumerToken\x12\x12\n" +
+	"\x04guid\x18\x02 \x01(\tR\x04guid\"\xa6\x01\n" +
+	"\x16WithdrawTokenDetailRep\x12-\n" +
+	"\x04code\x18\x01 \x01(\x0e2\x19.theweb3.event.ReturnCodeR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12C\n" +
+	"\x0ewithdraw_token\x18\x03 \x01(\v2\x1c.theweb3.event.WithdrawTokenR\rwithdrawToken\"\x8b\x03\n" +
+	"\x10GrantRewardToken\x12\x12\n" +
+	"\x04guid\x18\x01 \x01(\tR\x04guid\x12!\n" +
+	"\fblock_number\x18\x02 \x01(\x04R\vblockNumber\x12\x1d\n" +
+	"\n" +
+	"block_hash\x18\x03 \x01(\tR\tblockHash\x12)\n" +
+	"\x10transaction_hash\x18\x04 \x01(\tR\x0ftransactionHash\x12\x1b\n" +
+	"\tlog_index\x18\x05 \x01(\x04R\blogIndex\x12#\n" +
+	"\rtoken_address\x18\x06 \x01(\tR\ftokenAddress\x12\x18\n" +
+	"\agranter\x18\a \x01(\tR\agranter\x12\x1d\n" +
+	"\n" +
+	"amount_raw\x18\b \x01(\tR\tamountRaw\x12)\n" +
+	"\x10amount_formatted\x18\t \x01(\tR\x0famountFormatted\x12\x16\n" +
+	"\x06symbol\x18\n" +
+	" \x01(\tR\x06symbol\x12\x1a\n" +
+	"\bdecimals\x18\v \x01(\rR\bdecimals\x12\x1c\n" +
+	"\ttimestamp\x18\f \x01(\x04R\ttimestamp\"\xe9\x01\n" +
+	"\x17GrantRewardTokenListReq\x12%\n" +
+	"\x0econsumer_token\x18\x01 \x01(\tR\rconsumerToken\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x04R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x04R\bpageSize\x12\x14\n" +
+	"\x05order\x18\x04 \x01(\tR\x05order\x122\n" +
+	"\x06filter\x18\x05 \x01(\v2\x1a.theweb3.event.EventFilterR\x06filter\x12\x16\n" +
+	"\x06cursor\x18\x06 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05count\x18\a \x01(\tR\x05count\"\x9f\x02\n" +
+	"\x17GrantRewardTokenListRep\x12-\n" +
+	"\x04code\x18\x01 \x01(\x0e2\x19.theweb3.event.ReturnCodeR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12M\n" +
+	"\x12grant_reward_token\x18\x03 \x03(\v2\x1f.theweb3.event.GrantRewardTokenR\x10grantRewardToken\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x04R\x05total\x12\x1f\n" +
+	"\vnext_cursor\x18\x05 \x01(\tR\n" +
+	"nextCursor\x12\x1f\n" +
+	"\vprev_cursor\x18\x06 \x01(\tR\n" +
+	"prevCursor\x12\x14\n" +
+	"\x05count\x18\a \x01(\tR\x05count\"V\n" +
+	"\x19GrantRewardTokenDetailReq\x12%\n" +
+	"\x0econsumer_token\x18\x01 \x01(\tR\rconsumerToken\x12\x12\n" +
+	"\x04guid\x18\x02 \x01(\tR\x04guid\"\xb3\x01\n" +
+	"\x19GrantRewardTokenDetailRep\x12-\n" +
+	"\x04code\x18\x01 \x01(\x0e2\x19.theweb3.event.ReturnCodeR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12M\n" +
+	"\x12grant_reward_token\x18\x03 \x01(\v2\x1f.theweb3.event.GrantRewardTokenR\x10grantRewardToken\"\xfe\x01\n" +
+	"\x15WithdrawManagerUpdate\x12\x12\n" +
+	"\x04guid\x18\x01 \x01(\tR\x04guid\x12!\n" +
+	"\fblock_number\x18\x02 \x01(\x04R\vblockNumber\x12\x1d\n" +
+	"\n" +
+	"block_hash\x18\x03 \x01(\tR\tblockHash\x12)\n" +
+	"\x10transaction_hash\x18\x04 \x01(\tR\x0ftransactionHash\x12\x1b\n" +
+	"\tlog_index\x18\x05 \x01(\x04R\blogIndex\x12)\n" +
+	"\x10withdraw_manager\x18\x06 \x01(\tR\x0fwithdrawManager\x12\x1c\n" +
+	"\ttimestamp\x18\a \x01(\x04R\ttimestamp\"\xee\x01\n" +
+	"\x1cWithdrawManagerUpdateListReq\x12%\n" +
+	"\x0econsumer_token\x18\x01 \x01(\tR\rconsumerToken\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x04R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x04R\bpageSize\x12\x14\n" +
+	"\x05order\x18\x04 \x01(\tR\x05order\x122\n" +
+	"\x06filter\x18\x05 \x01(\v2\x1a.theweb3.event.EventFilterR\x06filter\x12\x16\n" +
+	"\x06cursor\x18\x06 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05count\x18\a \x01(\tR\x05count\"\xb3\x02\n" +
+	"\x1cWithdrawManagerUpdateListRep\x12-\n" +
+	"\x04code\x18\x01 \x01(\x0e2\x19.theweb3.event.ReturnCodeR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\\\n" +
+	"\x17withdraw_manager_update\x18\x03 \x03(\v2$.theweb3.event.WithdrawManagerUpdateR\x15withdrawManagerUpdate\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x04R\x05total\x12\x1f\n" +
+	"\vnext_cursor\x18\x05 \x01(\tR\n" +
+	"nextCursor\x12\x1f\n" +
+	"\vprev_cursor\x18\x06 \x01(\tR\n" +
+	"prevCursor\x12\x14\n" +
+	"\x05count\x18\a \x01(\tR\x05count\"[\n" +
+	"\x1eWithdrawManagerUpdateDetailReq\x12%\n" +
+	"\x0econsumer_token\x18\x01 \x01(\tR\rconsumerToken\x12\x12\n" +
+	"\x04guid\x18\x02 \x01(\tR\x04guid\"\xc7\x01\n" +
+	"\x1eWithdrawManagerUpdateDetailRep\x12-\n" +
+	"\x04code\x18\x01 \x01(\x0e2\x19.theweb3.event.ReturnCodeR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\\\n" +
+	"\x17withdraw_manager_update\x18\x03 \x01(\v2$.theweb3.event.WithdrawManagerUpdateR\x15withdrawManagerUpdate\"\xe1\x03\n" +
+	"\rRewardBalance\x12!\n" +
+	"\fuser_address\x18\x01 \x01(\tR\vuserAddress\x12#\n" +
+	"\rtoken_address\x18\x02 \x01(\tR\ftokenAddress\x12\x18\n" +
+	"\agranted\x18\x03 \x01(\tR\agranted\x12\x18\n" +
+	"\aclaimed\x18\x04 \x01(\tR\aclaimed\x12\x1c\n" +
+	"\tclaimable\x18\x05 \x01(\tR\tclaimable\x12!\n" +
+	"\fblock_number\x18\x06 \x01(\x04R\vblockNumber\x126\n" +
+	"\x17reconciled_block_number\x18\a \x01(\x04R\x15reconciledBlockNumber\x12\x1c\n" +
+	"\ttimestamp\x18\b \x01(\x04R\ttimestamp\x12+\n" +
+	"\x11granted_formatted\x18\t \x01(\tR\x10grantedFormatted\x12+\n" +
+	"\x11claimed_formatted\x18\n" +
+	" \x01(\tR\x10claimedFormatted\x12/\n" +
+	"\x13claimable_formatted\x18\v \x01(\tR\x12claimableFormatted\x12\x16\n" +
+	"\x06symbol\x18\f \x01(\tR\x06symbol\x12\x1a\n" +
+	"\bdecimals\x18\r \x01(\rR\bdecimals\"\xe5\x02\n" +
+	"\x11RewardLedgerEntry\x12\x12\n" +
+	"\x04guid\x18\x01 \x01(\tR\x04guid\x12!\n" +
+	"\fuser_address\x18\x02 \x01(\tR\vuserAddress\x12#\n" +
+	"\rtoken_address\x18\x03 \x01(\tR\ftokenAddress\x12\x1d\n" +
+	"\n" +
+	"entry_type\x18\x04 \x01(\tR\tentryType\x12\x16\n" +
+	"\x06amount\x18\x05 \x01(\tR\x06amount\x12!\n" +
+	"\fblock_number\x18\x06 \x01(\x04R\vblockNumber\x12\x1d\n" +
+	"\n" +
+	"block_hash\x18\a \x01(\tR\tblockHash\x12\x1c\n" +
+	"\ttimestamp\x18\b \x01(\x04R\ttimestamp\x12)\n" +
+	"\x10amount_formatted\x18\t \x01(\tR\x0famountFormatted\x12\x16\n" +
+	"\x06symbol\x18\n" +
+	" \x01(\tR\x06symbol\x12\x1a\n" +
+	"\bdecimals\x18\v \x01(\rR\bdecimals\"\x83\x01\n" +
+	"\x0fRewardLedgerReq\x12%\n" +
+	"\x0econsumer_token\x18\x01 \x01(\tR\rconsumerToken\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\tR\aaddress\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x04R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x04R\bpageSize\"\xe0\x01\n" +
+	"\x0fRewardLedgerRep\x12-\n" +
+	"\x04code\x18\x01 \x01(\x0e2\x19.theweb3.event.ReturnCodeR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x126\n" +
+	"\abalance\x18\x03 \x03(\v2\x1c.theweb3.event.RewardBalanceR\abalance\x126\n" +
+	"\x05entry\x18\x04 \x03(\v2 .theweb3.event.RewardLedgerEntryR\x05entry\x12\x14\n" +
+	"\x05total\x18\x05 \x01(\x04R\x05total\"\xc3\x03\n" +
+	"\x0fAddressActivity\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x01 \x01(\tR\teventType\x12\x12\n" +
+	"\x04guid\x18\x02 \x01(\tR\x04guid\x12!\n" +
+	"\fblock_number\x18\x03 \x01(\x04R\vblockNumber\x12\x1d\n" +
+	"\n" +
+	"block_hash\x18\x04 \x01(\tR\tblockHash\x12)\n" +
+	"\x10transaction_hash\x18\x05 \x01(\tR\x0ftransactionHash\x12\x1b\n" +
+	"\tlog_index\x18\x06 \x01(\x04R\blogIndex\x12#\n" +
+	"\rtoken_address\x18\a \x01(\tR\ftokenAddress\x12\x16\n" +
+	"\x06sender\x18\b \x01(\tR\x06sender\x12\x1a\n" +
+	"\breceiver\x18\t \x01(\tR\breceiver\x12\x1d\n" +
+	"\n" +
+	"amount_raw\x18\n" +
+	" \x01(\tR\tamountRaw\x12)\n" +
+	"\x10amount_formatted\x18\v \x01(\tR\x0famountFormatted\x12\x16\n" +
+	"\x06symbol\x18\f \x01(\tR\x06symbol\x12\x1a\n" +
+	"\bdecimals\x18\r \x01(\rR\bdecimals\x12\x1c\n" +
+	"\ttimestamp\x18\x0e \x01(\x04R\ttimestamp\"\xde\x03\n" +
+	"\x13AddressTokenSummary\x12#\n" +
+	"\rtoken_address\x18\x01 \x01(\tR\ftokenAddress\x12#\n" +
+	"\rdeposit_count\x18\x02 \x01(\x04R\fdepositCount\x12\x1c\n" +
+	"\tdeposited\x18\x03 \x01(\tR\tdeposited\x12/\n" +
+	"\x13deposited_formatted\x18\x04 \x01(\tR\x12depositedFormatted\x12%\n" +
+	"\x0ewithdraw_count\x18\x05 \x01(\x04R\rwithdrawCount\x12\x1c\n" +
+	"\twithdrawn\x18\x06 \x01(\tR\twithdrawn\x12/\n" +
+	"\x13withdrawn_formatted\x18\a \x01(\tR\x12withdrawnFormatted\x12#\n" +
+	"\rgranter_count\x18\b \x01(\x04R\fgranterCount\x12%\n" +
+	"\x0egranter_amount\x18\t \x01(\tR\rgranterAmount\x128\n" +
+	"\x18granter_amount_formatted\x18\n" +
+	" \x01(\tR\x16granterAmountFormatted\x12\x16\n" +
+	"\x06symbol\x18\v \x01(\tR\x06symbol\x12\x1a\n" +
+	"\bdecimals\x18\f \x01(\rR\bdecimals\"\xfe\x01\n" +
+	"\x12AddressActivityReq\x12%\n" +
+	"\x0econsumer_token\x18\x01 \x01(\tR\rconsumerToken\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\tR\aaddress\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x04R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x04R\bpageSize\x12\x14\n" +
+	"\x05order\x18\x05 \x01(\tR\x05order\x122\n" +
+	"\x06filter\x18\x06 \x01(\v2\x1a.theweb3.event.EventFilterR\x06filter\x12\x16\n" +
+	"\x06cursor\x18\a \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05count\x18\b \x01(\tR\x05count\"\xc5\x02\n" +
+	"\x12AddressActivityRep\x12-\n" +
+	"\x04code\x18\x01 \x01(\x0e2\x19.theweb3.event.ReturnCodeR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12<\n" +
+	"\asummary\x18\x03 \x03(\v2\".theweb3.event.AddressTokenSummaryR\asummary\x12:\n" +
+	"\bactivity\x18\x04 \x03(\v2\x1e.theweb3.event.AddressActivityR\bactivity\x12\x14\n" +
+	"\x05total\x18\x05 \x01(\x04R\x05total\x12\x1f\n" +
+	"\vnext_cursor\x18\x06 \x01(\tR\n" +
+	"nextCursor\x12\x1f\n" +
+	"\vprev_cursor\x18\a \x01(\tR\n" +
+	"prevCursor\x12\x14\n" +
+	"\x05count\x18\b \x01(\tR\x05count\"\xbf\x02\n" +
+	"\x12SubscribeEventsReq\x12%\n" +
+	"\x0econsumer_token\x18\x01 \x01(\tR\rconsumerToken\x12*\n" +
+	"\x11from_block_number\x18\x02 \x01(\x04R\x0ffromBlockNumber\x12$\n" +
+	"\x0efrom_log_index\x18\x03 \x01(\x04R\ffromLogIndex\x12\x1f\n" +
+	"\vfrom_latest\x18\x04 \x01(\bR\n" +
+	"fromLatest\x12\x1f\n" +
+	"\vevent_types\x18\x05 \x03(\tR\n" +
+	"eventTypes\x12\x1c\n" +
+	"\taddresses\x18\x06 \x03(\tR\taddresses\x12#\n" +
+	"\rtoken_address\x18\a \x01(\tR\ftokenAddress\x12+\n" +
+	"\x11heartbeat_seconds\x18\b \x01(\x04R\x10heartbeatSeconds\"\xb5\x02\n" +
+	"\vStreamEvent\x12\x1a\n" +
+	"\bsequence\x18\x01 \x01(\x04R\bsequence\x12)\n" +
+	"\x10contract_address\x18\x02 \x01(\tR\x0fcontractAddress\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x03 \x01(\tR\teventType\x12!\n" +
+	"\fblock_number\x18\x04 \x01(\x04R\vblockNumber\x12\x1d\n" +
+	"\n" +
+	"block_hash\x18\x05 \x01(\tR\tblockHash\x12)\n" +
+	"\x10transaction_hash\x18\x06 \x01(\tR\x0ftransactionHash\x12\x1b\n" +
+	"\tlog_index\x18\a \x01(\x04R\blogIndex\x12\x18\n" +
+	"\apayload\x18\b \x01(\tR\apayload\x12\x1c\n" +
+	"\ttimestamp\x18\t \x01(\x04R\ttimestamp\"\x8f\x02\n" +
+	"\x12SubscribeEventsRep\x12-\n" +
+	"\x04code\x18\x01 \x01(\x0e2\x19.theweb3.event.ReturnCodeR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x124\n" +
+	"\x04type\x18\x03 \x01(\x0e2 .theweb3.event.StreamMessageTypeR\x04type\x120\n" +
+	"\x05event\x18\x04 \x01(\v2\x1a.theweb3.event.StreamEventR\x05event\x12'\n" +
+	"\x0flatest_sequence\x18\x05 \x01(\x04R\x0elatestSequence\x12\x1f\n" +
+	"\vserver_time\x18\x06 \x01(\x04R\n" +
+	"serverTime*$\n" +
 	"\n" +
 	"ReturnCode\x12\t\n" +
 	"\x05ERROR\x10\x00\x12\v\n" +
-	"\aSUCCESS\x10\x012\xd6\x01\n" +
+	"\aSUCCESS\x10\x01*[\n" +
+	"\x11StreamMessageType\x12\x16\n" +
+	"\x12STREAM_EVENT_ADDED\x10\x00\x12\x18\n" +
+	"\x14STREAM_EVENT_REMOVED\x10\x01\x12\x14\n" +
+	"\x10STREAM_HEARTBEAT\x10\x022\x93\t\n" +
 	"\fEventService\x12_\n" +
 	"\x13getDepositTokenList\x12\".theweb3.event.DepositTokenListReq\x1a\".theweb3.event.DepositTokenListRep\"\x00\x12e\n" +
-	"\x15getDepositTokenDetail\x12$.theweb3.event.DepositTokenDetailReq\x1a$.theweb3.event.DepositTokenDetailRep\"\x00B\x19Z\x17./services/grpc/eventpbb\x06proto3"
+	"\x15getDepositTokenDetail\x12$.theweb3.event.DepositTokenDetailReq\x1a$.theweb3.event.DepositTokenDetailRep\"\x00\x12b\n" +
+	"\x14getWithdrawTokenList\x12#.theweb3.event.WithdrawTokenListReq\x1a#.theweb3.event.WithdrawTokenListRep\"\x00\x12h\n" +
+	"\x16getWithdrawTokenDetail\x12%.theweb3.event.WithdrawTokenDetailReq\x1a%.theweb3.event.WithdrawTokenDetailRep\"\x00\x12k\n" +
+	"\x17getGrantRewardTokenList\x12&.theweb3.event.GrantRewardTokenListReq\x1a&.theweb3.event.GrantRewardTokenListRep\"\x00\x12q\n" +
+	"\x19getGrantRewardTokenDetail\x12(.theweb3.event.GrantRewardTokenDetailReq\x1a(.theweb3.event.GrantRewardTokenDetailRep\"\x00\x12z\n" +
+	"\x1cgetWithdrawManagerUpdateList\x12+.theweb3.event.WithdrawManagerUpdateListReq\x1a+.theweb3.event.WithdrawManagerUpdateListRep\"\x00\x12\x80\x01\n" +
+	"\x1egetWithdrawManagerUpdateDetail\x12-.theweb3.event.WithdrawManagerUpdateDetailReq\x1a-.theweb3.event.WithdrawManagerUpdateDetailRep\"\x00\x12S\n" +
+	"\x0fgetRewardLedger\x12\x1e.theweb3.event.RewardLedgerReq\x1a\x1e.theweb3.event.RewardLedgerRep\"\x00\x12\\\n" +
+	"\x12getAddressActivity\x12!.theweb3.event.AddressActivityReq\x1a!.theweb3.event.AddressActivityRep\"\x00\x12[\n" +
+	"\x0fsubscribeEvents\x12!.theweb3.event.SubscribeEventsReq\x1a!.theweb3.event.SubscribeEventsRep\"\x000\x01B\x19Z\x17./services/grpc/eventpbb\x06proto3"
 
 var (
 	file_services_grpc_protobuf_event_sync_proto_rawDescOnce sync.Once
@@ -475,29 +3541,101 @@ func file_services_grpc_protobuf_event_sync_proto_rawDescGZIP() []byte {
 	return file_services_grpc_protobuf_event_sync_proto_rawDescData
 }
 
-var file_services_grpc_protobuf_event_sync_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_services_grpc_protobuf_event_sync_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_services_grpc_protobuf_event_sync_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_services_grpc_protobuf_event_sync_proto_msgTypes = make([]protoimpl.MessageInfo, 32)
 var file_services_grpc_protobuf_event_sync_proto_goTypes = []any{
-	(ReturnCode)(0),               // 0: theweb3.event.ReturnCode
-	(*DepositToken)(nil),          // 1: theweb3.event.DepositToken
-	(*DepositTokenListReq)(nil),   // 2: theweb3.event.DepositTokenListReq
-	(*DepositTokenListRep)(nil),   // 3: theweb3.event.DepositTokenListRep
-	(*DepositTokenDetailReq)(nil), // 4: theweb3.event.DepositTokenDetailReq
-	(*DepositTokenDetailRep)(nil), // 5: theweb3.event.DepositTokenDetailRep
+	(ReturnCode)(0),                        // 0: theweb3.event.ReturnCode
+	(StreamMessageType)(0),                 // 1: theweb3.event.StreamMessageType
+	(*EventFilter)(nil),                    // 2: theweb3.event.EventFilter
+	(*DepositToken)(nil),                   // 3: theweb3.event.DepositToken
+	(*DepositTokenListReq)(nil),            // 4: theweb3.event.DepositTokenListReq
+	(*DepositTokenListRep)(nil),            // 5: theweb3.event.DepositTokenListRep
+	(*DepositTokenDetailReq)(nil),          // 6: theweb3.event.DepositTokenDetailReq
+	(*DepositTokenDetailRep)(nil),          // 7: theweb3.event.DepositTokenDetailRep
+	(*WithdrawToken)(nil),                  // 8: theweb3.event.WithdrawToken
+	(*WithdrawTokenListReq)(nil),           // 9: theweb3.event.WithdrawTokenListReq
+	(*WithdrawTokenListRep)(nil),           // 10: theweb3.event.WithdrawTokenListRep
+	(*WithdrawTokenDetailReq)(nil),         // 11: theweb3.event.WithdrawTokenDetailReq
+	(*WithdrawTokenDetailRep)(nil),         // 12: theweb3.event.WithdrawTokenDetailRep
+	(*GrantRewardToken)(nil),               // 13: theweb3.event.GrantRewardToken
+	(*GrantRewardTokenListReq)(nil),        // 14: theweb3.event.GrantRewardTokenListReq
+	(*GrantRewardTokenListRep)(nil),        // 15: theweb3.event.GrantRewardTokenListRep
+	(*GrantRewardTokenDetailReq)(nil),      // 16: theweb3.event.GrantRewardTokenDetailReq
+	(*GrantRewardTokenDetailRep)(nil),      // 17: theweb3.event.GrantRewardTokenDetailRep
+	(*WithdrawManagerUpdate)(nil),          // 18: theweb3.event.WithdrawManagerUpdate
+	(*WithdrawManagerUpdateListReq)(nil),   // 19: theweb3.event.WithdrawManagerUpdateListReq
+	(*WithdrawManagerUpdateListRep)(nil),   // 20: theweb3.event.WithdrawManagerUpdateListRep
+	(*WithdrawManagerUpdateDetailReq)(nil), // 21: theweb3.event.WithdrawManagerUpdateDetailReq
+	(*WithdrawManagerUpdateDetailRep)(nil), // 22: theweb3.event.WithdrawManagerUpdateDetailRep
+	(*RewardBalance)(nil),                  // 23: theweb3.event.RewardBalance
+	(*RewardLedgerEntry)(nil),              // 24: theweb3.event.RewardLedgerEntry
+	(*RewardLedgerReq)(nil),                // 25: theweb3.event.RewardLedgerReq
+	(*RewardLedgerRep)(nil),                // 26: theweb3.event.RewardLedgerRep
+	(*AddressActivity)(nil),                // 27: theweb3.event.AddressActivity
+	(*AddressTokenSummary)(nil),            // 28: theweb3.event.AddressTokenSummary
+	(*AddressActivityReq)(nil),             // 29: theweb3.event.AddressActivityReq
+	(*AddressActivityRep)(nil),             // 30: theweb3.event.AddressActivityRep
+	(*SubscribeEventsReq)(nil),             // 31: theweb3.event.SubscribeEventsReq
+	(*StreamEvent)(nil),                    // 32: theweb3.event.StreamEvent
+	(*SubscribeEventsRep)(nil),             // 33: theweb3.event.SubscribeEventsRep
 }
 var file_services_grpc_protobuf_event_sync_proto_depIdxs = []int32{
-	0, // 0: theweb3.event.DepositTokenListRep.code:type_name -> theweb3.event.ReturnCode
-	1, // 1: theweb3.event.DepositTokenListRep.deposit_token:type_name -> theweb3.event.DepositToken
-	0, // 2: theweb3.event.DepositTokenDetailRep.code:type_name -> theweb3.event.ReturnCode
-	2, // 3: theweb3.event.EventService.getDepositTokenList:input_type -> theweb3.event.DepositTokenListReq
-	4, // 4: theweb3.event.EventService.getDepositTokenDetail:input_type -> theweb3.event.DepositTokenDetailReq
-	3, // 5: theweb3.event.EventService.getDepositTokenList:output_type -> theweb3.event.DepositTokenListRep
-	5, // 6: theweb3.event.EventService.getDepositTokenDetail:output_type -> theweb3.event.DepositTokenDetailRep
-	5, // [5:7] is the sub-list for method output_type
-	3, // [3:5] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	2,  // 0: theweb3.event.DepositTokenListReq.filter:type_name -> theweb3.event.EventFilter
+	0,  // 1: theweb3.event.DepositTokenListRep.code:type_name -> theweb3.event.ReturnCode
+	3,  // 2: theweb3.event.DepositTokenListRep.deposit_token:type_name -> theweb3.event.DepositToken
+	0,  // 3: theweb3.event.DepositTokenDetailRep.code:type_name -> theweb3.event.ReturnCode
+	2,  // 4: theweb3.event.WithdrawTokenListReq.filter:type_name -> theweb3.event.EventFilter
+	0,  // 5: theweb3.event.WithdrawTokenListRep.code:type_name -> theweb3.event.ReturnCode
+	8,  // 6: theweb3.event.WithdrawTokenListRep.withdraw_token:type_name -> theweb3.event.WithdrawToken
+	0,  // 7: theweb3.event.WithdrawTokenDetailRep.code:type_name -> theweb3.event.ReturnCode
+	8,  // 8: theweb3.event.WithdrawTokenDetailRep.withdraw_token:type_name -> theweb3.event.WithdrawToken
+	2,  // 9: theweb3.event.GrantRewardTokenListReq.filter:type_name -> theweb3.event.EventFilter
+	0,  // 10: theweb3.event.GrantRewardTokenListRep.code:type_name -> theweb3.event.ReturnCode
+	13, // 11: theweb3.event.GrantRewardTokenListRep.grant_reward_token:type_name -> theweb3.event.GrantRewardToken
+	0,  // 12: theweb3.event.GrantRewardTokenDetailRep.code:type_name -> theweb3.event.ReturnCode
+	13, // 13: theweb3.event.GrantRewardTokenDetailRep.grant_reward_token:type_name -> theweb3.event.GrantRewardToken
+	2,  // 14: theweb3.event.WithdrawManagerUpdateListReq.filter:type_name -> theweb3.event.EventFilter
+	0,  // 15: theweb3.event.WithdrawManagerUpdateListRep.code:type_name -> theweb3.event.ReturnCode
+	18, // 16: theweb3.event.WithdrawManagerUpdateListRep.withdraw_manager_update:type_name -> theweb3.event.WithdrawManagerUpdate
+	0,  // 17: theweb3.event.WithdrawManagerUpdateDetailRep.code:type_name -> theweb3.event.ReturnCode
+	18, // 18: theweb3.event.WithdrawManagerUpdateDetailRep.withdraw_manager_update:type_name -> theweb3.event.WithdrawManagerUpdate
+	0,  // 19: theweb3.event.RewardLedgerRep.code:type_name -> theweb3.event.ReturnCode
+	23, // 20: theweb3.event.RewardLedgerRep.balance:type_name -> theweb3.event.RewardBalance
+	24, // 21: theweb3.event.RewardLedgerRep.entry:type_name -> theweb3.event.RewardLedgerEntry
+	2,  // 22: theweb3.event.AddressActivityReq.filter:type_name -> theweb3.event.EventFilter
+	0,  // 23: theweb3.event.AddressActivityRep.code:type_name -> theweb3.event.ReturnCode
+	28, // 24: theweb3.event.AddressActivityRep.summary:type_name -> theweb3.event.AddressTokenSummary
+	27, // 25: theweb3.event.AddressActivityRep.activity:type_name -> theweb3.event.AddressActivity
+	0,  // 26: theweb3.event.SubscribeEventsRep.code:type_name -> theweb3.event.ReturnCode
+	1,  // 27: theweb3.event.SubscribeEventsRep.type:type_name -> theweb3.event.StreamMessageType
+	32, // 28: theweb3.event.SubscribeEventsRep.event:type_name -> theweb3.event.StreamEvent
+	4,  // 29: theweb3.event.EventService.getDepositTokenList:input_type -> theweb3.event.DepositTokenListReq
+	6,  // 30: theweb3.event.EventService.getDepositTokenDetail:input_type -> theweb3.event.DepositTokenDetailReq
+	9,  // 31: theweb3.event.EventService.getWithdrawTokenList:input_type -> theweb3.event.WithdrawTokenListReq
+	11, // 32: theweb3.event.EventService.getWithdrawTokenDetail:input_type -> theweb3.event.WithdrawTokenDetailReq
+	14, // 33: theweb3.event.EventService.getGrantRewardTokenList:input_type -> theweb3.event.GrantRewardTokenListReq
+	16, // 34: theweb3.event.EventService.getGrantRewardTokenDetail:input_type -> theweb3.event.GrantRewardTokenDetailReq
+	19, // 35: theweb3.event.EventService.getWithdrawManagerUpdateList:input_type -> theweb3.event.WithdrawManagerUpdateListReq
+	21, // 36: theweb3.event.EventService.getWithdrawManagerUpdateDetail:input_type -> theweb3.event.WithdrawManagerUpdateDetailReq
+	25, // 37: theweb3.event.EventService.getRewardLedger:input_type -> theweb3.event.RewardLedgerReq
+	29, // 38: theweb3.event.EventService.getAddressActivity:input_type -> theweb3.event.AddressActivityReq
+	31, // 39: theweb3.event.EventService.subscribeEvents:input_type -> theweb3.event.SubscribeEventsReq
+	5,  // 40: theweb3.event.EventService.getDepositTokenList:output_type -> theweb3.event.DepositTokenListRep
+	7,  // 41: theweb3.event.EventService.getDepositTokenDetail:output_type -> theweb3.event.DepositTokenDetailRep
+	10, // 42: theweb3.event.EventService.getWithdrawTokenList:output_type -> theweb3.event.WithdrawTokenListRep
+	12, // 43: theweb3.event.EventService.getWithdrawTokenDetail:output_type -> theweb3.event.WithdrawTokenDetailRep
+	15, // 44: theweb3.event.EventService.getGrantRewardTokenList:output_type -> theweb3.event.GrantRewardTokenListRep
+	17, // 45: theweb3.event.EventService.getGrantRewardTokenDetail:output_type -> theweb3.event.GrantRewardTokenDetailRep
+	20, // 46: theweb3.event.EventService.getWithdrawManagerUpdateList:output_type -> theweb3.event.WithdrawManagerUpdateListRep
+	22, // 47: theweb3.event.EventService.getWithdrawManagerUpdateDetail:output_type -> theweb3.event.WithdrawManagerUpdateDetailRep
+	26, // 48: theweb3.event.EventService.getRewardLedger:output_type -> theweb3.event.RewardLedgerRep
+	30, // 49: theweb3.event.EventService.getAddressActivity:output_type -> theweb3.event.AddressActivityRep
+	33, // 50: theweb3.event.EventService.subscribeEvents:output_type -> theweb3.event.SubscribeEventsRep
+	40, // [40:51] is the sub-list for method output_type
+	29, // [29:40] is the sub-list for method input_type
+	29, // [29:29] is the sub-list for extension type_name
+	29, // [29:29] is the sub-list for extension extendee
+	0,  // [0:29] is the sub-list for field type_name
 }
 
 func init() { file_services_grpc_protobuf_event_sync_proto_init() }
@@ -510,8 +3648,8 @@ func file_services_grpc_protobuf_event_sync_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_services_grpc_protobuf_event_sync_proto_rawDesc), len(file_services_grpc_protobuf_event_sync_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   5,
+			NumEnums:      2,
+			NumMessages:   32,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,8 +19,17 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	EventService_GetDepositTokenList_FullMethodName   = "/theweb3.event.EventService/getDepositTokenList"
-	EventService_GetDepositTokenDetail_FullMethodName = "/theweb3.event.EventService/getDepositTokenDetail"
+	EventService_GetDepositTokenList_FullMethodName            = "/theweb3.event.EventService/getDepositTokenList"
+	EventService_GetDepositTokenDetail_FullMethodName          = "/theweb3.event.EventService/getDepositTokenDetail"
+	EventService_GetWithdrawTokenList_FullMethodName           = "/theweb3.event.EventService/getWithdrawTokenList"
+	EventService_GetWithdrawTokenDetail_FullMethodName         = "/theweb3.event.EventService/getWithdrawTokenDetail"
+	EventService_GetGrantRewardTokenList_FullMethodName        = "/theweb3.event.EventService/getGrantRewardTokenList"
+	EventService_GetGrantRewardTokenDetail_FullMethodName      = "/theweb3.event.EventService/getGrantRewardTokenDetail"
+	EventService_GetWithdrawManagerUpdateList_FullMethodName   = "/theweb3.event.EventService/getWithdrawManagerUpdateList"
+	EventService_GetWithdrawManagerUpdateDetail_FullMethodName = "/theweb3.event.EventService/getWithdrawManagerUpdateDetail"
+	EventService_GetRewardLedger_FullMethodName                = "/theweb3.event.EventService/getRewardLedger"
+	EventService_GetAddressActivity_FullMethodName             = "/theweb3.event.EventService/getAddressActivity"
+	EventService_SubscribeEvents_FullMethodName                = "/theweb3.event.EventService/subscribeEvents"
 )
 
 // EventServiceClient is the client API for EventService service.
@@ -29,6 +38,15 @@ const (
 type EventServiceClient interface {
 	GetDepositTokenList(ctx context.Context, in *DepositTokenListReq, opts ...grpc.CallOption) (*DepositTokenListRep, error)
 	GetDepositTokenDetail(ctx context.Context, in *DepositTokenDetailReq, opts ...grpc.CallOption) (*DepositTokenDetailRep, error)
+	GetWithdrawTokenList(ctx context.Context, in *WithdrawTokenListReq, opts ...grpc.CallOption) (*WithdrawTokenListRep, error)
+	GetWithdrawTokenDetail(ctx context.Context, in *WithdrawTokenDetailReq, opts ...grpc.CallOption) (*WithdrawTokenDetailRep, error)
+	GetGrantRewardTokenList(ctx context.Context, in *GrantRewardTokenListReq, opts ...grpc.CallOption) (*GrantRewardTokenListRep, error)
+	GetGrantRewardTokenDetail(ctx context.Context, in *GrantRewardTokenDetailReq, opts ...grpc.CallOption) (*GrantRewardTokenDetailRep, error)
+	GetWithdrawManagerUpdateList(ctx context.Context, in *WithdrawManagerUpdateListReq, opts ...grpc.CallOption) (*WithdrawManagerUpdateListRep, error)
+	GetWithdrawManagerUpdateDetail(ctx context.Context, in *WithdrawManagerUpdateDetailReq, opts ...grpc.CallOption) (*WithdrawManagerUpdateDetailRep, error)
+	GetRewardLedger(ctx context.Context, in *RewardLedgerReq, opts ...grpc.CallOption) (*RewardLedgerRep, error)
+	GetAddressActivity(ctx context.Context, in *AddressActivityReq, opts ...grpc.CallOption) (*AddressActivityRep, error)
+	SubscribeEvents(ctx context.Context, in *SubscribeEventsReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SubscribeEventsRep], error)
 }
 
 type eventServiceClient struct {
@@ -59,12 +77,120 @@ func (c *eventServiceClient) GetDepositTokenDetail(ctx context.Context, in *Depo
 	return out, nil
 }
 
+func (c *eventServiceClient) GetWithdrawTokenList(ctx context.Context, in *WithdrawTokenListReq, opts ...grpc.CallOption) (*WithdrawTokenListRep, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WithdrawTokenListRep)
+	err := c.cc.Invoke(ctx, EventService_GetWithdrawTokenList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceClient) GetWithdrawTokenDetail(ctx context.Context, in *WithdrawTokenDetailReq, opts ...grpc.CallOption) (*WithdrawTokenDetailRep, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WithdrawTokenDetailRep)
+	err := c.cc.Invoke(ctx, EventService_GetWithdrawTokenDetail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceClient) GetGrantRewardTokenList(ctx context.Context, in *GrantRewardTokenListReq, opts ...grpc.CallOption) (*GrantRewardTokenListRep, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GrantRewardTokenListRep)
+	err := c.cc.Invoke(ctx, EventService_GetGrantRewardTokenList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceClient) GetGrantRewardTokenDetail(ctx context.Context, in *GrantRewardTokenDetailReq, opts ...grpc.CallOption) (*GrantRewardTokenDetailRep, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GrantRewardTokenDetailRep)
+	err := c.cc.Invoke(ctx, EventService_GetGrantRewardTokenDetail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceClient) GetWithdrawManagerUpdateList(ctx context.Context, in *WithdrawManagerUpdateListReq, opts ...grpc.CallOption) (*WithdrawManagerUpdateListRep, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WithdrawManagerUpdateListRep)
+	err := c.cc.Invoke(ctx, EventService_GetWithdrawManagerUpdateList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceClient) GetWithdrawManagerUpdateDetail(ctx context.Context, in *WithdrawManagerUpdateDetailReq, opts ...grpc.CallOption) (*WithdrawManagerUpdateDetailRep, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WithdrawManagerUpdateDetailRep)
+	err := c.cc.Invoke(ctx, EventService_GetWithdrawManagerUpdateDetail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceClient) GetRewardLedger(ctx context.Context, in *RewardLedgerReq, opts ...grpc.CallOption) (*RewardLedgerRep, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RewardLedgerRep)
+	err := c.cc.Invoke(ctx, EventService_GetRewardLedger_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceClient) GetAddressActivity(ctx context.Context, in *AddressActivityReq, opts ...grpc.CallOption) (*AddressActivityRep, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddressActivityRep)
+	err := c.cc.Invoke(ctx, EventService_GetAddressActivity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceClient) SubscribeEvents(ctx context.Context, in *SubscribeEventsReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SubscribeEventsRep], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &EventService_ServiceDesc.Streams[0], EventService_SubscribeEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeEventsReq, SubscribeEventsRep]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type EventService_SubscribeEventsClient = grpc.ServerStreamingClient[SubscribeEventsRep]
+
 // EventServiceServer is the server API for EventService service.
 // All implementations should embed UnimplementedEventServiceServer
 // for forward compatibility.
 type EventServiceServer interface {
 	GetDepositTokenList(context.Context, *DepositTokenListReq) (*DepositTokenListRep, error)
 	GetDepositTokenDetail(context.Context, *DepositTokenDetailReq) (*DepositTokenDetailRep, error)
+	GetWithdrawTokenList(context.Context, *WithdrawTokenListReq) (*WithdrawTokenListRep, error)
+	GetWithdrawTokenDetail(context.Context, *WithdrawTokenDetailReq) (*WithdrawTokenDetailRep, error)
+	GetGrantRewardTokenList(context.Context, *GrantRewardTokenListReq) (*GrantRewardTokenListRep, error)
+	GetGrantRewardTokenDetail(context.Context, *GrantRewardTokenDetailReq) (*GrantRewardTokenDetailRep, error)
+	GetWithdrawManagerUpdateList(context.Context, *WithdrawManagerUpdateListReq) (*WithdrawManagerUpdateListRep, error)
+	GetWithdrawManagerUpdateDetail(context.Context, *WithdrawManagerUpdateDetailReq) (*WithdrawManagerUpdateDetailRep, error)
+	GetRewardLedger(context.Context, *RewardLedgerReq) (*RewardLedgerRep, error)
+	GetAddressActivity(context.Context, *AddressActivityReq) (*AddressActivityRep, error)
+	SubscribeEvents(*SubscribeEventsReq, grpc.ServerStreamingServer[SubscribeEventsRep]) error
 }
 
 // UnimplementedEventServiceServer should be embedded to have
@@ -80,6 +206,33 @@ func (UnimplementedEventServiceServer) GetDepositTokenList(context.Context, *Dep
 func (UnimplementedEventServiceServer) GetDepositTokenDetail(context.Context, *DepositTokenDetailReq) (*DepositTokenDetailRep, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetDepositTokenDetail not implemented")
 }
+func (UnimplementedEventServiceServer) GetWithdrawTokenList(context.Context, *WithdrawTokenListReq) (*WithdrawTokenListRep, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWithdrawTokenList not implemented")
+}
+func (UnimplementedEventServiceServer) GetWithdrawTokenDetail(context.Context, *WithdrawTokenDetailReq) (*WithdrawTokenDetailRep, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWithdrawTokenDetail not implemented")
+}
+func (UnimplementedEventServiceServer) GetGrantRewardTokenList(context.Context, *GrantRewardTokenListReq) (*GrantRewardTokenListRep, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGrantRewardTokenList not implemented")
+}
+func (UnimplementedEventServiceServer) GetGrantRewardTokenDetail(context.Context, *GrantRewardTokenDetailReq) (*GrantRewardTokenDetailRep, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGrantRewardTokenDetail not implemented")
+}
+func (UnimplementedEventServiceServer) GetWithdrawManagerUpdateList(context.Context, *WithdrawManagerUpdateListReq) (*WithdrawManagerUpdateListRep, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWithdrawManagerUpdateList not implemented")
+}
+func (UnimplementedEventServiceServer) GetWithdrawManagerUpdateDetail(context.Context, *WithdrawManagerUpdateDetailReq) (*WithdrawManagerUpdateDetailRep, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWithdrawManagerUpdateDetail not implemented")
+}
+func (UnimplementedEventServiceServer) GetRewardLedger(context.Context, *RewardLedgerReq) (*RewardLedgerRep, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRewardLedger not implemented")
+}
+func (UnimplementedEventServiceServer) GetAddressActivity(context.Context, *AddressActivityReq) (*AddressActivityRep, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAddressActivity not implemented")
+}
+func (UnimplementedEventServiceServer) SubscribeEvents(*SubscribeEventsReq, grpc.ServerStreamingServer[SubscribeEventsRep]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeEvents not implemented")
+}
 func (UnimplementedEventServiceServer) testEmbeddedByValue() {}
 
 // UnsafeEventServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -136,6 +289,161 @@ func _EventService_GetDepositTokenDetail_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _EventService_GetWithdrawTokenList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WithdrawTokenListReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).GetWithdrawTokenList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_GetWithdrawTokenList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).GetWithdrawTokenList(ctx, req.(*WithdrawTokenListReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventService_GetWithdrawTokenDetail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WithdrawTokenDetailReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).GetWithdrawTokenDetail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_GetWithdrawTokenDetail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).GetWithdrawTokenDetail(ctx, req.(*WithdrawTokenDetailReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventService_GetGrantRewardTokenList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GrantRewardTokenListReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).GetGrantRewardTokenList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_GetGrantRewardTokenList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).GetGrantRewardTokenList(ctx, req.(*GrantRewardTokenListReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventService_GetGrantRewardTokenDetail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GrantRewardTokenDetailReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).GetGrantRewardTokenDetail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_GetGrantRewardTokenDetail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).GetGrantRewardTokenDetail(ctx, req.(*GrantRewardTokenDetailReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventService_GetWithdrawManagerUpdateList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WithdrawManagerUpdateListReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).GetWithdrawManagerUpdateList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_GetWithdrawManagerUpdateList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).GetWithdrawManagerUpdateList(ctx, req.(*WithdrawManagerUpdateListReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventService_GetWithdrawManagerUpdateDetail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WithdrawManagerUpdateDetailReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).GetWithdrawManagerUpdateDetail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_GetWithdrawManagerUpdateDetail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).GetWithdrawManagerUpdateDetail(ctx, req.(*WithdrawManagerUpdateDetailReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventService_GetRewardLedger_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RewardLedgerReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).GetRewardLedger(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_GetRewardLedger_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).GetRewardLedger(ctx, req.(*RewardLedgerReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventService_GetAddressActivity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddressActivityReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).GetAddressActivity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_GetAddressActivity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).GetAddressActivity(ctx, req.(*AddressActivityReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventService_SubscribeEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeEventsReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EventServiceServer).SubscribeEvents(m, &grpc.GenericServerStream[SubscribeEventsReq, SubscribeEventsRep]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type EventService_SubscribeEventsServer = grpc.ServerStreamingServer[SubscribeEventsRep]
+
 // EventService_ServiceDesc is the grpc.ServiceDesc for EventService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -151,7 +459,45 @@ var EventService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "getDepositTokenDetail",
 			Handler:    _EventService_GetDepositTokenDetail_Handler,
 		},
+		{
+			MethodName: "getWithdrawTokenList",
+			Handler:    _EventService_GetWithdrawTokenList_Handler,
+		},
+		{
+			MethodName: "getWithdrawTokenDetail",
+			Handler:    _EventService_GetWithdrawTokenDetail_Handler,
+		},
+		{
+			MethodName: "getGrantRewardTokenList",
+			Handler:    _EventService_GetGrantRewardTokenList_Handler,
+		},
+		{
+			MethodName: "getGrantRewardTokenDetail",
+			Handler:    _EventService_GetGrantRewardTokenDetail_Handler,
+		},
+		{
+			MethodName: "getWithdrawManagerUpdateList",
+			Handler:    _EventService_GetWithdrawManagerUpdateList_Handler,
+		},
+		{
+			MethodName: "getWithdrawManagerUpdateDetail",
+			Handler:    _EventService_GetWithdrawManagerUpdateDetail_Handler,
+		},
+		{
+			MethodName: "getRewardLedger",
+			Handler:    _EventService_GetRewardLedger_Handler,
+		},
+		{
+			MethodName: "getAddressActivity",
+			Handler:    _EventService_GetAddressActivity_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "subscribeEvents",
+			Handler:       _EventService_SubscribeEvents_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "services/grpc/protobuf/event_sync.proto",
 }
@@ -0,0 +1,195 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	common2 "github.com/Sandwichzzy/event-sync-go/database/common"
+	"github.com/Sandwichzzy/event-sync-go/database/worker"
+	"github.com/Sandwichzzy/event-sync-go/services/grpc/eventpb"
+)
+
+func (rs *RpcService) GetWithdrawTokenList(ctx context.Context, request *eventpb.WithdrawTokenListReq) (*eventpb.WithdrawTokenListRep, error) {
+	filter, page, err := listQuery(request)
+	if err != nil {
+		return nil, err
+	}
+	wtList, pageInfo, err := rs.db.WithdrawTokens.QueryWithdrawTokens(filter, page)
+	if err != nil {
+		return nil, queryError(err, "query withdraw tokens fail")
+	}
+	tokenAddresses := make([]common.Address, 0, len(wtList))
+	for _, wt := range wtList {
+		tokenAddresses = append(tokenAddresses, wt.TokenAddress)
+	}
+	tokens := rs.tokenMetadata(tokenAddresses)
+	withdrawTokenList := make([]*eventpb.WithdrawToken, 0, len(wtList))
+	for _, wt := range wtList {
+		withdrawTokenList = append(withdrawTokenList, toWithdrawToken(wt, tokens))
+	}
+	return &eventpb.WithdrawTokenListRep{
+		Code:          eventpb.ReturnCode_SUCCESS,
+		Message:       "get data success",
+		WithdrawToken: withdrawTokenList,
+		Total:         pageInfo.Total,
+		NextCursor:    encodeCursor(pageInfo.Next),
+		PrevCursor:    encodeCursor(pageInfo.Prev),
+		Count:         pageInfo.Count.String(),
+	}, nil
+}
+
+func (rs *RpcService) GetWithdrawTokenDetail(ctx context.Context, request *eventpb.WithdrawTokenDetailReq) (*eventpb.WithdrawTokenDetailRep, error) {
+	if err := validateGUID(request.Guid); err != nil {
+		return nil, err
+	}
+	wt, err := rs.db.WithdrawTokens.WithdrawTokensByGUID(request.Guid)
+	if err != nil {
+		return nil, queryError(err, "query withdraw token fail")
+	} else if wt == nil {
+		return nil, status.Error(codes.NotFound, "withdraw token not found")
+	}
+	return &eventpb.WithdrawTokenDetailRep{
+		Code:          eventpb.ReturnCode_SUCCESS,
+		Message:       "get data success",
+		WithdrawToken: toWithdrawToken(*wt, rs.tokenMetadata([]common.Address{wt.TokenAddress})),
+	}, nil
+}
+
+func (rs *RpcService) GetGrantRewardTokenList(ctx context.Context, request *eventpb.GrantRewardTokenListReq) (*eventpb.GrantRewardTokenListRep, error) {
+	filter, page, err := listQuery(request)
+	if err != nil {
+		return nil, err
+	}
+	grList, pageInfo, err := rs.db.GrantRewardTokens.QueryGrantRewardTokens(filter, page)
+	if err != nil {
+		return nil, queryError(err, "query grant reward tokens fail")
+	}
+	tokenAddresses := make([]common.Address, 0, len(grList))
+	for _, gr := range grList {
+		tokenAddresses = append(tokenAddresses, gr.TokenAddress)
+	}
+	tokens := rs.tokenMetadata(tokenAddresses)
+	grantList := make([]*eventpb.GrantRewardToken, 0, len(grList))
+	for _, gr := range grList {
+		grantList = append(grantList, toGrantRewardToken(gr, tokens))
+	}
+	return &eventpb.GrantRewardTokenListRep{
+		Code:             eventpb.ReturnCode_SUCCESS,
+		Message:          "get data success",
+		GrantRewardToken: grantList,
+		Total:            pageInfo.Total,
+		NextCursor:       encodeCursor(pageInfo.Next),
+		PrevCursor:       encodeCursor(pageInfo.Prev),
+		Count:            pageInfo.Count.String(),
+	}, nil
+}
+
+func (rs *RpcService) GetGrantRewardTokenDetail(ctx context.Context, request *eventpb.GrantRewardTokenDetailReq) (*eventpb.GrantRewardTokenDetailRep, error) {
+	if err := validateGUID(request.Guid); err != nil {
+		return nil, err
+	}
+	gr, err := rs.db.GrantRewardTokens.GrantRewardTokensByGUID(request.Guid)
+	if err != nil {
+		return nil, queryError(err, "query grant reward token fail")
+	} else if gr == nil {
+		return nil, status.Error(codes.NotFound, "grant reward token not found")
+	}
+	return &eventpb.GrantRewardTokenDetailRep{
+		Code:             eventpb.ReturnCode_SUCCESS,
+		Message:          "get data success",
+		GrantRewardToken: toGrantRewardToken(*gr, rs.tokenMetadata([]common.Address{gr.TokenAddress})),
+	}, nil
+}
+
+func (rs *RpcService) GetWithdrawManagerUpdateList(ctx context.Context, request *eventpb.WithdrawManagerUpdateListReq) (*eventpb.WithdrawManagerUpdateListRep, error) {
+	filter, page, err := listQuery(request)
+	if err != nil {
+		return nil, err
+	}
+	updates, pageInfo, err := rs.db.WithdrawManagerUpdate.QueryWithdrawManagerUpdates(filter, page)
+	if err != nil {
+		return nil, queryError(err, "query withdraw manager updates fail")
+	}
+	updateList := make([]*eventpb.WithdrawManagerUpdate, 0, len(updates))
+	for _, update := range updates {
+		updateList = append(updateList, toWithdrawManagerUpdate(update))
+	}
+	return &eventpb.WithdrawManagerUpdateListRep{
+		Code:                  eventpb.ReturnCode_SUCCESS,
+		Message:               "get data success",
+		WithdrawManagerUpdate: updateList,
+		Total:                 pageInfo.Total,
+		NextCursor:            encodeCursor(pageInfo.Next),
+		PrevCursor:            encodeCursor(pageInfo.Prev),
+		Count:                 pageInfo.Count.String(),
+	}, nil
+}
+
+func (rs *RpcService) GetWithdrawManagerUpdateDetail(ctx context.Context, request *eventpb.WithdrawManagerUpdateDetailReq) (*eventpb.WithdrawManagerUpdateDetailRep, error) {
+	if err := validateGUID(request.Guid); err != nil {
+		return nil, err
+	}
+	update, err := rs.db.WithdrawManagerUpdate.WithdrawManagerUpdateByGUID(request.Guid)
+	if err != nil {
+		return nil, queryError(err, "query withdraw manager update fail")
+	} else if update == nil {
+		return nil, status.Error(codes.NotFound, "withdraw manager update not found")
+	}
+	return &eventpb.WithdrawManagerUpdateDetailRep{
+		Code:                  eventpb.ReturnCode_SUCCESS,
+		Message:               "get data success",
+		WithdrawManagerUpdate: toWithdrawManagerUpdate(*update),
+	}, nil
+}
+
+func toWithdrawToken(wt worker.WithdrawTokens, tokens map[common.Address]common2.Token) *eventpb.WithdrawToken {
+	token, known := tokens[wt.TokenAddress]
+	return &eventpb.WithdrawToken{
+		Guid:            wt.GUID.String(),
+		BlockNumber:     wt.BlockNumber.Uint64(),
+		BlockHash:       wt.BlockHash.String(),
+		TransactionHash: wt.TransactionHash.String(),
+		LogIndex:        wt.LogIndex,
+		TokenAddress:    wt.TokenAddress.String(),
+		Sender:          wt.Sender.String(),
+		Receiver:        wt.Receiver.String(),
+		AmountRaw:       wt.Amount.String(),
+		AmountFormatted: formatTokenAmount(wt.Amount, token, known),
+		Symbol:          token.Symbol,
+		Decimals:        uint32(token.Decimals),
+		Timestamp:       wt.Timestamp,
+	}
+}
+
+func toGrantRewardToken(gr worker.GrantRewardTokens, tokens map[common.Address]common2.Token) *eventpb.GrantRewardToken {
+	token, known := tokens[gr.TokenAddress]
+	return &eventpb.GrantRewardToken{
+		Guid:            gr.GUID.String(),
+		BlockNumber:     gr.BlockNumber.Uint64(),
+		BlockHash:       gr.BlockHash.String(),
+		TransactionHash: gr.TransactionHash.String(),
+		LogIndex:        gr.LogIndex,
+		TokenAddress:    gr.TokenAddress.String(),
+		Granter:         gr.Granter.String(),
+		AmountRaw:       gr.Amount.String(),
+		AmountFormatted: formatTokenAmount(gr.Amount, token, known),
+		Symbol:          token.Symbol,
+		Decimals:        uint32(token.Decimals),
+		Timestamp:       gr.Timestamp,
+	}
+}
+
+func toWithdrawManagerUpdate(update worker.WithdrawManagerUpdate) *eventpb.WithdrawManagerUpdate {
+	return &eventpb.WithdrawManagerUpdate{
+		Guid:            update.GUID.String(),
+		BlockNumber:     update.BlockNumber.Uint64(),
+		BlockHash:       update.BlockHash.String(),
+		TransactionHash: update.TransactionHash.String(),
+		LogIndex:        update.LogIndex,
+		WithdrawManager: update.WithdrawManager.String(),
+		Timestamp:       update.Timestamp,
+	}
+}
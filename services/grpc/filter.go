@@ -0,0 +1,126 @@
+package grpc
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Sandwichzzy/event-sync-go/database/utils"
+	"github.com/Sandwichzzy/event-sync-go/database/worker"
+	"github.com/Sandwichzzy/event-sync-go/services/eventfilter"
+	"github.com/Sandwichzzy/event-sync-go/services/grpc/eventpb"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 1000
+)
+
+// eventFilter 校验请求中的过滤条件并转换为数据库查询条件
+func eventFilter(request *eventpb.EventFilter) (worker.EventFilter, error) {
+	if request == nil {
+		return worker.EventFilter{}, nil
+	}
+	req := eventfilter.Request{
+		Sender:   eventfilter.Field{Name: "sender", Value: request.Sender},
+		Receiver: eventfilter.Field{Name: "receiver", Value: request.Receiver},
+		Address:  eventfilter.Field{Name: "address", Value: request.Address},
+		Token:    eventfilter.Field{Name: "token_address", Value: request.TokenAddress},
+		TxHash:   eventfilter.Field{Name: "transaction_hash", Value: request.TransactionHash},
+		FromTime: request.FromTimestamp,
+		ToTime:   request.ToTimestamp,
+	}
+	if request.FromBlock > 0 {
+		req.FromBlock = new(big.Int).SetUint64(request.FromBlock)
+	}
+	if request.ToBlock > 0 {
+		req.ToBlock = new(big.Int).SetUint64(request.ToBlock)
+	}
+	return eventfilter.Parse(req)
+}
+
+// eventPage 校验分页和排序参数，page 从 1 开始，page_size 默认 20、最大 1000，order 默认 desc
+func eventPage(page, pageSize uint64, order string) (utils.Page, error) {
+	if page == 0 {
+		page = 1
+	}
+	if pageSize == 0 {
+		pageSize = defaultPageSize
+	} else if pageSize > maxPageSize {
+		return utils.Page{}, fmt.Errorf("page_size must not exceed %d", maxPageSize)
+	}
+	result := utils.Page{Page: int(page), PageSize: int(pageSize)}
+	switch strings.ToLower(order) {
+	case "", "desc":
+	case "asc":
+		result.Ascending = true
+	default:
+		return utils.Page{}, fmt.Errorf("invalid order %q", order)
+	}
+	return result, nil
+}
+
+// listRequest 列表请求的公共参数
+type listRequest interface {
+	GetFilter() *eventpb.EventFilter
+	GetPage() uint64
+	GetPageSize() uint64
+	GetOrder() string
+	GetCursor() string
+	GetCount() string
+}
+
+// listQuery 解析列表请求的过滤、分页和计数参数，参数无效时返回 InvalidArgument。
+// 带游标时按游标分页并默认不计数，否则按页码分页并默认精确计数
+func listQuery(request listRequest) (worker.EventFilter, utils.Page, error) {
+	filter, err := eventFilter(request.GetFilter())
+	if err != nil {
+		return filter, utils.Page{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	page, err := eventPage(request.GetPage(), request.GetPageSize(), request.GetOrder())
+	if err != nil {
+		return filter, page, status.Error(codes.InvalidArgument, err.Error())
+	}
+	defaultCount := utils.CountExact
+	if request.GetCursor() != "" {
+		if page.Cursor, err = utils.ParseCursor(request.GetCursor()); err != nil {
+			return filter, page, status.Error(codes.InvalidArgument, err.Error())
+		}
+		defaultCount = utils.CountNone
+	}
+	if page.Count, err = utils.ParseCountMode(request.GetCount(), defaultCount); err != nil {
+		return filter, page, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return filter, page, nil
+}
+
+// encodeCursor 把分页游标编码为响应中的 next_cursor / prev_cursor，没有游标时返回空字符串
+func encodeCursor(cursor *utils.Cursor) string {
+	if cursor == nil {
+		return ""
+	}
+	return cursor.Encode()
+}
+
+// validateGUID 校验详情请求中的 GUID，无效时返回 InvalidArgument
+func validateGUID(guid string) error {
+	if _, err := uuid.Parse(guid); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid guid %q", guid)
+	}
+	return nil
+}
+
+// queryError 把查询错误转换为 gRPC 状态：不支持的过滤条件返回 InvalidArgument，其余记录日志后返回 Internal
+func queryError(err error, message string) error {
+	if errors.Is(err, worker.ErrUnsupportedFilter) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	log.Error(message, "err", err)
+	return status.Error(codes.Internal, message)
+}
@@ -0,0 +1,51 @@
+package grpc
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Sandwichzzy/event-sync-go/services/grpc/eventpb"
+)
+
+func TestEventFilter(t *testing.T) {
+	filter, err := eventFilter(&eventpb.EventFilter{
+		TokenAddress: "0x1111111111111111111111111111111111111111",
+		Receiver:     "0x2222222222222222222222222222222222222222",
+		FromBlock:    10,
+		ToBlock:      20,
+	})
+	require.NoError(t, err)
+	require.Equal(t, common.HexToAddress("0x1111111111111111111111111111111111111111"), *filter.TokenAddress)
+	require.Equal(t, common.HexToAddress("0x2222222222222222222222222222222222222222"), *filter.Receiver)
+	require.Nil(t, filter.Sender)
+	require.Equal(t, big.NewInt(10), filter.FromBlock)
+	require.Equal(t, big.NewInt(20), filter.ToBlock)
+
+	_, err = eventFilter(&eventpb.EventFilter{Sender: "0x1234"})
+	require.Error(t, err)
+	_, err = eventFilter(&eventpb.EventFilter{TransactionHash: "0x1234"})
+	require.Error(t, err)
+	_, err = eventFilter(&eventpb.EventFilter{FromBlock: 20, ToBlock: 10})
+	require.Error(t, err)
+}
+
+func TestEventPage(t *testing.T) {
+	page, err := eventPage(0, 0, "")
+	require.NoError(t, err)
+	require.Equal(t, 1, page.Page)
+	require.Equal(t, defaultPageSize, page.PageSize)
+	require.False(t, page.Ascending)
+
+	page, err = eventPage(3, 50, "ASC")
+	require.NoError(t, err)
+	require.Equal(t, 3, page.Page)
+	require.True(t, page.Ascending)
+
+	_, err = eventPage(1, maxPageSize+1, "")
+	require.Error(t, err)
+	_, err = eventPage(1, 10, "random")
+	require.Error(t, err)
+}
@@ -2,54 +2,185 @@ package grpc
 
 import (
 	"context"
+	"math/big"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Sandwichzzy/event-sync-go/common/bigint"
+	common2 "github.com/Sandwichzzy/event-sync-go/database/common"
 	"github.com/Sandwichzzy/event-sync-go/services/grpc/eventpb"
 )
 
 func (rs *RpcService) GetDepositTokenList(ctx context.Context, request *eventpb.DepositTokenListReq) (*eventpb.DepositTokenListRep, error) {
-	dtList, totalCount := rs.db.DepositTokens.QueryDepositTokensList(int(request.Page), int(request.PageSize))
-	if totalCount == 0 {
-		return &eventpb.DepositTokenListRep{
-			Code:         eventpb.ReturnCode_SUCCESS,
-			Message:      "No data in database",
-			DepositToken: nil,
-		}, nil
-	}
-	var depositTokenList []*eventpb.DepositToken
+	filter, page, err := listQuery(request)
+	if err != nil {
+		return nil, err
+	}
+	dtList, pageInfo, err := rs.db.DepositTokens.QueryDepositTokens(filter, page)
+	if err != nil {
+		return nil, queryError(err, "query deposit tokens fail")
+	}
+	tokenAddresses := make([]common.Address, 0, len(dtList))
 	for _, dt := range dtList {
-		dtItem := &eventpb.DepositToken{
-			Guid:         dt.GUID.String(),
-			BlockNumber:  dt.BlockNumber.Uint64(),
-			TokenAddress: dt.TokenAddress.String(),
-			Sender:       dt.Sender.String(),
-			Amount:       dt.Amount.Uint64(),
-			Timestamp:    dt.Timestamp,
-		}
-		depositTokenList = append(depositTokenList, dtItem)
+		tokenAddresses = append(tokenAddresses, dt.TokenAddress)
+	}
+	tokens := rs.tokenMetadata(tokenAddresses)
+	depositTokenList := make([]*eventpb.DepositToken, 0, len(dtList))
+	for _, dt := range dtList {
+		token, known := tokens[dt.TokenAddress]
+		depositTokenList = append(depositTokenList, &eventpb.DepositToken{
+			Guid:            dt.GUID.String(),
+			BlockNumber:     dt.BlockNumber.Uint64(),
+			TokenAddress:    dt.TokenAddress.String(),
+			Sender:          dt.Sender.String(),
+			Amount:          legacyAmount(dt.Amount),
+			Timestamp:       dt.Timestamp,
+			AmountRaw:       dt.Amount.String(),
+			AmountFormatted: formatTokenAmount(dt.Amount, token, known),
+			Symbol:          token.Symbol,
+			Decimals:        uint32(token.Decimals),
+			BlockHash:       dt.BlockHash.String(),
+			TransactionHash: dt.TransactionHash.String(),
+			LogIndex:        dt.LogIndex,
+		})
 	}
 	return &eventpb.DepositTokenListRep{
 		Code:         eventpb.ReturnCode_SUCCESS,
 		Message:      "get data success",
 		DepositToken: depositTokenList,
+		Total:        pageInfo.Total,
+		NextCursor:   encodeCursor(pageInfo.Next),
+		PrevCursor:   encodeCursor(pageInfo.Prev),
+		Count:        pageInfo.Count.String(),
 	}, nil
 }
 
 func (rs *RpcService) GetDepositTokenDetail(ctx context.Context, request *eventpb.DepositTokenDetailReq) (*eventpb.DepositTokenDetailRep, error) {
-	dt, err := rs.db.DepositTokens.QueryDepositTokensById(request.Guid)
+	if err := validateGUID(request.Guid); err != nil {
+		return nil, err
+	}
+	dt, err := rs.db.DepositTokens.DepositTokensByGUID(request.Guid)
 	if err != nil {
-		return &eventpb.DepositTokenDetailRep{
-			Code:    eventpb.ReturnCode_ERROR,
-			Message: "query data fail",
-		}, nil
+		return nil, queryError(err, "query deposit token fail")
+	} else if dt == nil {
+		return nil, status.Error(codes.NotFound, "deposit token not found")
 	}
+	token, known := rs.tokenMetadata([]common.Address{dt.TokenAddress})[dt.TokenAddress]
 	return &eventpb.DepositTokenDetailRep{
-		Code:         eventpb.ReturnCode_SUCCESS,
-		Message:      "get data success",
-		Guid:         dt.GUID.String(),
-		BlockNumber:  dt.BlockNumber.Uint64(),
-		TokenAddress: dt.TokenAddress.String(),
-		Sender:       dt.Sender.String(),
-		Amount:       dt.Amount.Uint64(),
-		Timestamp:    dt.Timestamp,
+		Code:            eventpb.ReturnCode_SUCCESS,
+		Message:         "get data success",
+		Guid:            dt.GUID.String(),
+		BlockNumber:     dt.BlockNumber.Uint64(),
+		TokenAddress:    dt.TokenAddress.String(),
+		Sender:          dt.Sender.String(),
+		Amount:          legacyAmount(dt.Amount),
+		Timestamp:       dt.Timestamp,
+		AmountRaw:       dt.Amount.String(),
+		AmountFormatted: formatTokenAmount(dt.Amount, token, known),
+		Symbol:          token.Symbol,
+		Decimals:        uint32(token.Decimals),
+		BlockHash:       dt.BlockHash.String(),
+		TransactionHash: dt.TransactionHash.String(),
+		LogIndex:        dt.LogIndex,
+	}, nil
+}
+
+func (rs *RpcService) GetRewardLedger(ctx context.Context, request *eventpb.RewardLedgerReq) (*eventpb.RewardLedgerRep, error) {
+	if !common.IsHexAddress(request.Address) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid address %q", request.Address)
+	}
+	address := common.HexToAddress(request.Address)
+
+	page, err := eventPage(request.Page, request.PageSize, "")
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	balances, err := rs.db.RewardLedger.QueryRewardBalances(address)
+	if err != nil {
+		return nil, queryError(err, "query reward balances fail")
+	}
+	entries, totalCount := rs.db.RewardLedger.QueryRewardLedgerEntries(address, page.Page, page.PageSize)
+
+	tokenAddresses := make([]common.Address, 0, len(balances)+len(entries))
+	for _, b := range balances {
+		tokenAddresses = append(tokenAddresses, b.TokenAddress)
+	}
+	for _, e := range entries {
+		tokenAddresses = append(tokenAddresses, e.TokenAddress)
+	}
+	tokens := rs.tokenMetadata(tokenAddresses)
+
+	var balanceList []*eventpb.RewardBalance
+	for _, b := range balances {
+		token, known := tokens[b.TokenAddress]
+		balanceList = append(balanceList, &eventpb.RewardBalance{
+			UserAddress:           b.UserAddress.String(),
+			TokenAddress:          b.TokenAddress.String(),
+			Granted:               b.Granted.String(),
+			Claimed:               b.Claimed.String(),
+			Claimable:             b.Claimable.String(),
+			BlockNumber:           b.BlockNumber.Uint64(),
+			ReconciledBlockNumber: b.ReconciledBlockNumber.Uint64(),
+			Timestamp:             b.Timestamp,
+			GrantedFormatted:      formatTokenAmount(b.Granted, token, known),
+			ClaimedFormatted:      formatTokenAmount(b.Claimed, token, known),
+			ClaimableFormatted:    formatTokenAmount(b.Claimable, token, known),
+			Symbol:                token.Symbol,
+			Decimals:              uint32(token.Decimals),
+		})
+	}
+	var entryList []*eventpb.RewardLedgerEntry
+	for _, e := range entries {
+		token, known := tokens[e.TokenAddress]
+		entryList = append(entryList, &eventpb.RewardLedgerEntry{
+			Guid:            e.GUID.String(),
+			UserAddress:     e.UserAddress.String(),
+			TokenAddress:    e.TokenAddress.String(),
+			EntryType:       e.EntryType,
+			Amount:          e.Amount.String(),
+			BlockNumber:     e.BlockNumber.Uint64(),
+			BlockHash:       e.BlockHash.String(),
+			Timestamp:       e.Timestamp,
+			AmountFormatted: formatTokenAmount(e.Amount, token, known),
+			Symbol:          token.Symbol,
+			Decimals:        uint32(token.Decimals),
+		})
+	}
+	return &eventpb.RewardLedgerRep{
+		Code:    eventpb.ReturnCode_SUCCESS,
+		Message: "get data success",
+		Balance: balanceList,
+		Entry:   entryList,
+		Total:   totalCount,
 	}, nil
 }
+
+// tokenMetadata 批量读取代币元数据，读取失败时返回空 map（金额只返回原始值）
+func (rs *RpcService) tokenMetadata(addresses []common.Address) map[common.Address]common2.Token {
+	tokens, err := rs.db.Tokens.TokensByAddresses(addresses)
+	if err != nil {
+		log.Error("query token metadata fail", "err", err)
+		return map[common.Address]common2.Token{}
+	}
+	return tokens
+}
+
+// legacyAmount 已废弃的 uint64 amount 字段，金额超过 uint64 时返回 0 而不是截断后的错误值，完整金额见 amount_raw
+func legacyAmount(amount *big.Int) uint64 {
+	if amount == nil || !amount.IsUint64() {
+		return 0
+	}
+	return amount.Uint64()
+}
+
+// formatTokenAmount 按代币精度格式化金额，代币元数据未知时返回空字符串
+func formatTokenAmount(amount *big.Int, token common2.Token, known bool) string {
+	if !known {
+		return ""
+	}
+	return bigint.FormatUnits(amount, token.Decimals)
+}
@@ -0,0 +1,16 @@
+package grpc
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLegacyAmount(t *testing.T) {
+	require.Equal(t, uint64(42), legacyAmount(big.NewInt(42)))
+	require.Equal(t, uint64(1<<64-1), legacyAmount(new(big.Int).SetUint64(1<<64-1)))
+	// 超过 uint64 的金额不截断，返回 0
+	require.Zero(t, legacyAmount(new(big.Int).Lsh(big.NewInt(1), 64)))
+	require.Zero(t, legacyAmount(nil))
+}
@@ -0,0 +1,165 @@
+package grpc
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// ApiKeyHeader 客户端携带 API key 的 metadata 键，也接受 authorization: Bearer <key>
+const ApiKeyHeader = "x-api-key"
+
+// publicServices 不需要认证的服务：健康检查和反射
+var publicServices = []string{"/grpc.health.v1.Health/", "/grpc.reflection."}
+
+// recoveryUnaryInterceptor 把 handler 中的 panic 转换为 Internal 错误
+func recoveryUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = recovered(info.FullMethod, r)
+		}
+	}()
+	return handler(ctx, req)
+}
+
+func recoveryStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = recovered(info.FullMethod, r)
+		}
+	}()
+	return handler(srv, ss)
+}
+
+func recovered(method string, r interface{}) error {
+	log.Error("grpc handler panic", "method", method, "panic", r, "stack", string(debug.Stack()))
+	return status.Error(codes.Internal, "internal error")
+}
+
+// loggingUnaryInterceptor 记录每个请求的方法、耗时、状态码和客户端地址，并按方法统计耗时和错误数
+func loggingUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	observe(ctx, info.FullMethod, start, err)
+	return resp, err
+}
+
+func loggingStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	observe(ss.Context(), info.FullMethod, start, err)
+	return err
+}
+
+func observe(ctx context.Context, method string, start time.Time, err error) {
+	elapsed := time.Since(start)
+	code := status.Code(err)
+	name := metricName(method)
+	metrics.GetOrRegisterTimer(fmt.Sprintf("grpc/server/%s/duration", name), nil).Update(elapsed)
+	if code != codes.OK {
+		metrics.GetOrRegisterCounter(fmt.Sprintf("grpc/server/%s/errors", name), nil).Inc(1)
+	}
+
+	var remote string
+	if p, ok := peer.FromContext(ctx); ok {
+		remote = p.Addr.String()
+	}
+	logFn := log.Debug
+	switch code {
+	case codes.OK, codes.Canceled:
+	case codes.Internal, codes.Unknown, codes.DataLoss:
+		logFn = log.Error
+	default:
+		logFn = log.Warn
+	}
+	logFn("grpc request", "method", method, "code", code.String(), "duration", elapsed, "peer", remote, "err", err)
+}
+
+// metricName 把 /package.Service/method 转换为 Service/method
+func metricName(method string) string {
+	method = strings.TrimPrefix(method, "/")
+	if i := strings.LastIndex(method, "."); i >= 0 {
+		method = method[i+1:]
+	}
+	return method
+}
+
+// deadlineUnaryInterceptor 为没有 deadline 或 deadline 更长的 unary 请求设置默认 deadline，流式请求不受限制
+func deadlineUnaryInterceptor(timeout time.Duration) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if deadline, ok := ctx.Deadline(); !ok || time.Until(deadline) > timeout {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		return handler(ctx, req)
+	}
+}
+
+// apiKeyAuth 校验请求 metadata 中的 API key，keys 为空时不认证
+type apiKeyAuth struct {
+	keys []string
+}
+
+func (a apiKeyAuth) unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := a.authenticate(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (a apiKeyAuth) stream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := a.authenticate(ss.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+func (a apiKeyAuth) authenticate(ctx context.Context, method string) error {
+	if len(a.keys) == 0 {
+		return nil
+	}
+	for _, prefix := range publicServices {
+		if strings.HasPrefix(method, prefix) {
+			return nil
+		}
+	}
+	key := requestApiKey(ctx)
+	if key == "" {
+		return status.Error(codes.Unauthenticated, "missing api key")
+	}
+	for _, k := range a.keys {
+		if subtle.ConstantTimeCompare([]byte(k), []byte(key)) == 1 {
+			return nil
+		}
+	}
+	return status.Error(codes.Unauthenticated, "invalid api key")
+}
+
+// requestApiKey 从 x-api-key 或 authorization: Bearer 中读取 API key
+func requestApiKey(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if values := md.Get(ApiKeyHeader); len(values) > 0 {
+		return values[0]
+	}
+	for _, value := range md.Get("authorization") {
+		if token, ok := strings.CutPrefix(value, "Bearer "); ok {
+			return token
+		}
+	}
+	return ""
+}
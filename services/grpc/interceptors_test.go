@@ -0,0 +1,34 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestApiKeyAuth(t *testing.T) {
+	auth := apiKeyAuth{keys: []string{"secret"}}
+	method := "/theweb3.event.EventService/getDepositTokenList"
+	withMD := func(kv ...string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(kv...))
+	}
+
+	require.Equal(t, codes.Unauthenticated, status.Code(auth.authenticate(context.Background(), method)))
+	require.Equal(t, codes.Unauthenticated, status.Code(auth.authenticate(withMD(ApiKeyHeader, "wrong"), method)))
+	require.NoError(t, auth.authenticate(withMD(ApiKeyHeader, "secret"), method))
+	require.NoError(t, auth.authenticate(withMD("authorization", "Bearer secret"), method))
+	require.NoError(t, auth.authenticate(context.Background(), "/grpc.health.v1.Health/Check"))
+	require.NoError(t, apiKeyAuth{}.authenticate(context.Background(), method))
+}
+
+func TestRecoveryUnaryInterceptor(t *testing.T) {
+	_, err := recoveryUnaryInterceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/test"}, func(context.Context, interface{}) (interface{}, error) {
+		panic("boom")
+	})
+	require.Equal(t, codes.Internal, status.Code(err))
+}
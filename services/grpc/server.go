@@ -2,16 +2,23 @@ package grpc
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"net"
+	"os"
 	"sync/atomic"
 
 	"github.com/ethereum/go-ethereum/log"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 
 	"github.com/Sandwichzzy/event-sync-go/config"
 	"github.com/Sandwichzzy/event-sync-go/database"
+	"github.com/Sandwichzzy/event-sync-go/outbox"
 	"github.com/Sandwichzzy/event-sync-go/services/grpc/eventpb"
 )
 
@@ -20,13 +27,32 @@ const MaxReceivedMessageSize = 1024 * 1024 * 30000
 type RpcService struct {
 	conf *config.Config
 	db   *database.DB
+	hub  *outbox.Hub // 轮询 outbox 表并广播给 SubscribeEvents 的订阅者
 	eventpb.UnimplementedEventServiceServer
+
+	server  *grpc.Server
+	health  *health.Server
 	stopped atomic.Bool
 }
 
+// Stop 先把健康状态置为 NOT_SERVING 并关闭 Hub 结束所有事件流，再优雅关闭 grpc server，ctx 结束时强制关闭
 func (rs *RpcService) Stop(ctx context.Context) error {
 	rs.stopped.Store(true)
-	return nil
+	rs.health.Shutdown()
+	err := rs.hub.Close()
+	if rs.server != nil {
+		done := make(chan struct{})
+		go func() {
+			rs.server.GracefulStop()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-ctx.Done():
+			rs.server.Stop()
+		}
+	}
+	return err
 }
 
 func (rs *RpcService) Stopped() bool {
@@ -35,35 +61,79 @@ func (rs *RpcService) Stopped() bool {
 
 func NewRpcService(db *database.DB, conf *config.Config) (*RpcService, error) {
 	rpcService := &RpcService{
-		db:   db,
-		conf: conf,
+		db:     db,
+		conf:   conf,
+		hub:    outbox.NewHub(db.Outbox, streamPollInterval),
+		health: health.NewServer(),
 	}
 	return rpcService, nil
 }
 
 func (rs *RpcService) Start(ctx context.Context) error {
-	go func(s *RpcService) {
-		addr := fmt.Sprintf("%s:%d", rs.conf.GrpcServer.Host, rs.conf.GrpcServer.Port)
-		listener, err := net.Listen("tcp", addr)
+	opts := []grpc.ServerOption{grpc.MaxRecvMsgSize(MaxReceivedMessageSize)}
+	if rs.conf.Grpc.TLS.Enabled() {
+		creds, err := serverCredentials(rs.conf.Grpc.TLS)
 		if err != nil {
-			log.Error("Could not start grpc server on ", addr)
-			return
+			return err
 		}
+		opts = append(opts, grpc.Creds(creds))
+	}
+	auth := apiKeyAuth{keys: rs.conf.Grpc.ApiKeys}
+	unary := []grpc.UnaryServerInterceptor{recoveryUnaryInterceptor, loggingUnaryInterceptor, auth.unary}
+	if rs.conf.Grpc.RequestTimeout > 0 {
+		unary = append(unary, deadlineUnaryInterceptor(rs.conf.Grpc.RequestTimeout))
+	}
+	opts = append(opts,
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(recoveryStreamInterceptor, loggingStreamInterceptor, auth.stream))
 
-		gs := grpc.NewServer(
-			grpc.MaxRecvMsgSize(MaxReceivedMessageSize),
-			grpc.ChainUnaryInterceptor(nil))
-
-		reflection.Register(gs) // grpcui -plaintext 127.0.0.1:port
-
-		eventpb.RegisterEventServiceServer(gs, rs)
+	addr := fmt.Sprintf("%s:%d", rs.conf.GrpcServer.Host, rs.conf.GrpcServer.Port)
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("could not start grpc server on %s: %w", addr, err)
+	}
+	if err := rs.hub.Start(); err != nil {
+		listener.Close()
+		return err
+	}
 
-		log.Info("Grpc info", "Port", rs.conf.GrpcServer.Port, "addr", listener.Addr())
+	rs.server = grpc.NewServer(opts...)
+	reflection.Register(rs.server) // grpcui -plaintext 127.0.0.1:port
+	healthpb.RegisterHealthServer(rs.server, rs.health)
+	eventpb.RegisterEventServiceServer(rs.server, rs)
+	rs.health.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	rs.health.SetServingStatus(eventpb.EventService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
 
-		if err := gs.Serve(listener); err != nil {
-			log.Error("Could not GRPC services")
+	log.Info("Grpc info", "Port", rs.conf.GrpcServer.Port, "addr", listener.Addr(), "tls", rs.conf.Grpc.TLS.Enabled(), "auth", len(rs.conf.Grpc.ApiKeys) > 0)
+	go func() {
+		if err := rs.server.Serve(listener); err != nil {
+			log.Error("Could not GRPC services", "err", err)
 		}
-
-	}(rs)
+	}()
 	return nil
 }
+
+// serverCredentials 加载服务端证书，配置了客户端 CA 时要求并校验客户端证书
+func serverCredentials(conf config.TLSConfig) (credentials.TransportCredentials, error) {
+	cert, err := tls.LoadX509KeyPair(conf.CertFile, conf.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load grpc tls key pair: %w", err)
+	}
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if conf.ClientCAFile != "" {
+		pem, err := os.ReadFile(conf.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read grpc tls client ca: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", conf.ClientCAFile)
+		}
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return credentials.NewTLS(tlsConfig), nil
+}
@@ -0,0 +1,125 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Sandwichzzy/event-sync-go/database/event"
+	"github.com/Sandwichzzy/event-sync-go/outbox"
+	"github.com/Sandwichzzy/event-sync-go/services/grpc/eventpb"
+)
+
+const (
+	// streamPollInterval 实时推送轮询 outbox 表的间隔
+	streamPollInterval = time.Second
+	// defaultStreamHeartbeat 客户端未指定时的心跳间隔
+	defaultStreamHeartbeat = 15 * time.Second
+	// maxStreamHeartbeat 客户端可以指定的最大心跳间隔
+	maxStreamHeartbeat = 5 * time.Minute
+)
+
+// SubscribeEvents 先回放游标 (from_block_number, from_log_index) 之后的历史事件，再持续推送新事件。
+// 区块回滚或区间重建时推送 STREAM_EVENT_REMOVED，空闲时按 heartbeat_seconds 推送 STREAM_HEARTBEAT。
+// 客户端保存最后收到的 STREAM_EVENT_ADDED 的 (block_number, log_index)，断线后以此为游标重新订阅。
+func (rs *RpcService) SubscribeEvents(request *eventpb.SubscribeEventsReq, stream grpc.ServerStreamingServer[eventpb.SubscribeEventsRep]) error {
+	filter, err := streamFilter(request)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	heartbeat := defaultStreamHeartbeat
+	if request.HeartbeatSeconds > 0 {
+		heartbeat = time.Duration(request.HeartbeatSeconds) * time.Second
+		if heartbeat > maxStreamHeartbeat {
+			heartbeat = maxStreamHeartbeat
+		}
+	}
+
+	var afterID uint64
+	if request.FromLatest {
+		afterID = rs.hub.LatestID()
+	} else {
+		afterID, err = rs.db.Outbox.OutboxIDBeforePosition(new(big.Int).SetUint64(request.FromBlockNumber), request.FromLogIndex)
+		if err != nil {
+			log.Error("resolve stream cursor fail", "err", err)
+			return status.Error(codes.Internal, "resolve cursor fail")
+		}
+	}
+
+	err = rs.hub.Stream(stream.Context(), afterID, filter, heartbeat, func(message event.OutboxMessage) error {
+		messageType := eventpb.StreamMessageType_STREAM_EVENT_ADDED
+		if message.Action == event.OutboxActionRemoved {
+			messageType = eventpb.StreamMessageType_STREAM_EVENT_REMOVED
+		}
+		return stream.Send(&eventpb.SubscribeEventsRep{
+			Code:           eventpb.ReturnCode_SUCCESS,
+			Type:           messageType,
+			Event:          toStreamEvent(message),
+			LatestSequence: rs.hub.LatestID(),
+			ServerTime:     uint64(time.Now().Unix()),
+		})
+	}, func() error {
+		return stream.Send(&eventpb.SubscribeEventsRep{
+			Code:           eventpb.ReturnCode_SUCCESS,
+			Type:           eventpb.StreamMessageType_STREAM_HEARTBEAT,
+			LatestSequence: rs.hub.LatestID(),
+			ServerTime:     uint64(time.Now().Unix()),
+		})
+	})
+	switch {
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return status.FromContextError(err).Err()
+	case errors.Is(err, outbox.ErrHubClosed):
+		return status.Error(codes.Unavailable, "server shutting down")
+	case err != nil:
+		log.Error("event stream fail", "err", err)
+		return status.Error(codes.Internal, "event stream fail")
+	}
+	return nil
+}
+
+// streamFilter 校验并构建订阅的过滤条件
+func streamFilter(request *eventpb.SubscribeEventsReq) (outbox.Filter, error) {
+	filter := outbox.Filter{EventTypes: request.EventTypes}
+	for _, eventType := range request.EventTypes {
+		if !outbox.KnownEventTypes[eventType] {
+			return outbox.Filter{}, fmt.Errorf("unknown event type %q", eventType)
+		}
+	}
+	for _, address := range request.Addresses {
+		if !common.IsHexAddress(address) {
+			return outbox.Filter{}, fmt.Errorf("invalid address %q", address)
+		}
+		filter.Addresses = append(filter.Addresses, common.HexToAddress(address))
+	}
+	if request.TokenAddress != "" {
+		if !common.IsHexAddress(request.TokenAddress) {
+			return outbox.Filter{}, fmt.Errorf("invalid token address %q", request.TokenAddress)
+		}
+		token := common.HexToAddress(request.TokenAddress)
+		filter.TokenAddress = &token
+	}
+	return filter, nil
+}
+
+func toStreamEvent(message event.OutboxMessage) *eventpb.StreamEvent {
+	return &eventpb.StreamEvent{
+		Sequence:        message.ID,
+		ContractAddress: message.ContractAddress.String(),
+		EventType:       message.EventType,
+		BlockNumber:     message.BlockNumber.Uint64(),
+		BlockHash:       message.BlockHash.String(),
+		TransactionHash: message.TransactionHash.String(),
+		LogIndex:        message.LogIndex,
+		Payload:         string(message.Payload),
+		Timestamp:       message.Timestamp,
+	}
+}
@@ -21,9 +21,16 @@ import (
 	"github.com/Sandwichzzy/event-sync-go/synchronizer/node"
 )
 
+// CommittedBatch 同步器已经提交到数据库的一批区块 [FromHeight, ToHeight]
+type CommittedBatch struct {
+	FromHeight *big.Int
+	ToHeight   *big.Int
+}
+
 type Synchronizer struct {
 	ethClient node.EthClient
 	db        *database.DB
+	committed chan<- CommittedBatch // 批次提交后通知下游，为 nil 时不通知
 
 	loopInterval     time.Duration         // 同步循环间隔
 	headerBufferSize uint64                // 每次处理的区块数量
@@ -41,7 +48,8 @@ type Synchronizer struct {
 	tasks          tasks.Group // 任务管理组
 }
 
-func NewSynchronizer(cfg *config.Config, db *database.DB, client node.EthClient, shutdown context.CancelCauseFunc) (*Synchronizer, error) {
+// NewSynchronizer committed 为有界通道，每提交一个批次发送一次；下游处理不过来时通道写满，同步器阻塞等待（背压）
+func NewSynchronizer(cfg *config.Config, db *database.DB, client node.EthClient, committed chan<- CommittedBatch, shutdown context.CancelCauseFunc) (*Synchronizer, error) {
 	//确定同步起始点
 	latestHeader, err := db.Blocks.LatestBlockHeader()
 	if err != nil {
@@ -76,6 +84,7 @@ func NewSynchronizer(cfg *config.Config, db *database.DB, client node.EthClient,
 		headerBufferSize: uint64(cfg.Chain.BlockStep),
 		headerTraversal:  headerTraversal,
 		ethClient:        client,
+		committed:        committed,
 		latestHeader:     fromHeader,
 		db:               db,
 		chainCfg:         &cfg.Chain,
@@ -140,7 +149,7 @@ func (syncer *Synchronizer) processBatch(headers []types.Header, chainCfg *confi
 		header := headers[i]
 		headerMap[header.Hash()] = &header
 	}
-	log.Info("chainCfg Contracts", "contract address", chainCfg.Contracts[0])
+	log.Info("chainCfg Contracts", "contract addresses", chainCfg.Contracts)
 	// 3. 查询合约事件日志
 	filterQuery := ethereum.FilterQuery{FromBlock: firstHeader.Number, ToBlock: lastHeader.Number, Addresses: chainCfg.Contracts}
 	logs, err := syncer.ethClient.FilterLogs(filterQuery)
@@ -205,9 +214,30 @@ func (syncer *Synchronizer) processBatch(headers []types.Header, chainCfg *confi
 	}); err != nil {
 		return err
 	}
+	syncer.notifyCommitted(CommittedBatch{FromHeight: firstHeader.Number, ToHeight: lastHeader.Number})
 	return nil
 }
 
+// notifyCommitted 把已提交的批次发送给下游；通道已满时阻塞，直到下游取走或同步器关闭
+func (syncer *Synchronizer) notifyCommitted(batch CommittedBatch) {
+	if syncer.committed == nil {
+		return
+	}
+	select {
+	case syncer.committed <- batch:
+		return
+	default:
+	}
+	log.Info("event processor falling behind, synchronizer waiting", "toHeight", batch.ToHeight)
+	start := time.Now()
+	select {
+	case syncer.committed <- batch:
+		log.Info("synchronizer resumed", "waited", time.Since(start).Round(time.Millisecond))
+	case <-syncer.resourceCtx.Done():
+	}
+}
+
 func (syncer *Synchronizer) Close() error {
+	syncer.resourceCancel()
 	return nil
 }
@@ -0,0 +1,266 @@
+package tokens
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/Sandwichzzy/event-sync-go/bindings"
+	"github.com/Sandwichzzy/event-sync-go/common/tasks"
+	"github.com/Sandwichzzy/event-sync-go/config"
+	"github.com/Sandwichzzy/event-sync-go/database"
+	common2 "github.com/Sandwichzzy/event-sync-go/database/common"
+)
+
+const (
+	// 每轮最多补齐的代币数量
+	metadataBatchSize = 50
+	// 读取失败后的重试间隔从 metadataRetryMin 开始按失败次数翻倍，最长 metadataRetryMax
+	metadataRetryMin = time.Minute
+	metadataRetryMax = 24 * time.Hour
+	// fullScanInterval 全量扫描业务事件中的代币地址的间隔，其余轮次只扫描上次扫描之后处理的区块。
+	// reindex 可能在已经扫描过的区块中写入新的代币地址，由全量扫描补齐
+	fullScanInterval = time.Hour
+
+	nativeTokenName     = "Ether"
+	nativeTokenSymbol   = "ETH"
+	nativeTokenDecimals = 18
+)
+
+// erc20MetadataABI 只包含读取元数据需要的三个方法。
+// 部分老代币（如 MKR）的 name/symbol 返回 bytes32，解析失败时按 bytes32 再解一次。
+const erc20MetadataABI = `[
+	{"type":"function","name":"name","inputs":[],"outputs":[{"name":"","type":"string"}],"stateMutability":"view"},
+	{"type":"function","name":"symbol","inputs":[],"outputs":[{"name":"","type":"string"}],"stateMutability":"view"},
+	{"type":"function","name":"decimals","inputs":[],"outputs":[{"name":"","type":"uint8"}],"stateMutability":"view"}
+]`
+
+// MetadataFetcher 定期找出已经出现在事件中、但还没有缓存元数据的代币地址，
+// 通过 eth_call 读取 name / symbol / decimals 写入 tokens 表。
+type MetadataFetcher struct {
+	db           *database.DB
+	ethClient    *ethclient.Client
+	tmCaller     *bindings.TreasureManagerCaller
+	erc20ABI     abi.ABI
+	loopInterval time.Duration
+
+	nativeStored  bool     // ETH 占位地址的元数据是否已经写入
+	scannedHeight *big.Int // 已经扫描过新代币地址的最高事件区块，nil 表示下一轮全量扫描
+	fullScanAt    time.Time
+
+	resourceCtx    context.Context
+	resourceCancel context.CancelFunc
+	tasks          tasks.Group
+}
+
+// NewMetadataFetcher treasureManager 为配置中绑定到 treasure-manager 处理器的合约地址，用于读取 ETH 占位地址
+func NewMetadataFetcher(ctx context.Context, cfg *config.Config, db *database.DB, treasureManager common.Address, shutdown context.CancelCauseFunc) (*MetadataFetcher, error) {
+	erc20ABI, err := abi.JSON(strings.NewReader(erc20MetadataABI))
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := ethclient.DialContext(ctx, cfg.Chain.ChainRpcUrl)
+	if err != nil {
+		log.Error("dial eth client for token metadata fail", "err", err)
+		return nil, err
+	}
+
+	tmCaller, err := bindings.NewTreasureManagerCaller(treasureManager, client)
+	if err != nil {
+		log.Error("new treasure manager caller fail", "err", err)
+		client.Close()
+		return nil, err
+	}
+
+	resCtx, resCancel := context.WithCancel(context.Background())
+	return &MetadataFetcher{
+		db:             db,
+		ethClient:      client,
+		tmCaller:       tmCaller,
+		erc20ABI:       erc20ABI,
+		loopInterval:   cfg.Chain.LoopInterval,
+		resourceCtx:    resCtx,
+		resourceCancel: resCancel,
+		tasks: tasks.Group{HandleCrit: func(err error) {
+			shutdown(fmt.Errorf("critical error in token metadata fetcher: %w", err))
+		}},
+	}, nil
+}
+
+func (f *MetadataFetcher) Start() error {
+	log.Info("starting token metadata fetcher...", "interval", f.loopInterval)
+	tickerFetch := time.NewTicker(f.loopInterval)
+	f.tasks.Go(func() error {
+		defer tickerFetch.Stop()
+		for {
+			// ETH 占位地址不是合约，写入固定的元数据，失败时下一轮重试直到成功
+			if !f.nativeStored {
+				if err := f.storeNativeToken(); err != nil {
+					log.Error("store native token metadata fail", "err", err)
+				} else {
+					f.nativeStored = true
+				}
+			}
+			select {
+			case <-f.resourceCtx.Done():
+				return nil
+			case <-tickerFetch.C:
+				// 失败只记录日志，没写入的地址下一轮会重新读取
+				if err := f.fetchUnknownTokens(); err != nil {
+					log.Error("fetch token metadata fail", "err", err)
+				}
+			}
+		}
+	})
+	return nil
+}
+
+func (f *MetadataFetcher) Close() error {
+	f.resourceCancel()
+	err := f.tasks.Wait()
+	f.ethClient.Close()
+	return err
+}
+
+func (f *MetadataFetcher) storeNativeToken() error {
+	ethAddress, err := f.tmCaller.EthAddress(&bind.CallOpts{Context: f.resourceCtx})
+	if err != nil {
+		return err
+	}
+	return f.db.Tokens.StoreTokens([]common2.Token{{
+		Address:   ethAddress,
+		Name:      nativeTokenName,
+		Symbol:    nativeTokenSymbol,
+		Decimals:  nativeTokenDecimals,
+		IsNative:  true,
+		Timestamp: uint64(time.Now().Unix()),
+	}})
+}
+
+// fetchUnknownTokens 读取新出现的代币和到了重试时间的失败代币的元数据。
+// 读取失败的地址记录到 token_metadata_failures 并按失败次数退避，不会每轮重复读取，也不会占满新代币的批次
+func (f *MetadataFetcher) fetchUnknownTokens() error {
+	now := time.Now()
+	if now.Sub(f.fullScanAt) >= fullScanInterval {
+		f.scannedHeight, f.fullScanAt = nil, now
+	}
+	// 先读取处理进度，扫描结束后它之前的事件都已经扫描过
+	head, err := f.db.EventBlocks.LatestEventBlockHeader()
+	if err != nil {
+		return err
+	}
+	var fromBlock *big.Int
+	if f.scannedHeight != nil {
+		fromBlock = new(big.Int).Add(f.scannedHeight, big.NewInt(1))
+	}
+	addresses, err := f.db.Tokens.UnknownTokenAddresses(fromBlock, metadataBatchSize)
+	if err != nil {
+		return err
+	}
+	due, err := f.db.Tokens.DueTokenMetadataFailures(uint64(now.Unix()), metadataBatchSize)
+	if err != nil {
+		return err
+	}
+
+	attempts := make(map[common.Address]int, len(addresses)+len(due))
+	for _, address := range addresses {
+		attempts[address] = 0
+	}
+	for _, failure := range due {
+		attempts[failure.Address] = failure.Attempts
+	}
+
+	tokens := make([]common2.Token, 0, len(attempts))
+	var failures []common2.TokenMetadataFailure
+	for address, previous := range attempts {
+		token, err := f.fetchToken(address)
+		if err != nil {
+			failure := newMetadataFailure(address, previous+1, err, now)
+			log.Warn("read erc20 metadata fail", "token", address, "attempts", failure.Attempts, "retryAt", time.Unix(int64(failure.NextAttemptAt), 0), "err", err)
+			failures = append(failures, failure)
+			continue
+		}
+		tokens = append(tokens, *token)
+	}
+	if err := f.db.Tokens.StoreTokens(tokens); err != nil {
+		return err
+	}
+	if err := f.db.Tokens.StoreTokenMetadataFailures(failures); err != nil {
+		return err
+	}
+	// 批次没有取满时，head 之前出现的新地址都已经写入元数据或失败记录
+	if len(addresses) < metadataBatchSize && head != nil {
+		f.scannedHeight = head.Number
+	}
+	return nil
+}
+
+// newMetadataFailure 第 attempts 次失败后的记录，重试间隔为 metadataRetryMin * 2^(attempts-1)，最长 metadataRetryMax
+func newMetadataFailure(address common.Address, attempts int, err error, now time.Time) common2.TokenMetadataFailure {
+	backoff := metadataRetryMax
+	if attempts-1 < 16 {
+		backoff = min(metadataRetryMin<<(attempts-1), metadataRetryMax)
+	}
+	return common2.TokenMetadataFailure{
+		Address:       address,
+		Attempts:      attempts,
+		LastError:     err.Error(),
+		FailedAt:      uint64(now.Unix()),
+		NextAttemptAt: uint64(now.Add(backoff).Unix()),
+	}
+}
+
+// fetchToken 读取单个代币的元数据。decimals 读取失败视为错误（无法格式化金额），
+// name / symbol 是可选方法，读取失败时留空。
+func (f *MetadataFetcher) fetchToken(address common.Address) (*common2.Token, error) {
+	out, err := f.call(address, "decimals")
+	if err != nil {
+		return nil, err
+	}
+	decimals, err := f.erc20ABI.Unpack("decimals", out)
+	if err != nil {
+		return nil, err
+	}
+
+	return &common2.Token{
+		Address:   address,
+		Name:      f.callString(address, "name"),
+		Symbol:    f.callString(address, "symbol"),
+		Decimals:  *abi.ConvertType(decimals[0], new(uint8)).(*uint8),
+		Timestamp: uint64(time.Now().Unix()),
+	}, nil
+}
+
+func (f *MetadataFetcher) callString(address common.Address, method string) string {
+	out, err := f.call(address, method)
+	if err != nil {
+		return ""
+	}
+	if values, err := f.erc20ABI.Unpack(method, out); err == nil {
+		if value, ok := values[0].(string); ok {
+			return value
+		}
+	}
+	if len(out) == 32 {
+		return strings.TrimRight(string(out), "\x00")
+	}
+	return ""
+}
+
+func (f *MetadataFetcher) call(address common.Address, method string) ([]byte, error) {
+	data, err := f.erc20ABI.Pack(method)
+	if err != nil {
+		return nil, err
+	}
+	return f.ethClient.CallContract(f.resourceCtx, ethereum.CallMsg{To: &address, Data: data}, nil)
+}
@@ -0,0 +1,21 @@
+package tokens
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMetadataFailure(t *testing.T) {
+	now := time.Unix(1704067200, 0)
+	address := common.HexToAddress("0x1")
+	for attempts, backoff := range map[int]time.Duration{1: time.Minute, 3: 4 * time.Minute, 12: metadataRetryMax, 100: metadataRetryMax} {
+		failure := newMetadataFailure(address, attempts, errors.New("execution reverted"), now)
+		require.Equal(t, attempts, failure.Attempts)
+		require.Equal(t, uint64(now.Unix()), failure.FailedAt)
+		require.Equal(t, uint64(now.Add(backoff).Unix()), failure.NextAttemptAt, "attempts %d", attempts)
+	}
+}
@@ -0,0 +1,141 @@
+package verifier
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/Sandwichzzy/event-sync-go/database"
+	"github.com/Sandwichzzy/event-sync-go/synchronizer/node"
+)
+
+const (
+	CheckBlockHeaderGaps      = "block_header_gaps"
+	CheckEventBlockGaps       = "event_block_gaps"
+	CheckUnprocessedHeaders   = "unprocessed_block_headers"
+	CheckHeaderLinks          = "block_header_parent_links"
+	CheckEventBlockHeaders    = "event_blocks_without_header"
+	CheckOrphanContractEvents = "orphan_contract_events"
+	CheckOrphanWorkerRows     = "orphan_worker_rows"
+	CheckLegacyWorkerRows     = "legacy_worker_rows"
+	CheckRpcHeaders           = "rpc_header_spot_check"
+)
+
+// Config 校验参数
+type Config struct {
+	SampleLimit     int    // 每项检查在报告中最多列出的问题样本数
+	RpcSamples      int    // 随机抽取与链上比对的区块数，0 表示不比对
+	EventStartBlock uint64 // 事件起始区块，event_blocks 应覆盖之后到最新事件区块的所有区块头
+}
+
+// Check 一项检查的结果，Count 为发现的问题总数
+type Check struct {
+	Name    string      `json:"name"`
+	OK      bool        `json:"ok"`
+	Count   int64       `json:"count"`
+	Samples interface{} `json:"samples,omitempty"`
+	Error   string      `json:"error,omitempty"`
+}
+
+// Report 校验报告，任意一项检查不通过时 OK 为 false
+type Report struct {
+	OK        bool    `json:"ok"`
+	CheckedAt string  `json:"checked_at"`
+	Checks    []Check `json:"checks"`
+}
+
+// RpcHeaderMismatch 本地区块头与链上同一高度区块头 hash 不一致或链上不存在
+type RpcHeaderMismatch struct {
+	Number    string `json:"number"`
+	LocalHash string `json:"local_hash"`
+	ChainHash string `json:"chain_hash,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Verify 检查 block_headers、event_blocks、contract_events 和 worker 表的一致性。
+// client 为 nil 或 cfg.RpcSamples 为 0 时跳过链上抽样比对。
+func Verify(db *database.DB, client node.EthClient, cfg Config) *Report {
+	report := &Report{OK: true, CheckedAt: time.Now().UTC().Format(time.RFC3339)}
+	add := func(name string, samples interface{}, count int64, err error) {
+		check := Check{Name: name, OK: err == nil && count == 0, Count: count}
+		if err != nil {
+			check.Error = err.Error()
+			log.Error("verify check fail", "check", name, "err", err)
+		} else if count > 0 {
+			check.Samples = samples
+		}
+		report.OK = report.OK && check.OK
+		report.Checks = append(report.Checks, check)
+	}
+
+	headerGaps, count, err := db.BlockHeaderGaps(cfg.SampleLimit)
+	add(CheckBlockHeaderGaps, headerGaps, count, err)
+
+	eventBlockGaps, count, err := db.EventBlockGaps(cfg.SampleLimit)
+	add(CheckEventBlockGaps, eventBlockGaps, count, err)
+
+	unprocessed, count, err := db.UnprocessedBlockHeaders(new(big.Int).SetUint64(cfg.EventStartBlock), cfg.SampleLimit)
+	add(CheckUnprocessedHeaders, unprocessed, count, err)
+
+	linkBreaks, count, err := db.BrokenHeaderLinks(cfg.SampleLimit)
+	add(CheckHeaderLinks, linkBreaks, count, err)
+
+	eventBlocks, count, err := db.EventBlocksWithoutHeader(cfg.SampleLimit)
+	add(CheckEventBlockHeaders, eventBlocks, count, err)
+
+	contractEvents, count, err := db.OrphanContractEvents(cfg.SampleLimit)
+	add(CheckOrphanContractEvents, contractEvents, count, err)
+
+	workerRows, count, err := db.OrphanWorkerRows(cfg.SampleLimit)
+	add(CheckOrphanWorkerRows, workerRows, count, err)
+
+	legacyRows, err := db.CountLegacyWorkerRows()
+	add(CheckLegacyWorkerRows, nil, legacyRows, err)
+
+	if client != nil && cfg.RpcSamples > 0 {
+		mismatches, err := spotCheckHeaders(db, client, cfg.RpcSamples)
+		samples := mismatches
+		if len(samples) > cfg.SampleLimit {
+			samples = samples[:cfg.SampleLimit]
+		}
+		add(CheckRpcHeaders, samples, int64(len(mismatches)), err)
+	}
+	return report
+}
+
+// spotCheckHeaders 随机抽取本地区块头，与链上同一高度的区块头 hash 比对
+func spotCheckHeaders(db *database.DB, client node.EthClient, samples int) ([]RpcHeaderMismatch, error) {
+	headers, err := db.RandomBlockHeaders(samples)
+	if err != nil {
+		return nil, err
+	}
+	var mismatches []RpcHeaderMismatch
+	for _, header := range headers {
+		chainHeader, err := client.BlockHeaderByNumber(header.Number)
+		if err != nil {
+			mismatches = append(mismatches, RpcHeaderMismatch{Number: header.Number.String(), LocalHash: header.Hash.String(), Error: err.Error()})
+			continue
+		} else if chainHeader == nil {
+			mismatches = append(mismatches, RpcHeaderMismatch{Number: header.Number.String(), LocalHash: header.Hash.String(), Error: "block not found"})
+			continue
+		}
+		if chainHeader.Hash() != header.Hash {
+			mismatches = append(mismatches, RpcHeaderMismatch{Number: header.Number.String(), LocalHash: header.Hash.String(), ChainHash: chainHeader.Hash().String()})
+		}
+	}
+	log.Info("rpc header spot check done", "samples", len(headers), "mismatches", len(mismatches))
+	return mismatches, nil
+}
+
+// Failed 返回报告中未通过的检查名称
+func (r *Report) Failed() []string {
+	var failed []string
+	for _, check := range r.Checks {
+		if !check.OK {
+			failed = append(failed, fmt.Sprintf("%s(%d)", check.Name, check.Count))
+		}
+	}
+	return failed
+}
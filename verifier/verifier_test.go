@@ -0,0 +1,40 @@
+package verifier
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/Sandwichzzy/event-sync-go/database"
+	"github.com/Sandwichzzy/event-sync-go/database/dbtest"
+)
+
+// TestVerify 需要数据库：EVENT_SYNC_MASTER_DB_HOST=... go test ./verifier/
+func TestVerify(t *testing.T) {
+	db := dbtest.Open(t)
+	dbtest.InTx(t, db, func(tx *database.DB) {
+		cfg := Config{SampleLimit: 1}
+		before := Verify(tx, nil, cfg)
+		dbtest.SeedIntegrityIssues(t, tx)
+		after := Verify(tx, nil, cfg)
+
+		require.False(t, after.OK)
+		require.Len(t, after.Checks, len(before.Checks))
+		added := map[string]int64{CheckBlockHeaderGaps: 1, CheckHeaderLinks: 1, CheckOrphanWorkerRows: 1}
+		for i, check := range after.Checks {
+			require.Equal(t, before.Checks[i].Name, check.Name)
+			require.Empty(t, check.Error, check.Name)
+			require.Equal(t, before.Checks[i].Count+added[check.Name], check.Count, check.Name)
+			if added[check.Name] > 0 {
+				require.False(t, check.OK, check.Name)
+				require.NotNil(t, check.Samples, check.Name)
+				require.Contains(t, after.Failed(), fmt.Sprintf("%s(%d)", check.Name, check.Count))
+			}
+		}
+		// 没有 RPC 客户端时跳过链上抽样比对
+		for _, check := range after.Checks {
+			require.NotEqual(t, CheckRpcHeaders, check.Name)
+		}
+	})
+}
@@ -0,0 +1,284 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/google/uuid"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/Sandwichzzy/event-sync-go/common/retry"
+	"github.com/Sandwichzzy/event-sync-go/common/tasks"
+	"github.com/Sandwichzzy/event-sync-go/config"
+	"github.com/Sandwichzzy/event-sync-go/database"
+	"github.com/Sandwichzzy/event-sync-go/database/event"
+)
+
+const (
+	// offsetSink 调度器在 outbox_offsets 中使用的 sink 名称
+	offsetSink = "webhooks"
+	// fanoutBatchSize 每次从 outbox 读取并生成投递记录的最大消息数
+	fanoutBatchSize = 500
+	// deliverBatchSize 每轮最多投递的记录数
+	deliverBatchSize = 200
+	// deliverConcurrency 同时投递的订阅数，同一订阅内按 outbox_id 顺序逐条投递，遇到失败的记录后本轮不再投递该订阅
+	deliverConcurrency = 8
+	// requestTimeout 单次 HTTP 请求超时
+	requestTimeout = 10 * time.Second
+)
+
+// Dispatcher 把 outbox 消息投递到匹配的 webhook 订阅。
+// 每轮先把 outbox 偏移量之后的消息按订阅过滤条件生成投递记录（与偏移量在同一个事务内提交），
+// 再对到期的待投递记录发送带 HMAC 签名的 POST 请求。每次投递的结果立即写入数据库，失败时按指数退避设置下次投递时间，
+// 由之后的轮次重试，不在投递循环中等待；达到最大次数后进入死信。
+type Dispatcher struct {
+	db           *database.DB
+	client       *http.Client
+	maxAttempts  int
+	strategy     retry.Strategy
+	loopInterval time.Duration
+
+	resourceCtx    context.Context
+	resourceCancel context.CancelFunc
+	tasks          tasks.Group
+}
+
+func NewDispatcher(cfg *config.Config, db *database.DB, shutdown context.CancelCauseFunc) (*Dispatcher, error) {
+	if cfg.WebhookMaxAttempts < 1 {
+		return nil, fmt.Errorf("webhook max attempts must be at least 1, got %d", cfg.WebhookMaxAttempts)
+	}
+	resCtx, resCancel := context.WithCancel(context.Background())
+	return &Dispatcher{
+		db:           db,
+		client:       newHTTPClient(cfg.WebhookAllowPrivateTargets),
+		maxAttempts:  cfg.WebhookMaxAttempts,
+		strategy:     &retry.ExponentialStrategy{Min: time.Second, Max: time.Hour, MaxJitter: 250 * time.Millisecond},
+		loopInterval: cfg.Chain.LoopInterval,
+
+		resourceCtx:    resCtx,
+		resourceCancel: resCancel,
+		tasks: tasks.Group{HandleCrit: func(err error) {
+			shutdown(fmt.Errorf("critical error in webhook dispatcher: %w", err))
+		}},
+	}, nil
+}
+
+func (d *Dispatcher) Start() error {
+	log.Info("starting webhook dispatcher...", "maxAttempts", d.maxAttempts)
+	d.tasks.Go(func() error {
+		ticker := time.NewTicker(d.loopInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-d.resourceCtx.Done():
+				return nil
+			case <-ticker.C:
+				if err := d.fanout(); err != nil {
+					log.Error("webhook fanout fail", "err", err)
+				}
+				if err := d.deliver(); err != nil {
+					log.Error("webhook deliver fail", "err", err)
+				}
+			}
+		}
+	})
+	return nil
+}
+
+func (d *Dispatcher) Close() error {
+	d.resourceCancel()
+	return d.tasks.Wait()
+}
+
+// fanout 为每个合约偏移量之后的 outbox 消息生成匹配订阅的投递记录。
+// 没有订阅时同样推进偏移量，新建的订阅只接收创建之后的事件。
+func (d *Dispatcher) fanout() error {
+	contracts, err := d.db.Outbox.OutboxContracts()
+	if err != nil {
+		return err
+	}
+	for _, contract := range contracts {
+		if err := d.fanoutContract(contract); err != nil {
+			return fmt.Errorf("contract %s: %w", contract, err)
+		}
+	}
+	return nil
+}
+
+func (d *Dispatcher) fanoutContract(contract common.Address) error {
+	offset, err := d.db.Outbox.OutboxOffset(offsetSink, contract)
+	if err != nil {
+		return err
+	}
+	for d.resourceCtx.Err() == nil {
+		messages, err := d.db.Outbox.OutboxMessagesAfter(contract, offset, fanoutBatchSize)
+		if err != nil {
+			return err
+		} else if len(messages) == 0 {
+			return nil
+		}
+		subscriptions, err := d.db.Webhooks.WebhookSubscriptions(true)
+		if err != nil {
+			return err
+		}
+		deliveries, err := buildDeliveries(subscriptions, messages)
+		if err != nil {
+			return err
+		}
+
+		offset = messages[len(messages)-1].ID
+		err = d.db.Transaction(func(tx *database.DB) error {
+			if err := tx.Webhooks.StoreWebhookDeliveries(deliveries); err != nil {
+				return err
+			}
+			return tx.Outbox.UpdateOutboxOffset(offsetSink, contract, offset)
+		})
+		if err != nil {
+			return err
+		}
+		if len(deliveries) > 0 {
+			log.Info("webhook deliveries queued", "contract", contract, "count", len(deliveries), "offset", offset)
+		}
+		if len(messages) < fanoutBatchSize {
+			return nil
+		}
+	}
+	return nil
+}
+
+// buildDeliveries 为每条消息和每个匹配的订阅生成一条待投递记录，请求体为 outbox 消息的 JSON
+func buildDeliveries(subscriptions []event.WebhookSubscription, messages []event.OutboxMessage) ([]event.WebhookDelivery, error) {
+	var deliveries []event.WebhookDelivery
+	for _, message := range messages {
+		var body []byte
+		for _, subscription := range subscriptions {
+			if !matches(subscription, message) {
+				continue
+			}
+			if body == nil {
+				var err error
+				if body, err = json.Marshal(message); err != nil {
+					return nil, err
+				}
+			}
+			deliveries = append(deliveries, event.WebhookDelivery{
+				GUID:             uuid.New(),
+				SubscriptionGUID: subscription.GUID,
+				OutboxID:         message.ID,
+				EventType:        message.EventType,
+				Action:           message.Action,
+				Payload:          body,
+				Status:           event.WebhookDeliveryPending,
+			})
+		}
+	}
+	return deliveries, nil
+}
+
+// deliver 投递到期的待投递记录，不同订阅并发，同一订阅按 outbox_id 顺序
+func (d *Dispatcher) deliver() error {
+	pending, err := d.db.Webhooks.PendingWebhookDeliveries(time.Now(), deliverBatchSize)
+	if err != nil {
+		return err
+	} else if len(pending) == 0 {
+		return nil
+	}
+	subscriptions, err := d.db.Webhooks.WebhookSubscriptions(true)
+	if err != nil {
+		return err
+	}
+	bySubscription := make(map[uuid.UUID]event.WebhookSubscription, len(subscriptions))
+	for _, subscription := range subscriptions {
+		bySubscription[subscription.GUID] = subscription
+	}
+
+	var order []uuid.UUID
+	groups := make(map[uuid.UUID][]event.WebhookDelivery)
+	for _, delivery := range pending {
+		if _, ok := groups[delivery.SubscriptionGUID]; !ok {
+			order = append(order, delivery.SubscriptionGUID)
+		}
+		groups[delivery.SubscriptionGUID] = append(groups[delivery.SubscriptionGUID], delivery)
+	}
+
+	var g errgroup.Group
+	g.SetLimit(deliverConcurrency)
+	for _, guid := range order {
+		subscription, ok := bySubscription[guid]
+		if !ok {
+			continue
+		}
+		deliveries := groups[guid]
+		g.Go(func() error {
+			for _, delivery := range deliveries {
+				if d.resourceCtx.Err() != nil {
+					return nil
+				}
+				delivered, err := d.deliverOne(subscription, delivery)
+				if err != nil {
+					return err
+				} else if !delivered {
+					// 之后的记录等这条重试或进入死信后再投递
+					return nil
+				}
+			}
+			return nil
+		})
+	}
+	return g.Wait()
+}
+
+// deliverOne 投递一次并立即记录结果：成功时标记为 delivered；失败时累加 attempts，
+// 次数用尽时标记为 dead，否则保持 pending 并按指数退避设置下次投递时间。
+// 调度器关闭导致的中断不计入次数，下次启动后重新投递。
+func (d *Dispatcher) deliverOne(subscription event.WebhookSubscription, delivery event.WebhookDelivery) (bool, error) {
+	attempts := delivery.Attempts + 1
+	err := d.post(subscription, delivery)
+	now := time.Now()
+	switch {
+	case err == nil:
+		return true, d.db.Webhooks.UpdateWebhookDelivery(delivery.GUID, event.WebhookDeliveryDelivered, attempts, "", now)
+	case d.resourceCtx.Err() != nil:
+		return false, nil
+	case attempts >= d.maxAttempts:
+		log.Warn("webhook delivery moved to dead letter", "delivery", delivery.GUID, "subscription", subscription.GUID, "attempts", attempts, "err", err)
+		return false, d.db.Webhooks.UpdateWebhookDelivery(delivery.GUID, event.WebhookDeliveryDead, attempts, err.Error(), now)
+	default:
+		next := now.Add(d.strategy.Duration(attempts - 1))
+		log.Debug("webhook delivery failed, retry later", "delivery", delivery.GUID, "attempts", attempts, "next", next, "err", err)
+		return false, d.db.Webhooks.UpdateWebhookDelivery(delivery.GUID, event.WebhookDeliveryPending, attempts, err.Error(), next)
+	}
+}
+
+// post 发送一次签名请求，非 2xx 响应视为失败
+func (d *Dispatcher) post(subscription event.WebhookSubscription, delivery event.WebhookDelivery) error {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req, err := http.NewRequestWithContext(d.resourceCtx, http.MethodPost, subscription.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, Sign(subscription.Secret, timestamp, delivery.Payload))
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(DeliveryHeader, delivery.GUID.String())
+	req.Header.Set(EventHeader, delivery.EventType)
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+package webhooks
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/Sandwichzzy/event-sync-go/database/event"
+	"github.com/Sandwichzzy/event-sync-go/outbox"
+)
+
+// matches 判断 outbox 消息是否满足订阅的事件类型、代币和地址过滤条件
+func matches(subscription event.WebhookSubscription, message event.OutboxMessage) bool {
+	filter := outbox.Filter{EventTypes: subscription.EventTypes, TokenAddress: subscription.TokenAddress}
+	if subscription.Address != nil {
+		filter.Addresses = []common.Address{*subscription.Address}
+	}
+	return filter.Matches(message)
+}
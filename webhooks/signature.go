@@ -0,0 +1,42 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+const (
+	// SignatureHeader 请求体签名，格式为 sha256=<hex>
+	SignatureHeader = "X-Event-Sync-Signature"
+	// TimestampHeader 签名时间（Unix 秒），参与签名，接收方可据此拒绝重放
+	TimestampHeader = "X-Event-Sync-Timestamp"
+	// DeliveryHeader 投递 GUID，重新投递时不变，接收方可据此去重
+	DeliveryHeader = "X-Event-Sync-Delivery"
+	// EventHeader 事件类型
+	EventHeader = "X-Event-Sync-Event"
+)
+
+// Sign 返回 HMAC-SHA256(secret, timestamp + "." + body) 的签名头取值
+func Sign(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify 以常量时间比较签名，供接收方校验
+func Verify(secret string, timestamp string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
+
+// NewSecret 生成一个随机的 32 字节签名密钥
+func NewSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
@@ -0,0 +1,63 @@
+package webhooks
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ErrPrivateTarget 回调地址指向回环、私有或链路本地等非公网地址
+var ErrPrivateTarget = errors.New("webhook target must be a public address")
+
+// sharedAddressSpace 运营商级 NAT 地址（RFC 6598），netip 不把它视为私有地址
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// CheckTargetIP 拒绝回环、私有、链路本地（包括云厂商的元数据地址 169.254.169.254）、未指定、组播和运营商级 NAT 地址
+func CheckTargetIP(ip netip.Addr) error {
+	ip = ip.Unmap()
+	if !ip.IsValid() || ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsMulticast() ||
+		ip.IsUnspecified() || sharedAddressSpace.Contains(ip) {
+		return fmt.Errorf("%w: %s", ErrPrivateTarget, ip)
+	}
+	return nil
+}
+
+// CheckTargetHost 校验回调 URL 的主机：IP 字面量必须是公网地址，localhost 直接拒绝。
+// 域名在创建订阅时不解析，投递时由 dialer 对解析后的地址再次校验，防止域名指向内网或 DNS rebinding
+func CheckTargetHost(host string) error {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return fmt.Errorf("%w: %s", ErrPrivateTarget, host)
+	}
+	if ip, err := netip.ParseAddr(host); err == nil {
+		return CheckTargetIP(ip)
+	}
+	return nil
+}
+
+// dialControl 在建立连接前校验 DNS 解析后的地址，重定向后的连接同样经过校验
+func dialControl(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+	return CheckTargetIP(addrPort.Addr())
+}
+
+// newHTTPClient 投递使用的 HTTP 客户端。allowPrivate 为 false 时只连接公网地址，
+// 并且不使用环境变量中的代理（经过代理时 dialer 校验的是代理地址）
+func newHTTPClient(allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if !allowPrivate {
+		dialer.Control = dialControl
+		transport.Proxy = nil
+	}
+	transport.DialContext = dialer.DialContext
+	return &http.Client{Timeout: requestTimeout, Transport: transport}
+}
@@ -0,0 +1,77 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"net/netip"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Sandwichzzy/event-sync-go/database/event"
+)
+
+func TestMatches(t *testing.T) {
+	token := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	sender := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	receiver := common.HexToAddress("0x3333333333333333333333333333333333333333")
+	other := common.HexToAddress("0x4444444444444444444444444444444444444444")
+
+	payload, err := json.Marshal(map[string]interface{}{"token_address": token, "sender": sender, "receiver": receiver, "amount": "1"})
+	require.NoError(t, err)
+	withdraw := event.OutboxMessage{ID: 7, EventType: "WithdrawToken", Action: event.OutboxActionAdded, Payload: payload}
+
+	tests := []struct {
+		name         string
+		subscription event.WebhookSubscription
+		want         bool
+	}{
+		{"no filters", event.WebhookSubscription{}, true},
+		{"event type", event.WebhookSubscription{EventTypes: []string{"DepositToken", "WithdrawToken"}}, true},
+		{"other event type", event.WebhookSubscription{EventTypes: []string{"DepositToken"}}, false},
+		{"token", event.WebhookSubscription{TokenAddress: &token}, true},
+		{"other token", event.WebhookSubscription{TokenAddress: &other}, false},
+		{"sender", event.WebhookSubscription{Address: &sender}, true},
+		{"receiver", event.WebhookSubscription{Address: &receiver}, true},
+		{"other address", event.WebhookSubscription{Address: &other}, false},
+		{"token and address", event.WebhookSubscription{TokenAddress: &token, Address: &receiver}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, matches(tt.subscription, withdraw))
+		})
+	}
+
+	subscriptions := []event.WebhookSubscription{{GUID: uuid.New()}, {GUID: uuid.New(), Address: &other}, {GUID: uuid.New(), Address: &sender}}
+	deliveries, err := buildDeliveries(subscriptions, []event.OutboxMessage{withdraw})
+	require.NoError(t, err)
+	require.Len(t, deliveries, 2)
+	require.Equal(t, subscriptions[0].GUID, deliveries[0].SubscriptionGUID)
+	require.Equal(t, subscriptions[2].GUID, deliveries[1].SubscriptionGUID)
+	require.Equal(t, uint64(7), deliveries[1].OutboxID)
+	require.Equal(t, event.WebhookDeliveryPending, deliveries[1].Status)
+}
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"id":1}`)
+	signature := Sign("secret", "1700000000", body)
+	require.Equal(t, "sha256=", signature[:7])
+	require.True(t, Verify("secret", "1700000000", body, signature))
+	require.False(t, Verify("secret", "1700000001", body, signature))
+	require.False(t, Verify("other", "1700000000", body, signature))
+	require.False(t, Verify("secret", "1700000000", []byte(`{"id":2}`), signature))
+}
+
+func TestCheckTarget(t *testing.T) {
+	for _, host := range []string{"localhost", "api.localhost", "127.0.0.1", "10.1.2.3", "172.16.0.1", "192.168.1.1",
+		"169.254.169.254", "100.64.0.1", "0.0.0.0", "::1", "fe80::1", "fd00::1", "::ffff:127.0.0.1"} {
+		require.ErrorIs(t, CheckTargetHost(host), ErrPrivateTarget, host)
+	}
+	for _, host := range []string{"example.com", "8.8.8.8", "2001:4860:4860::8888"} {
+		require.NoError(t, CheckTargetHost(host), host)
+	}
+	// 解析后的地址在建立连接前校验
+	require.ErrorIs(t, dialControl("tcp", "127.0.0.1:443", nil), ErrPrivateTarget)
+	require.NoError(t, dialControl("tcp", netip.MustParseAddrPort("1.1.1.1:443").String(), nil))
+}